@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/bindings/conformance"
+	"github.com/spf13/cobra"
+)
+
+var vectorsDir string
+
+// conformanceCmd runs the shared FFI conformance vector corpus (see
+// pkg/bindings/conformance) and reports pass/fail per vector, for CI to
+// catch a built Rust consensus library that's diverged from the corpus.
+var conformanceCmd = &cobra.Command{
+	Use:   "conformance",
+	Short: "Run the Rust consensus FFI conformance vector corpus",
+	Long: `Loads a directory of JSON test vectors and runs each through the matching
+pkg/bindings call (VerifyTransaction, ComputeEscrowID,
+ValidateEscrowCreation/Release/Refund), reporting pass/fail per vector.
+Exits non-zero if any vector fails.`,
+	RunE: runConformanceCmd,
+}
+
+func init() {
+	conformanceCmd.Flags().StringVarP(&vectorsDir, "vectors-dir", "d", "", "Directory of JSON conformance vectors (defaults to $COINJ_VECTORS_DIR)")
+	rootCmd.AddCommand(conformanceCmd)
+}
+
+func runConformanceCmd(cmd *cobra.Command, args []string) error {
+	dir := vectorsDir
+	if dir == "" {
+		dir = os.Getenv("COINJ_VECTORS_DIR")
+	}
+	if dir == "" {
+		return fmt.Errorf("no vectors directory: pass --vectors-dir or set COINJ_VECTORS_DIR")
+	}
+
+	vectors, err := conformance.LoadDir(dir)
+	if err != nil {
+		return fmt.Errorf("load vectors: %w", err)
+	}
+
+	report := conformance.RunAll(vectors)
+	failures := report.Failures()
+
+	for _, res := range report.Results {
+		status := "PASS"
+		if !res.Passed {
+			status = "FAIL"
+		}
+		if res.Passed {
+			fmt.Printf("[%s] %s\n", status, res.Vector.Name)
+		} else {
+			fmt.Printf("[%s] %s: %s\n", status, res.Vector.Name, res.Detail)
+		}
+	}
+
+	fmt.Printf("\n%d/%d vectors passed\n", len(report.Results)-len(failures), len(report.Results))
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d conformance vector(s) failed", len(failures))
+	}
+	return nil
+}
@@ -21,15 +21,18 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/api"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/beacon"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/ipfs"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/limiter"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool/admission"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/metrics"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/p2p"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
-	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 
 	"github.com/spf13/cobra"
 )
@@ -41,6 +44,11 @@ var (
 	BuildTime = "unknown"
 )
 
+// expirySweepTickBlocks is how many blocks must pass between escrow
+// expiry sweeps (see state.RunExpirySweeper); not yet exposed as a config
+// field since nothing else in cfg.Features needs tuning per-deployment.
+const expirySweepTickBlocks = 10
+
 // Root command
 var rootCmd = &cobra.Command{
 	Use:   "coinjectured",
@@ -85,10 +93,10 @@ func runDaemon(cmd *cobra.Command, args []string) {
 	}
 
 	log.WithFields(logger.Fields{
-		"api_port":       cfg.API.Port,
-		"p2p_port":       cfg.P2P.Port,
-		"ipfs_nodes":     len(cfg.IPFS.Nodes),
-		"codec_mode":     cfg.Features.CodecMode,
+		"api_port":           cfg.API.Port,
+		"p2p_port":           cfg.P2P.Port,
+		"ipfs_nodes":         len(cfg.IPFS.Nodes),
+		"codec_mode":         cfg.Features.CodecMode,
 		"rate_limit_enabled": cfg.RateLimiter.Enabled,
 	}).Info("Configuration loaded")
 
@@ -105,11 +113,7 @@ func runDaemon(cmd *cobra.Command, args []string) {
 		}
 	}()
 
-	// 2. Rate limiter
-	rateLimiter := limiter.NewRateLimiter(cfg.RateLimiter, log)
-	log.Info("Rate limiter initialized")
-
-	// 3. Mempool for transaction management
+	// 2. Mempool for transaction management
 	mempoolCfg := mempool.Config{
 		MaxSize:           10000,
 		MaxTxAge:          1 * time.Hour,
@@ -117,9 +121,22 @@ func runDaemon(cmd *cobra.Command, args []string) {
 		PriorityThreshold: 0,
 	}
 	mp := mempool.NewMempool(mempoolCfg, log)
+	mp.SetMetricsSink(metricsExporter)
 	log.Info("Mempool initialized")
 
-	// 4. State manager (SQLite)
+	verifier := mempool.NewVerifier(mp, mempool.DefaultVerifierConfig(), log)
+	verifier.SetMetricsSink(metricsExporter)
+	verifier.Start()
+	defer verifier.Stop()
+	log.Info("Mempool signature verifier initialized")
+
+	admissionController := admission.NewController(mp, admission.DefaultConfig(), log)
+	admissionController.SetMetricsSink(metricsExporter)
+	admissionController.Start()
+	defer admissionController.Stop()
+	log.Info("Mempool admission controller initialized")
+
+	// 3. State manager (SQLite)
 	stateManager, err := state.NewStateManager("coinjecture.db", log)
 	if err != nil {
 		log.WithError(err).Fatal("Failed to initialize state manager")
@@ -127,14 +144,50 @@ func runDaemon(cmd *cobra.Command, args []string) {
 	defer stateManager.Close()
 	log.Info("State manager initialized")
 
-	// 5. IPFS client with pinning quorum
+	metricsExporter.SetHealthCheck(stateManager.Ping)
+	// No consensus.Engine is constructed in this daemon yet, so there's
+	// nothing to back a readiness check with (see Engine.LastBlockAge) —
+	// /readyz reports "not configured" until one is.
+
+	// 3a. Escrow expiry sweeper: auto-refunds escrows past their
+	// expiry_block, the same way Mempool.Start's cleanupLoop and Engine's
+	// blockProductionLoop run as their own background goroutines. With no
+	// consensus.Engine wired into this daemon yet, chain height comes from
+	// the last persisted block rather than a live Engine.GetBlockHeight.
+	go stateManager.RunExpirySweeper(ctx, expirySweepTickBlocks, func() uint64 {
+		latest, _, err := stateManager.GetLatestBlock()
+		if err != nil || latest == nil {
+			return 0
+		}
+		return latest.BlockNumber
+	})
+	log.Info("Escrow expiry sweeper started")
+
+	// 4. IPFS client with pinning quorum
 	ipfsClient, err := ipfs.NewIPFSClient(cfg.IPFS, log)
 	if err != nil {
 		log.WithError(err).Fatal("Failed to initialize IPFS client")
 	}
 	log.WithField("quorum", cfg.IPFS.PinQuorum).Info("IPFS client initialized")
 
-	// 6. P2P network manager
+	// 4a. Randomness beacon networks, for beacon-bound escrow release
+	// (bindings.ValidateEscrowReleaseWithBeacon). No consensus.Engine is
+	// wired into this daemon yet (see the state manager readiness-check
+	// comment above), so nothing consumes beaconNetworks yet either — it's
+	// constructed here so the escrow release path can start using it
+	// without another daemon wiring pass.
+	var beaconNetworks beacon.BeaconNetworks
+	for _, net := range cfg.Beacon.Networks {
+		beaconNetworks = append(beaconNetworks, beacon.BeaconNetwork{
+			Name:      net.Name,
+			Start:     net.Start,
+			ChainHash: net.ChainHash,
+			API:       beacon.NewDrandClient(net.BaseURL, net.ChainHash),
+		})
+	}
+	log.WithField("networks", len(beaconNetworks)).Info("Randomness beacon networks configured")
+
+	// 5. P2P network manager
 	p2pManager, err := p2p.NewManager(ctx, cfg.P2P, mp, stateManager, log)
 	if err != nil {
 		log.WithError(err).Fatal("Failed to initialize P2P manager")
@@ -145,8 +198,41 @@ func runDaemon(cmd *cobra.Command, args []string) {
 	defer p2pManager.Stop()
 	log.Info("P2P network started")
 
-	// 7. API server
-	apiServer := api.NewServer(cfg.API, rateLimiter, ipfsClient, p2pManager, mp, stateManager, log)
+	// 6. Rate limiter. In distributed mode this coordinates admission
+	// cluster-wide over the P2P peer set, so it's created after the P2P
+	// manager is up.
+	var rateLimiter limiter.Limiter
+	if cfg.RateLimiter.Distributed {
+		rateLimiter = limiter.NewDistributedRateLimiter(cfg.RateLimiter, p2pManager, log)
+		log.Info("Distributed rate limiter initialized")
+	} else {
+		rl := limiter.NewRateLimiter(cfg.RateLimiter, log)
+		rl.SetPeerScorer(p2pManager)
+		rateLimiter = rl
+		log.Info("Rate limiter initialized")
+	}
+	p2pManager.SetBackpressureChecker(rateLimiter)
+	p2pManager.SetBanStore(stateManager)
+
+	// 7. Validator slashing/jailing, with a signed TxUnjail recovery path
+	slashingManager := consensus.NewSlashingManager(consensus.DefaultSlashingConfig(), log)
+	log.Info("Slashing manager initialized")
+
+	// 7a. Evidence pool: verifies and gossips double-sign/wrong-turn/invalid-
+	// block evidence between nodes and hands confirmed evidence to the
+	// slashing manager.
+	evidencePool := consensus.NewEvidencePool(consensus.DefaultEvidencePoolConfig(), slashingManager, log)
+	evidencePool.SetStore(stateManager)
+	evidencePool.SetGossip(p2pManager)
+	if err := evidencePool.LoadPersisted(); err != nil {
+		log.WithError(err).Warn("Failed to replay persisted slashing evidence")
+	}
+	log.Info("Evidence pool initialized")
+
+	// 8. API server
+	apiServer := api.NewServer(cfg.API, cfg.ChainID, rateLimiter, ipfsClient, p2pManager, mp, stateManager, slashingManager, evidencePool, log)
+	apiServer.SetVerifier(verifier)
+	apiServer.SetAdmissionController(admissionController)
 	go func() {
 		log.WithField("port", cfg.API.Port).Info("Starting API server")
 		if err := apiServer.Start(); err != nil && err != http.ErrServerClosed {
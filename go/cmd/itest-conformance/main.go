@@ -0,0 +1,55 @@
+// Conformance test vector runner: replays pkg/conformance JSON vectors
+// against pkg/state.StateManager and reports pass/fail for each.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/conformance"
+)
+
+func main() {
+	vectorsFlag := flag.String("vectors", "", "Directory of conformance vector JSON files (default: "+conformance.DefaultVectorsDir+", overridable via "+conformance.VectorsDirEnv+")")
+	flag.Parse()
+
+	dir := conformance.ResolveVectorsDir(*vectorsFlag)
+
+	vectors, err := conformance.LoadVectors(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load vectors from %q: %v\n", dir, err)
+		os.Exit(1)
+	}
+
+	if len(vectors) == 0 {
+		fmt.Fprintf(os.Stderr, "no vectors found in %q\n", dir)
+		os.Exit(1)
+	}
+
+	results := conformance.RunAll(vectors)
+
+	failures := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failures++
+			fmt.Printf("FAIL %s: %v\n", r.Name, r.Err)
+			continue
+		}
+		if !r.Passed {
+			failures++
+			fmt.Printf("FAIL %s:\n", r.Name)
+			for _, m := range r.Mismatches {
+				fmt.Printf("  - %s\n", m)
+			}
+			continue
+		}
+		fmt.Printf("PASS %s (state_root %x)\n", r.Name, r.GotRoot)
+	}
+
+	fmt.Printf("\n%d/%d vectors passed\n", len(vectors)-failures, len(vectors))
+
+	if failures > 0 {
+		os.Exit(1)
+	}
+}
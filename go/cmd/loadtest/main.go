@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/conformance"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
@@ -28,26 +29,44 @@ type TestConfig struct {
 
 // TestMetrics holds test results
 type TestMetrics struct {
-	TxSubmitted     int64
-	TxInBlocks      int64
-	BlocksProduced  int64
-	StartTime       time.Time
-	LastReportTime  time.Time
-	TxSinceReport   int64
+	TxSubmitted       int64
+	TxInBlocks        int64
+	BlocksProduced    int64
+	StartTime         time.Time
+	LastReportTime    time.Time
+	TxSinceReport     int64
 	BlocksSinceReport int64
+
+	// Realized EIP-1559 tip accounting. currentBaseFee is kept up to date by
+	// the engine's base fee callback (the fee each just-produced block's
+	// transactions were actually checked against) and read back by the new
+	// block callback to price that same block's included transactions.
+	currentBaseFee uint64
+	TipWeiTotal    uint64
+	TipGasTotal    uint64
 }
 
 func main() {
 	// Parse command-line flags
+	mode := flag.String("mode", "throughput", "Test mode: \"throughput\" (default load test) or \"conformance\" (run pkg/conformance vectors)")
 	duration := flag.Duration("duration", 60*time.Second, "Test duration")
 	txRate := flag.Int("txrate", 100, "Target transactions per second")
 	numAccounts := flag.Int("accounts", 100, "Number of test accounts")
 	blockTime := flag.Duration("blocktime", 2*time.Second, "Block time")
 	numValidators := flag.Int("validators", 1, "Number of validators")
 	reportInterval := flag.Duration("report", 5*time.Second, "Report interval")
+	vectors := flag.String("vectors", "", "Conformance mode only: vectors directory (default: "+conformance.DefaultVectorsDir+", overridable via "+conformance.VectorsDirEnv+")")
 
 	flag.Parse()
 
+	if *mode == "conformance" {
+		if err := runConformance(*vectors); err != nil {
+			fmt.Fprintf(os.Stderr, "Conformance run failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	config := TestConfig{
 		Duration:       *duration,
 		TxRate:         *txRate,
@@ -73,6 +92,45 @@ func main() {
 	}
 }
 
+// runConformance runs the pkg/conformance vector corpus and prints
+// pass/fail with a state root diff for each, instead of the throughput
+// load test loop.
+func runConformance(vectorsFlag string) error {
+	dir := conformance.ResolveVectorsDir(vectorsFlag)
+
+	vectors, err := conformance.LoadVectors(dir)
+	if err != nil {
+		return fmt.Errorf("failed to load vectors from %q: %w", dir, err)
+	}
+
+	fmt.Println("=== COINjecture Conformance Run ===")
+	fmt.Printf("Vectors: %s (%d found)\n\n", dir, len(vectors))
+
+	failures := 0
+	for _, r := range conformance.RunAll(vectors) {
+		if r.Err != nil {
+			failures++
+			fmt.Printf("FAIL %s: %v\n", r.Name, r.Err)
+			continue
+		}
+		if !r.Passed {
+			failures++
+			fmt.Printf("FAIL %s:\n", r.Name)
+			for _, m := range r.Mismatches {
+				fmt.Printf("  - %s\n", m)
+			}
+			continue
+		}
+		fmt.Printf("PASS %s (state_root %x)\n", r.Name, r.GotRoot)
+	}
+
+	fmt.Printf("\n%d/%d vectors passed\n", len(vectors)-failures, len(vectors))
+	if failures > 0 {
+		return fmt.Errorf("%d vector(s) failed", failures)
+	}
+	return nil
+}
+
 func runLoadTest(config TestConfig) error {
 	log := logger.NewLogger("info")
 
@@ -114,11 +172,23 @@ func runLoadTest(config TestConfig) error {
 		LastReportTime: time.Now(),
 	}
 
+	// Track the base fee each block's transactions were actually checked
+	// against, so the block callback below can price realized tips.
+	engine.SetBaseFeeCallback(func(baseFee uint64) {
+		metrics.currentBaseFee = baseFee
+	})
+
 	// Track blocks produced
 	engine.SetNewBlockCallback(func(block *consensus.Block) {
 		metrics.BlocksProduced++
 		metrics.BlocksSinceReport++
 		metrics.TxInBlocks += int64(len(block.Transactions))
+
+		baseFee := metrics.currentBaseFee
+		for _, tx := range block.Transactions {
+			metrics.TipWeiTotal += tx.EffectiveTip(baseFee) * tx.GasLimit
+			metrics.TipGasTotal += tx.GasLimit
+		}
 	})
 
 	// Start consensus engine
@@ -162,7 +232,7 @@ func runLoadTest(config TestConfig) error {
 		select {
 		case <-txTicker.C:
 			// Generate transaction
-			if err := generateTransaction(mp, accounts, sm); err != nil {
+			if err := generateTransaction(mp, accounts, sm, engine.GetBaseFee()); err != nil {
 				log.WithError(err).Warn("Failed to generate transaction")
 			} else {
 				metrics.TxSubmitted++
@@ -194,7 +264,7 @@ func runLoadTest(config TestConfig) error {
 	return nil
 }
 
-func generateTransaction(mp *mempool.Mempool, accounts [][32]byte, sm *state.StateManager) error {
+func generateTransaction(mp *mempool.Mempool, accounts [][32]byte, sm *state.StateManager, baseFee uint64) error {
 	// Pick random sender and recipient
 	senderIdx := randomInt(len(accounts))
 	recipientIdx := randomInt(len(accounts))
@@ -205,7 +275,10 @@ func generateTransaction(mp *mempool.Mempool, accounts [][32]byte, sm *state.Sta
 	sender := accounts[senderIdx]
 	recipient := accounts[recipientIdx]
 
-	// Get sender account to get correct nonce
+	// Get sender account to check balance and floor the nonce for a
+	// sender with nothing pending yet; once a sender has pending
+	// transactions, mp.NextNonce keeps them correctly ordered without
+	// going back to state for every generated tx.
 	account, err := sm.GetAccount(sender)
 	if err != nil {
 		return err
@@ -216,26 +289,36 @@ func generateTransaction(mp *mempool.Mempool, accounts [][32]byte, sm *state.Sta
 		return fmt.Errorf("insufficient balance")
 	}
 
-	// Create transaction
+	nonce := mp.NextNonce(sender)
+	if nonce < account.Nonce {
+		nonce = account.Nonce
+	}
+
+	// Create transaction. Priced as an EIP-1559 dynamic fee tx rather than
+	// legacy GasPrice: offer a small fixed tip over whatever the current
+	// base fee is, with 2x headroom so the tx still clears if base fee
+	// rises before it's included.
 	var txHash [32]byte
 	rand.Read(txHash[:])
 
+	const priorityFee = 2
 	tx := &mempool.Transaction{
-		Hash:      txHash,
-		From:      sender,
-		To:        recipient,
-		Amount:    100,
-		Nonce:     account.Nonce,
-		Fee:       10,
-		GasLimit:  21000,
-		GasPrice:  1,
-		Timestamp: time.Now().Unix(),
-		TxType:    1, // Transfer
-		Priority:  10.0,
+		Hash:                 txHash,
+		From:                 sender,
+		To:                   recipient,
+		Amount:               100,
+		Nonce:                nonce,
+		Fee:                  10,
+		GasLimit:             21000,
+		MaxFeePerGas:         baseFee*2 + priorityFee,
+		MaxPriorityFeePerGas: priorityFee,
+		Timestamp:            time.Now().Unix(),
+		TxType:               1, // Transfer
 	}
 
 	// Add to mempool
-	return mp.AddTransaction(tx)
+	_, err := mp.AddTransaction(tx)
+	return err
 }
 
 func randomInt(max int) int {
@@ -304,4 +387,13 @@ func printFinalReport(metrics *TestMetrics, config TestConfig) {
 	blockEfficiency := float64(metrics.BlocksProduced) / float64(expectedBlocks) * 100
 	fmt.Printf("Block Production Efficiency: %.1f%% (%d/%d expected)\n",
 		blockEfficiency, metrics.BlocksProduced, expectedBlocks)
+	fmt.Println()
+
+	// Realized EIP-1559 tip rate actually paid to validators, as opposed to
+	// the MaxPriorityFeePerGas offered by generateTransaction.
+	if metrics.TipGasTotal > 0 {
+		avgTipPerGas := float64(metrics.TipWeiTotal) / float64(metrics.TipGasTotal)
+		fmt.Printf("Realized Tip Rate: %.2f wei/gas (%d wei total over %d gas)\n",
+			avgTipPerGas, metrics.TipWeiTotal, metrics.TipGasTotal)
+	}
 }
@@ -6,6 +6,7 @@
 package main
 
 import (
+	"context"
 	"crypto/ed25519"
 	"crypto/sha256"
 	"encoding/binary"
@@ -16,6 +17,8 @@ import (
 	"time"
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/codec/ssz"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/execution"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
 )
@@ -24,12 +27,12 @@ const (
 	Version = "4.5.0+"
 
 	// Security limits (institutional standards)
-	MaxGasLimit           = 10000000 // 10M gas max
-	MaxGasPrice           = 1000000  // 1M wei per gas max
-	MaxTransactionSize    = 1048576  // 1MB max transaction
-	MaxBatchSize          = 1000     // Max 1000 txs in batch
-	MinAccountBalance     = 1000     // Minimum 1000 wei to transact
-	NonceValidityWindow   = 1000     // Max nonce skew allowed
+	MaxGasLimit         = 10000000 // 10M gas max
+	MaxGasPrice         = 1000000  // 1M wei per gas max
+	MaxTransactionSize  = 1048576  // 1MB max transaction
+	MaxBatchSize        = 1000     // Max 1000 txs in batch
+	MinAccountBalance   = 1000     // Minimum 1000 wei to transact
+	NonceValidityWindow = 1000     // Max nonce skew allowed
 
 	// Transaction types (from consensus)
 	TxTypeTransfer uint8 = 1
@@ -39,28 +42,38 @@ const (
 // Config represents command-line configuration with validation
 type Config struct {
 	// Required parameters
-	DBPath      string
-	FromKeyHex  string
-	ToAddrHex   string
+	DBPath     string
+	FromKeyHex string
+	ToAddrHex  string
+
+	// External signer (Clef-style), alternative to FromKeyHex
+	SignerEndpoint string
+	SignerCertPath string
+
+	// Durable submission queue
+	QueueDBPath string
 
 	// Transaction parameters
-	Amount      uint64
-	GasPrice    uint64
-	GasLimit    uint64
-	TxType      uint8
-	Data        []byte
+	Amount               uint64
+	GasPrice             uint64
+	GasLimit             uint64
+	MaxFeePerGas         uint64 // EIP-1559: enables codec_version=2 when non-zero
+	MaxPriorityFeePerGas uint64
+	BaseFee              uint64 // Current network base fee, for client-side validation
+	TxType               uint8
+	Data                 []byte
 
 	// Batch parameters
-	Count       int
-	Interval    time.Duration
+	Count    int
+	Interval time.Duration
 
 	// Security parameters
-	DryRun      bool
-	VerifyOnly  bool
-	Verbose     bool
+	DryRun     bool
+	VerifyOnly bool
+	Verbose    bool
 
 	// Operational parameters
-	Timeout     time.Duration
+	Timeout time.Duration
 }
 
 // TransactionSigner handles secure transaction signing
@@ -73,11 +86,11 @@ type TransactionSigner struct {
 
 // ValidationResult contains transaction validation results
 type ValidationResult struct {
-	Valid       bool
-	Errors      []string
-	Warnings    []string
-	TotalCost   uint64
-	Fee         uint64
+	Valid     bool
+	Errors    []string
+	Warnings  []string
+	TotalCost uint64
+	Fee       uint64
 }
 
 func main() {
@@ -106,12 +119,24 @@ func main() {
 	defer stateManager.Close()
 	log.WithField("db_path", config.DBPath).Info("✓ State manager initialized")
 
-	// Step 3: Initialize transaction signer
-	signer, err := NewTransactionSigner(config.FromKeyHex, log)
-	if err != nil {
-		fatal("Failed to initialize signer: %v", err)
+	// Step 3: Initialize transaction signer. --signer delegates signing to a
+	// remote Clef-style process over JSON-RPC, so the private key never
+	// enters this process; --from-key signs in-process instead.
+	var signer Signer
+	if config.SignerEndpoint != "" {
+		signer, err = NewExternalSigner(config.SignerEndpoint, config.SignerCertPath, log)
+		if err != nil {
+			fatal("Failed to initialize external signer: %v", err)
+		}
+		log.WithField("endpoint", config.SignerEndpoint).Info("✓ External signer initialized")
+	} else {
+		signer, err = NewTransactionSigner(config.FromKeyHex, log)
+		if err != nil {
+			fatal("Failed to initialize signer: %v", err)
+		}
 	}
-	log.WithField("address", hex.EncodeToString(signer.address[:8])).Info("✓ Signer initialized")
+	signerAddress := signer.Address()
+	log.WithField("address", hex.EncodeToString(signerAddress[:8])).Info("✓ Signer initialized")
 
 	// Step 4: Parse recipient address
 	recipientAddr, err := parseAddress(config.ToAddrHex)
@@ -121,7 +146,7 @@ func main() {
 	log.WithField("recipient", hex.EncodeToString(recipientAddr[:8])).Info("✓ Recipient address parsed")
 
 	// Step 5: Fetch sender account state
-	account, err := stateManager.GetAccount(signer.address)
+	account, err := stateManager.GetAccount(signerAddress)
 	if err != nil {
 		fatal("Failed to fetch sender account: %v", err)
 	}
@@ -131,10 +156,15 @@ func main() {
 	}).Info("✓ Sender account fetched")
 
 	// Step 6: Validate account balance
-	totalCost := config.Amount + (config.GasLimit * config.GasPrice)
+	// Worst-case fee per gas: MaxFeePerGas for dynamic-fee txs, GasPrice for legacy.
+	worstCaseFeePerGas := config.GasPrice
+	if config.MaxFeePerGas > 0 {
+		worstCaseFeePerGas = config.MaxFeePerGas
+	}
+	totalCost := config.Amount + (config.GasLimit * worstCaseFeePerGas)
 	if account.Balance < totalCost {
 		fatal("Insufficient balance: have %d wei, need %d wei (amount=%d + fee=%d)",
-			account.Balance, totalCost, config.Amount, config.GasLimit*config.GasPrice)
+			account.Balance, totalCost, config.Amount, config.GasLimit*worstCaseFeePerGas)
 	}
 	log.WithFields(map[string]interface{}{
 		"total_cost": totalCost,
@@ -144,19 +174,43 @@ func main() {
 
 	// Step 7: Initialize mempool
 	mempoolCfg := mempool.Config{
-		MaxSize:          10000,
-		MaxTxAge:         1 * time.Hour,
-		CleanupInterval:  5 * time.Minute,
+		MaxSize:           10000,
+		MaxTxAge:          1 * time.Hour,
+		CleanupInterval:   5 * time.Minute,
 		PriorityThreshold: 0.0,
 	}
 	mp := mempool.NewMempool(mempoolCfg, log)
 	log.Info("✓ Mempool initialized")
 
+	// Step 7b: Open the durable submission queue and resume any transaction
+	// left `pending` by a previous, interrupted run before signing anything new.
+	queue, err := NewSubmissionQueue(config.QueueDBPath, log)
+	if err != nil {
+		fatal("Failed to initialize submission queue: %v", err)
+	}
+	defer queue.Close()
+
+	if resumed, err := queue.PendingRows(); err != nil {
+		fatal("Failed to resume submission queue: %v", err)
+	} else if len(resumed) > 0 {
+		log.WithField("count", len(resumed)).Info("Resuming pending transactions from a previous run")
+	}
+	log.WithField("queue_db", config.QueueDBPath).Info("✓ Submission queue initialized")
+
 	// Step 8: Submit transactions
-	printTransactionSummary(config, account, signer.address, recipientAddr, totalCost)
+	printTransactionSummary(config, account, signerAddress, recipientAddr, totalCost)
+
+	sim := execution.NewSimulator(stateManager)
+
+	fees := FeeParams{
+		GasPrice:             config.GasPrice,
+		MaxFeePerGas:         config.MaxFeePerGas,
+		MaxPriorityFeePerGas: config.MaxPriorityFeePerGas,
+	}
 
 	if config.DryRun {
 		log.Warn("DRY RUN MODE - No transactions will be submitted")
+		runDryRunSimulation(log, sim, signer, recipientAddr, account, config, fees)
 		os.Exit(0)
 	}
 
@@ -168,12 +222,13 @@ func main() {
 		nonce := account.Nonce + uint64(i)
 
 		// Create and sign transaction
-		tx, err := signer.CreateTransaction(
+		tx, err := CreateTransaction(
+			signer,
 			recipientAddr,
 			config.Amount,
 			nonce,
 			config.GasLimit,
-			config.GasPrice,
+			fees,
 			config.TxType,
 			config.Data,
 		)
@@ -185,7 +240,7 @@ func main() {
 
 		// Validate transaction before submission
 		if config.VerifyOnly {
-			validation := validateTransaction(tx, account)
+			validation := validateTransaction(tx, account, config.BaseFee, sim)
 			if !validation.Valid {
 				log.WithFields(map[string]interface{}{
 					"errors":   validation.Errors,
@@ -199,9 +254,11 @@ func main() {
 			continue
 		}
 
-		// Submit to mempool
-		if err := mp.AddTransaction(tx); err != nil {
-			log.WithError(err).Errorf("Failed to submit transaction %d/%d", i+1, config.Count)
+		// Persist the signed transaction before attempting submission, so a
+		// transient mempool error — or the process dying mid-batch — loses
+		// neither the signature nor the nonce it consumed.
+		if err := queue.Enqueue(tx); err != nil {
+			log.WithError(err).Errorf("Failed to enqueue transaction %d/%d", i+1, config.Count)
 			failureCount++
 			continue
 		}
@@ -211,7 +268,7 @@ func main() {
 			"nonce":   nonce,
 			"amount":  config.Amount,
 			"fee":     tx.Fee,
-		}).Infof("✓ Transaction %d/%d submitted", i+1, config.Count)
+		}).Infof("✓ Transaction %d/%d queued", i+1, config.Count)
 		successCount++
 
 		// Rate limiting between transactions
@@ -220,6 +277,19 @@ func main() {
 		}
 	}
 
+	// Drain the queue: submit every row with exponential backoff retries,
+	// tolerating "already known" as success, until nothing is left pending
+	// or --timeout elapses.
+	drainCtx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	queue.Drain(drainCtx, mp)
+
+	if pending, err := queue.pendingCount(); err != nil {
+		log.WithError(err).Error("Failed to read final submission queue state")
+	} else if pending > 0 {
+		log.WithField("pending", pending).Warn("Some transactions are still pending — rerun submit-tx to resume them")
+	}
+
 	elapsed := time.Since(startTime)
 	printResults(successCount, failureCount, config.Count, elapsed)
 }
@@ -256,13 +326,31 @@ func NewTransactionSigner(privateKeyHex string, log *logger.Logger) (*Transactio
 	}, nil
 }
 
-// CreateTransaction creates and signs a transaction with institutional-grade validation
-func (s *TransactionSigner) CreateTransaction(
+// FeeParams bundles the fee fields for a transaction. A Legacy single gas
+// price (codec_version=1) is used when MaxFeePerGas and MaxPriorityFeePerGas
+// are both zero; otherwise the transaction is signed under the EIP-1559
+// codec_version=2 envelope.
+type FeeParams struct {
+	GasPrice             uint64
+	MaxFeePerGas         uint64
+	MaxPriorityFeePerGas uint64
+}
+
+// IsDynamicFee reports whether these fee params use the 1559-style fee market.
+func (f FeeParams) IsDynamicFee() bool {
+	return f.MaxFeePerGas > 0 || f.MaxPriorityFeePerGas > 0
+}
+
+// CreateTransaction creates and signs a transaction with institutional-grade
+// validation. signer may be a local *TransactionSigner or a remote
+// *ExternalSigner — CreateTransaction never sees the private key either way.
+func CreateTransaction(
+	signer Signer,
 	to [32]byte,
 	amount uint64,
 	nonce uint64,
 	gasLimit uint64,
-	gasPrice uint64,
+	fees FeeParams,
 	txType uint8,
 	data []byte,
 ) (*mempool.Transaction, error) {
@@ -270,66 +358,134 @@ func (s *TransactionSigner) CreateTransaction(
 	if gasLimit > MaxGasLimit {
 		return nil, fmt.Errorf("gas limit %d exceeds maximum %d", gasLimit, MaxGasLimit)
 	}
-	if gasPrice > MaxGasPrice {
-		return nil, fmt.Errorf("gas price %d exceeds maximum %d", gasPrice, MaxGasPrice)
+	if fees.GasPrice > MaxGasPrice || fees.MaxFeePerGas > MaxGasPrice {
+		return nil, fmt.Errorf("gas price exceeds maximum %d", MaxGasPrice)
+	}
+	if fees.IsDynamicFee() && fees.MaxPriorityFeePerGas > fees.MaxFeePerGas {
+		return nil, fmt.Errorf("max priority fee per gas %d exceeds max fee per gas %d", fees.MaxPriorityFeePerGas, fees.MaxFeePerGas)
 	}
 	if len(data) > MaxTransactionSize {
 		return nil, fmt.Errorf("transaction data size %d exceeds maximum %d", len(data), MaxTransactionSize)
 	}
+	if !fees.IsDynamicFee() && len(data) > ssz.MaxTxData {
+		return nil, fmt.Errorf("transaction data size %d exceeds SSZ codec maximum %d", len(data), ssz.MaxTxData)
+	}
 	if amount < 1 && txType == TxTypeTransfer {
 		return nil, fmt.Errorf("transfer amount must be at least 1 wei")
 	}
 
-	// Calculate fee
-	fee := gasLimit * gasPrice
+	// Calculate fee (worst case cost: gas_limit * the price the tx is willing to pay)
+	feePerGas := fees.GasPrice
+	if fees.IsDynamicFee() {
+		feePerGas = fees.MaxFeePerGas
+	}
+	fee := gasLimit * feePerGas
 
-	// Build canonical signing message
-	message := s.buildSigningMessage(to, amount, nonce, gasLimit, gasPrice, txType, data)
+	from := signer.Address()
 
-	// Sign with Ed25519
-	signature := ed25519.Sign(s.privateKey, message)
-	if len(signature) != 64 {
-		return nil, fmt.Errorf("invalid signature size: expected 64 bytes, got %d", len(signature))
+	// Build the canonical signing payload and sign it. Dynamic-fee
+	// transactions still use the codec_version=2 little-endian envelope
+	// (the SSZ container doesn't yet model the 1559 fee fields); everything
+	// else is signed via codec_version=3 SSZ.
+	message, txHash, err := buildSigningPayload(from, to, amount, nonce, gasLimit, fees, txType, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build signing payload: %w", err)
 	}
 
-	var sig [64]byte
-	copy(sig[:], signature)
-
-	// Compute transaction hash
-	txHash := sha256.Sum256(message)
+	sig, err := signer.SignMessage(message, SigningMetadata{
+		To:       to,
+		Amount:   amount,
+		Nonce:    nonce,
+		GasLimit: gasLimit,
+		Fee:      fee,
+		Data:     data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
 
 	// Create mempool transaction
 	tx := &mempool.Transaction{
-		Hash:      txHash,
-		From:      s.address,
-		To:        to,
-		Amount:    amount,
-		Nonce:     nonce,
-		GasLimit:  gasLimit,
-		GasPrice:  gasPrice,
-		Signature: sig,
-		Data:      data,
-		Timestamp: time.Now().Unix(),
-		TxType:    txType,
-		Fee:       fee,
-		AddedAt:   time.Now(),
-		Priority:  float64(gasPrice), // Priority = gas price
-	}
-
-	s.log.WithFields(map[string]interface{}{
-		"hash":       hex.EncodeToString(txHash[:8]),
-		"from":       hex.EncodeToString(s.address[:8]),
-		"to":         hex.EncodeToString(to[:8]),
-		"amount":     amount,
-		"nonce":      nonce,
-		"fee":        fee,
-	}).Debug("Transaction created and signed")
+		Hash:                 txHash,
+		From:                 from,
+		To:                   to,
+		Amount:               amount,
+		Nonce:                nonce,
+		GasLimit:             gasLimit,
+		GasPrice:             fees.GasPrice,
+		MaxFeePerGas:         fees.MaxFeePerGas,
+		MaxPriorityFeePerGas: fees.MaxPriorityFeePerGas,
+		Signature:            sig,
+		Data:                 data,
+		Timestamp:            time.Now().Unix(),
+		TxType:               txType,
+		Fee:                  fee,
+		AddedAt:              time.Now(),
+	}
 
 	return tx, nil
 }
 
-// buildSigningMessage builds the canonical message for Ed25519 signing
-func (s *TransactionSigner) buildSigningMessage(
+// buildSigningPayload builds the canonical message to run Ed25519 signing
+// over, along with the transaction hash derived from it.
+//
+// codec_version=1 (legacy): single gas_price field, little-endian
+// concatenation. Superseded by codec_version=3 for new transactions but
+// kept so transactions signed before this change remain verifiable.
+// codec_version=2 (EIP-1559): gas_price is replaced by max_fee_per_gas and
+// max_priority_fee_per_gas, same little-endian layout otherwise.
+// codec_version=3 (SSZ): the canonical path for non-dynamic-fee
+// transactions. The message signed is the SSZ signing hash itself
+// (sha256(domain || hash_tree_root)), which doubles as the transaction hash
+// and as a merkle root usable for light-client inclusion proofs.
+func buildSigningPayload(
+	from [32]byte,
+	to [32]byte,
+	amount uint64,
+	nonce uint64,
+	gasLimit uint64,
+	fees FeeParams,
+	txType uint8,
+	data []byte,
+) (message []byte, txHash [32]byte, err error) {
+	if fees.IsDynamicFee() {
+		message = buildSigningMessageV2(from, to, amount, nonce, gasLimit, fees, txType, data)
+		return message, sha256.Sum256(message), nil
+	}
+
+	payload := &ssz.SignedTransactionPayload{
+		Codec:    ssz.CodecVersion,
+		TxType:   txType,
+		From:     from,
+		To:       to,
+		Amount:   amount,
+		Nonce:    nonce,
+		GasLimit: gasLimit,
+		GasPrice: fees.GasPrice,
+		Data:     data,
+	}
+
+	hash, err := ssz.SigningHash(ssz.TxDomain, payload)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	return hash[:], hash, nil
+}
+
+// buildSigningMessageV1 builds the legacy single-gas-price message (little-endian):
+// 1 byte:  codec_version (1)
+// 1 byte:  tx_type
+// 32 bytes: from
+// 32 bytes: to
+// 8 bytes: amount
+// 8 bytes: nonce
+// 8 bytes: gas_limit
+// 8 bytes: gas_price
+// 4 bytes: data_len
+// N bytes: data
+func buildSigningMessageV1(
+	from [32]byte,
 	to [32]byte,
 	amount uint64,
 	nonce uint64,
@@ -338,24 +494,12 @@ func (s *TransactionSigner) buildSigningMessage(
 	txType uint8,
 	data []byte,
 ) []byte {
-	// Message format (little-endian):
-	// 1 byte:  codec_version (1)
-	// 1 byte:  tx_type
-	// 32 bytes: from
-	// 32 bytes: to
-	// 8 bytes: amount
-	// 8 bytes: nonce
-	// 8 bytes: gas_limit
-	// 8 bytes: gas_price
-	// 4 bytes: data_len
-	// N bytes: data
-
 	size := 1 + 1 + 32 + 32 + 8 + 8 + 8 + 8 + 4 + len(data)
 	message := make([]byte, 0, size)
 
 	message = append(message, 1) // codec_version = 1
 	message = append(message, txType)
-	message = append(message, s.address[:]...)
+	message = append(message, from[:]...)
 	message = append(message, to[:]...)
 	message = append(message, uint64ToLittleEndian(amount)...)
 	message = append(message, uint64ToLittleEndian(nonce)...)
@@ -367,8 +511,48 @@ func (s *TransactionSigner) buildSigningMessage(
 	return message
 }
 
+// buildSigningMessageV2 builds the EIP-1559 style message (little-endian):
+// 1 byte:  codec_version (2)
+// 1 byte:  tx_type
+// 32 bytes: from
+// 32 bytes: to
+// 8 bytes: amount
+// 8 bytes: nonce
+// 8 bytes: gas_limit
+// 8 bytes: max_fee_per_gas
+// 8 bytes: max_priority_fee_per_gas
+// 4 bytes: data_len
+// N bytes: data
+func buildSigningMessageV2(
+	from [32]byte,
+	to [32]byte,
+	amount uint64,
+	nonce uint64,
+	gasLimit uint64,
+	fees FeeParams,
+	txType uint8,
+	data []byte,
+) []byte {
+	size := 1 + 1 + 32 + 32 + 8 + 8 + 8 + 8 + 4 + len(data)
+	message := make([]byte, 0, size)
+
+	message = append(message, 2) // codec_version = 2
+	message = append(message, txType)
+	message = append(message, from[:]...)
+	message = append(message, to[:]...)
+	message = append(message, uint64ToLittleEndian(amount)...)
+	message = append(message, uint64ToLittleEndian(nonce)...)
+	message = append(message, uint64ToLittleEndian(gasLimit)...)
+	message = append(message, uint64ToLittleEndian(fees.MaxFeePerGas)...)
+	message = append(message, uint64ToLittleEndian(fees.MaxPriorityFeePerGas)...)
+	message = append(message, uint32ToLittleEndian(uint32(len(data)))...)
+	message = append(message, data...)
+
+	return message
+}
+
 // validateTransaction performs comprehensive transaction validation
-func validateTransaction(tx *mempool.Transaction, account *state.Account) ValidationResult {
+func validateTransaction(tx *mempool.Transaction, account *state.Account, baseFee uint64, sim *execution.Simulator) ValidationResult {
 	result := ValidationResult{
 		Valid:     true,
 		Errors:    []string{},
@@ -377,6 +561,12 @@ func validateTransaction(tx *mempool.Transaction, account *state.Account) Valida
 		Fee:       tx.Fee,
 	}
 
+	if tx.IsDynamicFee() && tx.MaxFeePerGas < baseFee {
+		result.Valid = false
+		result.Errors = append(result.Errors, fmt.Sprintf(
+			"max fee per gas %d below current base fee %d", tx.MaxFeePerGas, baseFee))
+	}
+
 	// Critical validations (must pass)
 	if tx.Amount == 0 && tx.TxType == TxTypeTransfer {
 		result.Valid = false
@@ -407,7 +597,15 @@ func validateTransaction(tx *mempool.Transaction, account *state.Account) Valida
 		result.Warnings = append(result.Warnings, "gas price is very low, transaction may be delayed")
 	}
 
-	if tx.GasLimit > 1000000 {
+	if estimate, err := execution.EstimateGas(sim, tx, MaxGasLimit); err == nil {
+		if tx.GasLimit > estimate+estimate/2 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"gas limit is much higher than needed: estimate=%d, provided=%d", estimate, tx.GasLimit))
+		}
+	} else if tx.GasLimit > 1000000 {
+		// Simulation couldn't produce an estimate (e.g. sender account lookup
+		// failed); fall back to the old blunt threshold rather than silently
+		// dropping the warning.
 		result.Warnings = append(result.Warnings, "gas limit is very high, check if necessary")
 	}
 
@@ -419,16 +617,23 @@ func parseFlags() *Config {
 	config := &Config{}
 
 	flag.StringVar(&config.DBPath, "db", "./data/validator1.db", "Database path")
-	flag.StringVar(&config.FromKeyHex, "from-key", "", "Sender private key (hex, required)")
+	flag.StringVar(&config.QueueDBPath, "queue-db", "./data/submit-tx-queue.db", "Durable submission queue database path")
+	flag.StringVar(&config.FromKeyHex, "from-key", "", "Sender private key (hex). Mutually exclusive with --signer")
+	flag.StringVar(&config.SignerEndpoint, "signer", "", "External signer endpoint (unix:///path/to/socket or https://host:port). Mutually exclusive with --from-key")
+	flag.StringVar(&config.SignerCertPath, "signer-cert", "", "PEM file pinning the external signer's certificate (required for an https:// --signer)")
 	flag.StringVar(&config.ToAddrHex, "to", "", "Recipient address (32-byte hex, required)")
 	flag.Uint64Var(&config.Amount, "amount", 1000000, "Amount to send (wei)")
-	flag.Uint64Var(&config.GasPrice, "gas-price", 100, "Gas price (wei per gas)")
+	flag.Uint64Var(&config.GasPrice, "gas-price", 100, "Gas price (wei per gas, legacy codec_version=1)")
 	flag.Uint64Var(&config.GasLimit, "gas-limit", 21000, "Gas limit")
+	flag.Uint64Var(&config.MaxFeePerGas, "max-fee-per-gas", 0, "EIP-1559 max fee per gas (enables codec_version=2 when set)")
+	flag.Uint64Var(&config.MaxPriorityFeePerGas, "max-priority-fee-per-gas", 0, "EIP-1559 max priority fee (tip) per gas")
+	flag.Uint64Var(&config.BaseFee, "base-fee", 0, "Current network base fee, used for client-side --verify checks")
 	flag.IntVar(&config.Count, "count", 1, "Number of transactions to submit")
 	flag.DurationVar(&config.Interval, "interval", 0, "Interval between transactions")
 	flag.BoolVar(&config.DryRun, "dry-run", false, "Validate only, don't submit")
 	flag.BoolVar(&config.VerifyOnly, "verify", false, "Verify transactions without submitting")
 	flag.BoolVar(&config.Verbose, "verbose", false, "Verbose logging")
+	flag.DurationVar(&config.Timeout, "timeout", 30*time.Second, "Max time to drain the submission queue before exiting with pending rows")
 
 	txType := flag.Uint("type", 1, "Transaction type (1=Transfer, 2=Escrow)")
 	dataHex := flag.String("data", "", "Transaction data (hex)")
@@ -455,8 +660,11 @@ func parseFlags() *Config {
 }
 
 func validateConfig(config *Config) error {
-	if config.FromKeyHex == "" {
-		return fmt.Errorf("--from-key is required")
+	if config.FromKeyHex == "" && config.SignerEndpoint == "" {
+		return fmt.Errorf("either --from-key or --signer is required")
+	}
+	if config.FromKeyHex != "" && config.SignerEndpoint != "" {
+		return fmt.Errorf("--from-key and --signer are mutually exclusive")
 	}
 	if config.ToAddrHex == "" {
 		return fmt.Errorf("--to is required")
@@ -470,6 +678,15 @@ func validateConfig(config *Config) error {
 	if config.GasPrice > MaxGasPrice {
 		return fmt.Errorf("gas price cannot exceed %d", MaxGasPrice)
 	}
+	if config.MaxFeePerGas > MaxGasPrice || config.MaxPriorityFeePerGas > MaxGasPrice {
+		return fmt.Errorf("max fee per gas cannot exceed %d", MaxGasPrice)
+	}
+	if config.MaxPriorityFeePerGas > 0 && config.MaxFeePerGas == 0 {
+		return fmt.Errorf("--max-priority-fee-per-gas requires --max-fee-per-gas")
+	}
+	if config.MaxPriorityFeePerGas > config.MaxFeePerGas {
+		return fmt.Errorf("--max-priority-fee-per-gas cannot exceed --max-fee-per-gas")
+	}
 	if config.TxType != TxTypeTransfer && config.TxType != TxTypeEscrow {
 		return fmt.Errorf("invalid transaction type: %d (must be 1 or 2)", config.TxType)
 	}
@@ -514,9 +731,16 @@ func printTransactionSummary(config *Config, account *state.Account, from, to [3
 	fmt.Printf("  From:         %s\n", hex.EncodeToString(from[:]))
 	fmt.Printf("  To:           %s\n", hex.EncodeToString(to[:]))
 	fmt.Printf("  Amount:       %d wei\n", config.Amount)
-	fmt.Printf("  Gas Price:    %d wei/gas\n", config.GasPrice)
-	fmt.Printf("  Gas Limit:    %d\n", config.GasLimit)
-	fmt.Printf("  Fee:          %d wei\n", config.GasLimit*config.GasPrice)
+	if config.MaxFeePerGas > 0 || config.MaxPriorityFeePerGas > 0 {
+		fmt.Printf("  Max Fee/Gas:  %d wei/gas\n", config.MaxFeePerGas)
+		fmt.Printf("  Max Tip/Gas:  %d wei/gas\n", config.MaxPriorityFeePerGas)
+		fmt.Printf("  Gas Limit:    %d\n", config.GasLimit)
+		fmt.Printf("  Fee (worst case): %d wei\n", config.GasLimit*config.MaxFeePerGas)
+	} else {
+		fmt.Printf("  Gas Price:    %d wei/gas\n", config.GasPrice)
+		fmt.Printf("  Gas Limit:    %d\n", config.GasLimit)
+		fmt.Printf("  Fee:          %d wei\n", config.GasLimit*config.GasPrice)
+	}
 	fmt.Printf("  Total Cost:   %d wei\n", totalCost)
 	fmt.Printf("  Balance:      %d wei\n", account.Balance)
 	fmt.Printf("  Remaining:    %d wei\n", account.Balance-totalCost)
@@ -541,6 +765,45 @@ func printResults(success, failure, total int, elapsed time.Duration) {
 	fmt.Println("═══════════════════════════════════════════════════════════")
 }
 
+// runDryRunSimulation signs a representative transaction (without consuming
+// a real nonce beyond the dry run) and runs it through the read-only
+// execution simulator, printing an ExecutionResult-style preview instead of
+// just logging that nothing will be submitted.
+func runDryRunSimulation(log *logger.Logger, sim *execution.Simulator, signer Signer, to [32]byte, account *state.Account, config *Config, fees FeeParams) {
+	tx, err := CreateTransaction(signer, to, config.Amount, account.Nonce, config.GasLimit, fees, config.TxType, config.Data)
+	if err != nil {
+		log.WithError(err).Error("Dry run: failed to create transaction")
+		return
+	}
+
+	result, err := sim.Simulate(tx)
+	if err != nil {
+		log.WithError(err).Error("Dry run: simulation failed")
+		return
+	}
+
+	estimate, estErr := execution.EstimateGas(sim, tx, MaxGasLimit)
+
+	fmt.Println("\n═══════════════════════════════════════════════════════════")
+	fmt.Println("  Simulation Result (ExecutionResult)")
+	fmt.Println("═══════════════════════════════════════════════════════════")
+	fmt.Printf("  Used Gas:      %d\n", result.UsedGas)
+	fmt.Printf("  Refunded Gas:  %d\n", result.RefundedGas)
+	if result.Err != nil {
+		fmt.Printf("  Result:        WOULD FAIL: %v\n", result.Err)
+	} else {
+		fmt.Printf("  Result:        would succeed\n")
+	}
+	if estErr == nil {
+		fmt.Printf("  Gas Estimate:  %d (provided: %d)\n", estimate, config.GasLimit)
+	}
+	for addr, delta := range result.StateDiff {
+		fmt.Printf("  State Diff:    %x: balance %d -> %d, nonce %d -> %d\n",
+			addr[:8], delta.BalanceBefore, delta.BalanceAfter, delta.NonceBefore, delta.NonceAfter)
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════")
+}
+
 func fatal(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, "\n❌ ERROR: "+format+"\n\n", args...)
 	os.Exit(1)
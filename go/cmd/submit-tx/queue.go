@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	_ "modernc.org/sqlite" // Pure-Go SQLite driver (no CGO required)
+)
+
+// QueueStatus tracks a queued transaction through its submission lifecycle.
+type QueueStatus string
+
+const (
+	StatusPending   QueueStatus = "pending"
+	StatusSubmitted QueueStatus = "submitted"
+	StatusConfirmed QueueStatus = "confirmed"
+	StatusFailed    QueueStatus = "failed"
+)
+
+const (
+	queueMaxAttempts   = 10
+	queueBaseBackoff   = 1 * time.Second
+	queueMaxBackoff    = 30 * time.Second
+	queueDrainInterval = 250 * time.Millisecond
+)
+
+// SubmissionQueue is a local, durable store-before-send queue. Every
+// transaction submit-tx signs is persisted here, keyed by (from, nonce,
+// hash), before it is ever handed to the mempool. This means a transient
+// mempool error — or the process dying mid-batch — loses neither the
+// signed transaction nor the nonce it consumed: a later run of submit-tx
+// resumes any row still `pending`.
+type SubmissionQueue struct {
+	db  *sql.DB
+	log *logger.Logger
+}
+
+// NewSubmissionQueue opens (creating if necessary) the local queue database.
+func NewSubmissionQueue(path string, log *logger.Logger) (*SubmissionQueue, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open submission queue db: %w", err)
+	}
+
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		log.WithError(err).Warn("Failed to enable WAL mode on submission queue (continuing with default journaling)")
+	}
+
+	if _, err := db.Exec(submissionQueueSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize submission queue schema: %w", err)
+	}
+
+	return &SubmissionQueue{db: db, log: log}, nil
+}
+
+const submissionQueueSchema = `
+CREATE TABLE IF NOT EXISTS submission_queue (
+	from_address             TEXT    NOT NULL,
+	nonce                     INTEGER NOT NULL,
+	hash                      TEXT    NOT NULL,
+	status                    TEXT    NOT NULL,
+	to_address                TEXT    NOT NULL,
+	amount                    INTEGER NOT NULL,
+	gas_limit                 INTEGER NOT NULL,
+	gas_price                 INTEGER NOT NULL,
+	max_fee_per_gas           INTEGER NOT NULL,
+	max_priority_fee_per_gas  INTEGER NOT NULL,
+	tx_type                   INTEGER NOT NULL,
+	data                      BLOB,
+	signature                 BLOB    NOT NULL,
+	timestamp                 INTEGER NOT NULL,
+	fee                       INTEGER NOT NULL,
+	attempts                  INTEGER NOT NULL DEFAULT 0,
+	next_attempt_at           INTEGER NOT NULL DEFAULT 0,
+	last_error                TEXT,
+	created_at                INTEGER NOT NULL,
+	updated_at                INTEGER NOT NULL,
+	PRIMARY KEY (from_address, nonce, hash)
+)`
+
+// Close closes the underlying database connection.
+func (q *SubmissionQueue) Close() error {
+	return q.db.Close()
+}
+
+// Enqueue persists a signed transaction as `pending`, before any submission
+// attempt is made. Re-enqueuing the same (from, nonce, hash) is a no-op, so
+// callers can safely re-sign and re-enqueue after a resume.
+func (q *SubmissionQueue) Enqueue(tx *mempool.Transaction) error {
+	now := time.Now().Unix()
+
+	_, err := q.db.Exec(`
+		INSERT INTO submission_queue (
+			from_address, nonce, hash, status, to_address, amount, gas_limit, gas_price,
+			max_fee_per_gas, max_priority_fee_per_gas, tx_type, data, signature,
+			timestamp, fee, attempts, next_attempt_at, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?, ?)
+		ON CONFLICT(from_address, nonce, hash) DO NOTHING
+	`,
+		addrHex(tx.From), tx.Nonce, addrHex(tx.Hash), string(StatusPending),
+		addrHex(tx.To), tx.Amount, tx.GasLimit, tx.GasPrice,
+		tx.MaxFeePerGas, tx.MaxPriorityFeePerGas, tx.TxType, tx.Data, tx.Signature[:],
+		tx.Timestamp, tx.Fee, now, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue transaction %x: %w", tx.Hash[:8], err)
+	}
+
+	return nil
+}
+
+// queuedTx is a row read back out of the submission queue.
+type queuedTx struct {
+	tx       *mempool.Transaction
+	status   QueueStatus
+	attempts int
+}
+
+// PendingRows returns every row still awaiting submission, in the order it
+// was originally enqueued. Called on startup so a previous run's
+// in-flight transactions are resumed rather than silently abandoned.
+func (q *SubmissionQueue) PendingRows() ([]*queuedTx, error) {
+	rows, err := q.db.Query(`
+		SELECT from_address, nonce, hash, status, to_address, amount, gas_limit, gas_price,
+		       max_fee_per_gas, max_priority_fee_per_gas, tx_type, data, signature, timestamp, fee, attempts
+		FROM submission_queue
+		WHERE status = ?
+		ORDER BY created_at ASC
+	`, string(StatusPending))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending submission rows: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*queuedTx
+	for rows.Next() {
+		var fromHex, hashHex, toHex, status string
+		var data, signature []byte
+		tx := &mempool.Transaction{}
+		var attempts int
+
+		err := rows.Scan(&fromHex, &tx.Nonce, &hashHex, &status, &toHex, &tx.Amount, &tx.GasLimit,
+			&tx.GasPrice, &tx.MaxFeePerGas, &tx.MaxPriorityFeePerGas, &tx.TxType, &data, &signature,
+			&tx.Timestamp, &tx.Fee, &attempts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan submission row: %w", err)
+		}
+
+		if err := decodeAddrHex(fromHex, &tx.From); err != nil {
+			return nil, err
+		}
+		if err := decodeAddrHex(toHex, &tx.To); err != nil {
+			return nil, err
+		}
+		if err := decodeAddrHex(hashHex, &tx.Hash); err != nil {
+			return nil, err
+		}
+		copy(tx.Signature[:], signature)
+		tx.Data = data
+		tx.AddedAt = time.Now()
+
+		out = append(out, &queuedTx{tx: tx, status: QueueStatus(status), attempts: attempts})
+	}
+
+	return out, rows.Err()
+}
+
+// MarkSubmitted records that the mempool accepted a transaction (or, for an
+// "already known" response, that it was already accepted on a prior attempt).
+func (q *SubmissionQueue) MarkSubmitted(hash [32]byte) error {
+	return q.updateStatus(hash, StatusSubmitted, "")
+}
+
+// MarkConfirmed records that a transaction was observed included on-chain.
+//
+// TODO: submit-tx currently talks directly to an in-process mempool rather
+// than a running node, so there is no `transaction`/`block` WebSocket feed
+// to watch here. Wire this up once submit-tx gains a real RPC client —
+// until then, rows only ever progress as far as `submitted`.
+func (q *SubmissionQueue) MarkConfirmed(hash [32]byte) error {
+	return q.updateStatus(hash, StatusConfirmed, "")
+}
+
+// MarkFailed records a terminal failure (attempts exhausted).
+func (q *SubmissionQueue) MarkFailed(hash [32]byte, lastErr error) error {
+	return q.updateStatus(hash, StatusFailed, lastErr.Error())
+}
+
+func (q *SubmissionQueue) updateStatus(hash [32]byte, status QueueStatus, lastErr string) error {
+	_, err := q.db.Exec(`
+		UPDATE submission_queue SET status = ?, last_error = ?, updated_at = ?
+		WHERE hash = ?
+	`, string(status), lastErr, time.Now().Unix(), addrHex(hash))
+	if err != nil {
+		return fmt.Errorf("failed to update submission queue row %x: %w", hash[:8], err)
+	}
+	return nil
+}
+
+// recordAttempt bumps the retry counter and schedules the next attempt with
+// exponential backoff, capped at queueMaxBackoff. Once queueMaxAttempts is
+// exceeded the row is marked `failed` instead of rescheduled.
+func (q *SubmissionQueue) recordAttempt(hash [32]byte, attempts int, lastErr error) error {
+	attempts++
+	if attempts >= queueMaxAttempts {
+		return q.MarkFailed(hash, lastErr)
+	}
+
+	backoff := queueBaseBackoff << uint(attempts-1)
+	if backoff > queueMaxBackoff {
+		backoff = queueMaxBackoff
+	}
+	nextAttemptAt := time.Now().Add(backoff).Unix()
+
+	errMsg := ""
+	if lastErr != nil {
+		errMsg = lastErr.Error()
+	}
+
+	_, err := q.db.Exec(`
+		UPDATE submission_queue SET attempts = ?, next_attempt_at = ?, last_error = ?, updated_at = ?
+		WHERE hash = ?
+	`, attempts, nextAttemptAt, errMsg, time.Now().Unix(), addrHex(hash))
+	if err != nil {
+		return fmt.Errorf("failed to record submission attempt for %x: %w", hash[:8], err)
+	}
+	return nil
+}
+
+// duePendingRows returns pending rows whose backoff has elapsed.
+func (q *SubmissionQueue) duePendingRows() ([]*queuedTx, error) {
+	rows, err := q.PendingRows()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	var due []*queuedTx
+	for _, row := range rows {
+		var nextAttemptAt int64
+		if err := q.db.QueryRow(`SELECT next_attempt_at FROM submission_queue WHERE hash = ?`,
+			addrHex(row.tx.Hash)).Scan(&nextAttemptAt); err != nil {
+			return nil, fmt.Errorf("failed to read backoff state for %x: %w", row.tx.Hash[:8], err)
+		}
+		if nextAttemptAt <= now {
+			due = append(due, row)
+		}
+	}
+	return due, nil
+}
+
+// pendingCount returns how many rows are still awaiting submission.
+func (q *SubmissionQueue) pendingCount() (int, error) {
+	var count int
+	err := q.db.QueryRow(`SELECT COUNT(*) FROM submission_queue WHERE status = ?`, string(StatusPending)).Scan(&count)
+	return count, err
+}
+
+// Drain repeatedly attempts to submit every pending row to mp, backing off
+// exponentially between retries on the same row, until either every row
+// leaves the `pending` state or ctx is cancelled (e.g. by --timeout).
+// "Already known" mempool responses are treated as success, since that
+// just means an earlier attempt actually got through.
+func (q *SubmissionQueue) Drain(ctx context.Context, mp *mempool.Mempool) {
+	ticker := time.NewTicker(queueDrainInterval)
+	defer ticker.Stop()
+
+	for {
+		due, err := q.duePendingRows()
+		if err != nil {
+			q.log.WithError(err).Error("Failed to read due submission queue rows")
+		}
+
+		for _, row := range due {
+			_, err := mp.AddTransaction(row.tx)
+			if err == nil || isAlreadyKnown(err) {
+				if markErr := q.MarkSubmitted(row.tx.Hash); markErr != nil {
+					q.log.WithError(markErr).Error("Failed to mark transaction submitted")
+				}
+				continue
+			}
+
+			if attemptErr := q.recordAttempt(row.tx.Hash, row.attempts, err); attemptErr != nil {
+				q.log.WithError(attemptErr).Error("Failed to record submission attempt")
+			}
+		}
+
+		pending, err := q.pendingCount()
+		if err != nil {
+			q.log.WithError(err).Error("Failed to count pending submission queue rows")
+			return
+		}
+		if pending == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func isAlreadyKnown(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "already in mempool")
+}
+
+func addrHex(b [32]byte) string {
+	return hex.EncodeToString(b[:])
+}
+
+func decodeAddrHex(s string, out *[32]byte) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("invalid hex in submission queue row: %w", err)
+	}
+	if len(b) != 32 {
+		return fmt.Errorf("expected 32 bytes in submission queue row, got %d", len(b))
+	}
+	copy(out[:], b)
+	return nil
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// Signer abstracts over how the canonical signing message for a transaction
+// gets turned into an Ed25519 signature. TransactionSigner signs in-process
+// with a key held in this process's memory; ExternalSigner instead delegates
+// to a Clef-style remote signer over JSON-RPC, so the private key never
+// enters submit-tx at all.
+type Signer interface {
+	// Address returns the sending account's 32-byte address.
+	Address() [32]byte
+	// SignMessage signs the canonical message bytes built by
+	// buildSigningPayload and returns a 64-byte Ed25519 signature. meta
+	// carries the human-readable transaction fields so an external signer
+	// can render an approval prompt without having to parse message itself.
+	SignMessage(message []byte, meta SigningMetadata) ([64]byte, error)
+}
+
+// SigningMetadata bundles the fields of a transaction a signer may want to
+// show a human (or an approve_tx policy) before signing.
+type SigningMetadata struct {
+	To       [32]byte
+	Amount   uint64
+	Nonce    uint64
+	GasLimit uint64
+	Fee      uint64
+	Data     []byte
+}
+
+// summary renders meta as a short human-readable line, used both for local
+// logging and as the approve_tx prompt sent to an external signer.
+func (m SigningMetadata) summary() string {
+	return fmt.Sprintf("to=%s amount=%d nonce=%d gas_limit=%d fee=%d data=%s",
+		hex.EncodeToString(m.To[:]), m.Amount, m.Nonce, m.GasLimit, m.Fee, hex.EncodeToString(m.Data))
+}
+
+// Address implements Signer for the in-process TransactionSigner.
+func (s *TransactionSigner) Address() [32]byte {
+	return s.address
+}
+
+// SignMessage implements Signer for the in-process TransactionSigner. The
+// private key never leaves this process, so there is no separate approval
+// step — holding the key is the approval.
+func (s *TransactionSigner) SignMessage(message []byte, meta SigningMetadata) ([64]byte, error) {
+	signature := ed25519.Sign(s.privateKey, message)
+	if len(signature) != ed25519.SignatureSize {
+		return [64]byte{}, fmt.Errorf("invalid signature size: expected %d bytes, got %d",
+			ed25519.SignatureSize, len(signature))
+	}
+	var sig [64]byte
+	copy(sig[:], signature)
+	return sig, nil
+}
+
+// rpcRequest is a minimal JSON-RPC 2.0 request envelope.
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+	ID      int         `json:"id"`
+}
+
+// rpcResponse is a minimal JSON-RPC 2.0 response envelope.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      int             `json:"id"`
+}
+
+// rpcError is the JSON-RPC 2.0 error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("signer RPC error %d: %s", e.Code, e.Message)
+}
+
+// ExternalSigner signs transactions by delegating to a Clef-style remote
+// signer process over JSON-RPC, so the private key material never touches
+// submit-tx. The remote end is reached either over a unix domain socket
+// (trusted local IPC, no TLS) or a pinned-certificate HTTPS endpoint.
+type ExternalSigner struct {
+	endpoint   string
+	httpClient *http.Client
+	address    [32]byte
+	log        *logger.Logger
+}
+
+// signTxParams is sent to the sign_tx RPC method.
+type signTxParams struct {
+	Message  string `json:"message"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Amount   uint64 `json:"amount"`
+	Nonce    uint64 `json:"nonce"`
+	GasLimit uint64 `json:"gas_limit"`
+	Fee      uint64 `json:"fee"`
+	Data     string `json:"data"`
+}
+
+// approveTxParams is sent to the approve_tx RPC method.
+type approveTxParams struct {
+	Summary string `json:"summary"`
+}
+
+// dummyRPCURL is the request URL used for the unix-socket transport: the
+// custom DialContext ignores the host entirely and always dials the
+// configured socket path, so any well-formed URL works here.
+const dummyRPCURL = "http://unix-signer/rpc"
+
+// NewExternalSigner connects to a Clef-style remote signer at endpoint,
+// which must be of the form "unix:///path/to/socket" or "https://host:port".
+// It looks up the signing account via list_accounts and caches the address
+// returned for subsequent SignMessage calls.
+func NewExternalSigner(endpoint string, certPath string, log *logger.Logger) (*ExternalSigner, error) {
+	httpClient, url, err := newSignerTransport(endpoint, certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	es := &ExternalSigner{
+		endpoint:   url,
+		httpClient: httpClient,
+		log:        log,
+	}
+
+	var accounts []string
+	if err := es.call("list_accounts", nil, &accounts); err != nil {
+		return nil, fmt.Errorf("failed to list accounts from signer: %w", err)
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("signer at %s returned no accounts", endpoint)
+	}
+
+	addrBytes, err := hex.DecodeString(strings.TrimPrefix(accounts[0], "0x"))
+	if err != nil || len(addrBytes) != 32 {
+		return nil, fmt.Errorf("signer returned invalid account address %q", accounts[0])
+	}
+	copy(es.address[:], addrBytes)
+
+	return es, nil
+}
+
+// newSignerTransport builds the HTTP client and request URL for endpoint,
+// dispatching on its scheme: unix:// dials a local domain socket with no
+// transport security, https:// uses a pinned-certificate TLS config loaded
+// from certPath.
+func newSignerTransport(endpoint string, certPath string) (*http.Client, string, error) {
+	switch {
+	case strings.HasPrefix(endpoint, "unix://"):
+		socketPath := strings.TrimPrefix(endpoint, "unix://")
+		dialer := &net.Dialer{Timeout: 5 * time.Second}
+		client := &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		}
+		return client, dummyRPCURL, nil
+
+	case strings.HasPrefix(endpoint, "https://"):
+		tlsConfig, err := tlsConfigWithPin(certPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load signer certificate: %w", err)
+		}
+		client := &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		}
+		return client, endpoint, nil
+
+	default:
+		return nil, "", fmt.Errorf("unsupported signer endpoint %q: must start with unix:// or https://", endpoint)
+	}
+}
+
+// tlsConfigWithPin builds a tls.Config that only trusts the certificate(s)
+// in the PEM file at certPath, rather than the system trust store — the
+// same pinned-cert pattern used to talk to a locally-operated signer over
+// an otherwise untrusted network.
+func tlsConfigWithPin(certPath string) (*tls.Config, error) {
+	if certPath == "" {
+		return nil, fmt.Errorf("--signer-cert is required for an https:// signer endpoint")
+	}
+	pemBytes, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signer cert: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemBytes) {
+		return nil, fmt.Errorf("no certificates found in %s", certPath)
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// call performs a single JSON-RPC request against the signer and decodes
+// its result into result.
+func (es *ExternalSigner) call(method string, params interface{}, result interface{}) error {
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpResp, err := es.httpClient.Post(es.endpoint, "application/json", strings.NewReader(string(reqBody)))
+	if err != nil {
+		return fmt.Errorf("signer request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp rpcResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return fmt.Errorf("failed to decode signer response: %w", err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// Address implements Signer.
+func (es *ExternalSigner) Address() [32]byte {
+	return es.address
+}
+
+// SignMessage implements Signer. It first asks the signer to approve the
+// human-readable summary of meta, then requests a signature over the raw
+// message bytes — the signer sees only the summary and the message, never
+// the private key.
+func (es *ExternalSigner) SignMessage(message []byte, meta SigningMetadata) ([64]byte, error) {
+	var approved bool
+	if err := es.call("approve_tx", approveTxParams{Summary: meta.summary()}, &approved); err != nil {
+		return [64]byte{}, fmt.Errorf("signer approval request failed: %w", err)
+	}
+	if !approved {
+		return [64]byte{}, fmt.Errorf("signer declined to approve transaction: %s", meta.summary())
+	}
+
+	params := signTxParams{
+		Message:  hex.EncodeToString(message),
+		From:     hex.EncodeToString(es.address[:]),
+		To:       hex.EncodeToString(meta.To[:]),
+		Amount:   meta.Amount,
+		Nonce:    meta.Nonce,
+		GasLimit: meta.GasLimit,
+		Fee:      meta.Fee,
+		Data:     hex.EncodeToString(meta.Data),
+	}
+
+	var sigHex string
+	if err := es.call("sign_tx", params, &sigHex); err != nil {
+		return [64]byte{}, fmt.Errorf("signer sign_tx failed: %w", err)
+	}
+
+	sigBytes, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
+	if err != nil {
+		return [64]byte{}, fmt.Errorf("signer returned invalid signature hex: %w", err)
+	}
+	if len(sigBytes) != ed25519.SignatureSize {
+		return [64]byte{}, fmt.Errorf("signer returned invalid signature size: expected %d bytes, got %d",
+			ed25519.SignatureSize, len(sigBytes))
+	}
+
+	var sig [64]byte
+	copy(sig[:], sigBytes)
+	return sig, nil
+}
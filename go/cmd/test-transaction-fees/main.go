@@ -15,6 +15,7 @@ import (
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus/fees"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
 )
@@ -27,6 +28,7 @@ func main() {
 	validatorKeyHex := flag.String("validator-key", "", "Validator public key (32 hex chars)")
 	numTxs := flag.Int("num-txs", 10, "Number of test transactions to create")
 	blockTime := flag.Duration("block-time", 2*time.Second, "Block production interval")
+	chainID := flag.Uint64("chain-id", 1, "Chain id to sign test transactions with (Network A = 1)")
 	flag.Parse()
 
 	fmt.Printf("═══════════════════════════════════════════\n")
@@ -105,7 +107,7 @@ func main() {
 
 	// Inject test transactions into mempool
 	fmt.Printf("🔬 Injecting %d test transactions...\n", *numTxs)
-	txHashes := injectTestTransactions(*numTxs, testAccounts, mp, stateManager, log)
+	txHashes := injectTestTransactions(*numTxs, testAccounts, mp, stateManager, log, *chainID)
 	fmt.Printf("✓ Injected %d transactions into mempool\n", len(txHashes))
 
 	// Display fee information
@@ -116,10 +118,10 @@ func main() {
 	}
 	fmt.Printf("\n💰 Total fees from all transactions: %d wei (%.9f $BEANS)\n", totalFees, float64(totalFees)/1e9)
 
-	// Calculate expected distribution
-	validatorShare := uint64(float64(totalFees) * 0.4142)
-	burnShare := uint64(float64(totalFees) * 0.2929)
-	treasuryShare := uint64(float64(totalFees) * 0.2929)
+	// Calculate expected distribution using the same exact integer math
+	// consensus.BlockBuilder applies on-chain (see consensus/fees), so
+	// this matches the real split to the wei rather than approximating it.
+	validatorShare, burnShare, treasuryShare := fees.SplitFee(totalFees)
 
 	fmt.Printf("\n📐 Expected Fee Distribution (Critical Complex Equilibrium):\n")
 	fmt.Printf("  Validator: %d wei (41.42%%) → %.9f $BEANS\n", validatorShare, float64(validatorShare)/1e9)
@@ -174,7 +176,7 @@ func createTestAccounts(count int, sm *state.StateManager, log *logger.Logger) [
 }
 
 // injectTestTransactions creates and injects test transactions into the mempool
-func injectTestTransactions(count int, accounts [][32]byte, mp *mempool.Mempool, sm *state.StateManager, log *logger.Logger) [][32]byte {
+func injectTestTransactions(count int, accounts [][32]byte, mp *mempool.Mempool, sm *state.StateManager, log *logger.Logger, chainID uint64) [][32]byte {
 	txHashes := make([][32]byte, 0, count)
 
 	for i := 0; i < count; i++ {
@@ -204,6 +206,7 @@ func injectTestTransactions(count int, accounts [][32]byte, mp *mempool.Mempool,
 			GasLimit:  gasLimit,
 			GasPrice:  gasPrice,
 			Fee:       gasLimit * gasPrice, // 21000 gwei
+			ChainID:   chainID,
 			Timestamp: time.Now().Unix(),
 			TxType:    1, // Transfer
 		}
@@ -216,11 +219,12 @@ func injectTestTransactions(count int, accounts [][32]byte, mp *mempool.Mempool,
 		hashData = append(hashData, uint64ToBytes(tx.Nonce)...)
 		hashData = append(hashData, uint64ToBytes(tx.GasLimit)...)
 		hashData = append(hashData, uint64ToBytes(tx.GasPrice)...)
+		hashData = append(hashData, uint64ToBytes(tx.ChainID)...)
 
 		tx.Hash = sha256.Sum256(hashData)
 
 		// Add to mempool
-		if err := mp.AddTransaction(tx); err != nil {
+		if _, err := mp.AddTransaction(tx); err != nil {
 			log.WithError(err).WithField("tx_hash", fmt.Sprintf("%x", tx.Hash[:8])).Warn("Failed to add transaction to mempool")
 			continue
 		}
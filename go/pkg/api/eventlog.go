@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// defaultEventRingSize is how many events per topic EventLog retains in
+// memory for replay when no explicit ring size is configured.
+const defaultEventRingSize = 10000
+
+// loggedEvent is one replayable WSMessage, plus the metadata needed to find
+// it again later: Seq for since_seq replay, Time for since_time replay, and
+// Attrs so a filtered subscription only replays events it would also have
+// received live.
+type loggedEvent struct {
+	Seq     uint64         `json:"seq"`
+	Topic   string         `json:"topic"`
+	Type    string         `json:"type"`
+	Time    int64          `json:"time"` // unix seconds, when the event was appended
+	Payload interface{}    `json:"payload"`
+	Attrs   BroadcastAttrs `json:"-"`
+}
+
+// EventLog is an append-only, on-disk record of every broadcast event, with
+// an in-memory ring buffer per topic for fast, bounded replay. WSHub uses it
+// to stamp every outgoing WSMessage with a monotonic event_seq and to serve
+// clients that reconnect (or poll the /v1/events HTTP endpoint) asking for
+// everything since a given sequence number or time.
+//
+// The on-disk log exists so the ring buffers survive a restart; it is not
+// itself queried directly — Since/SinceTime only ever look as far back as
+// the ring buffer's retention (ringSize events per topic).
+type EventLog struct {
+	mu       sync.Mutex
+	file     *os.File
+	ringSize int
+	ring     map[string][]*loggedEvent // topic -> ring buffer, oldest first
+	seq      uint64                    // atomic: last assigned event_seq
+	log      *logger.Logger
+}
+
+// NewEventLog opens (or creates) the append-only log at path and replays it
+// to rebuild each topic's in-memory ring buffer and resume the event_seq
+// counter where the last run left off. An empty path gives an in-memory-only
+// log (events are still ring-buffered for replay, just not persisted).
+func NewEventLog(path string, ringSize int, log *logger.Logger) (*EventLog, error) {
+	if ringSize <= 0 {
+		ringSize = defaultEventRingSize
+	}
+
+	el := &EventLog{
+		ringSize: ringSize,
+		ring:     make(map[string][]*loggedEvent),
+		log:      log,
+	}
+
+	if path == "" {
+		return el, nil
+	}
+
+	if err := el.replay(path); err != nil {
+		return nil, fmt.Errorf("failed to replay event log: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+	el.file = file
+
+	return el, nil
+}
+
+// replay reads every line of an existing log file at path (if any) back into
+// the in-memory ring buffers and advances seq past the highest seq seen, so
+// event_seq stays monotonic across restarts.
+func (el *EventLog) replay(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var ev loggedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			el.log.WithError(err).Warn("Skipping malformed event log entry")
+			continue
+		}
+		el.appendToRing(&ev)
+		if ev.Seq > el.seq {
+			el.seq = ev.Seq
+		}
+	}
+	return scanner.Err()
+}
+
+// Append assigns the next event_seq, stores the event in topic's ring
+// buffer, and persists it to disk (if a log file is open). It returns the
+// assigned seq, which the caller stamps onto the live WSMessage.
+func (el *EventLog) Append(topic, msgType string, payload interface{}, attrs BroadcastAttrs) (uint64, error) {
+	seq := atomic.AddUint64(&el.seq, 1)
+	ev := &loggedEvent{
+		Seq:     seq,
+		Topic:   topic,
+		Type:    msgType,
+		Time:    time.Now().Unix(),
+		Payload: payload,
+		Attrs:   attrs,
+	}
+
+	el.appendToRing(ev)
+
+	if el.file == nil {
+		return seq, nil
+	}
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return seq, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	data = append(data, '\n')
+
+	el.mu.Lock()
+	_, err = el.file.Write(data)
+	el.mu.Unlock()
+	if err != nil {
+		return seq, fmt.Errorf("failed to persist event: %w", err)
+	}
+
+	return seq, nil
+}
+
+// appendToRing adds ev to its topic's ring buffer, trimming the oldest entry
+// once the buffer exceeds ringSize.
+func (el *EventLog) appendToRing(ev *loggedEvent) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	buf := append(el.ring[ev.Topic], ev)
+	if len(buf) > el.ringSize {
+		buf = buf[len(buf)-el.ringSize:]
+	}
+	el.ring[ev.Topic] = buf
+}
+
+// Since returns every event on topic with Seq > sinceSeq, oldest first.
+// Events older than the ring buffer's retention are no longer available and
+// are silently omitted rather than erroring.
+func (el *EventLog) Since(topic string, sinceSeq uint64) []*loggedEvent {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	var out []*loggedEvent
+	for _, ev := range el.ring[topic] {
+		if ev.Seq > sinceSeq {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// SinceTime returns every event on topic appended at or after sinceUnix
+// (unix seconds), oldest first.
+func (el *EventLog) SinceTime(topic string, sinceUnix int64) []*loggedEvent {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	var out []*loggedEvent
+	for _, ev := range el.ring[topic] {
+		if ev.Time >= sinceUnix {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// Close releases the underlying log file, if one is open.
+func (el *EventLog) Close() error {
+	if el.file == nil {
+		return nil
+	}
+	return el.file.Close()
+}
@@ -3,6 +3,7 @@ package api
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -10,24 +11,57 @@ import (
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/bindings"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/execution"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/receipts"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/store/blockstore"
 	"github.com/gin-gonic/gin"
 )
 
 // ==================== TRANSACTION ENDPOINTS ====================
 
+// submitTransactionStatus maps a submitTransaction error to the HTTP status
+// that best describes it: 409 Conflict for a same-(sender, nonce)
+// replacement that didn't bid enough to evict the transaction it targets,
+// 400 Bad Request for everything else (validation failures, pool caps, etc).
+func submitTransactionStatus(err error) int {
+	if errors.Is(err, mempool.ErrUnderpricedReplacement) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
+// isTrustedAPIRequest reports whether c's X-API-Key header matches one of
+// config.APIConfig.TrustedAPIKeys, i.e. whether this submission should be
+// admitted as a local account (see mempool.Config.Locals).
+func (s *Server) isTrustedAPIRequest(c *gin.Context) bool {
+	key := c.GetHeader("X-API-Key")
+	if key == "" {
+		return false
+	}
+	for _, trusted := range s.config.TrustedAPIKeys {
+		if key == trusted {
+			return true
+		}
+	}
+	return false
+}
+
 // handleSubmitTransaction submits a transaction to the mempool and broadcasts to network
 func (s *Server) handleSubmitTransaction(c *gin.Context) {
 	var txReq struct {
-		From      string `json:"from" binding:"required"`      // Hex-encoded sender address (64 chars)
-		To        string `json:"to" binding:"required"`        // Hex-encoded recipient address (64 chars)
-		Amount    uint64 `json:"amount" binding:"required"`    // Amount in wei
-		Nonce     uint64 `json:"nonce" binding:"required"`     // Transaction nonce
-		GasLimit  uint64 `json:"gas_limit" binding:"required"` // Gas limit (21000 for transfer)
-		GasPrice  uint64 `json:"gas_price" binding:"required"` // Gas price in wei
-		Signature string `json:"signature" binding:"required"` // Hex-encoded signature (128 chars)
-		Data      string `json:"data"`                         // Optional hex-encoded data
+		From                 string `json:"from" binding:"required"`      // Hex-encoded sender address (64 chars)
+		To                   string `json:"to" binding:"required"`        // Hex-encoded recipient address (64 chars)
+		Amount               uint64 `json:"amount" binding:"required"`    // Amount in wei
+		Nonce                uint64 `json:"nonce" binding:"required"`     // Transaction nonce
+		GasLimit             uint64 `json:"gas_limit" binding:"required"` // Gas limit (21000 for transfer)
+		Type                 uint8  `json:"type"`                         // Fee envelope: omitted/1=legacy, 2=EIP-1559 dynamic fee
+		GasPrice             uint64 `json:"gas_price"`                    // Legacy gas price in wei; required when type != 2
+		MaxFeePerGas         uint64 `json:"max_fee_per_gas"`              // Dynamic fee cap; required when type == 2
+		MaxPriorityFeePerGas uint64 `json:"max_priority_fee_per_gas"`     // Dynamic fee tip; required when type == 2
+		Signature            string `json:"signature" binding:"required"` // Hex-encoded signature (128 chars)
+		Data                 string `json:"data"`                         // Optional hex-encoded data
 	}
 
 	if err := c.ShouldBindJSON(&txReq); err != nil {
@@ -38,6 +72,26 @@ func (s *Server) handleSubmitTransaction(c *gin.Context) {
 		return
 	}
 
+	codecVersion := bindings.FeeEnvelopeLegacy
+	if txReq.Type == bindings.FeeEnvelopeDynamicFee {
+		codecVersion = bindings.FeeEnvelopeDynamicFee
+	}
+
+	if codecVersion == bindings.FeeEnvelopeLegacy && txReq.GasPrice == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "gas_price is required for legacy transactions"})
+		return
+	}
+	if codecVersion == bindings.FeeEnvelopeDynamicFee {
+		if txReq.MaxFeePerGas == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "max_fee_per_gas is required for dynamic fee transactions"})
+			return
+		}
+		if txReq.MaxPriorityFeePerGas > txReq.MaxFeePerGas {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "max_priority_fee_per_gas cannot exceed max_fee_per_gas"})
+			return
+		}
+	}
+
 	// Parse addresses
 	from, err := hexToBytes32(txReq.From)
 	if err != nil {
@@ -68,19 +122,30 @@ func (s *Server) handleSubmitTransaction(c *gin.Context) {
 		}
 	}
 
+	// For a dynamic fee transaction, the price actually paid (and validated
+	// against the sender's balance) is the effective gas price at inclusion
+	// time: min(max_fee, base_fee + max_priority_fee), mirroring EIP-1559.
+	gasPrice := txReq.GasPrice
+	if codecVersion == bindings.FeeEnvelopeDynamicFee {
+		gasPrice = effectiveGasPrice(s.mempool.BaseFee(), txReq.MaxFeePerGas, txReq.MaxPriorityFeePerGas)
+	}
+
 	// Create transaction for validation
 	tx := &bindings.Transaction{
-		CodecVersion: 1,
-		TxType:       bindings.TxTypeTransfer,
-		From:         from,
-		To:           to,
-		Amount:       txReq.Amount,
-		Nonce:        txReq.Nonce,
-		GasLimit:     txReq.GasLimit,
-		GasPrice:     txReq.GasPrice,
-		Signature:    signature,
-		Data:         data,
-		Timestamp:    time.Now().Unix(),
+		CodecVersion:         codecVersion,
+		TxType:               bindings.TxTypeTransfer,
+		From:                 from,
+		To:                   to,
+		Amount:               txReq.Amount,
+		Nonce:                txReq.Nonce,
+		GasLimit:             txReq.GasLimit,
+		GasPrice:             gasPrice,
+		MaxFeePerGas:         txReq.MaxFeePerGas,
+		MaxPriorityFeePerGas: txReq.MaxPriorityFeePerGas,
+		Signature:            signature,
+		Data:                 data,
+		ChainID:              s.chainID,
+		Timestamp:            time.Now().Unix(),
 	}
 
 	// Get sender account state
@@ -97,7 +162,7 @@ func (s *Server) handleSubmitTransaction(c *gin.Context) {
 	}
 
 	// Validate transaction via Rust FFI
-	result, err := bindings.VerifyTransaction(tx, senderState)
+	result, err := bindings.VerifyTransaction(tx, senderState, s.chainID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "transaction validation failed",
@@ -116,24 +181,36 @@ func (s *Server) handleSubmitTransaction(c *gin.Context) {
 
 	// Create mempool transaction
 	mempoolTx := &mempool.Transaction{
-		Hash:      txHash,
-		From:      tx.From,
-		To:        tx.To,
-		Amount:    tx.Amount,
-		Nonce:     tx.Nonce,
-		GasLimit:  tx.GasLimit,
-		GasPrice:  tx.GasPrice,
-		Signature: tx.Signature,
-		Data:      tx.Data,
-		Timestamp: tx.Timestamp,
-		TxType:    tx.TxType,
-		Fee:       result.Fee,
-		AddedAt:   time.Now(),
+		Hash:                 txHash,
+		From:                 tx.From,
+		To:                   tx.To,
+		Amount:               tx.Amount,
+		Nonce:                tx.Nonce,
+		GasLimit:             tx.GasLimit,
+		GasPrice:             tx.GasPrice,
+		MaxFeePerGas:         tx.MaxFeePerGas,
+		MaxPriorityFeePerGas: tx.MaxPriorityFeePerGas,
+		Signature:            tx.Signature,
+		Data:                 tx.Data,
+		ChainID:              tx.ChainID,
+		Timestamp:            tx.Timestamp,
+		TxType:               tx.TxType,
+		Fee:                  result.Fee,
+		AddedAt:              time.Now(),
 	}
 
-	// Add to mempool
-	if err := s.mempool.AddTransaction(mempoolTx); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	// A request bearing one of config.APIConfig.TrustedAPIKeys gets its
+	// sender treated as a local account (see mempool.Config.Locals) for
+	// this and every future submission, bypassing the mempool's fee floor
+	// and size-based eviction.
+	if s.isTrustedAPIRequest(c) {
+		s.mempool.SetLocal(from)
+	}
+
+	// Add to mempool, running signature verification through the async
+	// pipeline if one is wired in (see Server.SetVerifier).
+	if err := s.submitTransaction(mempoolTx); err != nil {
+		c.JSON(submitTransactionStatus(err), gin.H{
 			"error":   "failed to add to mempool",
 			"details": err.Error(),
 		})
@@ -177,33 +254,81 @@ func (s *Server) handleGetTransaction(c *gin.Context) {
 	tx, err := s.mempool.GetTransaction(txHash)
 	if err == nil {
 		c.JSON(http.StatusOK, gin.H{
-			"status":     "pending",
-			"tx_hash":    fmt.Sprintf("%x", tx.Hash),
-			"from":       fmt.Sprintf("%x", tx.From),
-			"to":         fmt.Sprintf("%x", tx.To),
-			"amount":     tx.Amount,
-			"nonce":      tx.Nonce,
-			"gas_limit":  tx.GasLimit,
-			"gas_price":  tx.GasPrice,
-			"fee":        tx.Fee,
-			"timestamp":  tx.Timestamp,
-			"added_at":   tx.AddedAt.Unix(),
-			"priority":   tx.Priority,
+			"status":    "pending",
+			"tx_hash":   fmt.Sprintf("%x", tx.Hash),
+			"from":      fmt.Sprintf("%x", tx.From),
+			"to":        fmt.Sprintf("%x", tx.To),
+			"amount":    tx.Amount,
+			"nonce":     tx.Nonce,
+			"gas_limit": tx.GasLimit,
+			"gas_price": tx.GasPrice,
+			"fee":       tx.Fee,
+			"timestamp": tx.Timestamp,
+			"added_at":  tx.AddedAt.Unix(),
+			"priority":  tx.Priority,
 		})
 		return
 	}
 
-	// TODO: Check database for confirmed transaction
+	// Fall back to the blockstore for a transaction that's already confirmed.
+	blockHash, index, found, err := s.stateManager.GetTransactionLocation(txHash)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to query transaction location")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query transaction"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "transaction not found"})
+		return
+	}
+
+	block, _, err := s.stateManager.GetBlockByHash(blockHash)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to load confirmed transaction's block")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query transaction"})
+		return
+	}
+	if block == nil || index < 0 || index >= len(block.Transactions) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "transaction not found"})
+		return
+	}
+	confirmedTx := block.Transactions[index]
+
+	response := gin.H{
+		"status":       "confirmed",
+		"tx_hash":      fmt.Sprintf("%x", confirmedTx.Hash),
+		"from":         fmt.Sprintf("%x", confirmedTx.From),
+		"to":           fmt.Sprintf("%x", confirmedTx.To),
+		"amount":       confirmedTx.Amount,
+		"nonce":        confirmedTx.Nonce,
+		"fee":          confirmedTx.Fee,
+		"block_hash":   fmt.Sprintf("%x", blockHash),
+		"block_number": block.BlockNumber,
+		"index":        index,
+	}
+
+	if receipt, ok, err := s.stateManager.GetReceipt(txHash); err != nil {
+		s.log.WithError(err).Error("Failed to load transaction receipt")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query transaction"})
+		return
+	} else if ok {
+		response["receipt"] = gin.H{
+			"gas_used": receipt.GasUsed,
+			"success":  receipt.Success,
+		}
+	}
 
-	c.JSON(http.StatusNotFound, gin.H{"error": "transaction not found"})
+	c.JSON(http.StatusOK, response)
 }
 
 // handleMempoolStats returns mempool statistics
 func (s *Server) handleMempoolStats(c *gin.Context) {
 	stats := gin.H{
-		"size":         s.mempool.Size(),
-		"max_size":     10000, // TODO: Get from config
-		"utilization":  float64(s.mempool.Size()) / 10000.0,
+		"size":          s.mempool.Size(),
+		"max_size":      10000, // TODO: Get from config
+		"utilization":   float64(s.mempool.Size()) / 10000.0,
+		"local_pending": s.mempool.LocalPendingSize(),
+		"local_queued":  s.mempool.LocalQueuedSize(),
 	}
 
 	// Get top transactions (by priority)
@@ -217,6 +342,316 @@ func (s *Server) handleMempoolStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// ==================== TXPOOL ENDPOINTS ====================
+//
+// Mirrors the txpool_* RPC namespace common in Ethereum-family nodes, built
+// on top of mempool.Mempool's pending/queued split (see Mempool.Content) so
+// wallets/explorers can debug why a transaction is stuck behind a nonce gap.
+
+// txPoolTxJSON renders tx the same way handleGetTransaction's "pending"
+// branch does, so a client sees one consistent shape for a pending
+// transaction across both endpoints.
+func txPoolTxJSON(tx *mempool.Transaction) gin.H {
+	return gin.H{
+		"tx_hash":   fmt.Sprintf("%x", tx.Hash),
+		"from":      fmt.Sprintf("%x", tx.From),
+		"to":        fmt.Sprintf("%x", tx.To),
+		"amount":    tx.Amount,
+		"nonce":     tx.Nonce,
+		"gas_limit": tx.GasLimit,
+		"gas_price": tx.GasPrice,
+		"fee":       tx.Fee,
+		"timestamp": tx.Timestamp,
+		"added_at":  tx.AddedAt.Unix(),
+		"priority":  tx.Priority,
+	}
+}
+
+// txPoolSummary renders tx as the single human-readable line
+// handleTxPoolInspect returns in place of the full transaction fields.
+func txPoolSummary(tx *mempool.Transaction) string {
+	return fmt.Sprintf("to: 0x%x amount: %d gas: %d@%d", tx.To, tx.Amount, tx.GasLimit, tx.GasPrice)
+}
+
+// txPoolGroupByNonce re-keys one sender's transactions by their (string)
+// nonce, matching the txpool_* RPC namespace's {addr: {nonce: tx}} shape —
+// JSON object keys must be strings, so the nonce can't stay a uint64 key.
+func txPoolGroupByNonce(txs []*mempool.Transaction, render func(*mempool.Transaction) interface{}) gin.H {
+	byNonce := make(gin.H, len(txs))
+	for _, tx := range txs {
+		byNonce[strconv.FormatUint(tx.Nonce, 10)] = render(tx)
+	}
+	return byNonce
+}
+
+// txPoolGroupByAddress re-keys every sender in byAddr by its hex address,
+// rendering each sender's transactions with render.
+func txPoolGroupByAddress(byAddr map[[32]byte][]*mempool.Transaction, render func(*mempool.Transaction) interface{}) gin.H {
+	out := make(gin.H, len(byAddr))
+	for addr, txs := range byAddr {
+		out[fmt.Sprintf("0x%x", addr)] = txPoolGroupByNonce(txs, render)
+	}
+	return out
+}
+
+// handleTxPoolContent returns every pending and queued transaction, grouped
+// by sender and nonce, in full — equivalent to txpool_content.
+func (s *Server) handleTxPoolContent(c *gin.Context) {
+	pending, queued := s.mempool.Content()
+
+	render := func(tx *mempool.Transaction) interface{} { return txPoolTxJSON(tx) }
+	c.JSON(http.StatusOK, gin.H{
+		"pending": txPoolGroupByAddress(pending, render),
+		"queued":  txPoolGroupByAddress(queued, render),
+	})
+}
+
+// handleTxPoolInspect returns the same tree as handleTxPoolContent, but with
+// each transaction collapsed to a human-readable summary line instead of its
+// full fields — equivalent to txpool_inspect.
+func (s *Server) handleTxPoolInspect(c *gin.Context) {
+	pending, queued := s.mempool.Content()
+
+	render := func(tx *mempool.Transaction) interface{} { return txPoolSummary(tx) }
+	c.JSON(http.StatusOK, gin.H{
+		"pending": txPoolGroupByAddress(pending, render),
+		"queued":  txPoolGroupByAddress(queued, render),
+	})
+}
+
+// handleTxPoolStatus returns the current pending and queued pool sizes —
+// equivalent to txpool_status.
+func (s *Server) handleTxPoolStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"pending": s.mempool.PendingSize(),
+		"queued":  s.mempool.QueuedSize(),
+	})
+}
+
+// handleTxPoolContentFrom returns handleTxPoolContent's tree scoped to a
+// single sender address — equivalent to txpool_contentFrom.
+func (s *Server) handleTxPoolContentFrom(c *gin.Context) {
+	address, err := hexToBytes32(c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid address"})
+		return
+	}
+
+	pending, queued := s.mempool.Content()
+	render := func(tx *mempool.Transaction) interface{} { return txPoolTxJSON(tx) }
+
+	response := gin.H{"pending": gin.H{}, "queued": gin.H{}}
+	if txs, ok := pending[address]; ok {
+		response["pending"] = txPoolGroupByNonce(txs, render)
+	}
+	if txs, ok := queued[address]; ok {
+		response["queued"] = txPoolGroupByNonce(txs, render)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// maxSimulationGasLimit bounds how high handleSimulateTransaction's
+// EstimateGas binary search will climb, mirroring cmd/submit-tx's
+// MaxGasLimit security ceiling.
+const maxSimulationGasLimit = 10000000
+
+// handleSimulateTransaction runs a read-only dry-run of an unsigned or
+// signed transaction against current account state and returns an
+// ExecutionResult-style preview, without touching the mempool or state DB.
+func (s *Server) handleSimulateTransaction(c *gin.Context) {
+	var txReq struct {
+		From                 string `json:"from" binding:"required"`
+		To                   string `json:"to" binding:"required"`
+		Amount               uint64 `json:"amount"`
+		Nonce                uint64 `json:"nonce"`
+		GasLimit             uint64 `json:"gas_limit"`
+		GasPrice             uint64 `json:"gas_price"`
+		MaxFeePerGas         uint64 `json:"max_fee_per_gas"`
+		MaxPriorityFeePerGas uint64 `json:"max_priority_fee_per_gas"`
+		TxType               uint8  `json:"tx_type"`
+		Data                 string `json:"data"`
+		EstimateGas          bool   `json:"estimate_gas"`
+	}
+
+	if err := c.ShouldBindJSON(&txReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid simulation request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	from, err := hexToBytes32(txReq.From)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from address"})
+		return
+	}
+
+	to, err := hexToBytes32(txReq.To)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to address"})
+		return
+	}
+
+	var data []byte
+	if txReq.Data != "" {
+		data, err = hex.DecodeString(txReq.Data)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid data hex"})
+			return
+		}
+	}
+
+	gasLimit := txReq.GasLimit
+	if gasLimit == 0 {
+		gasLimit = execution.MinGasLimit
+	}
+
+	tx := &mempool.Transaction{
+		From:                 from,
+		To:                   to,
+		Amount:               txReq.Amount,
+		Nonce:                txReq.Nonce,
+		GasLimit:             gasLimit,
+		GasPrice:             txReq.GasPrice,
+		MaxFeePerGas:         txReq.MaxFeePerGas,
+		MaxPriorityFeePerGas: txReq.MaxPriorityFeePerGas,
+		TxType:               txReq.TxType,
+		Data:                 data,
+		Timestamp:            time.Now().Unix(),
+	}
+
+	result, err := s.sim.Simulate(tx)
+	if err != nil {
+		s.log.WithError(err).Error("Transaction simulation failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "simulation failed"})
+		return
+	}
+
+	response := gin.H{
+		"used_gas":     result.UsedGas,
+		"refunded_gas": result.RefundedGas,
+		"success":      result.Err == nil,
+	}
+	if result.Err != nil {
+		response["error"] = result.Err.Error()
+	}
+
+	if txReq.EstimateGas {
+		if estimate, err := execution.EstimateGas(s.sim, tx, maxSimulationGasLimit); err == nil {
+			response["gas_estimate"] = estimate
+		} else {
+			response["gas_estimate_error"] = err.Error()
+		}
+	}
+
+	if s.wsHub != nil {
+		s.wsHub.Broadcast("simulation", "simulation", response)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ==================== FEE MARKET ENDPOINTS ====================
+
+// handleSuggestBaseFee returns the current rolling EIP-1559 base fee.
+//
+// TODO: once block storage is implemented, derive this from the next
+// block's computed base fee rather than the mempool's cached value.
+func (s *Server) handleSuggestBaseFee(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"base_fee": s.mempool.BaseFee(),
+	})
+}
+
+// handleSuggestGasTipCap suggests a priority fee (tip) likely to get a
+// transaction included promptly, based on the effective tips of
+// transactions currently sitting in the mempool.
+//
+// TODO: once block storage is implemented, this should inspect a fee
+// histogram over recent blocks rather than the live mempool snapshot.
+func (s *Server) handleSuggestGasTipCap(c *gin.Context) {
+	baseFee := s.mempool.BaseFee()
+	topTxs := s.mempool.GetTopTransactions(20)
+
+	if len(topTxs) == 0 {
+		c.JSON(http.StatusOK, gin.H{
+			"tip_cap":     uint64(1),
+			"base_fee":    baseFee,
+			"sample_size": 0,
+		})
+		return
+	}
+
+	// Median effective tip of the highest-priority pending transactions
+	tips := make([]uint64, len(topTxs))
+	for i, tx := range topTxs {
+		tips[i] = tx.EffectiveTip(baseFee)
+	}
+	sortUint64s(tips)
+	median := tips[len(tips)/2]
+
+	c.JSON(http.StatusOK, gin.H{
+		"tip_cap":     median,
+		"base_fee":    baseFee,
+		"sample_size": len(tips),
+	})
+}
+
+// handleGetEvents serves the same replay log the WebSocket hub uses to
+// resume a subscription, for clients (indexers, block explorers) that would
+// rather poll than hold a WS connection open. Query params: topic
+// (required), since (event_seq, exclusive) or since_time (unix seconds,
+// inclusive) — at most one of the two.
+func (s *Server) handleGetEvents(c *gin.Context) {
+	topic := c.Query("topic")
+	if topic == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "topic is required"})
+		return
+	}
+
+	sinceStr := c.Query("since")
+	sinceTimeStr := c.Query("since_time")
+	if sinceStr != "" && sinceTimeStr != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "specify only one of since or since_time"})
+		return
+	}
+
+	var events []*loggedEvent
+	switch {
+	case sinceTimeStr != "":
+		sinceTime, err := strconv.ParseInt(sinceTimeStr, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since_time"})
+			return
+		}
+		events = s.wsHub.EventsSinceTime(topic, sinceTime)
+	default:
+		since, err := strconv.ParseUint(sinceStr, 10, 64) // sinceStr == "" parses to 0, i.e. everything retained
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since"})
+			return
+		}
+		events = s.wsHub.EventsSince(topic, since)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"topic":  topic,
+		"events": events,
+	})
+}
+
+// sortUint64s sorts a small slice of uint64s in place (insertion sort is
+// fine here — callers pass at most a few dozen samples).
+func sortUint64s(vals []uint64) {
+	for i := 1; i < len(vals); i++ {
+		for j := i; j > 0 && vals[j-1] > vals[j]; j-- {
+			vals[j-1], vals[j] = vals[j], vals[j-1]
+		}
+	}
+}
+
 // ==================== ACCOUNT ENDPOINTS ====================
 
 // handleGetAccount retrieves account balance and nonce
@@ -408,6 +843,239 @@ func (s *Server) handleGetEscrow(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// ==================== DEPOSIT ENDPOINTS ====================
+
+// depositGasLimit is the fixed gas cost charged for a deposit transaction,
+// analogous to the 21000 gas/transfer convention used elsewhere in this
+// file; deposits carry no arbitrary data beyond the fixed-size payload, so
+// there is no per-byte component to size for.
+const depositGasLimit uint64 = 60000
+
+// handleSubmitDeposit registers a new validator by submitting a deposit as
+// a specially typed transaction (bindings.TxTypeDeposit). This mirrors the
+// execution-layer-triggered deposit design referenced in the request:
+// validator set changes flow from user transactions rather than an
+// out-of-band API, giving the PoA authority set an auditable, on-chain
+// rotation mechanism.
+func (s *Server) handleSubmitDeposit(c *gin.Context) {
+	var depositReq struct {
+		Pubkey                string `json:"pubkey" binding:"required"`                 // Hex-encoded validator public key (64 chars)
+		WithdrawalCredentials string `json:"withdrawal_credentials" binding:"required"` // Hex-encoded withdrawal address (64 chars)
+		Amount                uint64 `json:"amount" binding:"required"`                 // Deposit amount in wei
+		Signature             string `json:"signature" binding:"required"`              // Hex-encoded signature (128 chars)
+	}
+
+	if err := c.ShouldBindJSON(&depositReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid deposit request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	pubkey, err := hexToBytes32(depositReq.Pubkey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pubkey"})
+		return
+	}
+
+	withdrawalCredentials, err := hexToBytes32(depositReq.WithdrawalCredentials)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid withdrawal_credentials"})
+		return
+	}
+
+	signature, err := hexToBytes64(depositReq.Signature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid signature"})
+		return
+	}
+
+	// The depositor signs with the validator key itself, so it doubles as
+	// the sending account (deposits are self-funded, not a transfer to a
+	// third party).
+	senderAccount, err := s.stateManager.GetAccount(pubkey)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get depositor account")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	tx := &bindings.Transaction{
+		CodecVersion: bindings.FeeEnvelopeLegacy,
+		TxType:       bindings.TxTypeDeposit,
+		From:         pubkey,
+		To:           pubkey,
+		Amount:       depositReq.Amount,
+		Nonce:        senderAccount.Nonce,
+		GasLimit:     depositGasLimit,
+		GasPrice:     s.mempool.BaseFee(),
+		Signature:    signature,
+		Data:         bindings.EncodeDepositData(pubkey, withdrawalCredentials, depositReq.Amount),
+		ChainID:      s.chainID,
+		Timestamp:    time.Now().Unix(),
+	}
+
+	senderState := &bindings.AccountState{
+		Balance: senderAccount.Balance,
+		Nonce:   senderAccount.Nonce,
+	}
+
+	result, err := bindings.VerifyTransaction(tx, senderState, s.chainID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "deposit validation failed",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if !result.Valid {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "deposit invalid"})
+		return
+	}
+
+	txHash := computeTxHash(tx)
+
+	mempoolTx := &mempool.Transaction{
+		Hash:      txHash,
+		From:      tx.From,
+		To:        tx.To,
+		Amount:    tx.Amount,
+		Nonce:     tx.Nonce,
+		GasLimit:  tx.GasLimit,
+		GasPrice:  tx.GasPrice,
+		Signature: tx.Signature,
+		Data:      tx.Data,
+		ChainID:   tx.ChainID,
+		Timestamp: tx.Timestamp,
+		TxType:    tx.TxType,
+		Fee:       result.Fee,
+		AddedAt:   time.Now(),
+	}
+
+	if err := s.submitTransaction(mempoolTx); err != nil {
+		c.JSON(submitTransactionStatus(err), gin.H{
+			"error":   "failed to add deposit to mempool",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	s.p2pManager.BroadcastTransaction(mempoolTx)
+
+	s.BroadcastDeposit(txHash, pubkey, withdrawalCredentials, depositReq.Amount)
+
+	s.log.WithFields(logger.Fields{
+		"tx_hash": fmt.Sprintf("%x", txHash[:8]),
+		"pubkey":  fmt.Sprintf("%x", pubkey[:8]),
+		"amount":  depositReq.Amount,
+	}).Info("Deposit submitted")
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "accepted",
+		"tx_hash": fmt.Sprintf("%x", txHash),
+		"fee":     result.Fee,
+	})
+}
+
+// handleGetDeposits retrieves every deposit included in a given block,
+// ordered by their index in the block's DepositsRoot.
+func (s *Server) handleGetDeposits(c *gin.Context) {
+	blockNumber, err := strconv.ParseUint(c.Param("block_number"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid block number"})
+		return
+	}
+
+	deposits, err := s.stateManager.GetDepositsByBlock(blockNumber)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get deposits")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	out := make([]gin.H, len(deposits))
+	for i, d := range deposits {
+		out[i] = gin.H{
+			"index":                  d.Index,
+			"pubkey":                 fmt.Sprintf("%x", d.Pubkey),
+			"withdrawal_credentials": fmt.Sprintf("%x", d.WithdrawalCredentials),
+			"amount":                 d.Amount,
+			"tx_hash":                fmt.Sprintf("%x", d.TxHash),
+			"created_at":             d.CreatedAt.Unix(),
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"block_number": blockNumber,
+		"deposits":     out,
+	})
+}
+
+// ==================== VALIDATOR ENDPOINTS ====================
+
+// handleUnjailValidator implements the TxUnjail recovery path (see
+// consensus.SlashingManager.Unjail): the validator's own key must sign the
+// request, so release from jail is an accountable, operator-visible action
+// rather than silent once JailDuration elapses.
+func (s *Server) handleUnjailValidator(c *gin.Context) {
+	addressHex := c.Param("address")
+
+	validator, err := hexToBytes32(addressHex)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid validator address"})
+		return
+	}
+
+	var req struct {
+		Signature string `json:"signature" binding:"required"` // Hex-encoded signature (128 chars)
+		Height    uint64 `json:"height"`                       // Current block height, to reset the liveness grace period
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid unjail request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	signature, err := hexToBytes64(req.Signature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid signature"})
+		return
+	}
+
+	if err := s.slashing.Unjail(validator, signature[:], req.Height); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"validator": fmt.Sprintf("%x", validator),
+		"status":    "unjailed",
+	})
+}
+
+// handleSubmitEvidence accepts slashing evidence (double-sign, wrong-turn,
+// or invalid-block) from an operator or external detector, verifies it, and
+// feeds it to the evidence pool (see consensus.EvidencePool), which handles
+// deduplication, persistence, gossip to other nodes, and slashing.
+func (s *Server) handleSubmitEvidence(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	if err := s.evidence.SubmitEncoded(body, false, c.ClientIP()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "accepted"})
+}
+
 // ==================== HELPER FUNCTIONS ====================
 
 // hexToBytes32 converts hex string to [32]byte
@@ -454,49 +1122,26 @@ func hexToBytes64(hexStr string) ([64]byte, error) {
 	return result, nil
 }
 
-// computeTxHash computes transaction hash (same as in transactions.go)
-func computeTxHash(tx *bindings.Transaction) [32]byte {
-	message := make([]byte, 0, 256)
-	message = append(message, tx.CodecVersion)
-	message = append(message, tx.TxType)
-	message = append(message, tx.From[:]...)
-	message = append(message, tx.To[:]...)
-	message = append(message, uint64ToBytes(tx.Amount)...)
-	message = append(message, uint64ToBytes(tx.Nonce)...)
-	message = append(message, uint64ToBytes(tx.GasLimit)...)
-	message = append(message, uint64ToBytes(tx.GasPrice)...)
-	message = append(message, uint32ToBytes(uint32(len(tx.Data)))...)
-	message = append(message, tx.Data...)
-	message = append(message, int64ToBytes(tx.Timestamp)...)
-
-	hash, _ := bindings.SHA256(message)
-	return hash
-}
-
-func uint64ToBytes(n uint64) []byte {
-	b := make([]byte, 8)
-	b[0] = byte(n)
-	b[1] = byte(n >> 8)
-	b[2] = byte(n >> 16)
-	b[3] = byte(n >> 24)
-	b[4] = byte(n >> 32)
-	b[5] = byte(n >> 40)
-	b[6] = byte(n >> 48)
-	b[7] = byte(n >> 56)
-	return b
-}
-
-func uint32ToBytes(n uint32) []byte {
-	b := make([]byte, 4)
-	b[0] = byte(n)
-	b[1] = byte(n >> 8)
-	b[2] = byte(n >> 16)
-	b[3] = byte(n >> 24)
-	return b
+// effectiveGasPrice computes the price per unit gas a dynamic fee
+// transaction actually pays at inclusion time, mirroring EIP-1559:
+// min(maxFeePerGas, baseFee + maxPriorityFeePerGas).
+func effectiveGasPrice(baseFee, maxFeePerGas, maxPriorityFeePerGas uint64) uint64 {
+	capped := baseFee + maxPriorityFeePerGas
+	if capped > maxFeePerGas {
+		return maxFeePerGas
+	}
+	return capped
 }
 
-func int64ToBytes(n int64) []byte {
-	return uint64ToBytes(uint64(n))
+// computeTxHash computes the transaction hash via bindings.TxHash, the
+// canonical codec-driven encoding every hashing call site in this repo now
+// shares (see pkg/codec) instead of each packing its own bytes.
+func computeTxHash(tx *bindings.Transaction) [32]byte {
+	hash, err := bindings.TxHash(tx)
+	if err != nil {
+		return [32]byte{}
+	}
+	return hash
 }
 
 func escrowStateToString(escrowState uint8) string {
@@ -514,6 +1159,45 @@ func escrowStateToString(escrowState uint8) string {
 
 // ==================== BLOCK ENDPOINTS ====================
 
+// blockToJSON renders a stored block and its receipts into the API's block
+// response shape, shared by handleGetBlock, handleGetBlockByNumber, and
+// handleGetLatestBlock.
+func blockToJSON(block *blockstore.Block, receipts []blockstore.Receipt) gin.H {
+	receiptByTx := make(map[[32]byte]blockstore.Receipt, len(receipts))
+	for _, r := range receipts {
+		receiptByTx[r.TxHash] = r
+	}
+
+	txs := make([]gin.H, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		entry := gin.H{
+			"tx_hash": fmt.Sprintf("%x", tx.Hash),
+			"from":    fmt.Sprintf("%x", tx.From),
+			"to":      fmt.Sprintf("%x", tx.To),
+			"amount":  tx.Amount,
+			"nonce":   tx.Nonce,
+			"fee":     tx.Fee,
+		}
+		if r, ok := receiptByTx[tx.Hash]; ok {
+			entry["gas_used"] = r.GasUsed
+			entry["success"] = r.Success
+		}
+		txs[i] = entry
+	}
+
+	return gin.H{
+		"block_number": block.BlockNumber,
+		"block_hash":   fmt.Sprintf("%x", block.BlockHash),
+		"parent_hash":  fmt.Sprintf("%x", block.ParentHash),
+		"state_root":   fmt.Sprintf("%x", block.StateRoot),
+		"validator":    fmt.Sprintf("%x", block.Validator),
+		"timestamp":    block.Timestamp,
+		"gas_used":     block.GasUsed,
+		"tx_count":     len(block.Transactions),
+		"transactions": txs,
+	}
+}
+
 // handleGetBlock retrieves a block by hash
 func (s *Server) handleGetBlock(c *gin.Context) {
 	blockHashHex := c.Param("hash")
@@ -524,43 +1208,118 @@ func (s *Server) handleGetBlock(c *gin.Context) {
 		return
 	}
 
-	// TODO: Retrieve from state manager once block storage is implemented
-	// For now, check if it's in P2P cache or return not found
+	block, receipts, err := s.stateManager.GetBlockByHash(blockHash)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to query block by hash")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query block"})
+		return
+	}
+	if block == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "block not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, blockToJSON(block, receipts))
+}
+
+// handleGetBlockWitness builds the stateless-verification witness for a
+// canonical block: the pre-state of every account its transactions touch,
+// enough for a light client to confirm the block's StateRoot via
+// bindings.VerifyBlockStateless without holding the full account database.
+//
+// This reads current account state rather than historical state as of the
+// block's height, since this chain does not snapshot state per block yet
+// (see bindings.AccountSnapshot's doc comment) — for the latest block
+// that's exactly the witness a verifier needs; for an older block it's
+// only correct if no later transaction has touched the same accounts.
+func (s *Server) handleGetBlockWitness(c *gin.Context) {
+	blockHashHex := c.Param("hash")
+
+	blockHash, err := hexToBytes32(blockHashHex)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid block hash"})
+		return
+	}
+
+	block, _, err := s.stateManager.GetBlockByHash(blockHash)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to query block by hash")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query block"})
+		return
+	}
+	if block == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "block not found"})
+		return
+	}
+
+	accounts := make(map[[32]byte]bindings.AccountSnapshot)
+	for _, tx := range block.Transactions {
+		for _, addr := range [2][32]byte{tx.From, tx.To} {
+			if _, ok := accounts[addr]; ok {
+				continue
+			}
+			account, err := s.stateManager.GetAccount(addr)
+			if err != nil {
+				s.log.WithError(err).Error("Failed to load witness account")
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build witness"})
+				return
+			}
+			accounts[addr] = bindings.AccountSnapshot{Balance: account.Balance, Nonce: account.Nonce}
+		}
+	}
 
-	s.log.WithField("block_hash", fmt.Sprintf("%x", blockHash[:8])).Debug("Block lookup (storage not yet implemented)")
+	out := make(map[string]bindings.AccountSnapshot, len(accounts))
+	for addr, snapshot := range accounts {
+		out[fmt.Sprintf("%x", addr)] = snapshot
+	}
 
-	c.JSON(http.StatusNotFound, gin.H{
-		"error":  "block not found",
-		"reason": "block storage not yet implemented",
-		"hint":   "blocks are propagated via P2P but not persisted to database yet",
+	c.JSON(http.StatusOK, gin.H{
+		"block_hash": fmt.Sprintf("%x", block.BlockHash),
+		"accounts":   out,
 	})
 }
 
-// handleGetLatestBlock retrieves the latest block
-func (s *Server) handleGetLatestBlock(c *gin.Context) {
-	// TODO: Retrieve latest block from state manager once implemented
-	// For now, return placeholder response with expected structure
+// handleGetFinality reports the current finalized checkpoint alongside the
+// canonical tip, so a client can tell how far behind the tip finality has
+// landed (see consensus.ForkChoice's FinalityConfig for the rule that
+// advances it automatically).
+func (s *Server) handleGetFinality(c *gin.Context) {
+	if s.forkChoice == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "consensus engine not wired into this node"})
+		return
+	}
 
-	s.log.Debug("Latest block lookup (storage not yet implemented)")
+	finalized := s.forkChoice.GetFinalized()
+	if finalized == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "no finalized checkpoint yet"})
+		return
+	}
+	canonical := s.forkChoice.GetCanonicalTip()
 
 	c.JSON(http.StatusOK, gin.H{
-		"status": "operational",
-		"message": "block storage not yet implemented",
-		"hint":    "latest block will be available once consensus and block persistence are added",
-		"expected_fields": gin.H{
-			"block_number": "uint64",
-			"block_hash":   "hex string",
-			"parent_hash":  "hex string",
-			"state_root":   "hex string",
-			"tx_root":      "hex string",
-			"timestamp":    "unix timestamp",
-			"miner":        "hex address",
-			"difficulty":   "uint64",
-			"tx_count":     "number of transactions",
-		},
+		"finalized_hash":   fmt.Sprintf("%x", finalized.Block.BlockHash),
+		"finalized_height": finalized.Height,
+		"canonical_hash":   fmt.Sprintf("%x", canonical.Block.BlockHash),
+		"canonical_height": canonical.Height,
 	})
 }
 
+// handleGetLatestBlock retrieves the latest block
+func (s *Server) handleGetLatestBlock(c *gin.Context) {
+	block, receipts, err := s.stateManager.GetLatestBlock()
+	if err != nil {
+		s.log.WithError(err).Error("Failed to query latest block")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query latest block"})
+		return
+	}
+	if block == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no blocks persisted yet"})
+		return
+	}
+
+	c.JSON(http.StatusOK, blockToJSON(block, receipts))
+}
+
 // handleGetBlockByNumber retrieves a block by number
 func (s *Server) handleGetBlockByNumber(c *gin.Context) {
 	blockNumberStr := c.Param("number")
@@ -571,13 +1330,152 @@ func (s *Server) handleGetBlockByNumber(c *gin.Context) {
 		return
 	}
 
-	// TODO: Retrieve from state manager once block storage is implemented
+	block, receipts, err := s.stateManager.GetBlockByNumber(blockNumber)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to query block by number")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query block"})
+		return
+	}
+	if block == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "block not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, blockToJSON(block, receipts))
+}
 
-	s.log.WithField("block_number", blockNumber).Debug("Block lookup by number (storage not yet implemented)")
+// ==================== RECEIPT & LOG ENDPOINTS ====================
 
-	c.JSON(http.StatusNotFound, gin.H{
-		"error":  "block not found",
-		"reason": "block storage not yet implemented",
-		"hint":   "blocks are propagated via P2P but not persisted to database yet",
-	})
+// logToJSON renders a receipts.Log into the API's log response shape.
+func logToJSON(l receipts.Log) gin.H {
+	topics := make([]string, len(l.Topics))
+	for i, topic := range l.Topics {
+		topics[i] = fmt.Sprintf("%x", topic)
+	}
+
+	return gin.H{
+		"address": fmt.Sprintf("%x", l.Address),
+		"topics":  topics,
+		"data":    fmt.Sprintf("%x", l.Data),
+	}
+}
+
+// handleGetReceipt retrieves the full execution receipt (status, gas used,
+// cumulative gas used, logs, contract address) for a confirmed
+// transaction, persisted by pkg/receipts at finalization time. This is the
+// richer counterpart to the minimal receipt embedded in
+// handleGetTransaction's response.
+func (s *Server) handleGetReceipt(c *gin.Context) {
+	txHashHex := c.Param("hash")
+
+	txHash, err := hexToBytes32(txHashHex)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction hash"})
+		return
+	}
+
+	receipt, ok, err := s.stateManager.Receipts().GetReceipt(txHash)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to load transaction receipt")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query receipt"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "receipt not found"})
+		return
+	}
+
+	logs := make([]gin.H, len(receipt.Logs))
+	for i, l := range receipt.Logs {
+		logs[i] = logToJSON(l)
+	}
+
+	response := gin.H{
+		"tx_hash":             fmt.Sprintf("%x", receipt.TxHash),
+		"block_hash":          fmt.Sprintf("%x", receipt.BlockHash),
+		"block_number":        receipt.BlockNumber,
+		"tx_index":            receipt.TxIndex,
+		"status":              receipt.Status,
+		"gas_used":            receipt.GasUsed,
+		"cumulative_gas_used": receipt.CumulativeGasUsed,
+		"logs":                logs,
+	}
+	if receipt.ContractAddress != nil {
+		response["contract_address"] = fmt.Sprintf("%x", *receipt.ContractAddress)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// maxLogFilterBlockRange bounds how many blocks handleGetLogs will scan per
+// request — even with bloom filtering skipping most of them cheaply,
+// decoding receipts for every candidate in an unbounded range is still
+// real work an unauthenticated caller shouldn't be able to force for free.
+const maxLogFilterBlockRange = 10000
+
+// handleGetLogs runs pkg/receipts.FilterLogs over [from_block, to_block],
+// optionally narrowed by address and topic, mirroring eth_getLogs. Pass
+// address/topic multiple times to match any one of several values.
+func (s *Server) handleGetLogs(c *gin.Context) {
+	fromBlock, err := strconv.ParseUint(c.Query("from_block"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing from_block"})
+		return
+	}
+	toBlock, err := strconv.ParseUint(c.Query("to_block"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing to_block"})
+		return
+	}
+	if toBlock < fromBlock {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to_block must be >= from_block"})
+		return
+	}
+	if toBlock-fromBlock > maxLogFilterBlockRange {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":     "block range too large",
+			"max_range": maxLogFilterBlockRange,
+		})
+		return
+	}
+
+	query := receipts.FilterQuery{FromBlock: fromBlock, ToBlock: toBlock}
+
+	for _, addrHex := range c.QueryArray("address") {
+		addr, err := hexToBytes32(addrHex)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid address"})
+			return
+		}
+		query.Addresses = append(query.Addresses, addr)
+	}
+
+	for _, topicHex := range c.QueryArray("topic") {
+		topic, err := hexToBytes32(topicHex)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid topic"})
+			return
+		}
+		query.Topics = append(query.Topics, topic)
+	}
+
+	matches, err := s.stateManager.Receipts().FilterLogs(query)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to filter logs")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to filter logs"})
+		return
+	}
+
+	out := make([]gin.H, len(matches))
+	for i, m := range matches {
+		entry := logToJSON(m.Log)
+		entry["block_hash"] = fmt.Sprintf("%x", m.BlockHash)
+		entry["block_number"] = m.BlockNumber
+		entry["tx_hash"] = fmt.Sprintf("%x", m.TxHash)
+		entry["tx_index"] = m.TxIndex
+		entry["log_index"] = m.LogIndex
+		out[i] = entry
+	}
+
+	c.JSON(http.StatusOK, gin.H{"logs": out})
 }
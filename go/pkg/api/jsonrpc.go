@@ -0,0 +1,219 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/bindings"
+	"github.com/gin-gonic/gin"
+)
+
+// jsonrpc.go is the one JSON-RPC 2.0 endpoint this API exposes, alongside
+// the REST routes in server.go. It exists only to give external clients
+// (wallets, other nodes) a coinj_-namespaced method surface without forcing
+// them onto our REST shapes — it is not a general RPC framework, just a
+// dispatch table keyed by method name.
+
+// rpcRequest is a JSON-RPC 2.0 request object.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response object. Result and Error are
+// mutually exclusive per spec, enforced by only ever setting one of them.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// JSON-RPC 2.0 standard error codes.
+const (
+	rpcErrParse          = -32700
+	rpcErrInvalidRequest = -32600
+	rpcErrMethodNotFound = -32601
+	rpcErrInvalidParams  = -32602
+	rpcErrInternal       = -32603
+)
+
+// handleJSONRPC dispatches a single JSON-RPC 2.0 request to the matching
+// coinj_ method. Batched requests (a JSON array body) aren't supported —
+// coinj_simulateTransaction is the only method today and nothing needs it
+// yet.
+func (s *Server) handleJSONRPC(c *gin.Context) {
+	var req rpcRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, rpcResponse{
+			JSONRPC: "2.0",
+			Error:   &rpcError{Code: rpcErrParse, Message: "invalid JSON-RPC request: " + err.Error()},
+		})
+		return
+	}
+
+	var (
+		result interface{}
+		rpcErr *rpcError
+	)
+
+	switch req.Method {
+	case "coinj_simulateTransaction":
+		result, rpcErr = s.rpcSimulateTransaction(req.Params)
+	default:
+		rpcErr = &rpcError{Code: rpcErrMethodNotFound, Message: "method not found: " + req.Method}
+	}
+
+	c.JSON(http.StatusOK, rpcResponse{
+		JSONRPC: "2.0",
+		Result:  result,
+		Error:   rpcErr,
+		ID:      req.ID,
+	})
+}
+
+// rpcStateOverrideParams is the wire shape of coinj_simulateTransaction's
+// eth_call-style state overrides: addresses are hex strings (64 hex chars,
+// optionally 0x-prefixed, matching hexToBytes32) since JSON has no byte-array
+// type.
+type rpcStateOverrideParams struct {
+	BaseFeePerGas uint64            `json:"base_fee_per_gas"`
+	Validator     string            `json:"validator"`
+	Balances      map[string]uint64 `json:"balances"`
+	Nonces        map[string]uint64 `json:"nonces"`
+}
+
+// rpcSimulateTransactionParams is coinj_simulateTransaction's params object:
+// the transaction to preview plus the state it should be previewed against.
+type rpcSimulateTransactionParams struct {
+	CodecVersion         uint8                  `json:"codec_version"`
+	TxType               uint8                  `json:"tx_type"`
+	From                 string                 `json:"from"`
+	To                   string                 `json:"to"`
+	Amount               uint64                 `json:"amount"`
+	Nonce                uint64                 `json:"nonce"`
+	GasLimit             uint64                 `json:"gas_limit"`
+	GasPrice             uint64                 `json:"gas_price"`
+	MaxFeePerGas         uint64                 `json:"max_fee_per_gas"`
+	MaxPriorityFeePerGas uint64                 `json:"max_priority_fee_per_gas"`
+	ChainID              uint64                 `json:"chain_id"`
+	Overrides            rpcStateOverrideParams `json:"overrides"`
+}
+
+// rpcSimulateTransaction implements coinj_simulateTransaction: it builds a
+// bindings.Transaction and bindings.StateOverrides from params and runs
+// bindings.SimulateTransaction, so external clients get the exact same
+// preview (gas used, and the resulting sender/recipient/validator/burn/
+// treasury balances) the fee-test node gets from calling the bindings
+// function directly.
+func (s *Server) rpcSimulateTransaction(raw json.RawMessage) (interface{}, *rpcError) {
+	var params rpcSimulateTransactionParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &rpcError{Code: rpcErrInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+
+	from, err := hexToBytes32(params.From)
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrInvalidParams, Message: "invalid from address"}
+	}
+	to, err := hexToBytes32(params.To)
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrInvalidParams, Message: "invalid to address"}
+	}
+
+	codecVersion := params.CodecVersion
+	if codecVersion == 0 {
+		codecVersion = bindings.FeeEnvelopeLegacy
+	}
+
+	tx := &bindings.Transaction{
+		CodecVersion:         codecVersion,
+		TxType:               params.TxType,
+		From:                 from,
+		To:                   to,
+		Amount:               params.Amount,
+		Nonce:                params.Nonce,
+		GasLimit:             params.GasLimit,
+		GasPrice:             params.GasPrice,
+		MaxFeePerGas:         params.MaxFeePerGas,
+		MaxPriorityFeePerGas: params.MaxPriorityFeePerGas,
+		ChainID:              params.ChainID,
+	}
+
+	overrides, rpcErr := s.rpcStateOverrides(params.Overrides)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	result, err := bindings.SimulateTransaction(tx, overrides)
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrInvalidParams, Message: err.Error()}
+	}
+
+	return gin.H{
+		"gas_used":          result.GasUsed,
+		"sender_balance":    result.SenderBalance,
+		"recipient_balance": result.RecipientBalance,
+		"validator_balance": result.ValidatorBalance,
+		"burn_balance":      result.BurnBalance,
+		"treasury_balance":  result.TreasuryBalance,
+	}, nil
+}
+
+// rpcStateOverrides converts rpcStateOverrideParams into a
+// bindings.StateOverrides backed by s.stateManager: Get reads the node's
+// live account state, and Balances/Nonces patch it per-address exactly as
+// the caller specified, without ever writing back to stateManager.
+func (s *Server) rpcStateOverrides(params rpcStateOverrideParams) (*bindings.StateOverrides, *rpcError) {
+	var validator [32]byte
+	if params.Validator != "" {
+		v, err := hexToBytes32(params.Validator)
+		if err != nil {
+			return nil, &rpcError{Code: rpcErrInvalidParams, Message: "invalid validator address"}
+		}
+		validator = v
+	}
+
+	balances := make(map[[32]byte]uint64, len(params.Balances))
+	for addrHex, balance := range params.Balances {
+		addr, err := hexToBytes32(addrHex)
+		if err != nil {
+			return nil, &rpcError{Code: rpcErrInvalidParams, Message: "invalid balance override address: " + addrHex}
+		}
+		balances[addr] = balance
+	}
+
+	nonces := make(map[[32]byte]uint64, len(params.Nonces))
+	for addrHex, nonce := range params.Nonces {
+		addr, err := hexToBytes32(addrHex)
+		if err != nil {
+			return nil, &rpcError{Code: rpcErrInvalidParams, Message: "invalid nonce override address: " + addrHex}
+		}
+		nonces[addr] = nonce
+	}
+
+	return &bindings.StateOverrides{
+		Get: func(address [32]byte) (*bindings.AccountState, error) {
+			// GetAccount itself already returns a zero-balance Account
+			// (rather than an error) for an address with no row, so no
+			// special-casing is needed here for a fresh address.
+			account, err := s.stateManager.GetAccount(address)
+			if err != nil {
+				return nil, err
+			}
+			return &bindings.AccountState{Balance: account.Balance, Nonce: account.Nonce}, nil
+		},
+		Balances:      balances,
+		Nonces:        nonces,
+		BaseFeePerGas: params.BaseFeePerGas,
+		Validator:     validator,
+	}, nil
+}
@@ -10,9 +10,13 @@ import (
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/execution"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/flowcontrol"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/ipfs"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/limiter"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool/admission"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/p2p"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
 	"github.com/gin-gonic/gin"
@@ -68,12 +72,19 @@ var (
 // Server is the REST API server
 type Server struct {
 	config       config.APIConfig
+	chainID      uint64 // replay-protection id checked against submitted transactions' ChainID
 	log          *logger.Logger
-	limiter      *limiter.RateLimiter
+	limiter      limiter.Limiter
 	ipfsClient   *ipfs.IPFSClient
 	p2pManager   *p2p.Manager
 	mempool      *mempool.Mempool
+	verifier     *mempool.Verifier     // nil until SetVerifier is called; falls back to a synchronous AddTransaction
+	admission    *admission.Controller // nil until SetAdmissionController is called; falls back to submitting straight to the verifier/mempool
 	stateManager *state.StateManager
+	slashing     *consensus.SlashingManager
+	evidence     *consensus.EvidencePool
+	forkChoice   *consensus.ForkChoice
+	sim          *execution.Simulator
 	wsHub        *WSHub
 	router       *gin.Engine
 	httpServer   *http.Server
@@ -82,11 +93,14 @@ type Server struct {
 // NewServer creates a new API server
 func NewServer(
 	cfg config.APIConfig,
-	rateLimiter *limiter.RateLimiter,
+	chainID uint64,
+	rateLimiter limiter.Limiter,
 	ipfsClient *ipfs.IPFSClient,
 	p2pManager *p2p.Manager,
 	mp *mempool.Mempool,
 	sm *state.StateManager,
+	slashing *consensus.SlashingManager,
+	evidence *consensus.EvidencePool,
 	log *logger.Logger,
 ) *Server {
 	// Set Gin mode
@@ -95,26 +109,93 @@ func NewServer(
 	router := gin.New()
 	router.Use(gin.Recovery())
 
-	// Create WebSocket hub
-	wsHub := NewWSHub(log)
+	// Create the replay log backing the WebSocket hub, then the hub itself.
+	eventLog, eventLogErr := NewEventLog(cfg.EventLogPath, cfg.EventRingSize, log)
+	if eventLogErr != nil {
+		log.WithError(eventLogErr).Error("Failed to open WS event log on disk; replay will be in-memory only")
+		eventLog, _ = NewEventLog("", cfg.EventRingSize, log)
+	}
+
+	wsHub := NewWSHub(log, eventLog)
+	wsHub.SetBackpressureLimiter(rateLimiter, cfg.WSMaxBuffered)
 	go wsHub.Run()
 
 	s := &Server{
 		config:       cfg,
+		chainID:      chainID,
 		log:          log,
 		limiter:      rateLimiter,
 		ipfsClient:   ipfsClient,
 		p2pManager:   p2pManager,
 		mempool:      mp,
 		stateManager: sm,
+		slashing:     slashing,
+		evidence:     evidence,
+		sim:          execution.NewSimulator(sm),
 		wsHub:        wsHub,
 		router:       router,
 	}
 
+	mp.SetTxEventSink(s)
+
 	s.setupRoutes()
 	return s
 }
 
+// SetForkChoice wires a consensus.ForkChoice into the server so
+// /v1/consensus/finality can report finality status. A node running the
+// API without a local consensus engine (e.g. a pure indexer) can leave
+// this unset; the endpoint reports 503 until it's called.
+func (s *Server) SetForkChoice(fc *consensus.ForkChoice) {
+	s.forkChoice = fc
+}
+
+// SetVerifier wires a mempool.Verifier into the server so transaction
+// submission runs signature verification through its worker pool instead of
+// blocking the handling goroutine. Leaving it unset makes submission fall
+// back to adding straight to the mempool once bindings.VerifyTransaction has
+// already validated the transaction.
+func (s *Server) SetVerifier(v *mempool.Verifier) {
+	s.verifier = v
+}
+
+// SetAdmissionController wires an admission.Controller into the server so
+// transaction submission clears the global rate limiter and per-peer/
+// per-sender fair queues before verification. Leaving it unset makes
+// submission skip straight to the verifier-or-mempool step. HTTP submission
+// has no P2P peer, so it is admitted under peer ID "" alongside any local
+// JSON-RPC traffic.
+func (s *Server) SetAdmissionController(c *admission.Controller) {
+	s.admission = c
+	if c != nil {
+		c.SetAdmitter(s.admitTransaction)
+	}
+}
+
+// admitTransaction runs the verifier's async pipeline if one is wired in, and
+// the mempool directly otherwise. It is the terminal step of submission,
+// called either directly by submitTransaction or, once an admission
+// controller is wired in, as that controller's Admitter once tx has cleared
+// its fair queues.
+func (s *Server) admitTransaction(tx *mempool.Transaction) error {
+	if s.verifier == nil {
+		_, err := s.mempool.AddTransaction(tx)
+		return err
+	}
+	return <-s.verifier.SubmitAsync(tx)
+}
+
+// submitTransaction runs tx through the admission controller if one is wired
+// in, and straight to admitTransaction otherwise; either way it blocks until
+// tx has been admitted or rejected, since callers still need a definite
+// answer to return to the submitter.
+func (s *Server) submitTransaction(tx *mempool.Transaction) error {
+	if s.admission == nil {
+		return s.admitTransaction(tx)
+	}
+	return s.admission.Admit("", tx)
+}
+
 // setupRoutes configures API routes
 func (s *Server) setupRoutes() {
 	// Middleware
@@ -131,6 +212,12 @@ func (s *Server) setupRoutes() {
 	// Prometheus metrics endpoint
 	s.router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// LES-style flow control buffer state, per peer
+	s.router.GET("/flowcontrol/peers", s.handleFlowControlPeers)
+
+	// Peer reputation scores (see p2p.Scorer)
+	s.router.GET("/peers/score", s.handlePeerScores)
+
 	// WebSocket endpoint for real-time updates
 	s.router.GET("/ws", s.handleWebSocket)
 
@@ -145,6 +232,24 @@ func (s *Server) setupRoutes() {
 		v1.GET("/transactions/:hash", s.handleGetTransaction)
 		v1.GET("/mempool/stats", s.handleMempoolStats)
 
+		// txpool_* namespace (see pkg/mempool.Mempool.Content)
+		v1.GET("/txpool/content", s.handleTxPoolContent)
+		v1.GET("/txpool/inspect", s.handleTxPoolInspect)
+		v1.GET("/txpool/status", s.handleTxPoolStatus)
+		v1.GET("/txpool/contentFrom/:address", s.handleTxPoolContentFrom)
+
+		// EIP-1559 fee market endpoints
+		v1.GET("/fees/suggest-base-fee", s.handleSuggestBaseFee)
+		v1.GET("/fees/suggest-tip-cap", s.handleSuggestGasTipCap)
+		v1.GET("/base_fee", s.handleSuggestBaseFee)
+
+		// Simulation endpoints
+		v1.POST("/tx/simulate", s.handleSimulateTransaction)
+		v1.POST("/rpc", s.handleJSONRPC) // JSON-RPC 2.0: coinj_simulateTransaction and friends
+
+		// WebSocket event replay, for clients that prefer polling
+		v1.GET("/events", s.handleGetEvents)
+
 		// Account endpoints
 		v1.GET("/accounts/:address", s.handleGetAccount)
 		v1.GET("/accounts/:address/nonce", s.handleGetAccountNonce)
@@ -153,13 +258,30 @@ func (s *Server) setupRoutes() {
 		v1.POST("/escrows", s.handleCreateEscrow)
 		v1.GET("/escrows/:id", s.handleGetEscrow)
 
+		// Deposit endpoints (validator registration)
+		v1.POST("/deposits", s.handleSubmitDeposit)
+		v1.GET("/deposits/:block_number", s.handleGetDeposits)
+
 		// Block endpoints
 		v1.GET("/blocks/latest", s.handleGetLatestBlock)
 		v1.GET("/blocks/number/:number", s.handleGetBlockByNumber)
 		v1.GET("/blocks/:hash", s.handleGetBlock)
+		v1.GET("/blocks/:hash/witness", s.handleGetBlockWitness)
+
+		// Receipt and log-filter endpoints (see pkg/receipts)
+		v1.GET("/receipts/:hash", s.handleGetReceipt)
+		v1.GET("/logs", s.handleGetLogs)
 
 		// IPFS endpoints
 		v1.GET("/ipfs/:cid", s.handleGetIPFS)
+		v1.GET("/ipfs/manifest/:cid", s.handleGetPinManifest)
+
+		// Validator slashing/jailing
+		v1.POST("/validators/:address/unjail", s.handleUnjailValidator)
+		v1.POST("/evidence", s.handleSubmitEvidence)
+
+		// Consensus finality
+		v1.GET("/consensus/finality", s.handleGetFinality)
 
 		// Status/monitoring
 		v1.GET("/status", s.handleStatus)
@@ -191,9 +313,15 @@ func (s *Server) Shutdown(ctx context.Context) error {
 
 // Middleware
 
+// rateLimitMiddleware admits the request at its route's rolling cost
+// estimate (rather than a flat 1), then reports how long it actually took
+// so that estimate keeps tracking reality. See pkg/flowcontrol.
 func (s *Server) rateLimitMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		allowed, err := s.limiter.CheckRequest(c.Request.RemoteAddr)
+		kind := flowcontrol.RequestKind(c.FullPath())
+		cost := flowcontrol.EstimatedCost(kind)
+
+		allowed, err := s.limiter.CheckRequest(c.Request.RemoteAddr, cost)
 		if !allowed {
 			s.log.WithError(err).WithField("ip", c.ClientIP()).Warn("Rate limit exceeded")
 			c.JSON(http.StatusTooManyRequests, gin.H{
@@ -202,7 +330,10 @@ func (s *Server) rateLimitMiddleware() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+
+		start := time.Now()
 		c.Next()
+		flowcontrol.Report(kind, time.Since(start), c.Writer.Size())
 	}
 }
 
@@ -263,6 +394,24 @@ func (s *Server) handleHealth(c *gin.Context) {
 	})
 }
 
+// handleFlowControlPeers reports each known peer's LES-style flow control
+// buffer state (credit remaining, limit, recharge rate), for operators
+// diagnosing admission pressure from the P2P side.
+func (s *Server) handleFlowControlPeers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"peers": s.limiter.PeerBufferStats(),
+	})
+}
+
+// handlePeerScores reports each known peer's reputation score (decayed,
+// clamped to [-100, 100]) along with its quarantine/ban state, for
+// operators diagnosing gossip/rate-limit behavior (see p2p.Scorer).
+func (s *Server) handlePeerScores(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"peers": s.p2pManager.PeerScores(),
+	})
+}
+
 func (s *Server) handleSubmitProof(c *gin.Context) {
 	// Check backpressure FIRST (early reject before parsing/validation)
 	nearCapacity, utilization := s.limiter.CheckBackpressure()
@@ -276,8 +425,8 @@ func (s *Server) handleSubmitProof(c *gin.Context) {
 		c.Header("Retry-After", strconv.Itoa(retryAfter))
 
 		c.JSON(http.StatusTooManyRequests, gin.H{
-			"error":            "verification queue at capacity",
-			"queue_utilization": fmt.Sprintf("%.1f%%", utilization*100),
+			"error":               "verification queue at capacity",
+			"queue_utilization":   fmt.Sprintf("%.1f%%", utilization*100),
 			"retry_after_seconds": retryAfter,
 		})
 		return
@@ -285,19 +434,19 @@ func (s *Server) handleSubmitProof(c *gin.Context) {
 
 	// Parse request body
 	var proof struct {
-		ProblemType string   `json:"problem_type" binding:"required"`
-		Tier        string   `json:"tier" binding:"required"`
-		Elements    []int    `json:"elements" binding:"required"`
-		Target      int      `json:"target" binding:"required"`
-		Solution    []int    `json:"solution" binding:"required"`
-		Commitment  string   `json:"commitment" binding:"required"`
-		Timestamp   int64    `json:"timestamp" binding:"required"`
+		ProblemType string `json:"problem_type" binding:"required"`
+		Tier        string `json:"tier" binding:"required"`
+		Elements    []int  `json:"elements" binding:"required"`
+		Target      int    `json:"target" binding:"required"`
+		Solution    []int  `json:"solution" binding:"required"`
+		Commitment  string `json:"commitment" binding:"required"`
+		Timestamp   int64  `json:"timestamp" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&proof); err != nil {
 		proofSubmissionsTotal.WithLabelValues("rejected_syntax").Inc()
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid proof format",
+			"error":   "invalid proof format",
 			"details": err.Error(),
 		})
 		return
@@ -307,7 +456,7 @@ func (s *Server) handleSubmitProof(c *gin.Context) {
 	if proof.Tier != "MOBILE" && proof.Tier != "DESKTOP" && proof.Tier != "SERVER" {
 		proofSubmissionsTotal.WithLabelValues("rejected_syntax").Inc()
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid tier",
+			"error":       "invalid tier",
 			"valid_tiers": []string{"MOBILE", "DESKTOP", "SERVER"},
 		})
 		return
@@ -317,8 +466,8 @@ func (s *Server) handleSubmitProof(c *gin.Context) {
 	if len(proof.Elements) > 1000 || len(proof.Solution) > 100 {
 		proofSubmissionsTotal.WithLabelValues("rejected_syntax").Inc()
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "proof size exceeds limits",
-			"max_elements": 1000,
+			"error":             "proof size exceeds limits",
+			"max_elements":      1000,
 			"max_solution_size": 100,
 		})
 		return
@@ -329,8 +478,8 @@ func (s *Server) handleSubmitProof(c *gin.Context) {
 	if proof.Timestamp > now+300 || proof.Timestamp < now-3600 {
 		proofSubmissionsTotal.WithLabelValues("rejected_syntax").Inc()
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "timestamp out of acceptable range",
-			"now": now,
+			"error":           "timestamp out of acceptable range",
+			"now":             now,
 			"proof_timestamp": proof.Timestamp,
 		})
 		return
@@ -361,15 +510,15 @@ func (s *Server) handleSubmitProof(c *gin.Context) {
 	proofSubmissionsTotal.WithLabelValues("accepted").Inc()
 
 	s.log.WithFields(logger.Fields{
-		"tier": proof.Tier,
-		"problem_size": len(proof.Elements),
+		"tier":          proof.Tier,
+		"problem_size":  len(proof.Elements),
 		"solution_size": len(proof.Solution),
 	}).Info("Proof submission accepted")
 
 	c.JSON(http.StatusAccepted, gin.H{
-		"status": "accepted",
+		"status":  "accepted",
 		"message": "proof queued for verification",
-		"tier": proof.Tier,
+		"tier":    proof.Tier,
 	})
 }
 
@@ -377,7 +526,7 @@ func (s *Server) handleGetIPFS(c *gin.Context) {
 	cid := c.Param("cid")
 
 	// Get content from IPFS
-	reader, err := s.ipfsClient.Get(c.Request.Context(), cid)
+	reader, err := s.ipfsClient.Get(c.Request.Context(), cid, "")
 	if err != nil {
 		s.log.WithError(err).WithField("cid", cid).Error("Failed to get IPFS content")
 		c.JSON(http.StatusNotFound, gin.H{
@@ -391,6 +540,23 @@ func (s *Server) handleGetIPFS(c *gin.Context) {
 	c.DataFromReader(http.StatusOK, -1, "application/octet-stream", reader, nil)
 }
 
+// handleGetPinManifest retrieves the persisted, attestation-bearing
+// PinManifest for a CID (see ipfs.PinWithQuorum and state.RecordPinManifest),
+// for auditing which nodes actually attested to pinning it.
+func (s *Server) handleGetPinManifest(c *gin.Context) {
+	cid := c.Param("cid")
+
+	manifest, err := s.stateManager.GetPinManifest(cid)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "pin manifest not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, manifest)
+}
+
 func (s *Server) handleStatus(c *gin.Context) {
 	status := map[string]interface{}{
 		"api_version":  "4.3.0",
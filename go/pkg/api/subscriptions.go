@@ -0,0 +1,375 @@
+// Ethereum-style eth_subscribe-alike namespace for the WebSocket hub:
+// clients send {"method":"subscribe","params":["newPendingTransactions" |
+// "newHeads" | "logs", <filterCriteria>]} and get back a subscription id,
+// then receive {"method":"subscription","params":{"subscription":id,
+// "result":...}} pushes until they unsubscribe. This is a second protocol
+// layered on top of websocket.go's pre-existing topic/Action one (readPump
+// routes a message carrying "method" here, one carrying "action" to
+// handleSubscribeMessage) rather than a replacement for it, so existing
+// clients subscribed to "transactions"/"blocks"/"escrows"/"all" keep working
+// unchanged.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/receipts"
+)
+
+// RPC subscription topics. Unlike the legacy topic namespace, these three
+// are fixed — a client can't subscribe to an arbitrary string here.
+const (
+	rpcTopicNewPendingTransactions = "newPendingTransactions"
+	rpcTopicNewHeads               = "newHeads"
+	rpcTopicLogs                   = "logs"
+)
+
+func isValidRPCTopic(topic string) bool {
+	switch topic {
+	case rpcTopicNewPendingTransactions, rpcTopicNewHeads, rpcTopicLogs:
+		return true
+	}
+	return false
+}
+
+// rpcRequest is the client->server envelope for this namespace.
+type rpcRequest struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// rpcAck is the immediate reply to a subscribe/unsubscribe call — the new
+// subscription id, or whether the unsubscribed id existed.
+type rpcAck struct {
+	Method string      `json:"method"`
+	Result interface{} `json:"result"`
+}
+
+// rpcSubscriptionPush is the server->client envelope streamed to a
+// subscriber for every matching event, mirroring geth's eth_subscription
+// notification shape.
+type rpcSubscriptionPush struct {
+	Method string        `json:"method"`
+	Params rpcPushParams `json:"params"`
+}
+
+type rpcPushParams struct {
+	Subscription string      `json:"subscription"`
+	Result       interface{} `json:"result"`
+}
+
+// rpcSubscription is one client's subscription to one of the three rpc
+// topics. logFilter is only set (and only consulted) for a "logs"
+// subscription.
+type rpcSubscription struct {
+	id        string
+	topic     string
+	logFilter *compiledLogFilter
+}
+
+// LogFilterCriteria is the {addresses, topics} shape accepted as a "logs"
+// subscription's second parameter, matching receipts.FilterQuery's
+// matching rules: an empty Addresses or Topics list matches any
+// address/topic. Addresses and Topics are hex-encoded [32]byte values,
+// optionally "0x"-prefixed.
+type LogFilterCriteria struct {
+	Addresses []string `json:"addresses,omitempty"`
+	Topics    []string `json:"topics,omitempty"`
+}
+
+// compiledLogFilter is LogFilterCriteria with its hex fields decoded once
+// at subscribe time rather than on every published log.
+type compiledLogFilter struct {
+	addresses map[[32]byte]bool
+	topics    map[[32]byte]bool
+}
+
+func compileLogFilter(criteria LogFilterCriteria) (*compiledLogFilter, error) {
+	f := &compiledLogFilter{}
+
+	if len(criteria.Addresses) > 0 {
+		f.addresses = make(map[[32]byte]bool, len(criteria.Addresses))
+		for _, a := range criteria.Addresses {
+			addr, err := decodeFilterAddress(a)
+			if err != nil {
+				return nil, err
+			}
+			f.addresses[addr] = true
+		}
+	}
+
+	if len(criteria.Topics) > 0 {
+		f.topics = make(map[[32]byte]bool, len(criteria.Topics))
+		for _, t := range criteria.Topics {
+			topic, err := decodeFilterAddress(t)
+			if err != nil {
+				return nil, err
+			}
+			f.topics[topic] = true
+		}
+	}
+
+	return f, nil
+}
+
+// Matches reports whether log satisfies f's address/topic constraints. A
+// nil f (an unfiltered "logs" subscription) matches every log.
+func (f *compiledLogFilter) Matches(log *receipts.Log) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.addresses) > 0 && !f.addresses[log.Address] {
+		return false
+	}
+	if len(f.topics) > 0 {
+		matched := false
+		for _, t := range log.Topics {
+			if f.topics[t] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// handleRPCMessage parses and applies one subscribe/unsubscribe request in
+// the newPendingTransactions/newHeads/logs namespace.
+func (c *WSClient) handleRPCMessage(raw []byte, method string) {
+	switch method {
+	case "subscribe":
+		c.handleRPCSubscribe(raw)
+	case "unsubscribe":
+		c.handleRPCUnsubscribe(raw)
+	default:
+		c.sendError("invalid_method", "unknown method: "+method)
+	}
+}
+
+func (c *WSClient) handleRPCSubscribe(raw []byte) {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		c.sendError("invalid_message", "could not parse subscribe request: "+err.Error())
+		return
+	}
+	if len(req.Params) == 0 {
+		c.sendError("invalid_params", "subscribe requires a topic parameter")
+		return
+	}
+
+	var topic string
+	if err := json.Unmarshal(req.Params[0], &topic); err != nil {
+		c.sendError("invalid_params", "subscribe topic must be a string")
+		return
+	}
+	if !isValidRPCTopic(topic) {
+		c.sendError("invalid_params", "unknown subscription topic: "+topic)
+		return
+	}
+
+	var logFilter *compiledLogFilter
+	if topic == rpcTopicLogs {
+		var criteria LogFilterCriteria
+		if len(req.Params) > 1 {
+			if err := json.Unmarshal(req.Params[1], &criteria); err != nil {
+				c.sendError("invalid_params", "invalid logs filter criteria: "+err.Error())
+				return
+			}
+		}
+		compiled, err := compileLogFilter(criteria)
+		if err != nil {
+			c.sendError("invalid_filter", err.Error())
+			return
+		}
+		logFilter = compiled
+	}
+
+	id := fmt.Sprintf("0x%x", atomic.AddUint64(&c.hub.nextSubID, 1))
+	sub := &rpcSubscription{id: id, topic: topic, logFilter: logFilter}
+
+	c.mu.Lock()
+	c.rpcSubs[id] = sub
+	c.mu.Unlock()
+
+	select {
+	case c.send <- mustMarshal(&rpcAck{Method: "subscribe", Result: id}):
+	default:
+	}
+}
+
+func (c *WSClient) handleRPCUnsubscribe(raw []byte) {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		c.sendError("invalid_message", "could not parse unsubscribe request: "+err.Error())
+		return
+	}
+	if len(req.Params) == 0 {
+		c.sendError("invalid_params", "unsubscribe requires a subscription id parameter")
+		return
+	}
+
+	var id string
+	if err := json.Unmarshal(req.Params[0], &id); err != nil {
+		c.sendError("invalid_params", "unsubscribe id must be a string")
+		return
+	}
+
+	c.mu.Lock()
+	_, existed := c.rpcSubs[id]
+	delete(c.rpcSubs, id)
+	c.mu.Unlock()
+
+	select {
+	case c.send <- mustMarshal(&rpcAck{Method: "unsubscribe", Result: existed}):
+	default:
+	}
+}
+
+// rpcPublishJob pairs an rpc topic's event with whatever its subscribers'
+// filters need to be evaluated against (only populated for "logs").
+type rpcPublishJob struct {
+	topic  string
+	result interface{}
+	log    *receipts.Log
+}
+
+// deliverRPC checks client's subscriptions against job and, for every match,
+// enqueues a subscription push — applying client's backpressure policy (see
+// WSHub.deliver) and, first, the hub-wide slow-subscriber check (see
+// dropIfBackpressured). Caller must hold h.mu.RLock (true of deliverRPC's
+// only caller, Run's rpcPublish case).
+func (h *WSHub) deliverRPC(client *WSClient, job *rpcPublishJob) {
+	client.mu.RLock()
+	var matched []*rpcSubscription
+	for _, sub := range client.rpcSubs {
+		if sub.topic != job.topic {
+			continue
+		}
+		if job.topic == rpcTopicLogs && !sub.logFilter.Matches(job.log) {
+			continue
+		}
+		matched = append(matched, sub)
+	}
+	client.mu.RUnlock()
+
+	if len(matched) == 0 {
+		return
+	}
+
+	if h.dropIfBackpressured(client) {
+		return
+	}
+
+	for _, sub := range matched {
+		data := mustMarshal(&rpcSubscriptionPush{
+			Method: "subscription",
+			Params: rpcPushParams{Subscription: sub.id, Result: job.result},
+		})
+		h.deliver(client, sub.id, data)
+	}
+}
+
+// dropIfBackpressured disconnects client if its send queue has backed up
+// past wsMaxBuffered while the hub-wide limiter reports backpressure (see
+// SetBackpressureLimiter), so one slow rpc subscriber can't hold up
+// delivery to every other client. Reports whether client was dropped.
+// Caller must hold h.mu.RLock.
+func (h *WSHub) dropIfBackpressured(client *WSClient) bool {
+	if h.backpressureLimiter == nil || h.wsMaxBuffered <= 0 {
+		return false
+	}
+	if len(client.send) <= h.wsMaxBuffered {
+		return false
+	}
+	nearCapacity, _ := h.backpressureLimiter.CheckBackpressure()
+	if !nearCapacity {
+		return false
+	}
+
+	atomic.AddInt64(&client.dropCount, 1)
+	atomic.AddUint64(&h.dropped, 1)
+	h.mu.RUnlock()
+	h.unregister <- client
+	h.mu.RLock()
+	return true
+}
+
+func (h *WSHub) enqueueRPCPublish(job *rpcPublishJob) {
+	select {
+	case h.rpcPublish <- job:
+	default:
+		h.log.Warn("WebSocket rpc publish channel full, dropping message")
+		atomic.AddUint64(&h.dropped, 1)
+	}
+}
+
+// PublishNewPendingTransaction notifies every newPendingTransactions
+// subscriber of a transaction hash newly admitted to the mempool. See
+// Server.OnTransactionAdded, which implements mempool.TxEventSink on top
+// of this.
+func (h *WSHub) PublishNewPendingTransaction(txHash [32]byte) {
+	h.enqueueRPCPublish(&rpcPublishJob{
+		topic:  rpcTopicNewPendingTransactions,
+		result: mustHex(txHash[:]),
+	})
+}
+
+// BlockHeader is the newHeads subscription's per-block payload: the
+// minimal summary a wallet or indexer needs to follow the chain tip,
+// mirroring the fields geth's eth_subscribe("newHeads") streams.
+type BlockHeader struct {
+	Number     uint64 `json:"number"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parent_hash"`
+	StateRoot  string `json:"state_root"`
+	Timestamp  int64  `json:"timestamp"`
+	TxCount    int    `json:"tx_count"`
+}
+
+// PublishNewHead notifies every newHeads subscriber of a block newly
+// applied to the chain.
+func (h *WSHub) PublishNewHead(header BlockHeader) {
+	h.enqueueRPCPublish(&rpcPublishJob{topic: rpcTopicNewHeads, result: header})
+}
+
+// logJSON is the logs subscription's per-event payload.
+type logJSON struct {
+	Address     string   `json:"address"`
+	Topics      []string `json:"topics"`
+	Data        string   `json:"data"`
+	BlockHash   string   `json:"block_hash"`
+	BlockNumber uint64   `json:"block_number"`
+	TxHash      string   `json:"tx_hash"`
+	LogIndex    int      `json:"log_index"`
+}
+
+// PublishLog notifies every logs subscriber whose {addresses, topics}
+// filter matches log.
+func (h *WSHub) PublishLog(log receipts.Log, blockHash [32]byte, blockNumber uint64, txHash [32]byte, logIndex int) {
+	h.enqueueRPCPublish(&rpcPublishJob{
+		topic: rpcTopicLogs,
+		result: logJSON{
+			Address:     mustHex(log.Address[:]),
+			Topics:      hexTopics(log.Topics),
+			Data:        mustHex(log.Data),
+			BlockHash:   mustHex(blockHash[:]),
+			BlockNumber: blockNumber,
+			TxHash:      mustHex(txHash[:]),
+			LogIndex:    logIndex,
+		},
+		log: &log,
+	})
+}
+
+func hexTopics(topics [][32]byte) []string {
+	out := make([]string, len(topics))
+	for i, t := range topics {
+		out[i] = mustHex(t[:])
+	}
+	return out
+}
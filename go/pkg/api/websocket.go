@@ -2,12 +2,17 @@
 package api
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/limiter"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/receipts"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
@@ -21,40 +26,154 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// BackpressurePolicy controls what a client's connection does when its send
+// buffer fills faster than writePump can drain it.
+type BackpressurePolicy string
+
+const (
+	// PolicyDisconnect drops the client entirely (the pre-existing behavior).
+	PolicyDisconnect BackpressurePolicy = "disconnect"
+	// PolicyDropOldest discards the oldest queued message to make room for
+	// the new one, favoring freshness over completeness.
+	PolicyDropOldest BackpressurePolicy = "drop_oldest"
+	// PolicyCoalesceByTopic keeps only the latest unsent message per topic,
+	// so a burst of updates collapses into the most recent one.
+	PolicyCoalesceByTopic BackpressurePolicy = "coalesce_by_topic"
+)
+
+func isValidBackpressurePolicy(p BackpressurePolicy) bool {
+	switch p {
+	case PolicyDisconnect, PolicyDropOldest, PolicyCoalesceByTopic:
+		return true
+	}
+	return false
+}
+
+// SubscriptionFilter narrows a topic subscription to messages matching all
+// of its set fields. A zero-value field (empty set, zero MinAmount, nil
+// TxType) is not enforced, so an empty filter matches everything on the topic.
+type SubscriptionFilter struct {
+	From      map[[32]byte]bool
+	To        map[[32]byte]bool
+	MinAmount uint64
+	TxType    *uint8
+}
+
+// Matches reports whether attrs satisfies every constraint set on f.
+func (f *SubscriptionFilter) Matches(attrs BroadcastAttrs) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.From) > 0 && (!attrs.HasFrom || !f.From[attrs.From]) {
+		return false
+	}
+	if len(f.To) > 0 && (!attrs.HasTo || !f.To[attrs.To]) {
+		return false
+	}
+	if f.MinAmount > 0 && attrs.Amount < f.MinAmount {
+		return false
+	}
+	if f.TxType != nil && (!attrs.HasTxType || attrs.TxType != *f.TxType) {
+		return false
+	}
+	return true
+}
+
+// BroadcastAttrs carries the fields a SubscriptionFilter can match against.
+// Not every broadcast has every attribute (a block has no From/To), so each
+// is paired with a Has* flag rather than relying on a zero value.
+type BroadcastAttrs struct {
+	From      [32]byte
+	HasFrom   bool
+	To        [32]byte
+	HasTo     bool
+	Amount    uint64
+	TxType    uint8
+	HasTxType bool
+}
+
 // WSClient represents a WebSocket client
 type WSClient struct {
 	conn       *websocket.Conn
 	send       chan []byte
 	hub        *WSHub
-	subscribed map[string]bool // Subscription topics
+	subscribed map[string]*SubscriptionFilter // topic -> filter (nil/absent key "all" matches everything)
+	policy     BackpressurePolicy
+	coalesced  map[string][]byte // topic -> latest unsent payload, used by PolicyCoalesceByTopic
+	wake       chan struct{}
+	dropCount  int64                       // atomic: messages dropped for this client since connect
+	rpcSubs    map[string]*rpcSubscription // subscription id -> subscription, see subscriptions.go
 	mu         sync.RWMutex
 }
 
 // WSHub manages WebSocket connections and broadcasts
 type WSHub struct {
 	clients    map[*WSClient]bool
-	broadcast  chan *WSMessage
+	broadcast  chan *wsBroadcastJob
+	rpcPublish chan *rpcPublishJob
 	register   chan *WSClient
 	unregister chan *WSClient
 	mu         sync.RWMutex
 	log        *logger.Logger
+	eventLog   *EventLog
+
+	bytesSent uint64 // atomic
+	dropped   uint64 // atomic
+	nextSubID uint64 // atomic: source of rpcSubscription ids, see subscriptions.go
+
+	// backpressureLimiter/wsMaxBuffered, if both set via
+	// SetBackpressureLimiter, make the rpc publish path (subscriptions.go)
+	// disconnect a subscriber whose send queue has backed up past
+	// wsMaxBuffered once the hub is under backpressure, rather than letting
+	// one slow subscriber's policy (see BackpressurePolicy) decide its own
+	// fate.
+	backpressureLimiter limiter.Limiter
+	wsMaxBuffered       int
+}
+
+// SetBackpressureLimiter wires lim and maxBuffered into the hub so the rpc
+// publish path (newPendingTransactions/newHeads/logs) can shed its slowest
+// subscribers under load. Passing a nil lim disables the check.
+func (h *WSHub) SetBackpressureLimiter(lim limiter.Limiter, maxBuffered int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.backpressureLimiter = lim
+	h.wsMaxBuffered = maxBuffered
 }
 
 // WSMessage represents a WebSocket message
 type WSMessage struct {
-	Type    string      `json:"type"`    // "transaction", "block", "escrow", "status"
-	Topic   string      `json:"topic"`   // Subscription topic
-	Payload interface{} `json:"payload"` // Message data
+	Type     string      `json:"type"`      // "transaction", "block", "escrow", "status", "error"
+	Topic    string      `json:"topic"`     // Subscription topic
+	Payload  interface{} `json:"payload"`   // Message data
+	EventSeq uint64      `json:"event_seq"` // Monotonic sequence number, for resumable subscriptions
 }
 
-// NewWSHub creates a new WebSocket hub
-func NewWSHub(log *logger.Logger) *WSHub {
+// wsBroadcastJob pairs an outbound message with the attributes subscribers'
+// filters are evaluated against.
+type wsBroadcastJob struct {
+	msg   *WSMessage
+	attrs BroadcastAttrs
+}
+
+// WSStats is a point-in-time snapshot of hub-wide delivery health.
+type WSStats struct {
+	ClientCount int    `json:"client_count"`
+	BytesSent   uint64 `json:"bytes_sent"`
+	Dropped     uint64 `json:"dropped"`
+	SlowClients int    `json:"slow_clients"` // clients that have dropped at least one message
+}
+
+// NewWSHub creates a new WebSocket hub backed by eventLog for replay.
+func NewWSHub(log *logger.Logger, eventLog *EventLog) *WSHub {
 	return &WSHub{
 		clients:    make(map[*WSClient]bool),
-		broadcast:  make(chan *WSMessage, 256),
+		broadcast:  make(chan *wsBroadcastJob, 256),
+		rpcPublish: make(chan *rpcPublishJob, 256),
 		register:   make(chan *WSClient),
 		unregister: make(chan *WSClient),
 		log:        log,
+		eventLog:   eventLog,
 	}
 }
 
@@ -77,42 +196,113 @@ func (h *WSHub) Run() {
 			h.mu.Unlock()
 			h.log.WithField("client_count", len(h.clients)).Debug("WebSocket client unregistered")
 
-		case message := <-h.broadcast:
+		case job := <-h.broadcast:
 			h.mu.RLock()
+			data := mustMarshal(job.msg)
 			for client := range h.clients {
-				// Check if client is subscribed to this topic
 				client.mu.RLock()
-				subscribed := client.subscribed[message.Topic] || client.subscribed["all"]
+				topicFilter, hasTopic := client.subscribed[job.msg.Topic]
+				allFilter, hasAll := client.subscribed["all"]
 				client.mu.RUnlock()
 
-				if subscribed {
-					select {
-					case client.send <- mustMarshal(message):
-					default:
-						// Client send buffer full, disconnect
-						h.mu.RUnlock()
-						h.unregister <- client
-						h.mu.RLock()
-					}
+				matches := (hasTopic && topicFilter.Matches(job.attrs)) ||
+					(hasAll && allFilter.Matches(job.attrs))
+
+				if matches {
+					h.deliver(client, job.msg.Topic, data)
 				}
 			}
 			h.mu.RUnlock()
+
+		case job := <-h.rpcPublish:
+			h.mu.RLock()
+			for client := range h.clients {
+				h.deliverRPC(client, job)
+			}
+			h.mu.RUnlock()
 		}
 	}
 }
 
-// Broadcast sends a message to all subscribed clients
+// deliver enqueues data for client, applying that client's configured
+// backpressure policy if its send buffer is full.
+func (h *WSHub) deliver(client *WSClient, topic string, data []byte) {
+	select {
+	case client.send <- data:
+		return
+	default:
+	}
+
+	switch client.policy {
+	case PolicyDropOldest:
+		select {
+		case <-client.send:
+			atomic.AddInt64(&client.dropCount, 1)
+			atomic.AddUint64(&h.dropped, 1)
+		default:
+		}
+		select {
+		case client.send <- data:
+		default:
+			// Another producer raced us for the freed slot; count this
+			// message as dropped too rather than blocking the hub loop.
+			atomic.AddInt64(&client.dropCount, 1)
+			atomic.AddUint64(&h.dropped, 1)
+		}
+
+	case PolicyCoalesceByTopic:
+		client.mu.Lock()
+		if _, existed := client.coalesced[topic]; existed {
+			atomic.AddInt64(&client.dropCount, 1)
+			atomic.AddUint64(&h.dropped, 1)
+		}
+		client.coalesced[topic] = data
+		client.mu.Unlock()
+		select {
+		case client.wake <- struct{}{}:
+		default:
+		}
+
+	default: // PolicyDisconnect
+		atomic.AddInt64(&client.dropCount, 1)
+		atomic.AddUint64(&h.dropped, 1)
+		h.mu.RUnlock()
+		h.unregister <- client
+		h.mu.RLock()
+	}
+}
+
+// Broadcast sends a message with no filterable attributes to all subscribed
+// clients — suitable for topics (blocks) that don't support per-field filters.
 func (h *WSHub) Broadcast(msgType, topic string, payload interface{}) {
-	msg := &WSMessage{
-		Type:    msgType,
-		Topic:   topic,
-		Payload: payload,
+	h.BroadcastWithAttrs(msgType, topic, payload, BroadcastAttrs{})
+}
+
+// BroadcastWithAttrs sends a message to all subscribed clients whose topic
+// filter matches attrs. The message is first appended to the hub's event
+// log, which stamps it with a monotonic event_seq and makes it available
+// for replay to clients that reconnect or poll /v1/events.
+func (h *WSHub) BroadcastWithAttrs(msgType, topic string, payload interface{}, attrs BroadcastAttrs) {
+	seq, err := h.eventLog.Append(topic, msgType, payload, attrs)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to persist event to replay log")
+	}
+
+	job := &wsBroadcastJob{
+		msg: &WSMessage{
+			Type:     msgType,
+			Topic:    topic,
+			Payload:  payload,
+			EventSeq: seq,
+		},
+		attrs: attrs,
 	}
 
 	select {
-	case h.broadcast <- msg:
+	case h.broadcast <- job:
 	default:
 		h.log.Warn("WebSocket broadcast channel full, dropping message")
+		atomic.AddUint64(&h.dropped, 1)
 	}
 }
 
@@ -123,6 +313,27 @@ func (h *WSHub) ClientCount() int {
 	return len(h.clients)
 }
 
+// Stats returns a snapshot of hub-wide delivery health for observability
+// endpoints and metrics exporters.
+func (h *WSHub) Stats() WSStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	slow := 0
+	for client := range h.clients {
+		if atomic.LoadInt64(&client.dropCount) > 0 {
+			slow++
+		}
+	}
+
+	return WSStats{
+		ClientCount: len(h.clients),
+		BytesSent:   atomic.LoadUint64(&h.bytesSent),
+		Dropped:     atomic.LoadUint64(&h.dropped),
+		SlowClients: slow,
+	}
+}
+
 // handleWebSocket handles WebSocket connections
 func (s *Server) handleWebSocket(c *gin.Context) {
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
@@ -135,11 +346,15 @@ func (s *Server) handleWebSocket(c *gin.Context) {
 		conn:       conn,
 		send:       make(chan []byte, 256),
 		hub:        s.wsHub,
-		subscribed: make(map[string]bool),
+		subscribed: make(map[string]*SubscriptionFilter),
+		policy:     PolicyDisconnect, // preserves pre-existing behavior unless the client opts into another policy
+		coalesced:  make(map[string][]byte),
+		wake:       make(chan struct{}, 1),
+		rpcSubs:    make(map[string]*rpcSubscription),
 	}
 
-	// Default subscription to "all"
-	client.subscribed["all"] = true
+	// Default subscription to "all", unfiltered
+	client.subscribed["all"] = nil
 
 	s.wsHub.register <- client
 
@@ -148,6 +363,20 @@ func (s *Server) handleWebSocket(c *gin.Context) {
 	go client.readPump()
 }
 
+// subscribeMessage is the client->server control message for managing
+// subscriptions and per-connection delivery policy.
+type subscribeMessage struct {
+	Action       string   `json:"action"` // "subscribe" or "unsubscribe"
+	Topic        string   `json:"topic"`  // "transactions", "blocks", "escrows", "all"
+	From         []string `json:"from,omitempty"`
+	To           []string `json:"to,omitempty"`
+	MinAmount    uint64   `json:"min_amount,omitempty"`
+	TxType       *uint8   `json:"tx_type,omitempty"`
+	Backpressure string   `json:"backpressure,omitempty"`
+	SinceSeq     *uint64  `json:"since_seq,omitempty"`  // replay missed events with event_seq > this before joining the live stream
+	SinceTime    *int64   `json:"since_time,omitempty"` // alternative to since_seq: replay events at or after this unix time
+}
+
 // readPump reads messages from the client (for subscriptions)
 func (c *WSClient) readPump() {
 	defer func() {
@@ -170,22 +399,237 @@ func (c *WSClient) readPump() {
 			break
 		}
 
-		// Handle subscription messages
-		var sub struct {
-			Action string `json:"action"` // "subscribe" or "unsubscribe"
-			Topic  string `json:"topic"`  // "transactions", "blocks", "escrows", "all"
+		var envelope struct {
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(message, &envelope); err != nil {
+			c.sendError("invalid_message", "could not parse subscription message: "+err.Error())
+			continue
+		}
+
+		if envelope.Method != "" {
+			c.handleRPCMessage(message, envelope.Method)
+			continue
+		}
+
+		var sub subscribeMessage
+		if err := json.Unmarshal(message, &sub); err != nil {
+			c.sendError("invalid_message", "could not parse subscription message: "+err.Error())
+			continue
+		}
+
+		c.handleSubscribeMessage(sub)
+	}
+}
+
+// handleSubscribeMessage applies (or rejects) one subscribe/unsubscribe
+// control message. Invalid filters or backpressure policies are rejected
+// with a typed error frame rather than silently ignored.
+func (c *WSClient) handleSubscribeMessage(sub subscribeMessage) {
+	if sub.Backpressure != "" {
+		policy := BackpressurePolicy(sub.Backpressure)
+		if !isValidBackpressurePolicy(policy) {
+			c.sendError("invalid_backpressure_policy", "unknown backpressure policy: "+sub.Backpressure)
+			return
+		}
+		c.mu.Lock()
+		c.policy = policy
+		c.mu.Unlock()
+	}
+
+	switch sub.Action {
+	case "subscribe":
+		filter, err := buildSubscriptionFilter(sub)
+		if err != nil {
+			c.sendError("invalid_filter", err.Error())
+			return
+		}
+		c.mu.Lock()
+		c.subscribed[sub.Topic] = filter
+		c.mu.Unlock()
+
+		c.replayMissed(sub, filter)
+
+	case "unsubscribe":
+		c.mu.Lock()
+		delete(c.subscribed, sub.Topic)
+		c.mu.Unlock()
+
+	case "":
+		// A message carrying only a backpressure change with no
+		// subscribe/unsubscribe action is valid on its own.
+
+	default:
+		c.sendError("invalid_action", "unknown action: "+sub.Action)
+	}
+}
+
+// replayMissed drains any events the client missed — filtered through the
+// subscription it just joined — before the live stream catches up, so a
+// reconnecting client (or one resuming with a since_seq/since_time it saved
+// from an earlier session) doesn't lose history in between. A subscribe
+// message with neither field set gets no replay, matching the old behavior.
+func (c *WSClient) replayMissed(sub subscribeMessage, filter *SubscriptionFilter) {
+	var missed []*loggedEvent
+	switch {
+	case sub.SinceSeq != nil:
+		missed = c.hub.eventLog.Since(sub.Topic, *sub.SinceSeq)
+	case sub.SinceTime != nil:
+		missed = c.hub.eventLog.SinceTime(sub.Topic, *sub.SinceTime)
+	default:
+		return
+	}
+
+	for _, ev := range missed {
+		if !filter.Matches(ev.Attrs) {
+			continue
+		}
+		msg := &WSMessage{Type: ev.Type, Topic: ev.Topic, Payload: ev.Payload, EventSeq: ev.Seq}
+		c.enqueueReplay(ev.Topic, mustMarshal(msg))
+	}
+}
+
+// enqueueReplay delivers one replayed event to c, applying the same
+// backpressure policy as live delivery. It is a standalone counterpart to
+// WSHub.deliver rather than a direct call into it: deliver assumes the
+// caller already holds h.mu.RLock (true of its one caller, Run's broadcast
+// case), which does not hold here — replayMissed runs on the client's own
+// readPump goroutine while handling a subscribe message.
+func (c *WSClient) enqueueReplay(topic string, data []byte) {
+	select {
+	case c.send <- data:
+		return
+	default:
+	}
+
+	switch c.policy {
+	case PolicyDropOldest:
+		select {
+		case <-c.send:
+			atomic.AddInt64(&c.dropCount, 1)
+			atomic.AddUint64(&c.hub.dropped, 1)
+		default:
+		}
+		select {
+		case c.send <- data:
+		default:
+			atomic.AddInt64(&c.dropCount, 1)
+			atomic.AddUint64(&c.hub.dropped, 1)
+		}
+
+	case PolicyCoalesceByTopic:
+		c.mu.Lock()
+		if _, existed := c.coalesced[topic]; existed {
+			atomic.AddInt64(&c.dropCount, 1)
+			atomic.AddUint64(&c.hub.dropped, 1)
+		}
+		c.coalesced[topic] = data
+		c.mu.Unlock()
+		select {
+		case c.wake <- struct{}{}:
+		default:
+		}
+
+	default: // PolicyDisconnect
+		atomic.AddInt64(&c.dropCount, 1)
+		atomic.AddUint64(&c.hub.dropped, 1)
+		select {
+		case c.hub.unregister <- c:
+		default:
+		}
+	}
+}
+
+// EventsSince returns every event on topic with event_seq > sinceSeq, oldest
+// first, for clients that prefer polling over WebSocket replay.
+func (h *WSHub) EventsSince(topic string, sinceSeq uint64) []*loggedEvent {
+	return h.eventLog.Since(topic, sinceSeq)
+}
+
+// EventsSinceTime returns every event on topic at or after sinceUnix (unix
+// seconds), oldest first.
+func (h *WSHub) EventsSinceTime(topic string, sinceUnix int64) []*loggedEvent {
+	return h.eventLog.SinceTime(topic, sinceUnix)
+}
+
+// buildSubscriptionFilter validates and converts the hex address lists in a
+// subscribe message into a SubscriptionFilter.
+func buildSubscriptionFilter(sub subscribeMessage) (*SubscriptionFilter, error) {
+	if len(sub.From) == 0 && len(sub.To) == 0 && sub.MinAmount == 0 && sub.TxType == nil {
+		return nil, nil // unfiltered subscription to the whole topic
+	}
+
+	filter := &SubscriptionFilter{MinAmount: sub.MinAmount, TxType: sub.TxType}
+
+	if len(sub.From) > 0 {
+		filter.From = make(map[[32]byte]bool, len(sub.From))
+		for _, addrHex := range sub.From {
+			addr, err := decodeFilterAddress(addrHex)
+			if err != nil {
+				return nil, err
+			}
+			filter.From[addr] = true
 		}
+	}
 
-		if err := json.Unmarshal(message, &sub); err == nil {
-			c.mu.Lock()
-			if sub.Action == "subscribe" {
-				c.subscribed[sub.Topic] = true
-			} else if sub.Action == "unsubscribe" {
-				delete(c.subscribed, sub.Topic)
+	if len(sub.To) > 0 {
+		filter.To = make(map[[32]byte]bool, len(sub.To))
+		for _, addrHex := range sub.To {
+			addr, err := decodeFilterAddress(addrHex)
+			if err != nil {
+				return nil, err
 			}
-			c.mu.Unlock()
+			filter.To[addr] = true
 		}
 	}
+
+	return filter, nil
+}
+
+func decodeFilterAddress(addrHex string) ([32]byte, error) {
+	var addr [32]byte
+	b, err := hex.DecodeString(trimHexPrefix(addrHex))
+	if err != nil {
+		return addr, errInvalidFilterAddress(addrHex)
+	}
+	if len(b) != 32 {
+		return addr, errInvalidFilterAddress(addrHex)
+	}
+	copy(addr[:], b)
+	return addr, nil
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+	return s
+}
+
+func errInvalidFilterAddress(addrHex string) error {
+	return &filterError{msg: "invalid filter address: " + addrHex}
+}
+
+type filterError struct{ msg string }
+
+func (e *filterError) Error() string { return e.msg }
+
+// sendError enqueues a typed error frame for the client, best-effort.
+func (c *WSClient) sendError(code, message string) {
+	frame := &WSMessage{
+		Type: "error",
+		Payload: gin.H{
+			"code":    code,
+			"message": message,
+		},
+	}
+
+	select {
+	case c.send <- mustMarshal(frame):
+	default:
+		// Send buffer is full; the client will find out its subscription
+		// state didn't change when its next broadcast doesn't arrive.
+	}
 }
 
 // writePump writes messages to the client
@@ -206,20 +650,12 @@ func (c *WSClient) writePump() {
 				return
 			}
 
-			w, err := c.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
+			if err := c.writeAndDrainQueued(message); err != nil {
 				return
 			}
-			w.Write(message)
-
-			// Add queued messages to current websocket message
-			n := len(c.send)
-			for i := 0; i < n; i++ {
-				w.Write([]byte{'\n'})
-				w.Write(<-c.send)
-			}
 
-			if err := w.Close(); err != nil {
+		case <-c.wake:
+			if err := c.flushCoalesced(); err != nil {
 				return
 			}
 
@@ -232,6 +668,71 @@ func (c *WSClient) writePump() {
 	}
 }
 
+// writeAndDrainQueued writes message, then opportunistically folds in
+// anything else already queued on c.send so a burst of updates shares one
+// WebSocket frame.
+func (c *WSClient) writeAndDrainQueued(message []byte) error {
+	w, err := c.conn.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return err
+	}
+	n, _ := w.Write(message)
+	total := n
+
+	queued := len(c.send)
+	for i := 0; i < queued; i++ {
+		w.Write([]byte{'\n'})
+		m := <-c.send
+		written, _ := w.Write(m)
+		total += written + 1
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	atomic.AddUint64(&c.hub.bytesSent, uint64(total))
+	return nil
+}
+
+// flushCoalesced writes out every topic's latest coalesced payload and
+// clears the map, used by PolicyCoalesceByTopic.
+func (c *WSClient) flushCoalesced() error {
+	c.mu.Lock()
+	pending := c.coalesced
+	c.coalesced = make(map[string][]byte)
+	c.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	w, err := c.conn.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	first := true
+	for _, data := range pending {
+		if !first {
+			w.Write([]byte{'\n'})
+			total++
+		}
+		first = false
+		n, _ := w.Write(data)
+		total += n
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	atomic.AddUint64(&c.hub.bytesSent, uint64(total))
+	return nil
+}
+
 // Helper: marshal to JSON (panic on error, for internal use)
 func mustMarshal(v interface{}) []byte {
 	data, err := json.Marshal(v)
@@ -244,13 +745,19 @@ func mustMarshal(v interface{}) []byte {
 // BroadcastTransaction broadcasts a transaction to WebSocket clients
 func (s *Server) BroadcastTransaction(txHash [32]byte, from, to [32]byte, amount, fee uint64) {
 	if s.wsHub != nil {
-		s.wsHub.Broadcast("transaction", "transactions", gin.H{
+		s.wsHub.BroadcastWithAttrs("transaction", "transactions", gin.H{
 			"tx_hash": mustHex(txHash[:]),
 			"from":    mustHex(from[:]),
 			"to":      mustHex(to[:]),
 			"amount":  amount,
 			"fee":     fee,
 			"time":    time.Now().Unix(),
+		}, BroadcastAttrs{
+			From:    from,
+			HasFrom: true,
+			To:      to,
+			HasTo:   true,
+			Amount:  amount,
 		})
 	}
 }
@@ -267,6 +774,47 @@ func (s *Server) BroadcastBlock(blockNumber uint64, blockHash [32]byte, txCount
 	}
 }
 
+// OnTransactionAdded implements mempool.TxEventSink, notifying every
+// newPendingTransactions subscriber of tx's hash. Wired in via
+// mp.SetTxEventSink(s) in NewServer.
+func (s *Server) OnTransactionAdded(tx *mempool.Transaction) {
+	if s.wsHub != nil {
+		s.wsHub.PublishNewPendingTransaction(tx.Hash)
+	}
+}
+
+// PublishNewHead notifies every newHeads subscriber of a block newly
+// applied to the chain. Unlike BroadcastBlock (the legacy topic/Action
+// namespace), this feeds the Ethereum-style subscribe/unsubscribe
+// namespace in subscriptions.go.
+func (s *Server) PublishNewHead(header BlockHeader) {
+	if s.wsHub != nil {
+		s.wsHub.PublishNewHead(header)
+	}
+}
+
+// PublishLog notifies every logs subscriber whose {addresses, topics}
+// filter matches log, driven by whatever applies state transitions and
+// derives logs from them (see pkg/receipts.Log).
+func (s *Server) PublishLog(log receipts.Log, blockHash [32]byte, blockNumber uint64, txHash [32]byte, logIndex int) {
+	if s.wsHub != nil {
+		s.wsHub.PublishLog(log, blockHash, blockNumber, txHash, logIndex)
+	}
+}
+
+// BroadcastDeposit broadcasts a validator deposit to WebSocket clients
+func (s *Server) BroadcastDeposit(txHash [32]byte, pubkey, withdrawalCredentials [32]byte, amount uint64) {
+	if s.wsHub != nil {
+		s.wsHub.Broadcast("deposit", "deposits", gin.H{
+			"tx_hash":                mustHex(txHash[:]),
+			"pubkey":                 mustHex(pubkey[:]),
+			"withdrawal_credentials": mustHex(withdrawalCredentials[:]),
+			"amount":                 amount,
+			"time":                   time.Now().Unix(),
+		})
+	}
+}
+
 // BroadcastEscrow broadcasts an escrow event to WebSocket clients
 func (s *Server) BroadcastEscrow(escrowID [32]byte, event string, data interface{}) {
 	if s.wsHub != nil {
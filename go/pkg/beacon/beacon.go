@@ -0,0 +1,63 @@
+// Package beacon integrates a drand-style public randomness beacon into
+// escrow settlement: a release transaction must embed the beacon entry for
+// its settlement round, so a block producer can't grind on which solver
+// wins a bounty by choosing favorable randomness after the fact (see
+// bindings.ValidateEscrowReleaseWithBeacon).
+package beacon
+
+import (
+	"context"
+	"fmt"
+)
+
+// BeaconEntry is one published round of a randomness beacon.
+type BeaconEntry struct {
+	Round      uint64
+	Randomness [32]byte
+	Signature  []byte
+}
+
+// BeaconAPI is a source of verifiable public randomness, implemented by
+// DrandClient or a test double.
+type BeaconAPI interface {
+	// Entry fetches the published entry for round.
+	Entry(ctx context.Context, round uint64) (BeaconEntry, error)
+
+	// VerifyEntry checks that curr is a valid successor of prev.
+	VerifyEntry(prev, curr BeaconEntry) error
+}
+
+// BeaconNetwork is one configured beacon chain, effective from block
+// height Start onward, for networks that rotate or replace their beacon
+// over time.
+type BeaconNetwork struct {
+	Name      string
+	Start     uint64 // first block height this network is used for
+	ChainHash string
+	PublicKey []byte
+	API       BeaconAPI
+}
+
+// BeaconNetworks selects, for a given block height, the configured
+// BeaconNetwork that applies at that height.
+type BeaconNetworks []BeaconNetwork
+
+// ForHeight returns the network with the greatest Start <= height — the
+// most recently activated network as of that height. It errors if no
+// configured network has started by height.
+func (n BeaconNetworks) ForHeight(height uint64) (*BeaconNetwork, error) {
+	var best *BeaconNetwork
+	for i := range n {
+		net := &n[i]
+		if net.Start > height {
+			continue
+		}
+		if best == nil || net.Start > best.Start {
+			best = net
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no beacon network active at height %d", height)
+	}
+	return best, nil
+}
@@ -0,0 +1,43 @@
+package beacon
+
+import "testing"
+
+func TestBeaconNetworksForHeight(t *testing.T) {
+	networks := BeaconNetworks{
+		{Name: "v1", Start: 0},
+		{Name: "v2", Start: 1000},
+		{Name: "v3", Start: 500},
+	}
+
+	cases := []struct {
+		height uint64
+		want   string
+	}{
+		{height: 0, want: "v1"},
+		{height: 499, want: "v1"},
+		{height: 500, want: "v3"},
+		{height: 999, want: "v3"},
+		{height: 1000, want: "v2"},
+		{height: 5000, want: "v2"},
+	}
+
+	for _, c := range cases {
+		net, err := networks.ForHeight(c.height)
+		if err != nil {
+			t.Fatalf("height %d: ForHeight failed: %v", c.height, err)
+		}
+		if net.Name != c.want {
+			t.Fatalf("height %d: got network %q, want %q", c.height, net.Name, c.want)
+		}
+	}
+}
+
+func TestBeaconNetworksForHeightNoneActive(t *testing.T) {
+	networks := BeaconNetworks{
+		{Name: "v1", Start: 100},
+	}
+
+	if _, err := networks.ForHeight(50); err == nil {
+		t.Fatal("expected an error when no network has started yet")
+	}
+}
@@ -0,0 +1,121 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DrandClient is a BeaconAPI backed by a drand HTTP relay
+// (https://drand.love), fetching rounds via
+// GET {BaseURL}/{ChainHash}/public/{round}.
+type DrandClient struct {
+	BaseURL   string
+	ChainHash string
+	client    *http.Client
+}
+
+// NewDrandClient returns a DrandClient for the beacon chain at baseURL
+// (e.g. "https://api.drand.sh") identified by chainHash.
+func NewDrandClient(baseURL, chainHash string) *DrandClient {
+	return &DrandClient{
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+		ChainHash: chainHash,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// drandRoundResponse mirrors a drand HTTP relay's /public/{round} JSON
+// response shape.
+type drandRoundResponse struct {
+	Round             uint64 `json:"round"`
+	Randomness        string `json:"randomness"`
+	Signature         string `json:"signature"`
+	PreviousSignature string `json:"previous_signature,omitempty"`
+}
+
+// Entry fetches round's published entry over HTTP.
+func (d *DrandClient) Entry(ctx context.Context, round uint64) (BeaconEntry, error) {
+	url := fmt.Sprintf("%s/%s/public/%d", d.BaseURL, d.ChainHash, round)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("build beacon request: %w", err)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("fetch beacon round %d: %w", round, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return BeaconEntry{}, fmt.Errorf("beacon round %d: unexpected status %d", round, resp.StatusCode)
+	}
+
+	var body drandRoundResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return BeaconEntry{}, fmt.Errorf("decode beacon round %d: %w", round, err)
+	}
+
+	randomness, err := decodeHex32(body.Randomness)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("beacon round %d randomness: %w", round, err)
+	}
+
+	signature, err := hex.DecodeString(body.Signature)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("beacon round %d signature: %w", round, err)
+	}
+
+	return BeaconEntry{
+		Round:      body.Round,
+		Randomness: randomness,
+		Signature:  signature,
+	}, nil
+}
+
+// VerifyEntry checks that curr chains off prev: its round must be exactly
+// prev.Round+1, and its Randomness must equal SHA256(curr.Signature), the
+// construction every drand scheme uses to derive a round's randomness from
+// its signature.
+//
+// This checks structural chaining only — it does not verify curr.Signature
+// against the beacon group's BLS public key, which needs a pairing-based
+// curve library this module doesn't vendor (see BeaconNetwork.PublicKey,
+// currently unused by this client). Callers that need full cryptographic
+// assurance should treat VerifyEntry as necessary, not sufficient, until
+// that verification is added.
+func (d *DrandClient) VerifyEntry(prev, curr BeaconEntry) error {
+	if curr.Round != prev.Round+1 {
+		return fmt.Errorf("beacon round %d does not chain from round %d", curr.Round, prev.Round)
+	}
+
+	if len(curr.Signature) == 0 {
+		return fmt.Errorf("beacon round %d has no signature", curr.Round)
+	}
+
+	if sha256.Sum256(curr.Signature) != curr.Randomness {
+		return fmt.Errorf("beacon round %d randomness does not match sha256(signature)", curr.Round)
+	}
+
+	return nil
+}
+
+func decodeHex32(s string) ([32]byte, error) {
+	var out [32]byte
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return out, err
+	}
+	if len(b) != 32 {
+		return out, fmt.Errorf("expected 32 bytes, got %d", len(b))
+	}
+	copy(out[:], b)
+	return out, nil
+}
@@ -0,0 +1,93 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDrandClientEntry(t *testing.T) {
+	sig := []byte("test-signature-bytes")
+	randomness := sha256.Sum256(sig)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"round": 42, "randomness": %q, "signature": %q}`,
+			hex.EncodeToString(randomness[:]), hex.EncodeToString(sig))
+	}))
+	defer srv.Close()
+
+	client := NewDrandClient(srv.URL, "test-chain")
+	entry, err := client.Entry(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Entry failed: %v", err)
+	}
+
+	if entry.Round != 42 {
+		t.Fatalf("entry.Round = %d, want 42", entry.Round)
+	}
+	if entry.Randomness != randomness {
+		t.Fatalf("entry.Randomness = %x, want %x", entry.Randomness, randomness)
+	}
+	if hex.EncodeToString(entry.Signature) != hex.EncodeToString(sig) {
+		t.Fatalf("entry.Signature = %x, want %x", entry.Signature, sig)
+	}
+}
+
+func TestDrandClientEntryBadStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client := NewDrandClient(srv.URL, "test-chain")
+	if _, err := client.Entry(context.Background(), 1); err == nil {
+		t.Fatal("expected an error for a non-200 response")
+	}
+}
+
+func TestVerifyEntry(t *testing.T) {
+	sig := []byte("round-43-signature")
+	randomness := sha256.Sum256(sig)
+
+	prev := BeaconEntry{Round: 42}
+	curr := BeaconEntry{Round: 43, Randomness: randomness, Signature: sig}
+
+	client := &DrandClient{}
+	if err := client.VerifyEntry(prev, curr); err != nil {
+		t.Fatalf("VerifyEntry rejected a valid chained entry: %v", err)
+	}
+}
+
+func TestVerifyEntryRejectsWrongRound(t *testing.T) {
+	prev := BeaconEntry{Round: 42}
+	curr := BeaconEntry{Round: 44, Signature: []byte("sig")}
+
+	client := &DrandClient{}
+	if err := client.VerifyEntry(prev, curr); err == nil {
+		t.Fatal("expected an error for a non-consecutive round")
+	}
+}
+
+func TestVerifyEntryRejectsMismatchedRandomness(t *testing.T) {
+	prev := BeaconEntry{Round: 42}
+	curr := BeaconEntry{Round: 43, Randomness: [32]byte{0xff}, Signature: []byte("sig")}
+
+	client := &DrandClient{}
+	if err := client.VerifyEntry(prev, curr); err == nil {
+		t.Fatal("expected an error when randomness does not match sha256(signature)")
+	}
+}
+
+func TestVerifyEntryRejectsEmptySignature(t *testing.T) {
+	prev := BeaconEntry{Round: 42}
+	curr := BeaconEntry{Round: 43}
+
+	client := &DrandClient{}
+	if err := client.VerifyEntry(prev, curr); err == nil {
+		t.Fatal("expected an error for a missing signature")
+	}
+}
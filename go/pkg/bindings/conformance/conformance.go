@@ -0,0 +1,114 @@
+// Package conformance runs a shared corpus of JSON test vectors against
+// pkg/bindings' Rust consensus FFI calls, mirroring how Filecoin's
+// test-vectors crate validates independent implementations against one
+// corpus: a vector names the operation to exercise (VerifyTransaction,
+// ComputeEscrowID, ValidateEscrowCreation/Release/Refund), supplies its
+// JSON-encoded input, and states the outcome a conformant implementation
+// must produce. See LoadDir to load a corpus directory and Run/RunAll to
+// exercise it.
+package conformance
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Supported Vector.Operation values.
+const (
+	OpVerifyTransaction     = "verify_transaction"
+	OpComputeEscrowID       = "compute_escrow_id"
+	OpValidateEscrowCreate  = "validate_escrow_creation"
+	OpValidateEscrowRelease = "validate_escrow_release"
+	OpValidateEscrowRefund  = "validate_escrow_refund"
+)
+
+// Vector is one conformance test vector: which operation to exercise, its
+// input, and the outcome a conformant implementation must produce.
+type Vector struct {
+	Name      string          `json:"name"`
+	Operation string          `json:"operation"`
+	Input     json.RawMessage `json:"input"`
+	Expect    Expectation     `json:"expect"`
+}
+
+// Expectation is a vector's expected outcome. ExpectError, if non-empty,
+// means the operation must fail — the exact message isn't compared, since
+// error codes/wording are Rust-FFI-internal, only that it failed at all.
+// Otherwise the operation must succeed, and Result/EscrowID (whichever the
+// operation produces) must match if set.
+type Expectation struct {
+	ExpectError string `json:"expect_error,omitempty"`
+
+	// Result is checked for the verify_transaction operation.
+	Result *ValidationResultVector `json:"result,omitempty"`
+
+	// EscrowID (hex-encoded) is checked for the compute_escrow_id operation.
+	EscrowID string `json:"escrow_id,omitempty"`
+}
+
+// ValidationResultVector mirrors bindings.ValidationResult's fields in the
+// vector corpus's JSON shape.
+type ValidationResultVector struct {
+	Valid     bool   `json:"valid"`
+	TotalCost uint64 `json:"total_cost"`
+	GasUsed   uint64 `json:"gas_used"`
+	Fee       uint64 `json:"fee"`
+}
+
+// LoadDir reads every *.json file directly inside dir as a Vector, sorted
+// by filename so a run's ordering (and any output diff across runs) is
+// deterministic.
+func LoadDir(dir string) ([]Vector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read vectors dir: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	vectors := make([]Vector, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("read vector %s: %w", name, err)
+		}
+
+		var v Vector
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("parse vector %s: %w", name, err)
+		}
+		if v.Name == "" {
+			v.Name = name
+		}
+		vectors = append(vectors, v)
+	}
+
+	return vectors, nil
+}
+
+// decodeHash32 decodes a hex string (optionally "0x"-prefixed) into a
+// [32]byte, the shape every address/hash field in the vector corpus uses.
+func decodeHash32(s string) ([32]byte, error) {
+	var out [32]byte
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return out, fmt.Errorf("invalid hex %q: %w", s, err)
+	}
+	if len(b) != 32 {
+		return out, fmt.Errorf("expected 32 bytes, got %d", len(b))
+	}
+	copy(out[:], b)
+	return out, nil
+}
@@ -0,0 +1,42 @@
+package conformance
+
+import (
+	"flag"
+	"os"
+	"testing"
+)
+
+var runConformance = flag.Bool("conformance", false, "run the FFI conformance vector corpus (requires COINJ_VECTORS_DIR)")
+
+// TestConformance runs every vector under COINJ_VECTORS_DIR through Run,
+// failing if any vector's outcome doesn't match its Expectation. Skipped
+// by default: the corpus is a separate checkout this repo doesn't vendor,
+// and exercising it needs the Rust cdylib (see pkg/bindings) built and
+// linked, so `go test ./...` shouldn't fail without it. Opt in with
+// `go test -conformance ./pkg/bindings/conformance/... ` and
+// COINJ_VECTORS_DIR set to the corpus directory.
+func TestConformance(t *testing.T) {
+	if !*runConformance {
+		t.Skip("skipping conformance corpus; run with -conformance (and COINJ_VECTORS_DIR set)")
+	}
+
+	dir := os.Getenv("COINJ_VECTORS_DIR")
+	if dir == "" {
+		t.Fatal("COINJ_VECTORS_DIR must be set when running with -conformance")
+	}
+
+	vectors, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("load vectors: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatalf("no vectors found in %s", dir)
+	}
+
+	report := RunAll(vectors)
+	for _, res := range report.Failures() {
+		t.Errorf("%s: %s", res.Vector.Name, res.Detail)
+	}
+
+	t.Logf("ran %d conformance vectors, %d failed", len(report.Results), len(report.Failures()))
+}
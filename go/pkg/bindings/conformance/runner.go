@@ -0,0 +1,358 @@
+package conformance
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/bindings"
+)
+
+// Result is one vector's outcome after being run through the FFI.
+type Result struct {
+	Vector Vector
+	Passed bool
+	Detail string // reason for failure; empty when Passed
+}
+
+// Report summarizes a full corpus run.
+type Report struct {
+	Results []Result
+}
+
+// Failures returns every Result that didn't pass.
+func (r Report) Failures() []Result {
+	var out []Result
+	for _, res := range r.Results {
+		if !res.Passed {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// RunAll runs every vector through Run and collects the results.
+func RunAll(vectors []Vector) Report {
+	report := Report{Results: make([]Result, len(vectors))}
+	for i, v := range vectors {
+		report.Results[i] = Run(v)
+	}
+	return report
+}
+
+// Run exercises a single vector against the bindings call its Operation
+// names and checks the outcome against its Expectation.
+func Run(v Vector) Result {
+	switch v.Operation {
+	case OpVerifyTransaction:
+		return runVerifyTransaction(v)
+	case OpComputeEscrowID:
+		return runComputeEscrowID(v)
+	case OpValidateEscrowCreate:
+		return runValidateEscrowCreation(v)
+	case OpValidateEscrowRelease:
+		return runValidateEscrowRelease(v)
+	case OpValidateEscrowRefund:
+		return runValidateEscrowRefund(v)
+	default:
+		return fail(v, "unknown operation %q", v.Operation)
+	}
+}
+
+func fail(v Vector, format string, args ...interface{}) Result {
+	return Result{Vector: v, Passed: false, Detail: fmt.Sprintf(format, args...)}
+}
+
+func pass(v Vector) Result {
+	return Result{Vector: v, Passed: true}
+}
+
+// checkErr reconciles err against v.Expect.ExpectError (non-empty means an
+// error was required). The bool return reports whether the caller should
+// return the accompanying Result immediately; false means err was nil as
+// expected and the caller should go on to check its success-case result.
+func checkErr(v Vector, err error) (Result, bool) {
+	if v.Expect.ExpectError != "" {
+		if err == nil {
+			return fail(v, "expected error %q, got success", v.Expect.ExpectError), true
+		}
+		return pass(v), true
+	}
+	if err != nil {
+		return fail(v, "unexpected error: %v", err), true
+	}
+	return Result{}, false
+}
+
+func decodeHexBytes(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+// transactionVector is bindings.Transaction's vector corpus JSON shape —
+// fixed-size byte arrays become hex strings.
+type transactionVector struct {
+	CodecVersion         uint8  `json:"codec_version"`
+	TxType               uint8  `json:"tx_type"`
+	From                 string `json:"from"`
+	To                   string `json:"to"`
+	Amount               uint64 `json:"amount"`
+	Nonce                uint64 `json:"nonce"`
+	GasLimit             uint64 `json:"gas_limit"`
+	GasPrice             uint64 `json:"gas_price"`
+	MaxFeePerGas         uint64 `json:"max_fee_per_gas"`
+	MaxPriorityFeePerGas uint64 `json:"max_priority_fee_per_gas"`
+	Signature            string `json:"signature"`
+	Data                 string `json:"data"`
+	ChainID              uint64 `json:"chain_id"`
+	Timestamp            int64  `json:"timestamp"`
+}
+
+func (tv transactionVector) toTransaction() (*bindings.Transaction, error) {
+	from, err := decodeHash32(tv.From)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	to, err := decodeHash32(tv.To)
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+
+	var sig [64]byte
+	if tv.Signature != "" {
+		b, err := decodeHexBytes(tv.Signature)
+		if err != nil || len(b) != 64 {
+			return nil, fmt.Errorf("signature: expected 64-byte hex")
+		}
+		copy(sig[:], b)
+	}
+
+	data, err := decodeHexBytes(tv.Data)
+	if err != nil {
+		return nil, fmt.Errorf("data: %w", err)
+	}
+
+	return &bindings.Transaction{
+		CodecVersion:         tv.CodecVersion,
+		TxType:               tv.TxType,
+		From:                 from,
+		To:                   to,
+		Amount:               tv.Amount,
+		Nonce:                tv.Nonce,
+		GasLimit:             tv.GasLimit,
+		GasPrice:             tv.GasPrice,
+		MaxFeePerGas:         tv.MaxFeePerGas,
+		MaxPriorityFeePerGas: tv.MaxPriorityFeePerGas,
+		Signature:            sig,
+		Data:                 data,
+		ChainID:              tv.ChainID,
+		Timestamp:            tv.Timestamp,
+	}, nil
+}
+
+type accountStateVector struct {
+	Balance uint64 `json:"balance"`
+	Nonce   uint64 `json:"nonce"`
+}
+
+type verifyTransactionInput struct {
+	Tx          transactionVector  `json:"tx"`
+	SenderState accountStateVector `json:"sender_state"`
+	ChainID     uint64             `json:"chain_id"`
+}
+
+func runVerifyTransaction(v Vector) Result {
+	var in verifyTransactionInput
+	if err := json.Unmarshal(v.Input, &in); err != nil {
+		return fail(v, "parse input: %v", err)
+	}
+
+	tx, err := in.Tx.toTransaction()
+	if err != nil {
+		return fail(v, "decode tx: %v", err)
+	}
+
+	result, err := bindings.VerifyTransaction(tx, &bindings.AccountState{
+		Balance: in.SenderState.Balance,
+		Nonce:   in.SenderState.Nonce,
+	}, in.ChainID)
+	if res, done := checkErr(v, err); done {
+		return res
+	}
+
+	if v.Expect.Result != nil {
+		expect := *v.Expect.Result
+		got := ValidationResultVector{Valid: result.Valid, TotalCost: result.TotalCost, GasUsed: result.GasUsed, Fee: result.Fee}
+		if got != expect {
+			return fail(v, "result mismatch: expected %+v, got %+v", expect, got)
+		}
+	}
+
+	return pass(v)
+}
+
+// escrowVector is bindings.BountyEscrow's vector corpus JSON shape.
+type escrowVector struct {
+	ID           string  `json:"id"`
+	Submitter    string  `json:"submitter"`
+	Amount       uint64  `json:"amount"`
+	ProblemHash  string  `json:"problem_hash"`
+	CreatedBlock uint64  `json:"created_block"`
+	ExpiryBlock  uint64  `json:"expiry_block"`
+	State        uint8   `json:"state"`
+	Recipient    *string `json:"recipient,omitempty"`
+	SettledBlock *uint64 `json:"settled_block,omitempty"`
+	SettlementTx *string `json:"settlement_tx,omitempty"`
+	BeaconRound  uint64  `json:"beacon_round,omitempty"`
+}
+
+func (ev escrowVector) toBountyEscrow() (*bindings.BountyEscrow, error) {
+	id, err := decodeHash32(ev.ID)
+	if err != nil {
+		return nil, fmt.Errorf("id: %w", err)
+	}
+	submitter, err := decodeHash32(ev.Submitter)
+	if err != nil {
+		return nil, fmt.Errorf("submitter: %w", err)
+	}
+	problemHash, err := decodeHash32(ev.ProblemHash)
+	if err != nil {
+		return nil, fmt.Errorf("problem_hash: %w", err)
+	}
+
+	escrow := &bindings.BountyEscrow{
+		ID:           id,
+		Submitter:    submitter,
+		Amount:       ev.Amount,
+		ProblemHash:  problemHash,
+		CreatedBlock: ev.CreatedBlock,
+		ExpiryBlock:  ev.ExpiryBlock,
+		State:        ev.State,
+		SettledBlock: ev.SettledBlock,
+		BeaconRound:  ev.BeaconRound,
+	}
+
+	if ev.Recipient != nil {
+		recipient, err := decodeHash32(*ev.Recipient)
+		if err != nil {
+			return nil, fmt.Errorf("recipient: %w", err)
+		}
+		escrow.Recipient = &recipient
+	}
+	if ev.SettlementTx != nil {
+		settlementTx, err := decodeHash32(*ev.SettlementTx)
+		if err != nil {
+			return nil, fmt.Errorf("settlement_tx: %w", err)
+		}
+		escrow.SettlementTx = &settlementTx
+	}
+
+	return escrow, nil
+}
+
+type computeEscrowIDInput struct {
+	Submitter    string `json:"submitter"`
+	ProblemHash  string `json:"problem_hash"`
+	CreatedBlock uint64 `json:"created_block"`
+}
+
+func runComputeEscrowID(v Vector) Result {
+	var in computeEscrowIDInput
+	if err := json.Unmarshal(v.Input, &in); err != nil {
+		return fail(v, "parse input: %v", err)
+	}
+
+	submitter, err := decodeHash32(in.Submitter)
+	if err != nil {
+		return fail(v, "submitter: %v", err)
+	}
+	problemHash, err := decodeHash32(in.ProblemHash)
+	if err != nil {
+		return fail(v, "problem_hash: %v", err)
+	}
+
+	id, err := bindings.ComputeEscrowID(submitter, problemHash, in.CreatedBlock)
+	if res, done := checkErr(v, err); done {
+		return res
+	}
+
+	if v.Expect.EscrowID != "" {
+		if got := fmt.Sprintf("%x", id); got != strings.TrimPrefix(v.Expect.EscrowID, "0x") {
+			return fail(v, "escrow ID mismatch: expected %s, got %s", v.Expect.EscrowID, got)
+		}
+	}
+
+	return pass(v)
+}
+
+type validateEscrowCreationInput struct {
+	Amount       uint64 `json:"amount"`
+	CreatedBlock uint64 `json:"created_block"`
+	ExpiryBlock  uint64 `json:"expiry_block"`
+}
+
+func runValidateEscrowCreation(v Vector) Result {
+	var in validateEscrowCreationInput
+	if err := json.Unmarshal(v.Input, &in); err != nil {
+		return fail(v, "parse input: %v", err)
+	}
+
+	err := bindings.ValidateEscrowCreation(in.Amount, in.CreatedBlock, in.ExpiryBlock)
+	if res, done := checkErr(v, err); done {
+		return res
+	}
+	return pass(v)
+}
+
+type validateEscrowReleaseInput struct {
+	Escrow    escrowVector `json:"escrow"`
+	Recipient string       `json:"recipient"`
+}
+
+func runValidateEscrowRelease(v Vector) Result {
+	var in validateEscrowReleaseInput
+	if err := json.Unmarshal(v.Input, &in); err != nil {
+		return fail(v, "parse input: %v", err)
+	}
+
+	escrow, err := in.Escrow.toBountyEscrow()
+	if err != nil {
+		return fail(v, "decode escrow: %v", err)
+	}
+	recipient, err := decodeHash32(in.Recipient)
+	if err != nil {
+		return fail(v, "recipient: %v", err)
+	}
+
+	err = bindings.ValidateEscrowRelease(escrow, recipient)
+	if res, done := checkErr(v, err); done {
+		return res
+	}
+	return pass(v)
+}
+
+type validateEscrowRefundInput struct {
+	Escrow       escrowVector `json:"escrow"`
+	CurrentBlock uint64       `json:"current_block"`
+}
+
+func runValidateEscrowRefund(v Vector) Result {
+	var in validateEscrowRefundInput
+	if err := json.Unmarshal(v.Input, &in); err != nil {
+		return fail(v, "parse input: %v", err)
+	}
+
+	escrow, err := in.Escrow.toBountyEscrow()
+	if err != nil {
+		return fail(v, "decode escrow: %v", err)
+	}
+
+	err = bindings.ValidateEscrowRefund(escrow, in.CurrentBlock)
+	if res, done := checkErr(v, err); done {
+		return res
+	}
+	return pass(v)
+}
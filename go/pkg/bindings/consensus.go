@@ -63,6 +63,7 @@ typedef struct {
     uint64_t settled_block;
     int32_t has_settlement_tx;
     uint8_t settlement_tx[32];
+    uint64_t beacon_round;
 } BountyEscrowFFI;
 
 // Function declarations
@@ -72,6 +73,17 @@ extern CoinjResult coinjecture_verify_transaction(
     ValidationResultFFI* out_result
 );
 
+// Batch counterpart of coinjecture_verify_transaction: txs/states are
+// parallel arrays of length count, verified with a rayon-parallelized pass
+// on the Rust side rather than one cgo call per transaction. out_results
+// must point at a caller-allocated array of length count.
+extern CoinjResult coinjecture_verify_transaction_batch(
+    const TransactionFFI* txs,
+    const AccountStateFFI* states,
+    uint32_t count,
+    ValidationResultFFI* out_results
+);
+
 extern CoinjResult coinjecture_compute_escrow_id(
     const uint8_t* submitter,
     const uint8_t* problem_hash,
@@ -90,6 +102,19 @@ extern CoinjResult coinjecture_validate_escrow_release(
     const uint8_t* recipient
 );
 
+// Beacon-aware counterpart of coinjecture_validate_escrow_release: the
+// release is only valid if beacon_signature verifies as the settlement
+// round's published entry, binding the release to a round that couldn't
+// have been known (and so chosen favorably) before the settlement block.
+extern CoinjResult coinjecture_validate_escrow_release_with_beacon(
+    const BountyEscrowFFI* escrow,
+    const uint8_t* recipient,
+    uint64_t beacon_round,
+    const uint8_t* beacon_randomness,
+    const uint8_t* beacon_signature,
+    uint32_t beacon_signature_len
+);
+
 extern CoinjResult coinjecture_validate_escrow_refund(
     const BountyEscrowFFI* escrow,
     uint64_t current_block
@@ -106,8 +131,16 @@ extern uint32_t coinjecture_codec_version(void);
 */
 import "C"
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
+	"runtime"
+	"sort"
+	"sync"
 	"unsafe"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/codec"
 )
 
 // Transaction types
@@ -115,6 +148,23 @@ const (
 	TxTypeTransfer          uint8 = 1
 	TxTypeProblemSubmission uint8 = 2
 	TxTypeBountyPayment     uint8 = 3
+	TxTypeDeposit           uint8 = 4
+)
+
+// Typed-envelope transaction types, added after the four above were already
+// live: escrow operations move behind first-class typed txs (see
+// EncodeEscrowCreateData/EncodeEscrowReleaseData) instead of being validated
+// only by an out-of-band RPC call, and TxTypeAccessList lets a transaction
+// pre-declare the state reads it intends to make (see AccessListEntry).
+// Numbered starting at 5, continuing TxTypeDeposit's sequence, rather than
+// reusing 2-4: those values are already signed into existing transactions
+// as ProblemSubmission/BountyPayment/Deposit, and renumbering them out from
+// under those signatures would be the one thing this envelope is explicitly
+// meant not to do.
+const (
+	TxTypeEscrowCreate  uint8 = 5
+	TxTypeEscrowRelease uint8 = 6
+	TxTypeAccessList    uint8 = 7
 )
 
 // Escrow states
@@ -124,19 +174,344 @@ const (
 	EscrowStateRefunded uint8 = 2
 )
 
-// Transaction represents a blockchain transaction
+// Fee envelope versions carried in Transaction.CodecVersion: 1 selects the
+// legacy single GasPrice field, 2 selects EIP-1559 style dynamic fees
+// (MaxFeePerGas/MaxPriorityFeePerGas), mirroring mempool.Transaction.
+//
+// ChainID (replay protection) is orthogonal to the fee envelope and isn't
+// its own CodecVersion: it's carried by both versions above. The one
+// grandfather case is FeeEnvelopeLegacy transactions with ChainID 0 — the
+// implicit value for messages signed before chain ids existed — which
+// VerifyTransaction accepts on any chain rather than rejecting outright.
+const (
+	FeeEnvelopeLegacy     uint8 = 1
+	FeeEnvelopeDynamicFee uint8 = 2
+)
+
+// ErrChainIDMismatch is returned by VerifyTransaction when a transaction's
+// ChainID doesn't match the verifying node's configured chain id, and the
+// transaction isn't a grandfathered pre-chain-id legacy message (see
+// FeeEnvelopeLegacy above).
+var ErrChainIDMismatch = fmt.Errorf("bindings: transaction chain id does not match this node's configured chain id")
+
+// Transaction represents a blockchain transaction. The `codec` tags are the
+// one authoritative wire layout for hashing/signing (see pkg/codec and
+// TxHash) — previously each call site hand-packed its own bytes and one of
+// them silently left Signature out of the hash entirely.
 type Transaction struct {
-	CodecVersion uint8
-	TxType       uint8
-	From         [32]byte
-	To           [32]byte
-	Amount       uint64
-	Nonce        uint64
-	GasLimit     uint64
-	GasPrice     uint64
-	Signature    [64]byte
-	Data         []byte
-	Timestamp    int64
+	CodecVersion         uint8    `codec:"1,uint8"`
+	TxType               uint8    `codec:"2,uint8"`
+	From                 [32]byte `codec:"3,bytes32"`
+	To                   [32]byte `codec:"4,bytes32"`
+	Amount               uint64   `codec:"5,uint64"`
+	Nonce                uint64   `codec:"6,uint64"`
+	GasLimit             uint64   `codec:"7,uint64"`
+	GasPrice             uint64   `codec:"8,uint64"`  // Legacy gas price, wei per gas — CodecVersion=1
+	MaxFeePerGas         uint64   `codec:"9,uint64"`  // EIP-1559 absolute cap on wei per gas — CodecVersion=2
+	MaxPriorityFeePerGas uint64   `codec:"10,uint64"` // EIP-1559 tip offered to the block producer — CodecVersion=2
+	Signature            [64]byte `codec:"11,bytes64"`
+	Data                 []byte   `codec:"12,bytes"`
+	ChainID              uint64   `codec:"13,uint64"` // Replay protection: must match the verifying node's configured chain id
+	Timestamp            int64    `codec:"14,int64"`
+}
+
+// txCodecRegistry lets TxHash (and anything else decoding a canonical
+// Transaction payload) keep decoding a version forever; both fee-envelope
+// versions currently share this one Go struct's schema, but a future
+// breaking change can register its own type without touching either.
+var txCodecRegistry = codec.NewRegistry()
+
+func init() {
+	txCodecRegistry.Register(FeeEnvelopeLegacy, Transaction{})
+	txCodecRegistry.Register(FeeEnvelopeDynamicFee, Transaction{})
+}
+
+// TxHash is the canonical hash of tx's codec-tagged fields: sha256 of
+// EncodeCanonical(tx, tx.CodecVersion). This is the one place that layout
+// is defined; every hashing call site (submit-tx, mempool, consensus)
+// should call this rather than packing its own bytes.
+func TxHash(tx *Transaction) ([32]byte, error) {
+	encoded, err := codec.EncodeCanonical(tx, tx.CodecVersion)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("encode transaction: %w", err)
+	}
+	return sha256.Sum256(encoded), nil
+}
+
+// DecodeTx decodes a canonical payload produced by EncodeCanonical back
+// into a Transaction, using whichever version's schema txCodecRegistry has
+// on file for the payload's version byte.
+func DecodeTx(data []byte) (*Transaction, error) {
+	decoded, _, err := txCodecRegistry.DecodeCanonical(data)
+	if err != nil {
+		return nil, err
+	}
+	return decoded.(*Transaction), nil
+}
+
+// DepositDataLen is the byte length of an encoded deposit payload: pubkey
+// (32) + withdrawal credentials (32) + amount (8, little-endian).
+const DepositDataLen = 32 + 32 + 8
+
+// EncodeDepositData packs a deposit registration into the byte layout
+// carried in a TxTypeDeposit transaction's Data field. The depositing
+// transaction's own Signature field already authenticates the sender, so
+// the deposit payload itself only needs to carry what the validator set
+// change requires: the new validator's pubkey, its withdrawal credentials,
+// and the amount.
+func EncodeDepositData(pubkey, withdrawalCredentials [32]byte, amount uint64) []byte {
+	buf := make([]byte, 0, DepositDataLen)
+	buf = append(buf, pubkey[:]...)
+	buf = append(buf, withdrawalCredentials[:]...)
+	var amountBuf [8]byte
+	for i := 0; i < 8; i++ {
+		amountBuf[i] = byte(amount >> (8 * i))
+	}
+	return append(buf, amountBuf[:]...)
+}
+
+// DecodeDepositData unpacks a TxTypeDeposit transaction's Data field back
+// into its pubkey, withdrawal credentials, and amount.
+func DecodeDepositData(data []byte) (pubkey, withdrawalCredentials [32]byte, amount uint64, err error) {
+	if len(data) != DepositDataLen {
+		return pubkey, withdrawalCredentials, 0, fmt.Errorf("invalid deposit data length: got %d, want %d", len(data), DepositDataLen)
+	}
+
+	copy(pubkey[:], data[0:32])
+	copy(withdrawalCredentials[:], data[32:64])
+	for i := 0; i < 8; i++ {
+		amount |= uint64(data[64+i]) << (8 * i)
+	}
+	return pubkey, withdrawalCredentials, amount, nil
+}
+
+// EscrowCreateDataLen is the byte length of an encoded escrow-creation
+// payload: problem hash (32) + amount, created block, expiry block (8 each).
+const EscrowCreateDataLen = 32 + 8 + 8 + 8
+
+// EncodeEscrowCreateData validates amount/createdBlock/expiryBlock via
+// ValidateEscrowCreation and, on success, packs them alongside problemHash
+// into the byte layout carried in a TxTypeEscrowCreate transaction's Data
+// field. Routing escrow creation through this encoder is what makes it a
+// first-class typed tx: a malformed escrow creation fails here, before a
+// transaction carrying it is ever signed, instead of only being caught
+// later by a separate RPC call.
+func EncodeEscrowCreateData(problemHash [32]byte, amount, createdBlock, expiryBlock uint64) ([]byte, error) {
+	if err := ValidateEscrowCreation(amount, createdBlock, expiryBlock); err != nil {
+		return nil, fmt.Errorf("invalid escrow creation: %w", err)
+	}
+
+	buf := make([]byte, 0, EscrowCreateDataLen)
+	buf = append(buf, problemHash[:]...)
+	buf = appendUint64LE(buf, amount)
+	buf = appendUint64LE(buf, createdBlock)
+	buf = appendUint64LE(buf, expiryBlock)
+	return buf, nil
+}
+
+// DecodeEscrowCreateData unpacks a TxTypeEscrowCreate transaction's Data
+// field back into its problem hash, amount, created block, and expiry
+// block. It does not re-run ValidateEscrowCreation — callers that need to
+// confirm the payload still holds against current chain state should call
+// that themselves.
+func DecodeEscrowCreateData(data []byte) (problemHash [32]byte, amount, createdBlock, expiryBlock uint64, err error) {
+	if len(data) != EscrowCreateDataLen {
+		return problemHash, 0, 0, 0, fmt.Errorf("invalid escrow create data length: got %d, want %d", len(data), EscrowCreateDataLen)
+	}
+
+	copy(problemHash[:], data[0:32])
+	amount = binary.LittleEndian.Uint64(data[32:40])
+	createdBlock = binary.LittleEndian.Uint64(data[40:48])
+	expiryBlock = binary.LittleEndian.Uint64(data[48:56])
+	return problemHash, amount, createdBlock, expiryBlock, nil
+}
+
+// EscrowReleaseDataLen is the byte length of an encoded escrow-release
+// payload: escrow id (32) + recipient (32).
+const EscrowReleaseDataLen = 32 + 32
+
+// EncodeEscrowReleaseData validates escrow/recipient via
+// ValidateEscrowRelease and, on success, packs the escrow id and recipient
+// into the byte layout carried in a TxTypeEscrowRelease transaction's Data
+// field.
+func EncodeEscrowReleaseData(escrow *BountyEscrow, recipient [32]byte) ([]byte, error) {
+	if err := ValidateEscrowRelease(escrow, recipient); err != nil {
+		return nil, fmt.Errorf("invalid escrow release: %w", err)
+	}
+
+	buf := make([]byte, 0, EscrowReleaseDataLen)
+	buf = append(buf, escrow.ID[:]...)
+	buf = append(buf, recipient[:]...)
+	return buf, nil
+}
+
+// DecodeEscrowReleaseData unpacks a TxTypeEscrowRelease transaction's Data
+// field back into the escrow id it targets and the recipient it releases
+// to. It does not re-run ValidateEscrowRelease: the full BountyEscrow (and
+// its current on-chain state) isn't recoverable from the transaction alone,
+// so re-validating against live state happens wherever the escrow itself is
+// looked up, not here.
+func DecodeEscrowReleaseData(data []byte) (escrowID, recipient [32]byte, err error) {
+	if len(data) != EscrowReleaseDataLen {
+		return escrowID, recipient, fmt.Errorf("invalid escrow release data length: got %d, want %d", len(data), EscrowReleaseDataLen)
+	}
+
+	copy(escrowID[:], data[0:32])
+	copy(recipient[:], data[32:64])
+	return escrowID, recipient, nil
+}
+
+// AccessListEntry pre-declares a state read a TxTypeAccessList transaction
+// intends to make: an address plus the storage keys under it. Declaring
+// reads up front lets VerifyTransaction price them as warmed accesses (see
+// AccessListGasDiscount) instead of charging the default cold-access cost.
+type AccessListEntry struct {
+	Address     [32]byte
+	StorageKeys [][32]byte
+}
+
+// Gas costs an access-list entry pre-pays for a warmed read, mirroring
+// EIP-2930: declaring an address costs AccessListAddressGas, each storage
+// key under it costs AccessListStorageKeyGas — both cheaper than the cost
+// of touching them cold, which is what the discount in VerifyTransaction
+// accounts for.
+const (
+	AccessListAddressGas    uint64 = 2400
+	AccessListStorageKeyGas uint64 = 1900
+)
+
+// AccessListGasDiscount returns the gas VerifyTransaction subtracts from a
+// TxTypeAccessList transaction's computed fee: entries already pre-pay for
+// their own warmed reads via AccessListAddressGas/AccessListStorageKeyGas,
+// so that cost shouldn't also be charged as part of the base fee.
+func AccessListGasDiscount(entries []AccessListEntry) uint64 {
+	var total uint64
+	for _, e := range entries {
+		total += AccessListAddressGas
+		total += uint64(len(e.StorageKeys)) * AccessListStorageKeyGas
+	}
+	return total
+}
+
+// EncodeAccessListData packs entries into the byte layout carried in a
+// TxTypeAccessList transaction's Data field:
+//
+//	4 bytes:  entry count
+//	per entry:
+//	  32 bytes:       address
+//	  4 bytes:        storage key count
+//	  32 bytes each:  storage keys
+func EncodeAccessListData(entries []AccessListEntry) []byte {
+	size := 4
+	for _, e := range entries {
+		size += 32 + 4 + 32*len(e.StorageKeys)
+	}
+
+	buf := make([]byte, 0, size)
+	buf = appendUint32LE(buf, uint32(len(entries)))
+	for _, e := range entries {
+		buf = append(buf, e.Address[:]...)
+		buf = appendUint32LE(buf, uint32(len(e.StorageKeys)))
+		for _, k := range e.StorageKeys {
+			buf = append(buf, k[:]...)
+		}
+	}
+	return buf
+}
+
+// DecodeAccessListData unpacks a TxTypeAccessList transaction's Data field
+// back into its access list entries.
+func DecodeAccessListData(data []byte) ([]AccessListEntry, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("invalid access list data: truncated entry count")
+	}
+	count := binary.LittleEndian.Uint32(data[0:4])
+	data = data[4:]
+
+	entries := make([]AccessListEntry, 0, count)
+	for i := uint32(0); i < count; i++ {
+		if len(data) < 32+4 {
+			return nil, fmt.Errorf("invalid access list data: truncated entry %d", i)
+		}
+
+		var entry AccessListEntry
+		copy(entry.Address[:], data[0:32])
+		keyCount := binary.LittleEndian.Uint32(data[32:36])
+		data = data[36:]
+
+		entry.StorageKeys = make([][32]byte, 0, keyCount)
+		for j := uint32(0); j < keyCount; j++ {
+			if len(data) < 32 {
+				return nil, fmt.Errorf("invalid access list data: truncated storage key %d of entry %d", j, i)
+			}
+			var key [32]byte
+			copy(key[:], data[0:32])
+			entry.StorageKeys = append(entry.StorageKeys, key)
+			data = data[32:]
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// buildSigningMessage is the canonical message Ed25519 signs and Rust's FFI
+// verifies against: codecVersion || txType || the transaction's core
+// fields || length-prefixed Data || chainID || timestamp. This is the one
+// place a new TxType plugs in: decoding (and for escrow creation, validating)
+// Data against the payload shape its TxType declares, so a malformed typed
+// payload is rejected before a transaction carrying it is ever signed,
+// rather than only at block-apply time.
+func buildSigningMessage(tx *Transaction) ([]byte, error) {
+	switch tx.TxType {
+	case TxTypeDeposit:
+		if _, _, _, err := DecodeDepositData(tx.Data); err != nil {
+			return nil, fmt.Errorf("decode deposit payload: %w", err)
+		}
+	case TxTypeEscrowCreate:
+		if _, _, _, _, err := DecodeEscrowCreateData(tx.Data); err != nil {
+			return nil, fmt.Errorf("decode escrow create payload: %w", err)
+		}
+	case TxTypeEscrowRelease:
+		if _, _, err := DecodeEscrowReleaseData(tx.Data); err != nil {
+			return nil, fmt.Errorf("decode escrow release payload: %w", err)
+		}
+	case TxTypeAccessList:
+		if _, err := DecodeAccessListData(tx.Data); err != nil {
+			return nil, fmt.Errorf("decode access list payload: %w", err)
+		}
+	}
+
+	size := 1 + 1 + 32 + 32 + 8 + 8 + 8 + 8 + 4 + len(tx.Data) + 8 + 8
+	message := make([]byte, 0, size)
+
+	message = append(message, tx.CodecVersion)
+	message = append(message, tx.TxType)
+	message = append(message, tx.From[:]...)
+	message = append(message, tx.To[:]...)
+	message = appendUint64LE(message, tx.Amount)
+	message = appendUint64LE(message, tx.Nonce)
+	message = appendUint64LE(message, tx.GasLimit)
+	message = appendUint64LE(message, tx.GasPrice)
+	message = appendUint32LE(message, uint32(len(tx.Data)))
+	message = append(message, tx.Data...)
+	message = appendUint64LE(message, tx.ChainID)
+	message = appendInt64LE(message, tx.Timestamp)
+
+	return message, nil
+}
+
+func appendUint32LE(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+// appendInt64LE reuses appendUint64LE (defined further down, alongside the
+// witness-building helpers) via a straight bit-reinterpretation.
+func appendInt64LE(buf []byte, v int64) []byte {
+	return appendUint64LE(buf, uint64(v))
 }
 
 // AccountState represents account balance and nonce
@@ -165,32 +540,57 @@ type BountyEscrow struct {
 	Recipient    *[32]byte
 	SettledBlock *uint64
 	SettlementTx *[32]byte
+
+	// BeaconRound is the randomness beacon round (see pkg/beacon) this
+	// escrow's release must be settled against, once
+	// ValidateEscrowReleaseWithBeacon is in use. 0 means no beacon round
+	// is required, matching the plain ValidateEscrowRelease path.
+	BeaconRound uint64
+}
+
+// copyToCBytes copies src into dst, a fixed-size C uint8_t array view.
+// cgo's generated _Ctype_uchar is a distinct type from Go's byte as far as
+// copy() is concerned, even though both are single-byte unsigned integers,
+// so copy(dst, src) fails to compile across the two; this does the same
+// element-wise copy by hand instead.
+func copyToCBytes(dst []C.uint8_t, src []byte) {
+	for i := 0; i < len(dst) && i < len(src); i++ {
+		dst[i] = C.uint8_t(src[i])
+	}
 }
 
 // VerifyTransaction validates a transaction using Rust consensus
 //
+// chainID is the verifying node's configured chain id; tx is rejected with
+// ErrChainIDMismatch before ever reaching the FFI call if tx.ChainID
+// doesn't match it (see the grandfather case on FeeEnvelopeLegacy above).
+//
 // This calls into Rust FFI to perform:
 // - Ed25519 signature verification
 // - Nonce validation (replay protection)
 // - Balance checks
 // - Fee validation
 // - Gas limit validation
-func VerifyTransaction(tx *Transaction, senderState *AccountState) (*ValidationResult, error) {
+func VerifyTransaction(tx *Transaction, senderState *AccountState, chainID uint64) (*ValidationResult, error) {
 	if tx == nil || senderState == nil {
 		return nil, fmt.Errorf("nil transaction or sender state")
 	}
 
+	if tx.ChainID != chainID && !(tx.ChainID == 0 && tx.CodecVersion == FeeEnvelopeLegacy) {
+		return nil, ErrChainIDMismatch
+	}
+
 	// Convert to C types
 	var cTx C.TransactionFFI
 	cTx.codec_version = C.uint32_t(tx.CodecVersion)
 	cTx.tx_type = C.uint32_t(tx.TxType)
-	copy(cTx.from[:], tx.From[:])
-	copy(cTx.to[:], tx.To[:])
+	copyToCBytes(cTx.from[:], tx.From[:])
+	copyToCBytes(cTx.to[:], tx.To[:])
 	cTx.amount = C.uint64_t(tx.Amount)
 	cTx.nonce = C.uint64_t(tx.Nonce)
 	cTx.gas_limit = C.uint64_t(tx.GasLimit)
 	cTx.gas_price = C.uint64_t(tx.GasPrice)
-	copy(cTx.signature[:], tx.Signature[:])
+	copyToCBytes(cTx.signature[:], tx.Signature[:])
 	cTx.timestamp = C.int64_t(tx.Timestamp)
 
 	// Handle data
@@ -215,12 +615,165 @@ func VerifyTransaction(tx *Transaction, senderState *AccountState) (*ValidationR
 		return nil, fmt.Errorf("validation failed: error code %d", result)
 	}
 
-	return &ValidationResult{
+	validationResult := &ValidationResult{
 		Valid:     cResult.valid != 0,
 		TotalCost: uint64(cResult.total_cost),
 		GasUsed:   uint64(cResult.gas_used),
 		Fee:       uint64(cResult.fee),
-	}, nil
+	}
+
+	// Access-list transactions pre-pay for their declared warmed reads, so
+	// the flat cost the FFI priced them at gets discounted here rather than
+	// inside Rust, which has no notion of this TxType.
+	if tx.TxType == TxTypeAccessList {
+		entries, err := DecodeAccessListData(tx.Data)
+		if err != nil {
+			return nil, fmt.Errorf("decode access list payload: %w", err)
+		}
+
+		discount := AccessListGasDiscount(entries)
+		if discount > validationResult.Fee {
+			discount = validationResult.Fee
+		}
+		validationResult.Fee -= discount
+		validationResult.TotalCost -= discount
+	}
+
+	return validationResult, nil
+}
+
+// VerifyTransactionBatch verifies every (tx, state) pair with a single
+// cgo call instead of one coinjecture_verify_transaction call per
+// transaction: at high submission rates the cgo crossing cost and the
+// per-tx Ed25519 check dominate, and coinjecture_verify_transaction_batch
+// verifies the whole batch in one rayon-parallelized pass on the Rust
+// side. txs and states must be the same length, paired by index.
+//
+// Pre-marshaling into the contiguous C arrays the FFI call needs is itself
+// spread across a small worker pool (mirroring Verifier's worker pool in
+// pkg/mempool), so large batches don't serialize on that step either.
+func VerifyTransactionBatch(txs []*Transaction, states []*AccountState, chainID uint64) ([]ValidationResult, error) {
+	if len(txs) != len(states) {
+		return nil, fmt.Errorf("bindings: txs and states length mismatch: %d != %d", len(txs), len(states))
+	}
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	cTxs := make([]C.TransactionFFI, len(txs))
+	cStates := make([]C.AccountStateFFI, len(txs))
+
+	// dataPins keeps every tx's Data backing array alive (and addressable)
+	// until after the FFI call below, since cTxs[i].data points directly
+	// into it rather than copying — same as VerifyTransaction does for a
+	// single transaction.
+	dataPins := make([][]byte, len(txs))
+
+	workers := runtime.NumCPU()
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+	chunkSize := (len(txs) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	var errMu sync.Mutex
+	var firstErr error
+
+	for start := 0; start < len(txs); start += chunkSize {
+		end := start + chunkSize
+		if end > len(txs) {
+			end = len(txs)
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				tx, state := txs[i], states[i]
+				if tx == nil || state == nil {
+					errMu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("bindings: nil transaction or sender state at index %d", i)
+					}
+					errMu.Unlock()
+					continue
+				}
+				if tx.ChainID != chainID && !(tx.ChainID == 0 && tx.CodecVersion == FeeEnvelopeLegacy) {
+					errMu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("bindings: %w at index %d", ErrChainIDMismatch, i)
+					}
+					errMu.Unlock()
+					continue
+				}
+
+				cTx := &cTxs[i]
+				cTx.codec_version = C.uint32_t(tx.CodecVersion)
+				cTx.tx_type = C.uint32_t(tx.TxType)
+				copyToCBytes(cTx.from[:], tx.From[:])
+				copyToCBytes(cTx.to[:], tx.To[:])
+				cTx.amount = C.uint64_t(tx.Amount)
+				cTx.nonce = C.uint64_t(tx.Nonce)
+				cTx.gas_limit = C.uint64_t(tx.GasLimit)
+				cTx.gas_price = C.uint64_t(tx.GasPrice)
+				copyToCBytes(cTx.signature[:], tx.Signature[:])
+				cTx.timestamp = C.int64_t(tx.Timestamp)
+
+				if len(tx.Data) > 0 {
+					dataPins[i] = tx.Data
+					cTx.data = (*C.uint8_t)(unsafe.Pointer(&tx.Data[0]))
+					cTx.data_len = C.uint32_t(len(tx.Data))
+				}
+
+				cStates[i].balance = C.uint64_t(state.Balance)
+				cStates[i].nonce = C.uint64_t(state.Nonce)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	cResults := make([]C.ValidationResultFFI, len(txs))
+
+	result := C.coinjecture_verify_transaction_batch(&cTxs[0], &cStates[0], C.uint32_t(len(txs)), &cResults[0])
+	runtime.KeepAlive(dataPins)
+
+	if result != C.COINJ_OK {
+		return nil, fmt.Errorf("batch validation failed: error code %d", result)
+	}
+
+	out := make([]ValidationResult, len(txs))
+	for i, r := range cResults {
+		vr := ValidationResult{
+			Valid:     r.valid != 0,
+			TotalCost: uint64(r.total_cost),
+			GasUsed:   uint64(r.gas_used),
+			Fee:       uint64(r.fee),
+		}
+
+		// Mirror VerifyTransaction's access-list fee discount per-tx, since
+		// the FFI has no notion of TxTypeAccessList.
+		if txs[i].TxType == TxTypeAccessList {
+			entries, err := DecodeAccessListData(txs[i].Data)
+			if err != nil {
+				return nil, fmt.Errorf("decode access list payload at index %d: %w", i, err)
+			}
+
+			discount := AccessListGasDiscount(entries)
+			if discount > vr.Fee {
+				discount = vr.Fee
+			}
+			vr.Fee -= discount
+			vr.TotalCost -= discount
+		}
+
+		out[i] = vr
+	}
+
+	return out, nil
 }
 
 // ComputeEscrowID computes deterministic escrow ID
@@ -278,6 +831,41 @@ func ValidateEscrowRelease(escrow *BountyEscrow, recipient [32]byte) error {
 	return nil
 }
 
+// ValidateEscrowReleaseWithBeacon validates escrow release to solver the
+// same way ValidateEscrowRelease does, and additionally requires the
+// release to embed the settlement round's published beacon entry
+// (beaconRound/beaconRandomness/beaconSignature — see pkg/beacon), so a
+// block producer can't choose which solver wins by grinding on which round
+// settles the escrow. escrow.BeaconRound must equal beaconRound.
+func ValidateEscrowReleaseWithBeacon(escrow *BountyEscrow, recipient [32]byte, beaconRound uint64, beaconRandomness [32]byte, beaconSignature []byte) error {
+	if escrow == nil {
+		return fmt.Errorf("nil escrow")
+	}
+	if len(beaconSignature) == 0 {
+		return fmt.Errorf("empty beacon signature")
+	}
+	if escrow.BeaconRound != beaconRound {
+		return fmt.Errorf("escrow beacon round %d does not match provided round %d", escrow.BeaconRound, beaconRound)
+	}
+
+	cEscrow := bountyEscrowToC(escrow)
+
+	result := C.coinjecture_validate_escrow_release_with_beacon(
+		&cEscrow,
+		(*C.uint8_t)(&recipient[0]),
+		C.uint64_t(beaconRound),
+		(*C.uint8_t)(&beaconRandomness[0]),
+		(*C.uint8_t)(&beaconSignature[0]),
+		C.uint32_t(len(beaconSignature)),
+	)
+
+	if result != C.COINJ_OK {
+		return fmt.Errorf("invalid beacon-bound escrow release: error code %d", result)
+	}
+
+	return nil
+}
+
 // ValidateEscrowRefund validates escrow refund after expiry
 func ValidateEscrowRefund(escrow *BountyEscrow, currentBlock uint64) error {
 	if escrow == nil {
@@ -333,17 +921,17 @@ func CodecVersion() uint32 {
 func bountyEscrowToC(escrow *BountyEscrow) C.BountyEscrowFFI {
 	var c C.BountyEscrowFFI
 
-	copy(c.id[:], escrow.ID[:])
-	copy(c.submitter[:], escrow.Submitter[:])
+	copyToCBytes(c.id[:], escrow.ID[:])
+	copyToCBytes(c.submitter[:], escrow.Submitter[:])
 	c.amount = C.uint64_t(escrow.Amount)
-	copy(c.problem_hash[:], escrow.ProblemHash[:])
+	copyToCBytes(c.problem_hash[:], escrow.ProblemHash[:])
 	c.created_block = C.uint64_t(escrow.CreatedBlock)
 	c.expiry_block = C.uint64_t(escrow.ExpiryBlock)
 	c.state = C.uint32_t(escrow.State)
 
 	if escrow.Recipient != nil {
 		c.has_recipient = 1
-		copy(c.recipient[:], escrow.Recipient[:])
+		copyToCBytes(c.recipient[:], escrow.Recipient[:])
 	} else {
 		c.has_recipient = 0
 	}
@@ -357,10 +945,147 @@ func bountyEscrowToC(escrow *BountyEscrow) C.BountyEscrowFFI {
 
 	if escrow.SettlementTx != nil {
 		c.has_settlement_tx = 1
-		copy(c.settlement_tx[:], escrow.SettlementTx[:])
+		copyToCBytes(c.settlement_tx[:], escrow.SettlementTx[:])
 	} else {
 		c.has_settlement_tx = 0
 	}
 
+	c.beacon_round = C.uint64_t(escrow.BeaconRound)
+
 	return c
 }
+
+// ==================== STATELESS VERIFICATION (WITNESS) ====================
+
+// AccountSnapshot is the minimal account state a stateless verifier needs
+// to re-apply a transaction: balance and nonce. There is no state trie in
+// this chain yet, so unlike a real witness bundle this carries plain
+// values rather than Merkle inclusion proofs — it is trust-the-sender
+// honest data, same trust model as the rest of pre-trie state in this repo.
+type AccountSnapshot struct {
+	Balance uint64
+	Nonce   uint64
+}
+
+// Witness bundles everything VerifyBlockStateless needs to re-execute a
+// block's transactions without access to the full account database: the
+// pre-state of every account the block touches, keyed by address.
+type Witness struct {
+	Accounts map[[32]byte]AccountSnapshot
+}
+
+// VerifyBlockStateless re-applies txs against witness's account snapshots
+// and returns the resulting state root, without ever reading from a live
+// StateManager. This lets a light client or stateless verifier check a
+// block's StateRoot given only the block and its witness.
+//
+// The state transition modeled here is the same transfer-only accounting
+// used by pkg/execution.Simulator (balance and nonce updates; no contract
+// execution), since that is the only state transition this chain performs
+// today. A transaction touching an account missing from witness.Accounts
+// fails with an "incomplete witness" error rather than silently treating
+// it as zero-balance.
+func VerifyBlockStateless(txs []Transaction, witness *Witness) ([32]byte, error) {
+	if witness == nil || witness.Accounts == nil {
+		return [32]byte{}, fmt.Errorf("nil witness")
+	}
+
+	// Work on a local copy so a failed verification never mutates the
+	// caller's witness.
+	accounts := make(map[[32]byte]AccountSnapshot, len(witness.Accounts))
+	for addr, acct := range witness.Accounts {
+		accounts[addr] = acct
+	}
+
+	for i, tx := range txs {
+		sender, ok := accounts[tx.From]
+		if !ok {
+			return [32]byte{}, fmt.Errorf("incomplete witness: missing sender account for tx %d", i)
+		}
+
+		feePerGas := tx.GasPrice
+		if tx.MaxFeePerGas > 0 {
+			feePerGas = tx.MaxFeePerGas
+		}
+		totalCost := tx.Amount + tx.GasLimit*feePerGas
+
+		if sender.Balance < totalCost {
+			return [32]byte{}, fmt.Errorf("incomplete witness: insufficient balance for tx %d", i)
+		}
+		if tx.Nonce < sender.Nonce {
+			return [32]byte{}, fmt.Errorf("incomplete witness: stale nonce for tx %d", i)
+		}
+
+		recipient, ok := accounts[tx.To]
+		if !ok {
+			return [32]byte{}, fmt.Errorf("incomplete witness: missing recipient account for tx %d", i)
+		}
+
+		sender.Balance -= totalCost
+		sender.Nonce++
+		recipient.Balance += tx.Amount
+
+		accounts[tx.From] = sender
+		accounts[tx.To] = recipient
+	}
+
+	return accountsRoot(accounts), nil
+}
+
+// accountsRoot computes a deterministic Merkle root over a post-execution
+// account set, sorted by address so map iteration order never affects the
+// result.
+func accountsRoot(accounts map[[32]byte]AccountSnapshot) [32]byte {
+	addrs := make([][32]byte, 0, len(accounts))
+	for addr := range accounts {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+
+	leaves := make([][32]byte, len(addrs))
+	for i, addr := range addrs {
+		acct := accounts[addr]
+		buf := make([]byte, 0, 32+8+8)
+		buf = append(buf, addr[:]...)
+		buf = appendUint64LE(buf, acct.Balance)
+		buf = appendUint64LE(buf, acct.Nonce)
+		leaves[i] = sha256.Sum256(buf)
+	}
+
+	return merkleizeLeaves(leaves)
+}
+
+func appendUint64LE(b []byte, v uint64) []byte {
+	var tmp [8]byte
+	for i := 0; i < 8; i++ {
+		tmp[i] = byte(v >> (8 * i))
+	}
+	return append(b, tmp[:]...)
+}
+
+// merkleizeLeaves builds a simple binary Merkle tree over leaves: pairwise
+// sha256, duplicating the last leaf of a level when it's odd. Mirrors
+// consensus.merkleRoot's hash-pair-up pattern.
+func merkleizeLeaves(leaves [][32]byte) [32]byte {
+	if len(leaves) == 0 {
+		return sha256.Sum256(nil)
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				pair := append(append([]byte{}, level[i][:]...), level[i+1][:]...)
+				next = append(next, sha256.Sum256(pair))
+			} else {
+				pair := append(append([]byte{}, level[i][:]...), level[i][:]...)
+				next = append(next, sha256.Sum256(pair))
+			}
+		}
+		level = next
+	}
+	return level[0]
+}
@@ -0,0 +1,72 @@
+package bindings
+
+import "testing"
+
+// TestValidateEscrowReleaseWithBeacon_RejectsReplayAfterRelease mirrors
+// TestValidateEscrowRelease's already-released case through the
+// beacon-bound path: once an escrow has settled for one beacon round, a
+// replayed release for that same (now-stale) round must still be rejected.
+func TestValidateEscrowReleaseWithBeacon_RejectsReplayAfterRelease(t *testing.T) {
+	escrow := &BountyEscrow{
+		ID:           [32]byte{1, 2, 3},
+		Submitter:    [32]byte{4, 5, 6},
+		Amount:       1000000,
+		ProblemHash:  [32]byte{7, 8, 9},
+		CreatedBlock: 1000,
+		ExpiryBlock:  2000,
+		State:        EscrowStateLocked,
+		BeaconRound:  42,
+	}
+
+	recipient := [32]byte{10, 11, 12}
+	randomness := [32]byte{13, 14, 15}
+	signature := []byte{16, 17, 18, 19}
+
+	// Valid release, bound to round 42.
+	err := ValidateEscrowReleaseWithBeacon(escrow, recipient, 42, randomness, signature)
+	if err != nil {
+		t.Fatalf("valid beacon-bound release failed: %v", err)
+	}
+
+	// Replay: the escrow has settled, so the same round-42 release must
+	// now be rejected just like a plain ValidateEscrowRelease replay.
+	escrow.State = EscrowStateReleased
+	err = ValidateEscrowReleaseWithBeacon(escrow, recipient, 42, randomness, signature)
+	if err == nil {
+		t.Fatal("expected error replaying a release against an already-released escrow")
+	}
+	t.Logf("correctly rejected replayed release: %v", err)
+
+	// Grinding attempt: resubmitting against a later round the escrow was
+	// never bound to must be rejected before it ever reaches the FFI call.
+	escrow.State = EscrowStateLocked
+	err = ValidateEscrowReleaseWithBeacon(escrow, recipient, 43, randomness, signature)
+	if err == nil {
+		t.Fatal("expected error releasing against a beacon round the escrow isn't bound to")
+	}
+	t.Logf("correctly rejected mismatched beacon round: %v", err)
+}
+
+// TestValidateEscrowRefund_RejectsEarlyRefundThenAllowsAfterExpiry exercises
+// the refund-before-expiry adversarial case end to end: an attacker who
+// tries to refund before ExpiryBlock must be rejected, and the same escrow
+// must still refund successfully once it's actually expired.
+func TestValidateEscrowRefund_RejectsEarlyRefundThenAllowsAfterExpiry(t *testing.T) {
+	escrow := &BountyEscrow{
+		ID:           [32]byte{1, 2, 3},
+		Submitter:    [32]byte{4, 5, 6},
+		Amount:       1000000,
+		ProblemHash:  [32]byte{7, 8, 9},
+		CreatedBlock: 1000,
+		ExpiryBlock:  2000,
+		State:        EscrowStateLocked,
+	}
+
+	if err := ValidateEscrowRefund(escrow, 1999); err == nil {
+		t.Fatal("expected error refunding one block before expiry")
+	}
+
+	if err := ValidateEscrowRefund(escrow, 2000); err != nil {
+		t.Fatalf("expected refund to succeed exactly at expiry, got: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package bindings
+
+import (
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus/fees"
+)
+
+// StateOverrides lets a caller patch the account state SimulateTransaction
+// sees, eth_call-style: Get is consulted first for any address, then
+// Balances/Nonces are applied on top for whichever addresses the caller
+// wants to override — so a wallet can preview "what if my balance were X"
+// without actually mutating anything. A nil Get treats every unoverridden
+// address as a zero-value account, which is enough for previewing a
+// transaction in isolation (e.g. from the fee-test node) without wiring up
+// a real state reader.
+//
+// BaseFeePerGas is the base fee SimulateTransaction prices a dynamic-fee
+// transaction against, mirroring Engine.updateBaseFee's role for a real
+// block. Validator is the address that would receive the validator fee cut
+// were this transaction actually included — callers pass whichever
+// validator they're previewing against.
+type StateOverrides struct {
+	Get           func(address [32]byte) (*AccountState, error)
+	Balances      map[[32]byte]uint64
+	Nonces        map[[32]byte]uint64
+	BaseFeePerGas uint64
+	Validator     [32]byte
+}
+
+// resolve returns address's account state as of overrides: Get's result
+// (or a zero-value account if Get is nil or returns nil) with any
+// Balances/Nonces override applied on top.
+func (o *StateOverrides) resolve(address [32]byte) (AccountState, error) {
+	var acct AccountState
+
+	if o.Get != nil {
+		got, err := o.Get(address)
+		if err != nil {
+			return AccountState{}, err
+		}
+		if got != nil {
+			acct = *got
+		}
+	}
+
+	if balance, ok := o.Balances[address]; ok {
+		acct.Balance = balance
+	}
+	if nonce, ok := o.Nonces[address]; ok {
+		acct.Nonce = nonce
+	}
+
+	return acct, nil
+}
+
+// SimulationResult is the outcome of previewing a transaction: how much gas
+// it would consume and every balance it would touch, post-transaction. Only
+// the accounts a transaction can actually touch are reported — sender,
+// recipient, and the three fee sinks (validator, burn, treasury) — rather
+// than the whole overridden account set.
+type SimulationResult struct {
+	GasUsed          uint64
+	SenderBalance    uint64
+	RecipientBalance uint64
+	ValidatorBalance uint64
+	BurnBalance      uint64
+	TreasuryBalance  uint64
+}
+
+// feePerGas is the wei-per-gas tx actually pays under overrides.BaseFeePerGas:
+// the flat GasPrice for a legacy (CodecVersion=FeeEnvelopeLegacy) tx, or
+// baseFee plus the capped priority tip for a dynamic-fee one — the same
+// envelope-aware pricing fee_distribution.go's distributeFee applies on
+// chain, kept here rather than shared so bindings doesn't need to import
+// mempool.Transaction just for EffectiveTip.
+func feePerGas(tx *Transaction, baseFeePerGas uint64) uint64 {
+	if tx.CodecVersion != FeeEnvelopeDynamicFee {
+		return tx.GasPrice
+	}
+
+	tip := tx.MaxPriorityFeePerGas
+	if headroom := tx.MaxFeePerGas - baseFeePerGas; tip > headroom {
+		tip = headroom
+	}
+	return baseFeePerGas + tip
+}
+
+// SimulateTransaction previews tx against a copy-on-write view of state
+// built from overrides, without persisting anything: it never calls back
+// into overrides.Get to write, only to read, and every balance update below
+// lives in a local AccountState value. This lets a wallet or the fee-test
+// node see the exact post-transaction balances and fee split it would get
+// on chain (see fee_distribution.go's distributeFee, which this mirrors)
+// before ever broadcasting.
+func SimulateTransaction(tx *Transaction, overrides *StateOverrides) (*SimulationResult, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("nil transaction")
+	}
+	if overrides == nil {
+		return nil, fmt.Errorf("nil state overrides")
+	}
+
+	// touched caches every account this simulation resolves, so an address
+	// that plays more than one role (e.g. the validator paying itself, or a
+	// fee sink that happens to be the sender) is only ever resolved once
+	// and every subsequent balance update lands on the same value.
+	touched := make(map[[32]byte]*AccountState)
+	get := func(addr [32]byte) (*AccountState, error) {
+		if acct, ok := touched[addr]; ok {
+			return acct, nil
+		}
+		resolved, err := overrides.resolve(addr)
+		if err != nil {
+			return nil, err
+		}
+		touched[addr] = &resolved
+		return touched[addr], nil
+	}
+
+	sender, err := get(tx.From)
+	if err != nil {
+		return nil, fmt.Errorf("resolve sender: %w", err)
+	}
+
+	perGas := feePerGas(tx, overrides.BaseFeePerGas)
+	fee := tx.GasLimit * perGas
+	totalCost := tx.Amount + fee
+
+	if sender.Balance < totalCost {
+		return nil, fmt.Errorf("insufficient balance: have %d, need %d", sender.Balance, totalCost)
+	}
+	if tx.Nonce < sender.Nonce {
+		return nil, fmt.Errorf("stale nonce: tx has %d, account has %d", tx.Nonce, sender.Nonce)
+	}
+
+	recipient, err := get(tx.To)
+	if err != nil {
+		return nil, fmt.Errorf("resolve recipient: %w", err)
+	}
+
+	sender.Balance -= totalCost
+	recipient.Balance += tx.Amount
+
+	var validatorCut, burnCut, treasuryCut uint64
+	if tx.CodecVersion == FeeEnvelopeDynamicFee {
+		burnCut = overrides.BaseFeePerGas * tx.GasLimit
+		tip := fee - burnCut
+		validatorCut, treasuryCut = fees.SplitTip(tip)
+	} else {
+		validatorCut, burnCut, treasuryCut = fees.SplitFee(fee)
+	}
+
+	validator, err := get(overrides.Validator)
+	if err != nil {
+		return nil, fmt.Errorf("resolve validator: %w", err)
+	}
+	burn, err := get(fees.BurnAddress)
+	if err != nil {
+		return nil, fmt.Errorf("resolve burn address: %w", err)
+	}
+	treasury, err := get(fees.TreasuryAddress)
+	if err != nil {
+		return nil, fmt.Errorf("resolve treasury address: %w", err)
+	}
+
+	validator.Balance += validatorCut
+	burn.Balance += burnCut
+	treasury.Balance += treasuryCut
+
+	return &SimulationResult{
+		GasUsed:          tx.GasLimit,
+		SenderBalance:    sender.Balance,
+		RecipientBalance: recipient.Balance,
+		ValidatorBalance: validator.Balance,
+		BurnBalance:      burn.Balance,
+		TreasuryBalance:  treasury.Balance,
+	}, nil
+}
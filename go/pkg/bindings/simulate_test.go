@@ -0,0 +1,157 @@
+package bindings
+
+import "testing"
+
+func addr(b byte) [32]byte {
+	var a [32]byte
+	a[0] = b
+	return a
+}
+
+func TestSimulateTransaction_Legacy(t *testing.T) {
+	from, to, validator := addr(1), addr(2), addr(3)
+
+	tx := &Transaction{
+		CodecVersion: FeeEnvelopeLegacy,
+		TxType:       TxTypeTransfer,
+		From:         from,
+		To:           to,
+		Amount:       1000,
+		GasLimit:     21000,
+		GasPrice:     10,
+	}
+
+	overrides := &StateOverrides{
+		Balances:  map[[32]byte]uint64{from: 1_000_000},
+		Validator: validator,
+	}
+
+	result, err := SimulateTransaction(tx, overrides)
+	if err != nil {
+		t.Fatalf("SimulateTransaction failed: %v", err)
+	}
+
+	fee := tx.GasLimit * tx.GasPrice
+	wantSender := 1_000_000 - tx.Amount - fee
+	if result.SenderBalance != wantSender {
+		t.Errorf("sender balance = %d, want %d", result.SenderBalance, wantSender)
+	}
+	if result.RecipientBalance != tx.Amount {
+		t.Errorf("recipient balance = %d, want %d", result.RecipientBalance, tx.Amount)
+	}
+	if result.GasUsed != tx.GasLimit {
+		t.Errorf("gas used = %d, want %d", result.GasUsed, tx.GasLimit)
+	}
+	if result.ValidatorBalance+result.BurnBalance+result.TreasuryBalance != fee {
+		t.Errorf("fee cuts sum to %d, want %d", result.ValidatorBalance+result.BurnBalance+result.TreasuryBalance, fee)
+	}
+}
+
+func TestSimulateTransaction_DynamicFeeBurnsBaseFee(t *testing.T) {
+	from, to, validator := addr(1), addr(2), addr(3)
+
+	tx := &Transaction{
+		CodecVersion:         FeeEnvelopeDynamicFee,
+		TxType:               TxTypeTransfer,
+		From:                 from,
+		To:                   to,
+		Amount:               1000,
+		GasLimit:             21000,
+		MaxFeePerGas:         100,
+		MaxPriorityFeePerGas: 20,
+	}
+
+	overrides := &StateOverrides{
+		Balances:      map[[32]byte]uint64{from: 10_000_000},
+		BaseFeePerGas: 50,
+		Validator:     validator,
+	}
+
+	result, err := SimulateTransaction(tx, overrides)
+	if err != nil {
+		t.Fatalf("SimulateTransaction failed: %v", err)
+	}
+
+	wantBurn := overrides.BaseFeePerGas * tx.GasLimit
+	if result.BurnBalance != wantBurn {
+		t.Errorf("burn balance = %d, want %d (base fee burned in full)", result.BurnBalance, wantBurn)
+	}
+
+	wantTip := tx.MaxPriorityFeePerGas * tx.GasLimit // MaxFeePerGas leaves enough headroom, so the full tip is paid
+	if sum := result.ValidatorBalance + result.TreasuryBalance; sum != wantTip {
+		t.Errorf("validator+treasury = %d, want tip %d", sum, wantTip)
+	}
+	if result.ValidatorBalance == 0 || result.TreasuryBalance == 0 {
+		t.Fatal("expected a nonzero validator/treasury split of the tip")
+	}
+}
+
+func TestSimulateTransaction_InsufficientBalance(t *testing.T) {
+	from, to := addr(1), addr(2)
+
+	tx := &Transaction{
+		CodecVersion: FeeEnvelopeLegacy,
+		From:         from,
+		To:           to,
+		Amount:       1000,
+		GasLimit:     21000,
+		GasPrice:     10,
+	}
+
+	overrides := &StateOverrides{
+		Balances: map[[32]byte]uint64{from: 100},
+	}
+
+	if _, err := SimulateTransaction(tx, overrides); err == nil {
+		t.Fatal("expected insufficient balance error")
+	}
+}
+
+func TestSimulateTransaction_StaleNonce(t *testing.T) {
+	from, to := addr(1), addr(2)
+
+	tx := &Transaction{
+		CodecVersion: FeeEnvelopeLegacy,
+		From:         from,
+		To:           to,
+		Nonce:        0,
+		GasLimit:     21000,
+		GasPrice:     10,
+	}
+
+	overrides := &StateOverrides{
+		Balances: map[[32]byte]uint64{from: 1_000_000},
+		Nonces:   map[[32]byte]uint64{from: 5},
+	}
+
+	if _, err := SimulateTransaction(tx, overrides); err == nil {
+		t.Fatal("expected stale nonce error")
+	}
+}
+
+func TestSimulateTransaction_ValidatorIsSender(t *testing.T) {
+	from, to := addr(1), addr(2)
+
+	tx := &Transaction{
+		CodecVersion: FeeEnvelopeLegacy,
+		From:         from,
+		To:           to,
+		Amount:       1000,
+		GasLimit:     21000,
+		GasPrice:     10,
+	}
+
+	overrides := &StateOverrides{
+		Balances:  map[[32]byte]uint64{from: 1_000_000},
+		Validator: from, // sender pays itself the validator cut
+	}
+
+	result, err := SimulateTransaction(tx, overrides)
+	if err != nil {
+		t.Fatalf("SimulateTransaction failed: %v", err)
+	}
+
+	if result.SenderBalance != result.ValidatorBalance {
+		t.Errorf("sender == validator should report one consistent balance; got sender=%d validator=%d", result.SenderBalance, result.ValidatorBalance)
+	}
+}
@@ -0,0 +1,176 @@
+package bindings
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+)
+
+func TestEncodeDecodeEscrowCreateData(t *testing.T) {
+	problemHash := [32]byte{1, 2, 3}
+
+	data, err := EncodeEscrowCreateData(problemHash, 1000000, 1000, 2000)
+	if err != nil {
+		t.Fatalf("EncodeEscrowCreateData failed: %v", err)
+	}
+
+	gotHash, amount, createdBlock, expiryBlock, err := DecodeEscrowCreateData(data)
+	if err != nil {
+		t.Fatalf("DecodeEscrowCreateData failed: %v", err)
+	}
+
+	if gotHash != problemHash || amount != 1000000 || createdBlock != 1000 || expiryBlock != 2000 {
+		t.Fatalf("round trip mismatch: got (%x, %d, %d, %d)", gotHash[:8], amount, createdBlock, expiryBlock)
+	}
+}
+
+func TestEncodeEscrowCreateData_InvalidParams(t *testing.T) {
+	// Amount too low (< 1000 wei) — same rule ValidateEscrowCreation enforces.
+	if _, err := EncodeEscrowCreateData([32]byte{}, 500, 1000, 2000); err == nil {
+		t.Fatal("expected error for amount too low")
+	}
+}
+
+func TestEncodeDecodeEscrowReleaseData(t *testing.T) {
+	escrow := &BountyEscrow{
+		ID:           [32]byte{1, 2, 3},
+		Submitter:    [32]byte{4, 5, 6},
+		Amount:       1000000,
+		ProblemHash:  [32]byte{7, 8, 9},
+		CreatedBlock: 1000,
+		ExpiryBlock:  2000,
+		State:        EscrowStateLocked,
+	}
+	recipient := [32]byte{10, 11, 12}
+
+	data, err := EncodeEscrowReleaseData(escrow, recipient)
+	if err != nil {
+		t.Fatalf("EncodeEscrowReleaseData failed: %v", err)
+	}
+
+	gotID, gotRecipient, err := DecodeEscrowReleaseData(data)
+	if err != nil {
+		t.Fatalf("DecodeEscrowReleaseData failed: %v", err)
+	}
+	if gotID != escrow.ID || gotRecipient != recipient {
+		t.Fatalf("round trip mismatch: got (%x, %x)", gotID[:8], gotRecipient[:8])
+	}
+}
+
+func TestEncodeEscrowReleaseData_AlreadyReleased(t *testing.T) {
+	escrow := &BountyEscrow{ID: [32]byte{1}, State: EscrowStateReleased}
+	if _, err := EncodeEscrowReleaseData(escrow, [32]byte{10}); err == nil {
+		t.Fatal("expected error for already-released escrow")
+	}
+}
+
+func TestEncodeDecodeAccessListData(t *testing.T) {
+	entries := []AccessListEntry{
+		{Address: [32]byte{1}, StorageKeys: [][32]byte{{1, 1}, {2, 2}}},
+		{Address: [32]byte{2}, StorageKeys: nil},
+	}
+
+	data := EncodeAccessListData(entries)
+
+	decoded, err := DecodeAccessListData(data)
+	if err != nil {
+		t.Fatalf("DecodeAccessListData failed: %v", err)
+	}
+	if len(decoded) != len(entries) {
+		t.Fatalf("got %d entries, want %d", len(decoded), len(entries))
+	}
+	for i, e := range entries {
+		if decoded[i].Address != e.Address {
+			t.Errorf("entry %d: address mismatch", i)
+		}
+		if len(decoded[i].StorageKeys) != len(e.StorageKeys) {
+			t.Errorf("entry %d: got %d storage keys, want %d", i, len(decoded[i].StorageKeys), len(e.StorageKeys))
+		}
+	}
+}
+
+func TestDecodeAccessListData_Truncated(t *testing.T) {
+	if _, err := DecodeAccessListData([]byte{1, 2}); err == nil {
+		t.Fatal("expected error decoding truncated access list data")
+	}
+}
+
+func TestAccessListGasDiscount(t *testing.T) {
+	entries := []AccessListEntry{
+		{Address: [32]byte{1}, StorageKeys: [][32]byte{{1}, {2}, {3}}},
+		{Address: [32]byte{2}},
+	}
+
+	want := 2*AccessListAddressGas + 3*AccessListStorageKeyGas
+	if got := AccessListGasDiscount(entries); got != want {
+		t.Fatalf("AccessListGasDiscount = %d, want %d", got, want)
+	}
+}
+
+func TestVerifyTransaction_AccessListDiscountsFee(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate keypair: %v", err)
+	}
+
+	var from, to [32]byte
+	copy(from[:], publicKey)
+	copy(to[:], []byte("recipient_address_here______"))
+
+	entries := []AccessListEntry{{Address: to, StorageKeys: [][32]byte{{9}}}}
+	data := EncodeAccessListData(entries)
+
+	tx := &Transaction{
+		CodecVersion: FeeEnvelopeLegacy,
+		TxType:       TxTypeAccessList,
+		From:         from,
+		To:           to,
+		Amount:       1000000,
+		Nonce:        0,
+		GasLimit:     21000,
+		GasPrice:     100,
+		Data:         data,
+		Timestamp:    1234567890,
+	}
+
+	message, err := buildSigningMessage(tx)
+	if err != nil {
+		t.Fatalf("buildSigningMessage failed: %v", err)
+	}
+	signature := ed25519.Sign(privateKey, message)
+	copy(tx.Signature[:], signature)
+
+	senderState := &AccountState{Balance: 10000000, Nonce: 0}
+
+	withoutDiscount := &Transaction{
+		CodecVersion: tx.CodecVersion,
+		TxType:       TxTypeTransfer,
+		From:         from,
+		To:           to,
+		Amount:       tx.Amount,
+		Nonce:        tx.Nonce,
+		GasLimit:     tx.GasLimit,
+		GasPrice:     tx.GasPrice,
+		Timestamp:    tx.Timestamp,
+	}
+	baseMessage, err := buildSigningMessage(withoutDiscount)
+	if err != nil {
+		t.Fatalf("buildSigningMessage failed: %v", err)
+	}
+	copy(withoutDiscount.Signature[:], ed25519.Sign(privateKey, baseMessage))
+
+	plainResult, err := VerifyTransaction(withoutDiscount, senderState, 1337)
+	if err != nil {
+		t.Fatalf("baseline verification failed: %v", err)
+	}
+
+	result, err := VerifyTransaction(tx, senderState, 1337)
+	if err != nil {
+		t.Fatalf("access-list verification failed: %v", err)
+	}
+
+	discount := AccessListGasDiscount(entries)
+	if result.Fee != plainResult.Fee-discount {
+		t.Fatalf("discounted fee = %d, want %d (plain fee %d minus discount %d)", result.Fee, plainResult.Fee-discount, plainResult.Fee, discount)
+	}
+}
@@ -0,0 +1,90 @@
+// Package chaindb is a read-oriented facade over pkg/store/blockstore and
+// pkg/receipts. Both already persist every canonical block atomically
+// with fork choice (see consensus.ForkChoice's SetBlockStore/
+// SetReceiptsStore), so chaindb doesn't duplicate that write path; it
+// exists to give callers that want "the chain as of now" — Engine's
+// startup rehydration, transaction lookups — the combined accessors
+// neither store offers on its own: a transaction plus its enclosing
+// block, and a single HeadBlock call instead of reaching into blockstore
+// directly.
+package chaindb
+
+import (
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/receipts"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/store/blockstore"
+)
+
+// DB combines a blockstore.Store and a receipts.Store into chain-wide
+// lookups. Both stores are shared with (not owned by) the caller —
+// state.StateManager opens and closes the underlying database
+// connection.
+type DB struct {
+	blocks   *blockstore.Store
+	receipts *receipts.Store
+}
+
+// NewDB wraps an already-open blockstore.Store and receipts.Store.
+func NewDB(blocks *blockstore.Store, receipts *receipts.Store) *DB {
+	return &DB{blocks: blocks, receipts: receipts}
+}
+
+// GetBlockByHash returns the block persisted under hash, along with its
+// minimal blockstore receipts. Use GetTransaction for the fuller
+// pkg/receipts.Receipt (logs, contract address) of one transaction.
+func (db *DB) GetBlockByHash(hash [32]byte) (*blockstore.Block, []blockstore.Receipt, error) {
+	return db.blocks.GetBlockByHash(hash)
+}
+
+// GetBlockByNumber returns the canonical block at number, or a nil block
+// if none has been persisted at that height.
+func (db *DB) GetBlockByNumber(number uint64) (*blockstore.Block, []blockstore.Receipt, error) {
+	return db.blocks.GetBlockByNumber(number)
+}
+
+// HeadBlock returns the current canonical tip, or nil if no block has
+// ever been persisted (a fresh database). Engine calls this at startup
+// to decide whether to rehydrate chain state from disk or initialize
+// genesis.
+func (db *DB) HeadBlock() (*blockstore.Block, error) {
+	block, _, err := db.blocks.GetLatestBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load head block: %w", err)
+	}
+	return block, nil
+}
+
+// GetTransaction looks up a transaction by hash and returns its enclosing
+// block plus the full execution receipt pkg/receipts persisted for it.
+// found is false if txHash was never indexed (e.g. it's only in the
+// mempool, or unknown). receipt is nil if the block was indexed before
+// receipts.Store existed, which should not happen outside of very old
+// data.
+func (db *DB) GetTransaction(txHash [32]byte) (block *blockstore.Block, receipt *receipts.Receipt, found bool, err error) {
+	blockHash, _, found, err := db.blocks.GetTransactionLocation(txHash)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to look up transaction location: %w", err)
+	}
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	block, _, err = db.blocks.GetBlockByHash(blockHash)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to load block %x: %w", blockHash[:8], err)
+	}
+	if block == nil {
+		return nil, nil, false, fmt.Errorf("indexed transaction %x points at missing block %x", txHash[:8], blockHash[:8])
+	}
+
+	receipt, receiptFound, err := db.receipts.GetReceipt(txHash)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to load receipt: %w", err)
+	}
+	if !receiptFound {
+		return block, nil, true, nil
+	}
+
+	return block, receipt, true, nil
+}
@@ -0,0 +1,111 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+)
+
+type sample struct {
+	A uint8    `codec:"1,uint8"`
+	B uint64   `codec:"2,uint64"`
+	C [32]byte `codec:"3,bytes32"`
+	D []byte   `codec:"4,bytes"`
+	E int64    `codec:"5,int64"`
+	F uint32   `codec:"6,uint32"`
+	G [64]byte `codec:"7,bytes64"`
+
+	Untagged string
+}
+
+func testValue() sample {
+	var c [32]byte
+	for i := range c {
+		c[i] = byte(i)
+	}
+	var g [64]byte
+	for i := range g {
+		g[i] = byte(0xff - i)
+	}
+
+	return sample{
+		A:        9,
+		B:        1234567890,
+		C:        c,
+		D:        []byte("hello codec"),
+		E:        -42,
+		F:        4242,
+		G:        g,
+		Untagged: "ignored",
+	}
+}
+
+func TestEncodeCanonicalFieldOrderAndVersion(t *testing.T) {
+	v := testValue()
+
+	enc, err := EncodeCanonical(&v, 7)
+	if err != nil {
+		t.Fatalf("EncodeCanonical() error: %v", err)
+	}
+
+	if enc[0] != 7 {
+		t.Fatalf("version byte = %d, want 7", enc[0])
+	}
+	if enc[1] != v.A {
+		t.Fatalf("first field byte = %d, want %d", enc[1], v.A)
+	}
+}
+
+func TestEncodeCanonicalRoundTripsThroughRegistry(t *testing.T) {
+	v := testValue()
+
+	enc, err := EncodeCanonical(&v, 1)
+	if err != nil {
+		t.Fatalf("EncodeCanonical() error: %v", err)
+	}
+
+	r := NewRegistry()
+	r.Register(1, sample{})
+
+	decoded, version, err := r.DecodeCanonical(enc)
+	if err != nil {
+		t.Fatalf("DecodeCanonical() error: %v", err)
+	}
+	if version != 1 {
+		t.Fatalf("version = %d, want 1", version)
+	}
+
+	got, ok := decoded.(*sample)
+	if !ok {
+		t.Fatalf("decoded type = %T, want *sample", decoded)
+	}
+
+	if got.A != v.A || got.B != v.B || got.C != v.C || got.E != v.E || got.F != v.F || got.G != v.G {
+		t.Fatalf("decoded fixed fields = %+v, want %+v", got, v)
+	}
+	if !bytes.Equal(got.D, v.D) {
+		t.Fatalf("decoded D = %q, want %q", got.D, v.D)
+	}
+	if got.Untagged != "" {
+		t.Fatalf("untagged field leaked into decode: %q", got.Untagged)
+	}
+}
+
+func TestDecodeCanonicalUnregisteredVersion(t *testing.T) {
+	r := NewRegistry()
+	r.Register(1, sample{})
+
+	enc, err := EncodeCanonical(&sample{}, 2)
+	if err != nil {
+		t.Fatalf("EncodeCanonical() error: %v", err)
+	}
+
+	if _, _, err := r.DecodeCanonical(enc); err == nil {
+		t.Fatal("DecodeCanonical() with unregistered version succeeded, want error")
+	}
+}
+
+func TestEncodeCanonicalRejectsNonStruct(t *testing.T) {
+	if _, err := EncodeCanonical(42, 1); err == nil {
+		t.Fatal("EncodeCanonical(42, ...) succeeded, want error")
+	}
+}
@@ -10,34 +10,79 @@ import (
 
 // Config holds all daemon configuration
 type Config struct {
+	// ChainID distinguishes this network (Network A, Network B, a testnet,
+	// ...) for transaction replay protection: bindings.VerifyTransaction
+	// rejects any transaction whose ChainID doesn't match.
+	ChainID     uint64            `mapstructure:"chain_id"`
 	API         APIConfig         `mapstructure:"api"`
 	P2P         P2PConfig         `mapstructure:"p2p"`
 	IPFS        IPFSConfig        `mapstructure:"ipfs"`
 	RateLimiter RateLimiterConfig `mapstructure:"rate_limiter"`
 	Metrics     MetricsConfig     `mapstructure:"metrics"`
 	Features    FeaturesConfig    `mapstructure:"features"`
+	Beacon      BeaconConfig      `mapstructure:"beacon"`
 }
 
 // APIConfig for REST API server
 type APIConfig struct {
-	Port            int           `mapstructure:"port"`
-	Host            string        `mapstructure:"host"`
-	ReadTimeout     time.Duration `mapstructure:"read_timeout"`
-	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
-	MaxRequestSize  int64         `mapstructure:"max_request_size"`
-	EnableCORS      bool          `mapstructure:"enable_cors"`
-	TrustedProxies  []string      `mapstructure:"trusted_proxies"`
+	Port           int           `mapstructure:"port"`
+	Host           string        `mapstructure:"host"`
+	ReadTimeout    time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout   time.Duration `mapstructure:"write_timeout"`
+	MaxRequestSize int64         `mapstructure:"max_request_size"`
+	EnableCORS     bool          `mapstructure:"enable_cors"`
+	TrustedProxies []string      `mapstructure:"trusted_proxies"`
+	EventLogPath   string        `mapstructure:"event_log_path"`  // append-only WS event replay log
+	EventRingSize  int           `mapstructure:"event_ring_size"` // events retained per topic for replay
+
+	// WSMaxBuffered bounds how many outbound messages a WebSocket
+	// subscriber's send queue may hold before it's treated as the slowest
+	// subscriber: once the hub detects backpressure (see
+	// limiter.Limiter.CheckBackpressure), any subscriber still over this
+	// bound gets disconnected to protect the others. Matches the client
+	// send channel's own buffer size by default.
+	WSMaxBuffered int `mapstructure:"ws_max_buffered"`
+
+	// TrustedAPIKeys lists API keys (checked against a submitting request's
+	// X-API-Key header) whose transactions are admitted as local accounts
+	// (see mempool.Config.Locals) rather than remote ones. Empty means no
+	// request qualifies, regardless of header.
+	TrustedAPIKeys []string `mapstructure:"trusted_api_keys"`
 }
 
 // P2PConfig for peer-to-peer networking
 type P2PConfig struct {
-	Port                int      `mapstructure:"port"`
-	BootstrapPeers      []string `mapstructure:"bootstrap_peers"`
-	MaxPeers            int      `mapstructure:"max_peers"`
-	EquilibriumLambda   float64  `mapstructure:"equilibrium_lambda"`
-	BroadcastInterval   int      `mapstructure:"broadcast_interval_ms"`
-	PeerScoringEnabled  bool     `mapstructure:"peer_scoring_enabled"`
-	QuarantineThreshold int      `mapstructure:"quarantine_threshold"`
+	Port               int      `mapstructure:"port"`
+	BootstrapPeers     []string `mapstructure:"bootstrap_peers"`
+	MaxPeers           int      `mapstructure:"max_peers"`
+	EquilibriumLambda  float64  `mapstructure:"equilibrium_lambda"`
+	BroadcastInterval  int      `mapstructure:"broadcast_interval_ms"`
+	PeerScoringEnabled bool     `mapstructure:"peer_scoring_enabled"`
+
+	// QuarantineThreshold/BanThreshold are peer reputation score cutoffs (see
+	// p2p.Scorer): a peer whose score falls to or below QuarantineThreshold
+	// is temporarily quarantined with doubling backoff per re-offense; at or
+	// below BanThreshold it's added to the persistent banlist.
+	QuarantineThreshold int `mapstructure:"quarantine_threshold"`
+	BanThreshold        int `mapstructure:"ban_threshold"`
+
+	// ScoreHalfLife is how long it takes a peer's reputation score to decay
+	// halfway back toward 0.
+	ScoreHalfLife time.Duration `mapstructure:"score_half_life"`
+
+	// ScoreWeights configures how much each behavior event shifts a peer's
+	// reputation score.
+	ScoreWeights PeerScoreWeights `mapstructure:"score_weights"`
+}
+
+// PeerScoreWeights configures the reputation delta p2p.Scorer applies for
+// each kind of observed peer behavior.
+type PeerScoreWeights struct {
+	ValidBlock       float64 `mapstructure:"valid_block"`
+	InvalidSignature float64 `mapstructure:"invalid_signature"`
+	Timeout          float64 `mapstructure:"timeout"`
+	DuplicateGossip  float64 `mapstructure:"duplicate_gossip"`
+	RateLimitHit     float64 `mapstructure:"rate_limit_hit"`
 }
 
 // IPFSConfig for IPFS client
@@ -48,18 +93,95 @@ type IPFSConfig struct {
 	AuditInterval     time.Duration `mapstructure:"audit_interval"`
 	EnableManifests   bool          `mapstructure:"enable_manifests"`
 	ColdStorageMirror string        `mapstructure:"cold_storage_mirror"`
+
+	// GatewayFallback lists public HTTP gateway URL templates
+	// (e.g. "https://ipfs.io/ipfs/{cid}") that IPFSClient.Get tries, in
+	// order, once every configured shell node has failed to return a CID.
+	// Empty means Get gives up once the shell nodes are exhausted.
+	GatewayFallback []string `mapstructure:"gateway_fallback"`
+
+	// HedgeDelay is how long Get waits for one gateway to respond before
+	// also firing the next one in GatewayFallback, canceling whichever
+	// losers are still in flight once the first succeeds. 0 disables
+	// hedging: gateways are tried one at a time instead.
+	HedgeDelay time.Duration `mapstructure:"hedge_delay"`
+
+	// NodeSigningKeys maps a configured Nodes entry to its hex-encoded
+	// Ed25519 private key (64 bytes). PinWithQuorum uses the matching key
+	// to sign a PinAttestation for every node it successfully pins to,
+	// making the resulting PinManifest's PinnedNodes non-repudiable. A
+	// node with no entry here is still pinned to, just without an
+	// attestation.
+	NodeSigningKeys map[string]string `mapstructure:"node_signing_keys"`
+}
+
+// BeaconConfig configures the drand-style randomness beacon(s) used to
+// settle which solver's release transaction wins a bounty (see
+// pkg/beacon), preventing a block producer from grinding on favorable
+// randomness after the fact.
+type BeaconConfig struct {
+	Networks []BeaconNetworkConfig `mapstructure:"networks"`
 }
 
+// BeaconNetworkConfig is one configured beacon chain and the block height
+// it takes effect from (see beacon.BeaconNetwork).
+type BeaconNetworkConfig struct {
+	Name         string `mapstructure:"name"`
+	BaseURL      string `mapstructure:"base_url"`
+	ChainHash    string `mapstructure:"chain_hash"`
+	PublicKeyHex string `mapstructure:"public_key_hex"`
+	Start        uint64 `mapstructure:"start"`
+}
+
+// Algorithm selects the traffic-shaping behavior for a rate limiter tier.
+type Algorithm string
+
+const (
+	// TokenBucket admits bursts up to the configured burst size, refilling
+	// at the configured rate. This is the original, default behavior.
+	TokenBucket Algorithm = "token_bucket"
+	// LeakyBucket smooths traffic: each hit adds to a "level" that drains
+	// at a constant leak rate, admitting only while level+hits <= capacity.
+	LeakyBucket Algorithm = "leaky_bucket"
+)
+
 // RateLimiterConfig for request rate limiting
 type RateLimiterConfig struct {
-	Enabled          bool          `mapstructure:"enabled"`
-	IPLimit          int           `mapstructure:"ip_limit"`
-	IPWindow         time.Duration `mapstructure:"ip_window"`
-	PeerIDLimit      int           `mapstructure:"peer_id_limit"`
-	PeerIDWindow     time.Duration `mapstructure:"peer_id_window"`
-	GlobalLimit      int           `mapstructure:"global_limit"`
-	GlobalWindow     time.Duration `mapstructure:"global_window"`
-	BurstMultiplier  float64       `mapstructure:"burst_multiplier"`
+	Enabled         bool          `mapstructure:"enabled"`
+	IPLimit         int           `mapstructure:"ip_limit"`
+	IPWindow        time.Duration `mapstructure:"ip_window"`
+	PeerIDLimit     int           `mapstructure:"peer_id_limit"`
+	PeerIDWindow    time.Duration `mapstructure:"peer_id_window"`
+	GlobalLimit     int           `mapstructure:"global_limit"`
+	GlobalWindow    time.Duration `mapstructure:"global_window"`
+	BurstMultiplier float64       `mapstructure:"burst_multiplier"`
+
+	// Distributed enables cluster-wide admission: rate-limit keys are
+	// partitioned across the P2P peer set via consistent hashing instead of
+	// each node enforcing the configured limits independently. See
+	// limiter.NewDistributedRateLimiter.
+	Distributed bool `mapstructure:"distributed"`
+
+	// IPAlgorithm/PeerIDAlgorithm/GlobalAlgorithm select the traffic-shaping
+	// algorithm per tier. Defaults to TokenBucket when left empty.
+	IPAlgorithm     Algorithm `mapstructure:"ip_algorithm"`
+	PeerIDAlgorithm Algorithm `mapstructure:"peer_id_algorithm"`
+	GlobalAlgorithm Algorithm `mapstructure:"global_algorithm"`
+
+	// DrainOverLimit, when set, makes an over-limit leaky-bucket request
+	// still consume the bucket's remaining capacity (rather than leaving it
+	// unchanged) and report the time until it drains enough to admit again.
+	// Matches gubernator's semantics for callers that want the counter to
+	// keep advancing on rejection. Token-bucket tiers are unaffected.
+	DrainOverLimit bool `mapstructure:"drain_over_limit"`
+
+	// BufferLimit is the starting/maximum LES-style flow control credit (in
+	// cost units) each peer's buffer holds. See flowcontrol.BufferManager.
+	BufferLimit float64 `mapstructure:"buffer_limit"`
+
+	// RechargeRate is how fast a peer's flow control buffer regenerates, in
+	// cost units per second.
+	RechargeRate float64 `mapstructure:"recharge_rate"`
 }
 
 // MetricsConfig for Prometheus metrics
@@ -81,6 +203,7 @@ type FeaturesConfig struct {
 // Default configuration
 func DefaultConfig() *Config {
 	return &Config{
+		ChainID: 1, // Network A
 		API: APIConfig{
 			Port:           12346,
 			Host:           "0.0.0.0",
@@ -89,6 +212,10 @@ func DefaultConfig() *Config {
 			MaxRequestSize: 10 * 1024 * 1024, // 10MB
 			EnableCORS:     true,
 			TrustedProxies: []string{},
+			EventLogPath:   "./data/ws-events.log",
+			EventRingSize:  10000,
+			WSMaxBuffered:  256,
+			TrustedAPIKeys: []string{},
 		},
 		P2P: P2PConfig{
 			Port:                5000,
@@ -97,7 +224,16 @@ func DefaultConfig() *Config {
 			EquilibriumLambda:   0.7071, // âˆš2/2
 			BroadcastInterval:   14140,  // 14.14s in milliseconds
 			PeerScoringEnabled:  true,
-			QuarantineThreshold: 10,
+			QuarantineThreshold: -20,
+			BanThreshold:        -80,
+			ScoreHalfLife:       1 * time.Hour,
+			ScoreWeights: PeerScoreWeights{
+				ValidBlock:       5,
+				InvalidSignature: -50,
+				Timeout:          -2,
+				DuplicateGossip:  -1,
+				RateLimitHit:     -3,
+			},
 		},
 		IPFS: IPFSConfig{
 			Nodes:             []string{"localhost:5001"},
@@ -106,6 +242,9 @@ func DefaultConfig() *Config {
 			AuditInterval:     6 * time.Hour,
 			EnableManifests:   true,
 			ColdStorageMirror: "",
+			GatewayFallback:   []string{"https://ipfs.io/ipfs/{cid}", "https://dweb.link/ipfs/{cid}"},
+			HedgeDelay:        2 * time.Second,
+			NodeSigningKeys:   map[string]string{},
 		},
 		RateLimiter: RateLimiterConfig{
 			Enabled:         true,
@@ -116,6 +255,13 @@ func DefaultConfig() *Config {
 			GlobalLimit:     10000,
 			GlobalWindow:    time.Minute,
 			BurstMultiplier: 1.5,
+			Distributed:     false,
+			IPAlgorithm:     TokenBucket,
+			PeerIDAlgorithm: TokenBucket,
+			GlobalAlgorithm: TokenBucket,
+			DrainOverLimit:  false,
+			BufferLimit:     300,
+			RechargeRate:    200,
 		},
 		Metrics: MetricsConfig{
 			Port:    9090,
@@ -129,6 +275,18 @@ func DefaultConfig() *Config {
 			EnablePinQuorum:        true,
 			EnableAdmissionControl: true,
 		},
+		Beacon: BeaconConfig{
+			Networks: []BeaconNetworkConfig{
+				{
+					Name:    "default",
+					BaseURL: "https://api.drand.sh",
+					Start:   0,
+					// ChainHash/PublicKeyHex are left blank: they identify a
+					// specific beacon chain and must be set to the
+					// deployment's chosen network before use.
+				},
+			},
+		},
 	}
 }
 
@@ -168,10 +326,10 @@ func LoadConfig(path string) (*Config, error) {
 func (c *Config) Validate() error {
 	// Validate codec mode
 	validCodecModes := map[string]bool{
-		"legacy_only":         true,
-		"shadow":              true,
-		"refactored_primary":  true,
-		"refactored_only":     true,
+		"legacy_only":        true,
+		"shadow":             true,
+		"refactored_primary": true,
+		"refactored_only":    true,
 	}
 	if !validCodecModes[c.Features.CodecMode] {
 		return fmt.Errorf("invalid codec_mode: %s", c.Features.CodecMode)
@@ -195,8 +353,12 @@ func (c *Config) Validate() error {
 }
 
 func setDefaults(v *viper.Viper) {
+	v.SetDefault("chain_id", 1)
 	v.SetDefault("api.port", 12346)
 	v.SetDefault("api.host", "0.0.0.0")
+	v.SetDefault("api.event_log_path", "./data/ws-events.log")
+	v.SetDefault("api.event_ring_size", 10000)
+	v.SetDefault("api.ws_max_buffered", 256)
 	v.SetDefault("p2p.port", 5000)
 	v.SetDefault("p2p.equilibrium_lambda", 0.7071)
 	v.SetDefault("ipfs.pin_quorum", "2/3")
@@ -0,0 +1,70 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultVectorsDir is where LoadVectors looks when no override is given.
+const DefaultVectorsDir = "pkg/conformance/vectors"
+
+// VectorsDirEnv is checked by ResolveVectorsDir before falling back to
+// DefaultVectorsDir, so CI can point the harness at a pulled-down vectors
+// branch without a recompile.
+const VectorsDirEnv = "COINJECTURE_CONFORMANCE_VECTORS"
+
+// ResolveVectorsDir picks the vectors directory a conformance run should
+// use: flagVal if set (from a -vectors flag), else VectorsDirEnv if set,
+// else DefaultVectorsDir.
+func ResolveVectorsDir(flagVal string) string {
+	if flagVal != "" {
+		return flagVal
+	}
+	if envVal := os.Getenv(VectorsDirEnv); envVal != "" {
+		return envVal
+	}
+	return DefaultVectorsDir
+}
+
+// LoadVectors reads every *.json file directly under dir and parses it as
+// a Vector, in filename order so a run is reproducible. A Vector with no
+// Name is given the file's base name (without extension).
+func LoadVectors(dir string) ([]Vector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vectors dir %q: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	vectors := make([]Vector, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vector %q: %w", path, err)
+		}
+
+		var v Vector
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("failed to parse vector %q: %w", path, err)
+		}
+		if v.Name == "" {
+			v.Name = strings.TrimSuffix(name, ".json")
+		}
+		vectors = append(vectors, v)
+	}
+
+	return vectors, nil
+}
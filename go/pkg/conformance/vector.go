@@ -0,0 +1,53 @@
+// Package conformance runs a corpus of JSON test vectors — a pre-state, a
+// sequence of transactions, and the post-state + state root they must
+// produce — against pkg/state.StateManager, independent of the load test's
+// throughput focus. This gives the project a reproducible correctness
+// gate, and lets external contributors submit a failing-case vector
+// without writing Go.
+//
+// Vectors describe account-level state transitions via
+// StateManager.ApplyTransaction rather than full consensus.Engine blocks:
+// this snapshot's pkg/bindings (the cgo FFI to the Rust consensus core)
+// doesn't build in every environment, and pkg/consensus imports it, so
+// tying vectors to Engine would make the harness only as portable as that
+// dependency. Block-level vectors driving the full Engine can be added as
+// a second Vector shape once that dependency is reliably buildable here.
+package conformance
+
+// AccountState is one account's balance and nonce, as used in both a
+// vector's pre-state and its expected post-state.
+type AccountState struct {
+	Balance uint64 `json:"balance"`
+	Nonce   uint64 `json:"nonce"`
+}
+
+// TxVector is one transaction to apply via StateManager.ApplyTransaction.
+type TxVector struct {
+	From   string `json:"from"` // hex-encoded 32-byte address
+	To     string `json:"to"`   // hex-encoded 32-byte address
+	Amount uint64 `json:"amount"`
+	Fee    uint64 `json:"fee"`
+}
+
+// Vector describes one conformance test case.
+type Vector struct {
+	// Name identifies the vector in pass/fail output. Defaults to the
+	// source file's base name if empty.
+	Name string `json:"name"`
+
+	// PreState seeds account balances (and, via UpdateAccount, nonces)
+	// before any transaction is applied. Keyed by hex-encoded address.
+	PreState map[string]AccountState `json:"pre_state"`
+
+	Transactions []TxVector `json:"transactions"`
+
+	// PostState lists the accounts the run checks after applying every
+	// transaction in order. An address absent here is not checked, so a
+	// vector can assert only the accounts it cares about.
+	PostState map[string]AccountState `json:"post_state"`
+
+	// StateRoot, if set, is the hex-encoded root ComputeStateRoot must
+	// produce over PostState's addresses. Optional: a vector can assert
+	// PostState alone.
+	StateRoot string `json:"state_root"`
+}
@@ -0,0 +1,86 @@
+package consensus
+
+// Algorithm is the pluggable proposer-selection and block-sealing policy
+// Engine defers to, mirroring go-ethereum's consensus.Engine interface
+// (Author/Prepare/Seal/VerifySeal; IsTurn stands in for CalcDifficulty
+// since there's no PoW difficulty to compute over a fixed validator set).
+// It's named Algorithm rather than Engine because this package's Engine
+// type already names the concrete orchestrator that holds one of these —
+// NewEngine constructs the PoA implementation below by default, and
+// SetAlgorithm swaps in another (e.g. BFTAlgorithm), the same way
+// SetBlockValidator and SetStateProcessor swap in the pieces of
+// orchestration split out before this (see block_processing.go).
+type Algorithm interface {
+	// Author returns the validator credited with proposing block.
+	Author(block *Block) ([32]byte, error)
+
+	// IsTurn reports whether validator may propose the block at
+	// blockNumber. PoA: round-robin rotation over the validator set. BFT:
+	// whichever validator is proposer for the current round at that
+	// height.
+	IsTurn(validator [32]byte, blockNumber uint64) bool
+
+	// Prepare fills in any header fields this algorithm owns before
+	// block's transactions are executed (e.g. BFT's round number). A
+	// no-op under PoA.
+	Prepare(block *Block) error
+
+	// Seal attaches this algorithm's commitment to block once its
+	// StateRoot is set, and reports whether block is already
+	// irreversible. PoA always returns false: its blocks only become
+	// final probabilistically, via ForkChoice's GHOST weight rule and
+	// checkAutoFinalityLocked. BFT returns true once block's proposer
+	// vote alone reaches a precommit supermajority (the single-validator
+	// case); otherwise quorum completes later via HandleVote as peer
+	// votes arrive.
+	Seal(block *Block) (final bool, err error)
+
+	// VerifySeal checks the commitment a received block carries. PoA has
+	// nothing to check beyond BlockValidator's authorization check, so
+	// this is a no-op. BFT verifies the attached QuorumCert signs this
+	// block and carries signatures from a 2/3 supermajority of the
+	// validator set.
+	VerifySeal(block *Block) error
+}
+
+// poaAlgorithm is the Algorithm NewEngine constructs by default: the
+// round-robin turn-taking and single-validator seal that ProcessBlock and
+// produceBlock ran inline before this split existed.
+type poaAlgorithm struct {
+	config ConsensusConfig
+}
+
+func newPoAAlgorithm(cfg ConsensusConfig) *poaAlgorithm {
+	return &poaAlgorithm{config: cfg}
+}
+
+// Author implements Algorithm.
+func (a *poaAlgorithm) Author(block *Block) ([32]byte, error) {
+	return block.Validator, nil
+}
+
+// IsTurn implements Algorithm.
+func (a *poaAlgorithm) IsTurn(validator [32]byte, blockNumber uint64) bool {
+	if len(a.config.Validators) == 0 {
+		return false
+	}
+
+	validatorIndex := int(blockNumber % uint64(len(a.config.Validators)))
+	return a.config.Validators[validatorIndex] == validator
+}
+
+// Prepare implements Algorithm.
+func (a *poaAlgorithm) Prepare(block *Block) error {
+	return nil
+}
+
+// Seal implements Algorithm.
+func (a *poaAlgorithm) Seal(block *Block) (bool, error) {
+	block.Finalize() // Recompute hash with final header fields
+	return false, nil
+}
+
+// VerifySeal implements Algorithm.
+func (a *poaAlgorithm) VerifySeal(block *Block) error {
+	return nil
+}
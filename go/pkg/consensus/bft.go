@@ -0,0 +1,332 @@
+package consensus
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// QuorumCert is a BFTAlgorithm block's proof that a 2/3 supermajority of
+// the validator set precommitted to it in some round. Real Tendermint/
+// HotStuff implementations aggregate these into a single BLS signature;
+// this codebase has no BLS support yet, so a QuorumCert is instead the
+// plain list of Ed25519 signatures it supersedes — still a single
+// self-contained attachment a peer can verify without having watched the
+// round itself, just a larger one.
+type QuorumCert struct {
+	BlockHash [32]byte
+	Round     uint64
+	Signers   [][32]byte
+	Sigs      [][64]byte
+}
+
+// bftVotePhase distinguishes Tendermint's two voting phases within a
+// round: a prevote signals "I saw this proposal and it looks valid"; a
+// precommit signals "I saw a prevote supermajority for this block". Only
+// precommit supermajorities finalize a block.
+type bftVotePhase uint8
+
+const (
+	bftPrevote bftVotePhase = iota
+	bftPrecommit
+)
+
+func (p bftVotePhase) String() string {
+	if p == bftPrecommit {
+		return "precommit"
+	}
+	return "prevote"
+}
+
+// BFTVote is a single validator's signed message for one phase of one
+// round at one height.
+type BFTVote struct {
+	Height    uint64
+	Round     uint64
+	BlockHash [32]byte
+	Phase     bftVotePhase
+	Voter     [32]byte
+	Sig       [64]byte
+}
+
+// signingBytes is the canonical byte encoding a vote's Sig signs over.
+func (v BFTVote) signingBytes() []byte {
+	buf := make([]byte, 8+8+32+1)
+	binary.BigEndian.PutUint64(buf[0:8], v.Height)
+	binary.BigEndian.PutUint64(buf[8:16], v.Round)
+	copy(buf[16:48], v.BlockHash[:])
+	buf[48] = byte(v.Phase)
+	return buf
+}
+
+// Verify checks that Sig is a valid signature by Voter over this vote's
+// contents.
+func (v BFTVote) Verify() error {
+	if !ed25519.Verify(v.Voter[:], v.signingBytes(), v.Sig[:]) {
+		return fmt.Errorf("bft: invalid %s signature from validator %x", v.Phase, v.Voter[:8])
+	}
+	return nil
+}
+
+// bftRound accumulates the votes seen for one (height, round): prevotes
+// and precommits, each keyed by block hash and then by voter so a
+// validator's later vote in the same phase replaces its earlier one
+// rather than being double-counted.
+type bftRound struct {
+	prevotes   map[[32]byte]map[[32]byte]BFTVote
+	precommits map[[32]byte]map[[32]byte]BFTVote
+}
+
+func newBFTRound() *bftRound {
+	return &bftRound{
+		prevotes:   make(map[[32]byte]map[[32]byte]BFTVote),
+		precommits: make(map[[32]byte]map[[32]byte]BFTVote),
+	}
+}
+
+// BFTAlgorithm is a lightweight Tendermint-style Algorithm: validators
+// propose in the same round-robin order PoA uses (see IsTurn), then run a
+// prevote/precommit round over the proposal. A block whose precommits
+// reach a 2/3-of-validators supermajority gets a QuorumCert attached and
+// is immediately final — HandleVote calls ForkChoice.Finalize directly
+// the moment quorum forms, skipping the confirmations-behind wait
+// checkAutoFinalityLocked otherwise needs, and eliminating the
+// deep-reorg scenarios Engine.handleChainReorganization exists to handle
+// under PoA.
+//
+// Round-change on proposer timeout is not implemented: a stalled round
+// simply never finalizes, the same way a PoA validator going offline
+// simply stalls block production. Scoped this way because there's no
+// p2p transport yet for BFT votes to travel between nodes (HandleVote is
+// the hook future gossip wiring would call, the same way EvidencePool's
+// RPC handlers were wired into p2p.Manager after the pool itself
+// existed) — so today BFTAlgorithm only reaches quorum on its own, i.e.
+// a single-validator network, and documents the ingestion point for the
+// rest.
+type BFTAlgorithm struct {
+	config    ConsensusConfig
+	validator [32]byte           // This node's validator address, for casting its own votes.
+	signer    ed25519.PrivateKey // This node's signing key; nil if it isn't a validator.
+	log       *logger.Logger
+
+	// forkChoice is wired in by Engine once it constructs one (see
+	// initializeGenesis), so HandleVote can finalize directly on quorum.
+	forkChoice *ForkChoice
+
+	mu     sync.Mutex
+	height uint64
+	rounds map[uint64]*bftRound
+}
+
+// NewBFTAlgorithm constructs a BFTAlgorithm for cfg's validator set. If
+// validatorKey is one of cfg.Validators, signer must be its Ed25519
+// private key so this node can cast votes; a non-validator node passes a
+// nil signer and only ever verifies quorum certificates.
+func NewBFTAlgorithm(cfg ConsensusConfig, validatorKey [32]byte, signer ed25519.PrivateKey, log *logger.Logger) *BFTAlgorithm {
+	return &BFTAlgorithm{
+		config:    cfg,
+		validator: validatorKey,
+		signer:    signer,
+		log:       log,
+		rounds:    make(map[uint64]*bftRound),
+	}
+}
+
+// SetForkChoice wires the ForkChoice a precommit quorum finalizes
+// against. Must be called before any block reaches Seal or HandleVote.
+func (b *BFTAlgorithm) SetForkChoice(fc *ForkChoice) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.forkChoice = fc
+}
+
+// AdvanceHeight resets round-tracking for a new height once Engine has
+// committed the block at the previous one. Votes for a height other than
+// the current one are rejected by HandleVote, so Engine must call this
+// after every height change (block production, received-block
+// acceptance, and reorg) or peer votes for the new height will all be
+// rejected as stale.
+func (b *BFTAlgorithm) AdvanceHeight(height uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.height = height
+	b.rounds = make(map[uint64]*bftRound)
+}
+
+func (b *BFTAlgorithm) isValidator(addr [32]byte) bool {
+	for _, v := range b.config.Validators {
+		if v == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *BFTAlgorithm) quorumSize() int {
+	// 2/3 supermajority, rounded up.
+	return (len(b.config.Validators)*2 + 2) / 3
+}
+
+// HandleVote records vote and, if it completes a precommit supermajority
+// for its block hash, finalizes that block via ForkChoice and returns
+// the resulting QuorumCert. This is the ingestion point future p2p
+// gossip of peer votes would call; today it's only ever called with this
+// node's own votes, from Seal.
+func (b *BFTAlgorithm) HandleVote(vote BFTVote) (*QuorumCert, error) {
+	if err := vote.Verify(); err != nil {
+		return nil, err
+	}
+	if !b.isValidator(vote.Voter) {
+		return nil, fmt.Errorf("bft: vote from non-validator %x", vote.Voter[:8])
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if vote.Height != b.height {
+		return nil, fmt.Errorf("bft: vote for height %d does not match current height %d", vote.Height, b.height)
+	}
+
+	round, exists := b.rounds[vote.Round]
+	if !exists {
+		round = newBFTRound()
+		b.rounds[vote.Round] = round
+	}
+
+	phaseVotes := round.prevotes
+	if vote.Phase == bftPrecommit {
+		phaseVotes = round.precommits
+	}
+	byVoter, exists := phaseVotes[vote.BlockHash]
+	if !exists {
+		byVoter = make(map[[32]byte]BFTVote)
+		phaseVotes[vote.BlockHash] = byVoter
+	}
+	byVoter[vote.Voter] = vote
+
+	if vote.Phase != bftPrecommit || len(byVoter) < b.quorumSize() {
+		return nil, nil
+	}
+
+	qc := &QuorumCert{BlockHash: vote.BlockHash, Round: vote.Round}
+	for signer, v := range byVoter {
+		qc.Signers = append(qc.Signers, signer)
+		qc.Sigs = append(qc.Sigs, v.Sig)
+	}
+
+	if b.forkChoice != nil {
+		if err := b.forkChoice.Finalize(vote.BlockHash); err != nil {
+			b.log.WithError(err).Warn("BFT precommit quorum reached but ForkChoice.Finalize failed")
+		}
+	}
+
+	return qc, nil
+}
+
+// Author implements Algorithm.
+func (b *BFTAlgorithm) Author(block *Block) ([32]byte, error) {
+	return block.Validator, nil
+}
+
+// IsTurn implements Algorithm. Proposer rotation is the same round-robin
+// PoA uses; BFT's contribution is the voting round that comes after a
+// proposal, not a different proposer-selection rule.
+func (b *BFTAlgorithm) IsTurn(validator [32]byte, blockNumber uint64) bool {
+	if len(b.config.Validators) == 0 {
+		return false
+	}
+	validatorIndex := int(blockNumber % uint64(len(b.config.Validators)))
+	return b.config.Validators[validatorIndex] == validator
+}
+
+// Prepare implements Algorithm. Round-change is not implemented (see
+// BFTAlgorithm's doc comment), so every proposal runs at round 0.
+func (b *BFTAlgorithm) Prepare(block *Block) error {
+	block.Round = 0
+	return nil
+}
+
+// Seal implements Algorithm. It finalizes block's header, then — if this
+// node is a validator — casts its own prevote and precommit for it and
+// reports whether that alone reached a precommit supermajority (true
+// only when the validator set has one member; otherwise quorum completes
+// later as peer votes reach HandleVote, and block.QuorumCert is attached
+// by whichever caller observes that — see HandleVote).
+func (b *BFTAlgorithm) Seal(block *Block) (bool, error) {
+	block.Finalize()
+
+	if b.signer == nil {
+		return false, nil
+	}
+
+	var qc *QuorumCert
+	for _, phase := range []bftVotePhase{bftPrevote, bftPrecommit} {
+		vote := BFTVote{
+			Height:    block.BlockNumber,
+			Round:     block.Round,
+			BlockHash: block.BlockHash,
+			Phase:     phase,
+			Voter:     b.validator,
+		}
+		copy(vote.Sig[:], ed25519.Sign(b.signer, vote.signingBytes()))
+
+		result, err := b.HandleVote(vote)
+		if err != nil {
+			return false, fmt.Errorf("bft: failed to record own %s vote: %w", phase, err)
+		}
+		qc = result
+	}
+
+	if qc != nil {
+		block.QuorumCert = qc
+		return true, nil
+	}
+	return false, nil
+}
+
+// VerifySeal implements Algorithm.
+func (b *BFTAlgorithm) VerifySeal(block *Block) error {
+	qc := block.QuorumCert
+	if qc == nil {
+		return fmt.Errorf("bft: block %x carries no quorum certificate", block.BlockHash[:8])
+	}
+	if qc.BlockHash != block.BlockHash {
+		return fmt.Errorf("bft: quorum certificate is for a different block")
+	}
+	if len(qc.Signers) != len(qc.Sigs) {
+		return fmt.Errorf("bft: quorum certificate has %d signers but %d signatures", len(qc.Signers), len(qc.Sigs))
+	}
+	if len(qc.Signers) < b.quorumSize() {
+		return fmt.Errorf("bft: quorum certificate has %d signers, short of the %d needed for a 2/3 majority of %d validators",
+			len(qc.Signers), b.quorumSize(), len(b.config.Validators))
+	}
+
+	seen := make(map[[32]byte]bool, len(qc.Signers))
+	for i, signer := range qc.Signers {
+		if seen[signer] {
+			return fmt.Errorf("bft: quorum certificate double-counts signer %x", signer[:8])
+		}
+		seen[signer] = true
+
+		if !b.isValidator(signer) {
+			return fmt.Errorf("bft: quorum certificate signer %x is not in the validator set", signer[:8])
+		}
+
+		vote := BFTVote{
+			Height:    block.BlockNumber,
+			Round:     qc.Round,
+			BlockHash: block.BlockHash,
+			Phase:     bftPrecommit,
+			Voter:     signer,
+			Sig:       qc.Sigs[i],
+		}
+		if err := vote.Verify(); err != nil {
+			return fmt.Errorf("bft: quorum certificate signature invalid for %x: %w", signer[:8], err)
+		}
+	}
+
+	return nil
+}
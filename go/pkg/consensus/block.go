@@ -0,0 +1,201 @@
+package consensus
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/bindings"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+// Block is this engine's in-memory block representation, threaded through
+// Algorithm, BlockValidator, StateProcessor, and ForkChoice. Its persisted
+// form is blockstore.Block (see toStoreBlock/fromStoreBlock in
+// fork_choice.go), which mirrors BlockHash through Transactions rather
+// than importing this package; DepositsRoot, Round, and QuorumCert are
+// consensus-only extensions that never round-trip through blockstore.
+type Block struct {
+	BlockHash    [32]byte
+	ParentHash   [32]byte
+	BlockNumber  uint64
+	Validator    [32]byte
+	StateRoot    [32]byte
+	Timestamp    int64
+	GasUsed      uint64
+	Transactions []mempool.Transaction
+
+	DepositsRoot [32]byte
+	Round        uint64
+	QuorumCert   *QuorumCert
+}
+
+// NewGenesisBlock builds block 0, authored by validatorKey, with zeroed
+// parent hash and state root and no transactions. Finalize runs
+// immediately so ForkChoice and blockstore both see a hash-consistent
+// block from the start, unlike a produced block whose hash is only final
+// once Algorithm.Seal has set StateRoot/DepositsRoot/Round.
+func NewGenesisBlock(validatorKey [32]byte) *Block {
+	block := &Block{
+		Validator: validatorKey,
+		Timestamp: time.Now().Unix(),
+	}
+	block.Finalize()
+	return block
+}
+
+// IsValid reports whether BlockHash actually matches block's header
+// fields, catching a block mutated (or corrupted in transit) after it was
+// hashed. It deliberately doesn't check anything that needs chain
+// context — parent linkage is ForkChoice.AddBlock's job, validator
+// authority is poaBlockValidator.ValidateBlock's.
+func (b *Block) IsValid() bool {
+	return b.BlockHash == b.computeHash()
+}
+
+// Finalize (re)computes BlockHash from the block's current header fields.
+// Callers set StateRoot/DepositsRoot/Round and then call Finalize once,
+// last — see Algorithm.Seal, the only place outside NewGenesisBlock that's
+// expected to call it. QuorumCert is attached after Finalize (BFTAlgorithm
+// does this in Seal) and so is intentionally excluded from the hash: a
+// quorum certificate signs over the block hash, so it can't also be part
+// of what's hashed.
+func (b *Block) Finalize() {
+	b.BlockHash = b.computeHash()
+}
+
+// computeHash hashes the block header plus a Merkle root over its
+// transaction hashes, hand-packed little-endian to match the rest of this
+// repo's pre-codec hashing paths (see computeTxHash in pkg/api).
+func (b *Block) computeHash() [32]byte {
+	txLeaves := make([][32]byte, len(b.Transactions))
+	for i := range b.Transactions {
+		txLeaves[i] = b.Transactions[i].Hash
+	}
+	txRoot := merkleRoot(txLeaves)
+
+	buf := make([]byte, 0, 32+32+8+32+32+8+8+8+32)
+	buf = append(buf, b.ParentHash[:]...)
+	buf = append(buf, b.Validator[:]...)
+	buf = appendStateRootUint64(buf, b.BlockNumber)
+	buf = append(buf, b.StateRoot[:]...)
+	buf = append(buf, b.DepositsRoot[:]...)
+	buf = appendStateRootUint64(buf, uint64(b.Timestamp))
+	buf = appendStateRootUint64(buf, b.GasUsed)
+	buf = appendStateRootUint64(buf, b.Round)
+	buf = append(buf, txRoot[:]...)
+	return sha256.Sum256(buf)
+}
+
+// BlockBuilder does the real work behind poaStateProcessor/BFTAlgorithm's
+// block-level calls: selecting transactions from the mempool into a new
+// Block (BuildBlock) and applying an assembled Block's transactions
+// against state (ApplyBlock). Both halves go through stateManager rather
+// than back through mempool, mirroring how pkg/conformance/runner.go
+// replays a vector's transactions through a BlockSession instead of
+// StateManager.ApplyTransaction directly.
+type BlockBuilder struct {
+	mempool      *mempool.Mempool
+	stateManager *state.StateManager
+	log          *logger.Logger
+	metrics      MetricsSink
+}
+
+// NewBlockBuilder constructs a BlockBuilder backed by mp and sm. Either may
+// be nil (e.g. a lightweight engine used only for testing fork choice),
+// in which case BuildBlock produces empty blocks and ApplyBlock is a
+// no-op that returns a zero state root.
+func NewBlockBuilder(mp *mempool.Mempool, sm *state.StateManager, log *logger.Logger) *BlockBuilder {
+	return &BlockBuilder{
+		mempool:      mp,
+		stateManager: sm,
+		log:          log,
+	}
+}
+
+// BuildBlock assembles a new, unsealed Block on top of parentHash: it
+// pulls the mempool's highest-priority transactions, in priority order,
+// skipping any whose dynamic fee cap has fallen below the mempool's
+// current base fee (includableAtBaseFee — they may still clear a later
+// block once the base fee drops) and stopping once defaultBlockGasLimit
+// would be exceeded. The returned block's StateRoot/DepositsRoot/Round are
+// left zero; the caller (Engine.produceBlock) fills those in via
+// StateProcessor.Process and Algorithm.Seal before persisting it.
+func (b *BlockBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, validatorKey [32]byte) (*Block, error) {
+	block := &Block{
+		ParentHash:  parentHash,
+		BlockNumber: blockNumber,
+		Validator:   validatorKey,
+		Timestamp:   time.Now().Unix(),
+	}
+
+	if b.mempool == nil {
+		return block, nil
+	}
+
+	baseFee := b.mempool.BaseFee()
+	candidates := b.mempool.GetTopTransactions(defaultBlockGasLimit)
+
+	var gasUsed uint64
+	txs := make([]mempool.Transaction, 0, len(candidates))
+	for _, tx := range candidates {
+		if !includableAtBaseFee(tx, baseFee) {
+			continue
+		}
+		if gasUsed+tx.GasLimit > defaultBlockGasLimit {
+			break
+		}
+		gasUsed += tx.GasLimit
+		txs = append(txs, *tx)
+	}
+
+	block.Transactions = txs
+	block.GasUsed = gasUsed
+	return block, nil
+}
+
+// ApplyBlock executes block's transactions against state through a
+// BlockSession (the same journal/snapshot-capable path
+// pkg/conformance/runner.go uses), distributes each transaction's fee via
+// distributeFee, and commits. It returns the resulting state root.
+//
+// TxTypeDeposit transactions are skipped here: Engine.recordBlockDeposits
+// already records them against stateManager directly once Process
+// returns, and a deposit has no sender-side balance to debit through
+// ApplyTx (the deposited amount leaves the chain before it's ever
+// submitted on-chain), so applying it again here would double-process it.
+func (b *BlockBuilder) ApplyBlock(block *Block) ([32]byte, error) {
+	if b.stateManager == nil {
+		return [32]byte{}, nil
+	}
+
+	baseFee := uint64(0)
+	if b.mempool != nil {
+		baseFee = b.mempool.BaseFee()
+	}
+
+	session := b.stateManager.BeginBlock(block.BlockNumber)
+	for i := range block.Transactions {
+		tx := &block.Transactions[i]
+		if tx.TxType == bindings.TxTypeDeposit {
+			continue
+		}
+
+		if err := session.ApplyTx(tx.From, tx.To, tx.Amount, tx.Fee); err != nil {
+			session.Discard()
+			return [32]byte{}, fmt.Errorf("block %d: tx %x rejected: %w", block.BlockNumber, tx.Hash[:8], err)
+		}
+		if err := b.distributeFee(block.Validator, tx, baseFee); err != nil {
+			session.Discard()
+			return [32]byte{}, fmt.Errorf("block %d: tx %x fee distribution failed: %w", block.BlockNumber, tx.Hash[:8], err)
+		}
+	}
+
+	root, err := session.Commit()
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("block %d: failed to commit block session: %w", block.BlockNumber, err)
+	}
+	return root, nil
+}
@@ -0,0 +1,84 @@
+package consensus
+
+import "fmt"
+
+// BlockValidator decides whether a block is admissible before any of its
+// transactions are applied: well-formed contents and an authorized,
+// non-slashed validator. Splitting this out of Engine mirrors how
+// go-ethereum separates core.BlockValidator from core.StateProcessor — "is
+// this block legal?" is a different question from "what does applying it
+// produce?" (see StateProcessor), and keeping them as separate interfaces
+// lets either be swapped (a stricter witness check, a different validator
+// set policy, BFT quorum checks) without touching Engine's orchestration.
+type BlockValidator interface {
+	// ValidateBlock checks block against header- and body-level invariants
+	// that don't require executing its transactions. It must not mutate
+	// state.
+	ValidateBlock(block *Block) error
+}
+
+// StateProcessor applies a validated block's transactions to state and
+// returns the resulting state root. Engine always runs a BlockValidator
+// over a block before handing it to Process.
+type StateProcessor interface {
+	// Process applies block's transactions and returns the resulting state
+	// root.
+	Process(block *Block) ([32]byte, error)
+}
+
+// poaBlockValidator is the BlockValidator Engine constructs by default: the
+// structural, slashing and authorization checks ProcessBlock ran inline
+// before this split existed.
+type poaBlockValidator struct {
+	config   ConsensusConfig
+	slashing *SlashingManager
+}
+
+func newPoABlockValidator(cfg ConsensusConfig, slashing *SlashingManager) *poaBlockValidator {
+	return &poaBlockValidator{config: cfg, slashing: slashing}
+}
+
+// ValidateBlock implements BlockValidator.
+func (v *poaBlockValidator) ValidateBlock(block *Block) error {
+	if v.slashing != nil && !v.slashing.IsValidatorActive(block.Validator) {
+		return fmt.Errorf("validator is slashed or jailed")
+	}
+
+	if !block.IsValid() {
+		if v.slashing != nil {
+			v.slashing.Slash(block.Validator, OffenseInvalidBlock, block.BlockNumber, nil, localReporter)
+		}
+		return fmt.Errorf("invalid block")
+	}
+
+	if !v.isAuthorizedValidator(block.Validator) {
+		return fmt.Errorf("unauthorized validator")
+	}
+
+	return nil
+}
+
+func (v *poaBlockValidator) isAuthorizedValidator(address [32]byte) bool {
+	for _, validator := range v.config.Validators {
+		if validator == address {
+			return true
+		}
+	}
+	return false
+}
+
+// poaStateProcessor is the StateProcessor Engine constructs by default. It
+// just adapts the engine's existing BlockBuilder.ApplyBlock, which already
+// does the real work of executing a block's transactions against state.
+type poaStateProcessor struct {
+	builder *BlockBuilder
+}
+
+func newPoAStateProcessor(builder *BlockBuilder) *poaStateProcessor {
+	return &poaStateProcessor{builder: builder}
+}
+
+// Process implements StateProcessor.
+func (p *poaStateProcessor) Process(block *Block) ([32]byte, error) {
+	return p.builder.ApplyBlock(block)
+}
@@ -0,0 +1,37 @@
+// Deposit inclusion: a block's DepositsRoot commits to every validator
+// deposit it includes, giving the PoA authority set an auditable, on-chain
+// rotation mechanism instead of an out-of-band API (mirrors the
+// execution-layer-triggered deposit design referenced in the request).
+package consensus
+
+import (
+	"crypto/sha256"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+// ComputeDepositsRoot returns a Merkle root over an ordered list of
+// deposits, using the same leaf-hash-then-merkleRoot pattern as
+// SlashingManager.StateRoot. The list must be in on-chain inclusion order
+// (index ascending) so two nodes that processed the same block agree on
+// the root.
+func ComputeDepositsRoot(deposits []state.Deposit) [32]byte {
+	leaves := make([][32]byte, len(deposits))
+	for i, d := range deposits {
+		leaves[i] = hashDeposit(d)
+	}
+	return merkleRoot(leaves)
+}
+
+// hashDeposit hashes the fields of a deposit that affect consensus
+// (pubkey, withdrawal credentials, amount, index), hand-packed
+// little-endian to match the rest of this repo's pre-codec hashing paths
+// (see computeTxHash in pkg/api).
+func hashDeposit(d state.Deposit) [32]byte {
+	buf := make([]byte, 0, 32+32+8+8)
+	buf = append(buf, d.Pubkey[:]...)
+	buf = append(buf, d.WithdrawalCredentials[:]...)
+	buf = appendStateRootUint64(buf, d.Amount)
+	buf = appendStateRootUint64(buf, d.Index)
+	return sha256.Sum256(buf)
+}
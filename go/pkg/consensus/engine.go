@@ -8,16 +8,20 @@ import (
 	"time"
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/bindings"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/chaindb"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/store/blockstore"
 )
 
 // ConsensusConfig holds consensus engine configuration
 type ConsensusConfig struct {
-	BlockTime    time.Duration // Target time between blocks
-	Validators   [][32]byte    // List of authorized validator addresses
-	ValidatorKey [32]byte      // This node's validator key (if a validator)
-	IsValidator  bool          // Whether this node is a validator
+	BlockTime     time.Duration // Target time between blocks
+	Validators    [][32]byte    // List of authorized validator addresses
+	ValidatorKey  [32]byte      // This node's validator key (if a validator)
+	IsValidator   bool          // Whether this node is a validator
+	BlockGasLimit uint64        // Target gas per block for EIP-1559 base fee (0 = defaultBlockGasLimit)
 }
 
 // Engine is the Proof-of-Authority consensus engine
@@ -25,6 +29,7 @@ type Engine struct {
 	config       ConsensusConfig
 	builder      *BlockBuilder
 	stateManager *state.StateManager
+	mempool      *mempool.Mempool
 	log          *logger.Logger
 
 	// Current chain state
@@ -38,14 +43,51 @@ type Engine struct {
 	// Validator slashing
 	slashing *SlashingManager
 
+	// Block admission and execution, pluggable so a different validator
+	// set policy or execution model (e.g. a future BFT engine) can be
+	// substituted without touching the orchestration below. Defaults to
+	// the PoA implementations constructed in NewEngine.
+	validator BlockValidator
+	processor StateProcessor
+
+	// Proposer selection and block sealing, pluggable via SetAlgorithm.
+	// Defaults to the round-robin, single-seal poaAlgorithm; swapping in
+	// a BFTAlgorithm makes blocks final as soon as they carry a quorum
+	// certificate instead of waiting on ForkChoice's GHOST weight rule.
+	algorithm Algorithm
+
 	// Block production
 	blockTimer *time.Ticker
 	ctx        context.Context
 	cancel     context.CancelFunc
 
 	// Callbacks
-	onNewBlock func(*Block) // Called when a new block is produced
+	onNewBlock func(*Block)                                       // Called when a new block is produced
 	onReorg    func(oldTip *Block, newTip *Block, reorgDepth int) // Called on chain reorg
+	onBaseFee  func(baseFee uint64)                               // Called once per block with the base fee that governed it; see SetBaseFeeCallback
+
+	// Cached post-block state snapshots, keyed by block hash. Lets
+	// rollbackStateToBlock restore the nearest ancestor directly instead
+	// of always replaying every block since genesis; see snapshotAfter.
+	snapshots map[[32]byte]*stateSnapshot
+
+	// chain is the read-side facade over the same blockstore/receipts
+	// stores ForkChoice persists to (see initializeGenesis). nil if sm is
+	// nil (e.g. a test engine with no persistence), in which case
+	// initializeGenesis always starts fresh.
+	chain *chaindb.DB
+
+	// metrics receives per-block economic signals; nil unless
+	// SetMetricsSink is called.
+	metrics MetricsSink
+}
+
+// stateSnapshot is the unit snapshotAfter caches per canonical block: the
+// account and escrow tables together, since a rollback has to restore
+// both consistently or neither.
+type stateSnapshot struct {
+	accounts map[[32]byte]*state.Account
+	escrows  map[[32]byte]*state.Escrow
 }
 
 // NewEngine creates a new PoA consensus engine
@@ -65,18 +107,94 @@ func NewEngine(
 
 	// Register all validators for slashing tracking
 	for _, validator := range cfg.Validators {
-		slashing.RegisterValidator(validator)
+		slashing.RegisterValidator(validator, 0)
+	}
+
+	var chain *chaindb.DB
+	if sm != nil {
+		chain = chaindb.NewDB(sm.Blocks(), sm.Receipts())
 	}
 
 	return &Engine{
 		config:       cfg,
 		builder:      builder,
 		stateManager: sm,
+		mempool:      mp,
 		log:          log,
 		slashing:     slashing,
+		validator:    newPoABlockValidator(cfg, slashing),
+		processor:    newPoAStateProcessor(builder),
+		algorithm:    newPoAAlgorithm(cfg),
 		blockHeight:  0,
 		ctx:          ctx,
 		cancel:       cancel,
+		snapshots:    make(map[[32]byte]*stateSnapshot),
+		chain:        chain,
+	}
+}
+
+// SetBlockValidator overrides the engine's BlockValidator. Must be called
+// before Start; Engine is not safe to reconfigure while running.
+func (e *Engine) SetBlockValidator(v BlockValidator) {
+	e.validator = v
+}
+
+// SetStateProcessor overrides the engine's StateProcessor. Must be called
+// before Start; Engine is not safe to reconfigure while running.
+func (e *Engine) SetStateProcessor(p StateProcessor) {
+	e.processor = p
+}
+
+// SetAlgorithm overrides the engine's Algorithm. Must be called before
+// Start; Engine is not safe to reconfigure while running. If alg is a
+// *BFTAlgorithm, Start wires it to the engine's ForkChoice once one
+// exists (see initializeGenesis) so its quorum certificates can finalize
+// directly.
+func (e *Engine) SetAlgorithm(alg Algorithm) {
+	e.algorithm = alg
+}
+
+// SetMetricsSink wires sink to receive per-block economic signals going
+// forward, and propagates it to the engine's BlockBuilder so distributeFee's
+// validator/burn/treasury credits are reported too. Passing nil (the
+// default) disables reporting.
+func (e *Engine) SetMetricsSink(sink MetricsSink) {
+	e.metrics = sink
+	e.builder.SetMetricsSink(sink)
+}
+
+// LastBlockAge reports how long ago the current block was produced or
+// accepted, for /readyz-style liveness checks. Returns an error if the
+// engine hasn't initialized a chain yet (see Start/initializeGenesis).
+func (e *Engine) LastBlockAge() (time.Duration, error) {
+	e.chainLock.RLock()
+	defer e.chainLock.RUnlock()
+
+	if e.currentBlock == nil {
+		return 0, fmt.Errorf("engine has no current block yet")
+	}
+	return time.Since(time.Unix(e.currentBlock.Timestamp, 0)), nil
+}
+
+// updateBaseFee advances the mempool's rolling EIP-1559 base fee using the
+// block that was just produced or accepted, so subsequent submissions and
+// the /base_fee endpoint reflect actual chain activity.
+func (e *Engine) updateBaseFee(block *Block) {
+	if e.mempool == nil {
+		return
+	}
+
+	prevBaseFee := e.mempool.BaseFee()
+	if e.onBaseFee != nil {
+		go e.onBaseFee(prevBaseFee)
+	}
+
+	next := nextBaseFee(prevBaseFee, block.GasUsed, e.config.BlockGasLimit)
+	e.mempool.SetBaseFee(next)
+	currentBaseFee.Set(float64(next))
+
+	if e.metrics != nil {
+		e.metrics.ObserveBlockGasUsed(block.GasUsed)
 	}
 }
 
@@ -114,12 +232,22 @@ func (e *Engine) Stop() {
 	e.cancel()
 }
 
-// initializeGenesis initializes the genesis block
+// initializeGenesis brings up chain state: rehydrating from the
+// persisted chain head if chaindb already has one (a restart), or
+// creating a fresh genesis block otherwise (first run on an empty
+// database).
 func (e *Engine) initializeGenesis() error {
-	e.log.Info("Initializing genesis block")
+	if e.chain != nil {
+		head, err := e.chain.HeadBlock()
+		if err != nil {
+			return fmt.Errorf("failed to check for a persisted chain head: %w", err)
+		}
+		if head != nil {
+			return e.rehydrateFromDisk(head)
+		}
+	}
 
-	// Check if genesis already exists in database
-	// TODO: Load from state manager
+	e.log.Info("Initializing genesis block")
 
 	// Create genesis block
 	genesis := NewGenesisBlock(e.config.ValidatorKey)
@@ -129,16 +257,104 @@ func (e *Engine) initializeGenesis() error {
 	e.blockHeight = 0
 
 	// Initialize fork choice with genesis
-	e.forkChoice = NewForkChoice(genesis, e.log)
+	e.forkChoice = NewForkChoice(genesis, len(e.config.Validators), e.log)
+	if e.stateManager != nil {
+		e.forkChoice.SetBlockStore(e.stateManager.Blocks())
+		e.forkChoice.SetReceiptsStore(e.stateManager.Receipts())
+	}
+	if e.mempool != nil && e.mempool.BaseFee() == 0 {
+		e.mempool.SetBaseFee(initialBaseFee)
+	}
 	e.chainLock.Unlock()
 
+	if bft, ok := e.algorithm.(*BFTAlgorithm); ok {
+		bft.SetForkChoice(e.forkChoice)
+	}
+	e.notifyAlgorithmHeight(0)
+
+	e.snapshotAfter(genesis.BlockHash)
+
 	e.log.WithFields(logger.Fields{
-		"block_hash":   fmt.Sprintf("%x", genesis.BlockHash[:8]),
-		"validator":    fmt.Sprintf("%x", genesis.Validator[:8]),
-		"timestamp":    genesis.Timestamp,
+		"block_hash": fmt.Sprintf("%x", genesis.BlockHash[:8]),
+		"validator":  fmt.Sprintf("%x", genesis.Validator[:8]),
+		"timestamp":  genesis.Timestamp,
 	}).Info("Genesis block initialized")
 
-	// TODO: Save to database
+	return nil
+}
+
+// rehydrateFromDisk reconstructs in-memory chain state from a previously
+// persisted head block, so a restart doesn't discard ForkChoice's
+// history and fall back to replaying every block from genesis the way a
+// deep reorg would. It replays every persisted block from genesis through
+// head via ForkChoice.AddBlock — the same path a freshly-received block
+// takes — which rebuilds blockCache, children and weight as a side
+// effect.
+//
+// It deliberately does not call snapshotAfter for any block except head:
+// stateManager's tables already hold the live post-head state (they were
+// never touched by this replay), so caching that same state under an
+// earlier block's hash would be wrong. A reorg deeper than head during
+// this run falls back to a full genesis replay, same as
+// rollbackStateToBlock does whenever its snapshot cache comes up empty.
+func (e *Engine) rehydrateFromDisk(head *blockstore.Block) error {
+	e.log.WithFields(logger.Fields{
+		"block_number": head.BlockNumber,
+		"block_hash":   fmt.Sprintf("%x", head.BlockHash[:8]),
+	}).Info("Rehydrating chain state from disk")
+
+	storedGenesis, _, err := e.chain.GetBlockByNumber(0)
+	if err != nil {
+		return fmt.Errorf("failed to load persisted genesis block: %w", err)
+	}
+	if storedGenesis == nil {
+		return fmt.Errorf("chain head %x is persisted but genesis block is missing", head.BlockHash[:8])
+	}
+	genesis := fromStoreBlock(storedGenesis)
+
+	e.chainLock.Lock()
+	e.currentBlock = genesis
+	e.blockHeight = 0
+	e.forkChoice = NewForkChoice(genesis, len(e.config.Validators), e.log)
+	e.forkChoice.SetBlockStore(e.stateManager.Blocks())
+	e.forkChoice.SetReceiptsStore(e.stateManager.Receipts())
+	e.chainLock.Unlock()
+
+	if bft, ok := e.algorithm.(*BFTAlgorithm); ok {
+		bft.SetForkChoice(e.forkChoice)
+	}
+
+	for number := uint64(1); number <= head.BlockNumber; number++ {
+		stored, _, err := e.chain.GetBlockByNumber(number)
+		if err != nil {
+			return fmt.Errorf("failed to load persisted block %d: %w", number, err)
+		}
+		if stored == nil {
+			return fmt.Errorf("chain head is at height %d but block %d is missing", head.BlockNumber, number)
+		}
+		block := fromStoreBlock(stored)
+
+		if _, err := e.forkChoice.AddBlock(block); err != nil {
+			return fmt.Errorf("failed to replay persisted block %d into fork choice: %w", number, err)
+		}
+
+		e.chainLock.Lock()
+		e.currentBlock = block
+		e.blockHeight = block.BlockNumber
+		e.chainLock.Unlock()
+	}
+
+	e.notifyAlgorithmHeight(e.blockHeight)
+	e.snapshotAfter(e.currentBlock.BlockHash)
+
+	if e.mempool != nil && e.mempool.BaseFee() == 0 {
+		e.mempool.SetBaseFee(initialBaseFee)
+	}
+
+	e.log.WithFields(logger.Fields{
+		"block_number": e.blockHeight,
+		"block_hash":   fmt.Sprintf("%x", e.currentBlock.BlockHash[:8]),
+	}).Info("Chain state rehydrated from disk")
 
 	return nil
 }
@@ -170,7 +386,7 @@ func (e *Engine) produceBlock() error {
 	defer e.chainLock.Unlock()
 
 	// Check if it's our turn to validate
-	if !e.isOurTurn(e.blockHeight + 1) {
+	if !e.algorithm.IsTurn(e.config.ValidatorKey, e.blockHeight+1) {
 		e.log.Debug("Not our turn to validate, skipping block production")
 		return nil
 	}
@@ -189,15 +405,40 @@ func (e *Engine) produceBlock() error {
 		return fmt.Errorf("failed to build block: %w", err)
 	}
 
+	if err := e.algorithm.Prepare(block); err != nil {
+		return fmt.Errorf("algorithm rejected block preparation: %w", err)
+	}
+
 	// Apply block to state
-	stateRoot, err := e.builder.ApplyBlock(block)
+	stateRoot, err := e.processor.Process(block)
 	if err != nil {
 		return fmt.Errorf("failed to apply block: %w", err)
 	}
 
+	// Commit the post-state trie and use its deterministic root as the
+	// block's StateRoot in place of whatever the processor itself
+	// returned — see pkg/state/trie.go's Commit, which folds every
+	// account and escrow row into one 32-byte Merkle root a light client
+	// can later verify a single account against via ProveAccount. Falls
+	// back to the processor's root when no stateManager is wired in (see
+	// recordBlockDeposits above for the same fallback pattern).
+	if e.stateManager != nil {
+		if trieRoot, err := e.stateManager.Commit(block.BlockNumber); err != nil {
+			return fmt.Errorf("failed to commit state trie: %w", err)
+		} else {
+			stateRoot = trieRoot
+		}
+	}
+
 	// Update block with state root
 	block.StateRoot = stateRoot
-	block.Finalize() // Recompute hash with new state root
+	block.DepositsRoot = e.recordBlockDeposits(block)
+	final, err := e.algorithm.Seal(block) // Recomputes hash with final header fields
+	if err != nil {
+		return fmt.Errorf("failed to seal block: %w", err)
+	}
+
+	e.snapshotAfter(block.BlockHash)
 
 	// Add to fork choice
 	if e.forkChoice != nil {
@@ -210,10 +451,16 @@ func (e *Engine) produceBlock() error {
 	// Update chain state
 	e.currentBlock = block
 	e.blockHeight++
+	e.notifyAlgorithmHeight(e.blockHeight)
+	e.updateBaseFee(block)
+
+	if final {
+		e.log.WithField("block_number", block.BlockNumber).Info("Block reached quorum and is immediately final")
+	}
 
 	// Record successful block production (improves reputation)
 	if e.slashing != nil {
-		e.slashing.RecordBlockProduced(e.config.ValidatorKey)
+		e.slashing.RecordBlockProduced(e.config.ValidatorKey, block.BlockNumber)
 	}
 
 	e.log.WithFields(logger.Fields{
@@ -229,23 +476,54 @@ func (e *Engine) produceBlock() error {
 		go e.onNewBlock(block)
 	}
 
-	// TODO: Save block to database
+	// Persistence already happened above, via e.forkChoice.AddBlock ->
+	// syncBlockStoreLocked -> blockstore.PutBlock.
 
 	return nil
 }
 
-// isOurTurn determines if it's this validator's turn to produce a block
-// Uses round-robin validator rotation for simplicity
-func (e *Engine) isOurTurn(blockNumber uint64) bool {
-	if len(e.config.Validators) == 0 {
-		return false
+// recordBlockDeposits extracts every TxTypeDeposit transaction in block,
+// records each as a state.Deposit, and returns the resulting Merkle root
+// for inclusion in (or verification against) the block's DepositsRoot.
+// Deposits give the PoA authority set an auditable, on-chain rotation
+// mechanism: validator set changes flow from user transactions rather than
+// an out-of-band API.
+func (e *Engine) recordBlockDeposits(block *Block) [32]byte {
+	if e.stateManager == nil {
+		return ComputeDepositsRoot(nil)
+	}
+
+	var deposits []state.Deposit
+	for _, tx := range block.Transactions {
+		if tx.TxType != bindings.TxTypeDeposit {
+			continue
+		}
+
+		pubkey, withdrawalCredentials, amount, err := bindings.DecodeDepositData(tx.Data)
+		if err != nil {
+			e.log.WithError(err).Warn("Skipping malformed deposit transaction")
+			continue
+		}
+
+		deposit, err := e.stateManager.RecordDeposit(pubkey, withdrawalCredentials, amount, tx.Hash, block.BlockNumber)
+		if err != nil {
+			e.log.WithError(err).Warn("Failed to record deposit")
+			continue
+		}
+
+		deposits = append(deposits, *deposit)
 	}
 
-	// Round-robin: block_number % num_validators
-	validatorIndex := int(blockNumber % uint64(len(e.config.Validators)))
-	expectedValidator := e.config.Validators[validatorIndex]
+	return ComputeDepositsRoot(deposits)
+}
 
-	return expectedValidator == e.config.ValidatorKey
+// notifyAlgorithmHeight tells a BFTAlgorithm that the canonical height has
+// changed, resetting its round-vote tracking. A no-op under PoA, which
+// has no round state to reset.
+func (e *Engine) notifyAlgorithmHeight(height uint64) {
+	if bft, ok := e.algorithm.(*BFTAlgorithm); ok {
+		bft.AdvanceHeight(height)
+	}
 }
 
 // ProcessBlock processes a block received from the P2P network
@@ -259,29 +537,22 @@ func (e *Engine) ProcessBlock(block *Block) error {
 		"validator":    fmt.Sprintf("%x", block.Validator[:8]),
 	}).Info("Processing received block")
 
-	// Check if validator is slashed/jailed
-	if e.slashing != nil && !e.slashing.IsValidatorActive(block.Validator) {
-		e.log.WithField("validator", fmt.Sprintf("%x", block.Validator[:8])).Warn("Block from slashed/jailed validator rejected")
-		return fmt.Errorf("validator is slashed or jailed")
+	// Validate block (structure, slashing/jailing status, authorization)
+	if err := e.validator.ValidateBlock(block); err != nil {
+		e.log.WithError(err).Warn("Received block rejected by validator")
+		return err
 	}
 
-	// Validate block
-	if !block.IsValid() {
-		// Slash validator for producing invalid block
-		if e.slashing != nil {
-			e.slashing.Slash(block.Validator, OffenseInvalidBlock, block.BlockNumber, nil)
-		}
-		return fmt.Errorf("invalid block")
-	}
-
-	// Check if validator is authorized
-	if !e.isAuthorizedValidator(block.Validator) {
-		return fmt.Errorf("unauthorized validator")
+	// Verify the algorithm-specific seal (PoA: none; BFT: the attached
+	// quorum certificate)
+	if err := e.algorithm.VerifySeal(block); err != nil {
+		e.log.WithError(err).Warn("Received block rejected by algorithm seal check")
+		return err
 	}
 
 	// Record successful block from this validator
 	if e.slashing != nil {
-		e.slashing.RecordBlockProduced(block.Validator)
+		e.slashing.RecordBlockProduced(block.Validator, block.BlockNumber)
 	}
 
 	// Add block to fork choice
@@ -296,6 +567,15 @@ func (e *Engine) ProcessBlock(block *Block) error {
 		if err := e.handleChainReorganization(oldTip, block); err != nil {
 			return fmt.Errorf("chain reorganization failed: %w", err)
 		}
+		e.updateBaseFee(block)
+
+		if depositsRoot := e.recordBlockDeposits(block); depositsRoot != block.DepositsRoot {
+			e.log.WithFields(logger.Fields{
+				"block_number": block.BlockNumber,
+				"expected":     fmt.Sprintf("%x", block.DepositsRoot[:8]),
+				"computed":     fmt.Sprintf("%x", depositsRoot[:8]),
+			}).Warn("DepositsRoot mismatch on received block")
+		}
 	} else {
 		e.log.WithFields(logger.Fields{
 			"block_number": block.BlockNumber,
@@ -327,13 +607,14 @@ func (e *Engine) handleChainReorganization(oldTip *Block, newTip *Block) error {
 		"reorg_depth":     reorgDepth,
 	}).Info("Found common ancestor for reorg")
 
-	// Step 2: Take state snapshot (for rollback if reorg fails)
-	snapshot, err := e.stateManager.GetAccountSnapshot()
+	// Step 2: Take a full state snapshot as a safety net, in case the
+	// rollback or replay below fails partway through.
+	safetyNet, err := e.captureSnapshot()
 	if err != nil {
 		return fmt.Errorf("failed to create state snapshot: %w", err)
 	}
 
-	e.log.WithField("accounts_snapshotted", len(snapshot)).Debug("State snapshot created")
+	e.log.WithField("accounts_snapshotted", len(safetyNet.accounts)).Debug("State snapshot created")
 
 	// Step 3: Get path from common ancestor to new tip
 	reorgPath, err := e.forkChoice.GetChainPath(commonAncestor.BlockHash, newTip.BlockHash)
@@ -347,7 +628,7 @@ func (e *Engine) handleChainReorganization(oldTip *Block, newTip *Block) error {
 	if err := e.rollbackStateToBlock(commonAncestor); err != nil {
 		// Try to restore snapshot
 		e.log.WithError(err).Error("State rollback failed, restoring snapshot")
-		if restoreErr := e.stateManager.RestoreAccountSnapshot(snapshot); restoreErr != nil {
+		if restoreErr := e.restoreSnapshot(safetyNet); restoreErr != nil {
 			return fmt.Errorf("rollback failed and snapshot restore failed: %w (original: %v)", restoreErr, err)
 		}
 		return fmt.Errorf("state rollback failed: %w", err)
@@ -362,19 +643,21 @@ func (e *Engine) handleChainReorganization(oldTip *Block, newTip *Block) error {
 		}).Info("Replaying block")
 
 		// Apply block to state
-		if _, err := e.builder.ApplyBlock(block); err != nil {
+		if _, err := e.processor.Process(block); err != nil {
 			// Rollback failed, restore snapshot
 			e.log.WithError(err).Error("Block replay failed, restoring snapshot")
-			if restoreErr := e.stateManager.RestoreAccountSnapshot(snapshot); restoreErr != nil {
+			if restoreErr := e.restoreSnapshot(safetyNet); restoreErr != nil {
 				return fmt.Errorf("block replay failed and snapshot restore failed: %w (original: %v)", restoreErr, err)
 			}
 			return fmt.Errorf("failed to replay block %d: %w", block.BlockNumber, err)
 		}
+		e.snapshotAfter(block.BlockHash)
 	}
 
 	// Step 6: Update chain state
 	e.currentBlock = newTip
 	e.blockHeight = newTip.BlockNumber
+	e.notifyAlgorithmHeight(e.blockHeight)
 
 	// Trigger reorg callback if set
 	if e.onReorg != nil {
@@ -382,9 +665,9 @@ func (e *Engine) handleChainReorganization(oldTip *Block, newTip *Block) error {
 	}
 
 	e.log.WithFields(logger.Fields{
-		"new_height":  newTip.BlockNumber,
-		"new_hash":    fmt.Sprintf("%x", newTip.BlockHash[:8]),
-		"reorg_depth": reorgDepth,
+		"new_height":      newTip.BlockNumber,
+		"new_hash":        fmt.Sprintf("%x", newTip.BlockHash[:8]),
+		"reorg_depth":     reorgDepth,
 		"blocks_replayed": len(reorgPath),
 	}).Info("Chain reorganization complete")
 
@@ -431,32 +714,59 @@ func (e *Engine) findCommonAncestor(block1, block2 *Block) (*Block, int, error)
 	return nil, 0, fmt.Errorf("no common ancestor found")
 }
 
-// rollbackStateToBlock rolls back state by replaying from genesis to target block
+// rollbackStateToBlock brings state to exactly targetBlock's post-state.
+// It walks back from targetBlock to the nearest ancestor with a cached
+// snapshotAfter snapshot, restores that directly, then replays only the
+// (usually short) run of blocks between the snapshot and targetBlock —
+// rather than always clearing state and replaying every block since
+// genesis. Falls back to a full genesis replay only if no snapshot
+// survived on the path back to genesis (e.g. a reorg deeper than
+// snapshotAfter's pruning has retained).
 func (e *Engine) rollbackStateToBlock(targetBlock *Block) error {
 	e.log.WithFields(logger.Fields{
 		"target_height": targetBlock.BlockNumber,
 		"target_hash":   fmt.Sprintf("%x", targetBlock.BlockHash[:8]),
 	}).Info("Rolling back state")
 
-	// Clear account state
-	if err := e.stateManager.ClearAccountState(); err != nil {
-		return fmt.Errorf("failed to clear account state: %w", err)
+	var toReplay []*Block
+	current := targetBlock
+	for {
+		if snap, ok := e.snapshots[current.BlockHash]; ok {
+			if err := e.restoreSnapshot(snap); err != nil {
+				return fmt.Errorf("failed to restore cached snapshot at block %d: %w", current.BlockNumber, err)
+			}
+			e.log.WithFields(logger.Fields{
+				"snapshot_height":  current.BlockNumber,
+				"blocks_to_replay": len(toReplay),
+			}).Info("Restored cached state snapshot")
+			return e.replayBlocks(toReplay)
+		}
+
+		if current.BlockNumber == 0 {
+			break // No cached genesis snapshot either; fall through to a full replay.
+		}
+
+		toReplay = append([]*Block{current}, toReplay...) // Prepend
+		parent, exists := e.forkChoice.GetBlock(current.ParentHash)
+		if !exists {
+			return fmt.Errorf("missing parent block %x while searching for a state snapshot", current.ParentHash[:8])
+		}
+		current = parent
 	}
 
-	// Clear escrow state
-	if err := e.stateManager.ClearEscrowState(); err != nil {
-		return fmt.Errorf("failed to clear escrow state: %w", err)
+	e.log.Warn("No cached state snapshot on the path to genesis; rewinding via history and replaying")
+
+	if err := e.stateManager.RewindTo(0); err != nil {
+		return fmt.Errorf("failed to rewind state to genesis: %w", err)
 	}
 
-	// If target is genesis (block 0), we're done
 	if targetBlock.BlockNumber == 0 {
 		e.log.Info("Rolled back to genesis block")
 		return nil
 	}
 
-	// Build chain from genesis to target
 	chain := []*Block{}
-	current := targetBlock
+	current = targetBlock
 	for current.BlockNumber > 0 {
 		chain = append([]*Block{current}, chain...) // Prepend
 		parent, exists := e.forkChoice.GetBlock(current.ParentHash)
@@ -466,30 +776,99 @@ func (e *Engine) rollbackStateToBlock(targetBlock *Block) error {
 		current = parent
 	}
 
-	// Replay blocks from genesis to target
-	for i, block := range chain {
+	return e.replayBlocks(chain)
+}
+
+// replayBlocks applies each block in order via the state processor,
+// caching a fresh snapshot after each one so a future rollback doesn't
+// have to redo this work.
+func (e *Engine) replayBlocks(blocks []*Block) error {
+	for i, block := range blocks {
 		e.log.WithFields(logger.Fields{
 			"block_number": block.BlockNumber,
-			"progress":     fmt.Sprintf("%d/%d", i+1, len(chain)),
+			"progress":     fmt.Sprintf("%d/%d", i+1, len(blocks)),
 		}).Debug("Replaying block for state rollback")
 
-		if _, err := e.builder.ApplyBlock(block); err != nil {
+		if _, err := e.processor.Process(block); err != nil {
 			return fmt.Errorf("failed to replay block %d: %w", block.BlockNumber, err)
 		}
+		if e.stateManager != nil {
+			if _, err := e.stateManager.Commit(block.BlockNumber); err != nil {
+				return fmt.Errorf("failed to commit replayed state trie for block %d: %w", block.BlockNumber, err)
+			}
+		}
+		e.snapshotAfter(block.BlockHash)
+	}
+
+	if len(blocks) > 0 {
+		e.log.WithField("blocks_replayed", len(blocks)).Info("State rollback complete")
 	}
+	return nil
+}
+
+// captureSnapshot reads the current account and escrow tables as a single
+// unit, for caching against the block that was just committed (see
+// snapshotAfter) or as a pre-reorg safety net (see
+// handleChainReorganization).
+func (e *Engine) captureSnapshot() (*stateSnapshot, error) {
+	accounts, err := e.stateManager.GetAccountSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot accounts: %w", err)
+	}
+
+	escrows, err := e.stateManager.GetEscrowSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot escrows: %w", err)
+	}
+
+	return &stateSnapshot{accounts: accounts, escrows: escrows}, nil
+}
 
-	e.log.WithField("blocks_replayed", len(chain)).Info("State rollback complete")
+// restoreSnapshot replaces the live account and escrow tables with snap.
+func (e *Engine) restoreSnapshot(snap *stateSnapshot) error {
+	if err := e.stateManager.RestoreAccountSnapshot(snap.accounts); err != nil {
+		return fmt.Errorf("failed to restore accounts: %w", err)
+	}
+	if err := e.stateManager.RestoreEscrowSnapshot(snap.escrows); err != nil {
+		return fmt.Errorf("failed to restore escrows: %w", err)
+	}
 	return nil
 }
 
-// isAuthorizedValidator checks if an address is an authorized validator
-func (e *Engine) isAuthorizedValidator(address [32]byte) bool {
-	for _, validator := range e.config.Validators {
-		if validator == address {
-			return true
+// snapshotAfter caches the current post-state under blockHash once it has
+// joined the canonical chain, then prunes any cached snapshot older than
+// the fork choice's finalized checkpoint: a finalized block (and
+// everything before it) can never be rolled back past, so its snapshot,
+// and anything earlier, is dead weight. A no-op if stateManager is unset
+// (e.g. a test engine with no persistence).
+func (e *Engine) snapshotAfter(blockHash [32]byte) {
+	if e.stateManager == nil {
+		return
+	}
+
+	snap, err := e.captureSnapshot()
+	if err != nil {
+		e.log.WithError(err).Warn("Failed to cache state snapshot; a future rollback may fall back to full replay")
+		return
+	}
+	e.snapshots[blockHash] = snap
+
+	if e.forkChoice == nil {
+		return
+	}
+	finalized := e.forkChoice.GetFinalized()
+	if finalized == nil {
+		return
+	}
+
+	for hash := range e.snapshots {
+		if hash == finalized.Block.BlockHash {
+			continue
+		}
+		if block, exists := e.forkChoice.GetBlock(hash); !exists || block.BlockNumber < finalized.Height {
+			delete(e.snapshots, hash)
 		}
 	}
-	return false
 }
 
 // GetCurrentBlock returns the current block
@@ -511,16 +890,35 @@ func (e *Engine) SetNewBlockCallback(callback func(*Block)) {
 	e.onNewBlock = callback
 }
 
+// SetBaseFeeCallback registers a callback invoked once per block, alongside
+// SetNewBlockCallback, with the EIP-1559 base fee that governed the block
+// just produced or accepted — not the (already-recomputed) floor
+// updateBaseFee sets for the next block. Lets external tooling (e.g.
+// cmd/loadtest) report realized tip rates without reaching into the
+// mempool directly.
+func (e *Engine) SetBaseFeeCallback(callback func(baseFee uint64)) {
+	e.onBaseFee = callback
+}
+
+// GetBaseFee returns the mempool's current rolling EIP-1559 base fee, the
+// same value the /base_fee API endpoint reports.
+func (e *Engine) GetBaseFee() uint64 {
+	if e.mempool == nil {
+		return 0
+	}
+	return e.mempool.BaseFee()
+}
+
 // GetStats returns consensus engine statistics
 func (e *Engine) GetStats() map[string]interface{} {
 	e.chainLock.RLock()
 	defer e.chainLock.RUnlock()
 
 	stats := map[string]interface{}{
-		"block_height":   e.blockHeight,
-		"is_validator":   e.config.IsValidator,
+		"block_height":    e.blockHeight,
+		"is_validator":    e.config.IsValidator,
 		"validator_count": len(e.config.Validators),
-		"block_time":     e.config.BlockTime.String(),
+		"block_time":      e.config.BlockTime.String(),
 	}
 
 	if e.currentBlock != nil {
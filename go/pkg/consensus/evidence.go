@@ -0,0 +1,431 @@
+// Slashing evidence pool: accepts, verifies, deduplicates, and gossips
+// misbehavior evidence between nodes (modeled after Tendermint/Cosmos and
+// Polkadot dispute slashing), handing verified evidence to SlashingManager.
+package consensus
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/p2p"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+// BlockHeader is the minimal subset of block fields needed to detect and
+// verify equivocation: enough to identify a block (height, hash) and the
+// validator that produced it. There's no concrete Block type yet, so
+// evidence carries headers rather than full blocks.
+type BlockHeader struct {
+	Height    uint64
+	Hash      [32]byte
+	Validator [32]byte // proposer address; doubles as its Ed25519 public key
+	Timestamp int64
+}
+
+// signingBytes is the canonical byte packing a header's signature covers.
+// Hand-rolled little-endian packing, matching the rest of the pre-codec
+// hashing/signing paths in this repo (see computeTxHash in pkg/api).
+func (h BlockHeader) signingBytes() []byte {
+	buf := make([]byte, 0, 8+32+32+8)
+	buf = appendUint64(buf, h.Height)
+	buf = append(buf, h.Hash[:]...)
+	buf = append(buf, h.Validator[:]...)
+	buf = appendUint64(buf, uint64(h.Timestamp))
+	return buf
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], v)
+	return append(b, tmp[:]...)
+}
+
+// Evidence is the common surface every typed evidence struct satisfies, so
+// EvidencePool can verify, persist, gossip, and slash on them uniformly.
+type Evidence interface {
+	// Offense maps this evidence to the SlashingManager offense it proves.
+	Offense() SlashingOffense
+	// Validator returns the address of the validator the evidence accuses.
+	Validator() [32]byte
+	// Height returns the block height this evidence pertains to, used to
+	// enforce MaxEvidenceAge.
+	Height() uint64
+	// Verify checks the evidence is internally consistent and carries a
+	// valid signature from validatorPubKey.
+	Verify(validatorPubKey [32]byte) error
+}
+
+// DoubleSignEvidence proves a validator signed two different headers at the
+// same height (equivocation) — the classic Tendermint/Cosmos slashable
+// offense.
+type DoubleSignEvidence struct {
+	HeaderA BlockHeader
+	HeaderB BlockHeader
+	Sig1    [64]byte
+	Sig2    [64]byte
+}
+
+// Verify checks HeaderA and HeaderB are at the same height, differ in hash,
+// both name validatorPubKey as proposer, and both carry a valid signature
+// from it — i.e. that the validator really did sign two different blocks
+// at the same height.
+func (e *DoubleSignEvidence) Verify(validatorPubKey [32]byte) error {
+	if e.HeaderA.Height != e.HeaderB.Height {
+		return fmt.Errorf("double-sign evidence: headers are at different heights (%d != %d)", e.HeaderA.Height, e.HeaderB.Height)
+	}
+	if e.HeaderA.Hash == e.HeaderB.Hash {
+		return fmt.Errorf("double-sign evidence: headers have the same hash, not equivocation")
+	}
+	if e.HeaderA.Validator != validatorPubKey || e.HeaderB.Validator != validatorPubKey {
+		return fmt.Errorf("double-sign evidence: header validator does not match accused validator")
+	}
+	if !ed25519.Verify(validatorPubKey[:], e.HeaderA.signingBytes(), e.Sig1[:]) {
+		return fmt.Errorf("double-sign evidence: invalid signature on header A")
+	}
+	if !ed25519.Verify(validatorPubKey[:], e.HeaderB.signingBytes(), e.Sig2[:]) {
+		return fmt.Errorf("double-sign evidence: invalid signature on header B")
+	}
+	return nil
+}
+
+func (e *DoubleSignEvidence) Offense() SlashingOffense { return OffenseDoubleSign }
+func (e *DoubleSignEvidence) Validator() [32]byte      { return e.HeaderA.Validator }
+func (e *DoubleSignEvidence) Height() uint64           { return e.HeaderA.Height }
+
+// WrongTurnEvidence proves a validator produced a block when it wasn't
+// their round-robin turn.
+type WrongTurnEvidence struct {
+	Header       BlockHeader
+	Sig          [64]byte
+	ExpectedTurn [32]byte // validator who should have produced this block
+}
+
+// Verify checks Header names validatorPubKey as proposer, that ExpectedTurn
+// names someone else, and that Header carries a valid signature from
+// validatorPubKey.
+func (e *WrongTurnEvidence) Verify(validatorPubKey [32]byte) error {
+	if e.Header.Validator != validatorPubKey {
+		return fmt.Errorf("wrong-turn evidence: header validator does not match accused validator")
+	}
+	if e.ExpectedTurn == validatorPubKey {
+		return fmt.Errorf("wrong-turn evidence: accused validator was in fact the expected turn")
+	}
+	if !ed25519.Verify(validatorPubKey[:], e.Header.signingBytes(), e.Sig[:]) {
+		return fmt.Errorf("wrong-turn evidence: invalid header signature")
+	}
+	return nil
+}
+
+func (e *WrongTurnEvidence) Offense() SlashingOffense { return OffenseWrongTurn }
+func (e *WrongTurnEvidence) Validator() [32]byte      { return e.Header.Validator }
+func (e *WrongTurnEvidence) Height() uint64           { return e.Header.Height }
+
+// InvalidBlockEvidence proves a validator's block computed the wrong state
+// root.
+type InvalidBlockEvidence struct {
+	Block             BlockHeader
+	Sig               [64]byte
+	ExpectedStateRoot [32]byte
+	ActualStateRoot   [32]byte
+}
+
+// Verify checks Block names validatorPubKey as proposer, that its actual
+// state root really does differ from the expected one, and that Block
+// carries a valid signature from validatorPubKey.
+func (e *InvalidBlockEvidence) Verify(validatorPubKey [32]byte) error {
+	if e.Block.Validator != validatorPubKey {
+		return fmt.Errorf("invalid-block evidence: block validator does not match accused validator")
+	}
+	if e.ActualStateRoot == e.ExpectedStateRoot {
+		return fmt.Errorf("invalid-block evidence: actual state root matches expected, block was not invalid")
+	}
+	if !ed25519.Verify(validatorPubKey[:], e.Block.signingBytes(), e.Sig[:]) {
+		return fmt.Errorf("invalid-block evidence: invalid block signature")
+	}
+	return nil
+}
+
+func (e *InvalidBlockEvidence) Offense() SlashingOffense { return OffenseInvalidBlock }
+func (e *InvalidBlockEvidence) Validator() [32]byte      { return e.Block.Validator }
+func (e *InvalidBlockEvidence) Height() uint64           { return e.Block.Height }
+
+// evidenceKind identifies which typed evidence struct an evidenceEnvelope
+// carries, for persistence and gossip.
+type evidenceKind uint8
+
+const (
+	evidenceKindDoubleSign evidenceKind = iota + 1
+	evidenceKindWrongTurn
+	evidenceKindInvalidBlock
+)
+
+// evidenceEnvelope is the on-wire/persisted form of any Evidence: a kind tag
+// plus the JSON-encoded concrete struct.
+type evidenceEnvelope struct {
+	Kind    evidenceKind    `json:"kind"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+func encodeEvidence(ev Evidence) ([]byte, error) {
+	var kind evidenceKind
+	switch ev.(type) {
+	case *DoubleSignEvidence:
+		kind = evidenceKindDoubleSign
+	case *WrongTurnEvidence:
+		kind = evidenceKindWrongTurn
+	case *InvalidBlockEvidence:
+		kind = evidenceKindInvalidBlock
+	default:
+		return nil, fmt.Errorf("evidence pool: unsupported evidence type %T", ev)
+	}
+
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return nil, fmt.Errorf("evidence pool: failed to encode payload: %w", err)
+	}
+
+	raw, err := json.Marshal(evidenceEnvelope{Kind: kind, Payload: payload})
+	if err != nil {
+		return nil, fmt.Errorf("evidence pool: failed to encode envelope: %w", err)
+	}
+	return raw, nil
+}
+
+func decodeEvidence(raw []byte) (Evidence, error) {
+	var env evidenceEnvelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, fmt.Errorf("evidence pool: failed to decode envelope: %w", err)
+	}
+
+	switch env.Kind {
+	case evidenceKindDoubleSign:
+		var ev DoubleSignEvidence
+		if err := json.Unmarshal(env.Payload, &ev); err != nil {
+			return nil, fmt.Errorf("evidence pool: failed to decode double-sign payload: %w", err)
+		}
+		return &ev, nil
+	case evidenceKindWrongTurn:
+		var ev WrongTurnEvidence
+		if err := json.Unmarshal(env.Payload, &ev); err != nil {
+			return nil, fmt.Errorf("evidence pool: failed to decode wrong-turn payload: %w", err)
+		}
+		return &ev, nil
+	case evidenceKindInvalidBlock:
+		var ev InvalidBlockEvidence
+		if err := json.Unmarshal(env.Payload, &ev); err != nil {
+			return nil, fmt.Errorf("evidence pool: failed to decode invalid-block payload: %w", err)
+		}
+		return &ev, nil
+	default:
+		return nil, fmt.Errorf("evidence pool: unknown evidence kind %d", env.Kind)
+	}
+}
+
+// evidenceRPCMethod is the libp2p RPC method peers deliver evidence to; see
+// p2p.Manager.RegisterHandler/SendRPC.
+const evidenceRPCMethod = "evidence.submit"
+
+// EvidencePoolConfig holds evidence pool parameters.
+type EvidencePoolConfig struct {
+	// MaxEvidenceAge bounds state growth: evidence more than this many
+	// blocks behind the pool's current height is rejected as stale,
+	// following Tendermint's evidence expiry model.
+	MaxEvidenceAge uint64
+}
+
+// DefaultEvidencePoolConfig returns sensible evidence pool defaults.
+func DefaultEvidencePoolConfig() EvidencePoolConfig {
+	return EvidencePoolConfig{
+		MaxEvidenceAge: 100000,
+	}
+}
+
+// EvidencePool accepts, verifies, deduplicates, and gossips slashing
+// evidence between nodes, and hands verified evidence to
+// SlashingManager.Slash. Persistence (SetStore) and gossip (SetGossip) are
+// both optional, wired by cmd/coinjectured once their dependencies exist.
+type EvidencePool struct {
+	config   EvidencePoolConfig
+	slashing *SlashingManager
+	log      *logger.Logger
+
+	store *state.StateManager
+	p2p   *p2p.Manager
+
+	heightMu sync.RWMutex
+	height   uint64
+
+	mu   sync.Mutex
+	seen map[[32]byte]struct{} // evidence hash -> dedup marker
+}
+
+// NewEvidencePool creates a new evidence pool. slashing receives verified
+// evidence via Slash.
+func NewEvidencePool(config EvidencePoolConfig, slashing *SlashingManager, log *logger.Logger) *EvidencePool {
+	return &EvidencePool{
+		config:   config,
+		slashing: slashing,
+		log:      log,
+		seen:     make(map[[32]byte]struct{}),
+	}
+}
+
+// SetStore wires sm as the persistent backing store for unprocessed
+// evidence, so it survives process restarts. Call LoadPersisted afterward
+// to replay anything left over from a previous run.
+func (p *EvidencePool) SetStore(sm *state.StateManager) {
+	p.store = sm
+}
+
+// SetGossip wires mgr as the libp2p transport this pool broadcasts newly
+// submitted evidence over, and registers the RPC handler peers deliver
+// evidence to.
+func (p *EvidencePool) SetGossip(mgr *p2p.Manager) {
+	p.p2p = mgr
+	mgr.RegisterHandler(evidenceRPCMethod, p.handleEvidenceRPC)
+}
+
+// SetHeight updates the pool's view of the current chain height, used to
+// enforce MaxEvidenceAge against evidence arriving over gossip.
+func (p *EvidencePool) SetHeight(height uint64) {
+	p.heightMu.Lock()
+	p.height = height
+	p.heightMu.Unlock()
+}
+
+func (p *EvidencePool) currentHeight() uint64 {
+	p.heightMu.RLock()
+	defer p.heightMu.RUnlock()
+	return p.height
+}
+
+// LoadPersisted replays evidence left unprocessed by a previous run (e.g.
+// the node crashed after persisting but before handing it to
+// SlashingManager.Slash). Call once, after SetStore, during startup.
+func (p *EvidencePool) LoadPersisted() error {
+	if p.store == nil {
+		return nil
+	}
+
+	pending, err := p.store.UnprocessedEvidence()
+	if err != nil {
+		return fmt.Errorf("evidence pool: failed to load persisted evidence: %w", err)
+	}
+
+	for _, pe := range pending {
+		ev, err := decodeEvidence(pe.Payload)
+		if err != nil {
+			p.log.WithError(err).Warn("Failed to decode persisted evidence, skipping")
+			continue
+		}
+		if err := p.Submit(ev, p.currentHeight(), true, "replay"); err != nil {
+			p.log.WithError(err).Warn("Failed to replay persisted evidence")
+		}
+	}
+
+	p.log.WithField("count", len(pending)).Info("Replayed persisted slashing evidence")
+	return nil
+}
+
+// Submit verifies ev, deduplicates it, persists it, gossips it to peers
+// (unless it just arrived via gossip), and hands it to
+// SlashingManager.Slash. currentHeight bounds it against MaxEvidenceAge.
+// reporter identifies who submitted ev (a peer ID or client IP) and is
+// passed through to Slash's per-reporter rate limiter.
+func (p *EvidencePool) Submit(ev Evidence, currentHeight uint64, fromGossip bool, reporter string) error {
+	validator := ev.Validator()
+
+	if err := ev.Verify(validator); err != nil {
+		return fmt.Errorf("evidence pool: verification failed: %w", err)
+	}
+
+	if currentHeight > ev.Height() && currentHeight-ev.Height() > p.config.MaxEvidenceAge {
+		return fmt.Errorf("evidence pool: evidence for height %d exceeds MaxEvidenceAge (current height %d)", ev.Height(), currentHeight)
+	}
+
+	raw, err := encodeEvidence(ev)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(raw)
+
+	p.mu.Lock()
+	if _, dup := p.seen[hash]; dup {
+		p.mu.Unlock()
+		return nil // already processed; gossip naturally re-delivers duplicates
+	}
+	p.seen[hash] = struct{}{}
+	p.mu.Unlock()
+
+	if p.store != nil {
+		if err := p.store.PutEvidence(hash, uint8(ev.Offense()), ev.Height(), raw); err != nil {
+			p.log.WithError(err).Warn("Failed to persist slashing evidence")
+		}
+	}
+
+	if !fromGossip {
+		p.broadcast(raw)
+	}
+
+	if err := p.slashing.Slash(validator, ev.Offense(), ev.Height(), raw, reporter); err != nil {
+		// Slash returns an error when the validator crosses BanThreshold;
+		// that's an expected terminal outcome, not a pool failure.
+		p.log.WithError(err).WithField("validator", fmt.Sprintf("%x", validator[:8])).Warn("Slash returned an error processing evidence")
+	}
+
+	if p.store != nil {
+		if err := p.store.MarkEvidenceProcessed(hash); err != nil {
+			p.log.WithError(err).Warn("Failed to mark slashing evidence processed")
+		}
+	}
+
+	p.log.WithFields(logger.Fields{
+		"validator": fmt.Sprintf("%x", validator[:8]),
+		"offense":   ev.Offense(),
+		"height":    ev.Height(),
+		"gossiped":  !fromGossip,
+	}).Warn("Slashing evidence accepted")
+
+	return nil
+}
+
+// SubmitEncoded decodes raw (an evidence envelope produced by encodeEvidence)
+// and submits it against the pool's current height (see SetHeight). This is
+// the entrypoint for callers outside the package, e.g. the API server's
+// evidence submission endpoint.
+func (p *EvidencePool) SubmitEncoded(raw []byte, fromGossip bool, reporter string) error {
+	ev, err := decodeEvidence(raw)
+	if err != nil {
+		return err
+	}
+	return p.Submit(ev, p.currentHeight(), fromGossip, reporter)
+}
+
+// handleEvidenceRPC is the RPC entrypoint peers deliver evidence to, e.g.
+// evidence they observed directly or relayed from a third peer.
+func (p *EvidencePool) handleEvidenceRPC(fromPeerID string, payload []byte) ([]byte, error) {
+	if err := p.SubmitEncoded(payload, true, fromPeerID); err != nil {
+		return nil, err
+	}
+	return []byte(`{"ok":true}`), nil
+}
+
+// broadcast floods raw (an encoded evidence envelope) to every known peer.
+// Evidence is rare relative to regular CID/tx gossip traffic, so a plain
+// flood is enough — no fan-out/backoff logic like the equilibrium gossip
+// path in gossip.go.
+func (p *EvidencePool) broadcast(raw []byte) {
+	if p.p2p == nil {
+		return
+	}
+	for _, peerID := range p.p2p.Peers() {
+		if _, err := p.p2p.SendRPC(peerID, evidenceRPCMethod, raw); err != nil {
+			p.log.WithError(err).WithField("peer_id", peerID).Debug("Evidence broadcast to peer failed")
+		}
+	}
+}
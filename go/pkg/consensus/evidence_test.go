@@ -0,0 +1,273 @@
+package consensus
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+func signedHeader(t *testing.T, pub ed25519.PublicKey, priv ed25519.PrivateKey, height uint64, hash byte) (BlockHeader, [64]byte) {
+	t.Helper()
+	var h BlockHeader
+	h.Height = height
+	h.Hash[0] = hash
+	copy(h.Validator[:], pub)
+
+	var sig [64]byte
+	copy(sig[:], ed25519.Sign(priv, h.signingBytes()))
+	return h, sig
+}
+
+func TestDoubleSignEvidenceVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+
+	headerA, sigA := signedHeader(t, pub, priv, 10, 0xAA)
+	headerB, sigB := signedHeader(t, pub, priv, 10, 0xBB)
+
+	ev := &DoubleSignEvidence{HeaderA: headerA, HeaderB: headerB, Sig1: sigA, Sig2: sigB}
+	if err := ev.Verify(validator); err != nil {
+		t.Fatalf("expected valid double-sign evidence to verify, got: %v", err)
+	}
+	if ev.Offense() != OffenseDoubleSign {
+		t.Fatalf("Offense() = %v, want OffenseDoubleSign", ev.Offense())
+	}
+	if ev.Validator() != validator {
+		t.Fatal("Validator() does not match the accused validator")
+	}
+	if ev.Height() != 10 {
+		t.Fatalf("Height() = %d, want 10", ev.Height())
+	}
+}
+
+func TestDoubleSignEvidenceRejectsSameHash(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+
+	headerA, sigA := signedHeader(t, pub, priv, 10, 0xAA)
+	headerB, sigB := signedHeader(t, pub, priv, 10, 0xAA)
+
+	ev := &DoubleSignEvidence{HeaderA: headerA, HeaderB: headerB, Sig1: sigA, Sig2: sigB}
+	if err := ev.Verify(validator); err == nil {
+		t.Fatal("expected identical headers (no equivocation) to fail verification")
+	}
+}
+
+func TestDoubleSignEvidenceRejectsDifferentHeights(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+
+	headerA, sigA := signedHeader(t, pub, priv, 10, 0xAA)
+	headerB, sigB := signedHeader(t, pub, priv, 11, 0xBB)
+
+	ev := &DoubleSignEvidence{HeaderA: headerA, HeaderB: headerB, Sig1: sigA, Sig2: sigB}
+	if err := ev.Verify(validator); err == nil {
+		t.Fatal("expected headers at different heights to fail verification")
+	}
+}
+
+func TestDoubleSignEvidenceRejectsBadSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+
+	headerA, _ := signedHeader(t, pub, priv, 10, 0xAA)
+	headerB, sigB := signedHeader(t, pub, priv, 10, 0xBB)
+
+	var badSigA [64]byte
+	copy(badSigA[:], ed25519.Sign(otherPriv, headerA.signingBytes()))
+
+	ev := &DoubleSignEvidence{HeaderA: headerA, HeaderB: headerB, Sig1: badSigA, Sig2: sigB}
+	if err := ev.Verify(validator); err == nil {
+		t.Fatal("expected a signature from the wrong key to fail verification")
+	}
+}
+
+func TestWrongTurnEvidenceVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator, expected [32]byte
+	copy(validator[:], pub)
+	expected[0] = 0x01
+
+	header, sig := signedHeader(t, pub, priv, 5, 0xCC)
+	ev := &WrongTurnEvidence{Header: header, Sig: sig, ExpectedTurn: expected}
+
+	if err := ev.Verify(validator); err != nil {
+		t.Fatalf("expected valid wrong-turn evidence to verify, got: %v", err)
+	}
+	if ev.Offense() != OffenseWrongTurn {
+		t.Fatalf("Offense() = %v, want OffenseWrongTurn", ev.Offense())
+	}
+}
+
+func TestWrongTurnEvidenceRejectsWhenExpectedTurnMatchesAccused(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+
+	header, sig := signedHeader(t, pub, priv, 5, 0xCC)
+	ev := &WrongTurnEvidence{Header: header, Sig: sig, ExpectedTurn: validator}
+
+	if err := ev.Verify(validator); err == nil {
+		t.Fatal("expected evidence naming the accused as its own expected turn to fail verification")
+	}
+}
+
+func TestInvalidBlockEvidenceVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+
+	header, sig := signedHeader(t, pub, priv, 7, 0xDD)
+	ev := &InvalidBlockEvidence{
+		Block:             header,
+		Sig:               sig,
+		ExpectedStateRoot: [32]byte{1},
+		ActualStateRoot:   [32]byte{2},
+	}
+
+	if err := ev.Verify(validator); err != nil {
+		t.Fatalf("expected valid invalid-block evidence to verify, got: %v", err)
+	}
+	if ev.Offense() != OffenseInvalidBlock {
+		t.Fatalf("Offense() = %v, want OffenseInvalidBlock", ev.Offense())
+	}
+}
+
+func TestInvalidBlockEvidenceRejectsMatchingRoots(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+
+	header, sig := signedHeader(t, pub, priv, 7, 0xDD)
+	ev := &InvalidBlockEvidence{
+		Block:             header,
+		Sig:               sig,
+		ExpectedStateRoot: [32]byte{9},
+		ActualStateRoot:   [32]byte{9},
+	}
+
+	if err := ev.Verify(validator); err == nil {
+		t.Fatal("expected matching expected/actual state roots to fail verification")
+	}
+}
+
+func TestEncodeDecodeEvidenceRoundTrip(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+
+	header, sig := signedHeader(t, pub, priv, 5, 0xCC)
+	original := &WrongTurnEvidence{Header: header, Sig: sig, ExpectedTurn: [32]byte{1}}
+
+	raw, err := encodeEvidence(original)
+	if err != nil {
+		t.Fatalf("encodeEvidence failed: %v", err)
+	}
+
+	decoded, err := decodeEvidence(raw)
+	if err != nil {
+		t.Fatalf("decodeEvidence failed: %v", err)
+	}
+	got, ok := decoded.(*WrongTurnEvidence)
+	if !ok {
+		t.Fatalf("decodeEvidence returned %T, want *WrongTurnEvidence", decoded)
+	}
+	if got.Header != original.Header || got.Sig != original.Sig || got.ExpectedTurn != original.ExpectedTurn {
+		t.Fatal("decoded evidence does not match the original")
+	}
+}
+
+func TestDecodeEvidenceRejectsUnknownKind(t *testing.T) {
+	if _, err := decodeEvidence([]byte(`{"kind":99,"payload":{}}`)); err == nil {
+		t.Fatal("expected an unknown evidence kind to fail decoding")
+	}
+}
+
+func TestEvidencePoolSubmitRejectsStaleEvidence(t *testing.T) {
+	sm := NewSlashingManager(DefaultSlashingConfig(), logger.NewLogger("error"))
+	pool := NewEvidencePool(EvidencePoolConfig{MaxEvidenceAge: 10}, sm, logger.NewLogger("error"))
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+	sm.RegisterValidator(validator, 0)
+
+	headerA, sigA := signedHeader(t, pub, priv, 1, 0xAA)
+	headerB, sigB := signedHeader(t, pub, priv, 1, 0xBB)
+	ev := &DoubleSignEvidence{HeaderA: headerA, HeaderB: headerB, Sig1: sigA, Sig2: sigB}
+
+	if err := pool.Submit(ev, 1000, false, "test"); err == nil {
+		t.Fatal("expected evidence older than MaxEvidenceAge to be rejected")
+	}
+}
+
+func TestEvidencePoolSubmitDeduplicates(t *testing.T) {
+	sm := NewSlashingManager(DefaultSlashingConfig(), logger.NewLogger("error"))
+	pool := NewEvidencePool(DefaultEvidencePoolConfig(), sm, logger.NewLogger("error"))
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var validator [32]byte
+	copy(validator[:], pub)
+	sm.RegisterValidator(validator, 0)
+
+	headerA, sigA := signedHeader(t, pub, priv, 1, 0xAA)
+	headerB, sigB := signedHeader(t, pub, priv, 1, 0xBB)
+	ev := &DoubleSignEvidence{HeaderA: headerA, HeaderB: headerB, Sig1: sigA, Sig2: sigB}
+
+	if err := pool.Submit(ev, 1, false, "test"); err != nil {
+		t.Fatalf("first submission failed: %v", err)
+	}
+	events := sm.GetSlashingEvents()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 slashing event after first submission, got %d", len(events))
+	}
+
+	if err := pool.Submit(ev, 1, false, "test"); err != nil {
+		t.Fatalf("duplicate submission should be a no-op, not an error: %v", err)
+	}
+	if got := sm.GetSlashingEvents(); len(got) != 1 {
+		t.Fatalf("expected duplicate evidence to not produce a second slashing event, got %d events", len(got))
+	}
+}
@@ -0,0 +1,84 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus/fees"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+// distributeFee credits a single transaction's fee to the validator, burn,
+// and treasury addresses. ApplyBlock calls this once per transaction,
+// after deducting the fee from the sender, so the split math lives here
+// instead of being duplicated per call site.
+//
+// Legacy transactions (CodecVersion=1, a flat GasPrice) use the Critical
+// Complex Equilibrium split across the whole fee, via fees.SplitFee.
+// EIP-1559 transactions route baseFeePerGas*GasLimit straight to burn —
+// that portion was never the validator's to earn, it's the protocol's
+// congestion price — and only the remaining tip (tx.EffectiveTip times
+// gas used) is split between validator and treasury via fees.SplitTip.
+func (b *BlockBuilder) distributeFee(validator [32]byte, tx *mempool.Transaction, baseFeePerGas uint64) error {
+	if !tx.IsDynamicFee() {
+		if tx.Fee == 0 {
+			return nil
+		}
+
+		validatorCut, burnCut, treasuryCut := fees.SplitFee(tx.Fee)
+
+		if err := b.stateManager.CreditBalance(validator, validatorCut); err != nil {
+			return fmt.Errorf("failed to credit validator fee cut: %w", err)
+		}
+		if err := b.stateManager.CreditBalance(fees.BurnAddress, burnCut); err != nil {
+			return fmt.Errorf("failed to credit burn fee cut: %w", err)
+		}
+		if err := b.stateManager.CreditBalance(fees.TreasuryAddress, treasuryCut); err != nil {
+			return fmt.Errorf("failed to credit treasury fee cut: %w", err)
+		}
+		b.reportFeeSplit(validator, validatorCut, burnCut, treasuryCut)
+		return nil
+	}
+
+	burnCut := baseFeePerGas * tx.GasLimit
+	tip := tx.EffectiveTip(baseFeePerGas) * tx.GasLimit
+	validatorCut, treasuryCut := fees.SplitTip(tip)
+	blockTips.Observe(float64(tip))
+
+	if err := b.stateManager.CreditBalance(fees.BurnAddress, burnCut); err != nil {
+		return fmt.Errorf("failed to credit burn fee cut: %w", err)
+	}
+	if err := b.stateManager.CreditBalance(validator, validatorCut); err != nil {
+		return fmt.Errorf("failed to credit validator fee cut: %w", err)
+	}
+	if err := b.stateManager.CreditBalance(fees.TreasuryAddress, treasuryCut); err != nil {
+		return fmt.Errorf("failed to credit treasury fee cut: %w", err)
+	}
+	b.reportFeeSplit(validator, validatorCut, burnCut, treasuryCut)
+
+	return nil
+}
+
+// SetMetricsSink wires sink to receive validator reward/burn/treasury
+// signals as distributeFee credits them going forward. Passing nil (the
+// default) disables reporting.
+func (b *BlockBuilder) SetMetricsSink(sink MetricsSink) {
+	b.metrics = sink
+}
+
+// reportFeeSplit forwards one transaction's fee split to b.metrics, if one
+// is wired. validatorLabel trims the address down to a hex prefix so the
+// validator-labeled counter doesn't carry a full 32-byte cardinality.
+func (b *BlockBuilder) reportFeeSplit(validator [32]byte, validatorCut, burnCut, treasuryCut uint64) {
+	if b.metrics == nil {
+		return
+	}
+	b.metrics.AddValidatorReward(validatorLabel(validator), validatorCut)
+	b.metrics.AddFeesBurned(burnCut)
+	b.metrics.AddTreasuryCredited(treasuryCut)
+}
+
+// validatorLabel renders the first 4 bytes of a validator address as hex,
+// for use as a bounded-cardinality Prometheus label value.
+func validatorLabel(addr [32]byte) string {
+	return fmt.Sprintf("%x", addr[:4])
+}
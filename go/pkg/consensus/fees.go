@@ -0,0 +1,67 @@
+package consensus
+
+import "github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+
+// EIP-1559 style base fee parameters: target gas usage per block is half
+// the block gas limit (elasticity multiplier of 2), and the base fee can
+// move at most 1/8th (12.5%) per block toward the level that would bring
+// usage back to target.
+const (
+	defaultBlockGasLimit        = 30_000_000
+	baseFeeElasticityMultiplier = 2
+	baseFeeMaxChangeDenominator = 8
+
+	// initialBaseFee seeds the rolling base fee before any block has been
+	// produced; DefaultSlashingConfig-style defaults elsewhere in this
+	// package follow the same "reasonable starting point, tunable later"
+	// convention.
+	initialBaseFee uint64 = 1000
+)
+
+// nextBaseFee computes the base fee for the block following one that used
+// gasUsed out of gasLimit, given that block's base fee as parentBaseFee.
+// gasLimit falls back to defaultBlockGasLimit when unset (zero config).
+func nextBaseFee(parentBaseFee, gasUsed, gasLimit uint64) uint64 {
+	if gasLimit == 0 {
+		gasLimit = defaultBlockGasLimit
+	}
+
+	target := gasLimit / baseFeeElasticityMultiplier
+	if target == 0 {
+		return parentBaseFee
+	}
+
+	if gasUsed == target {
+		return parentBaseFee
+	}
+
+	if gasUsed > target {
+		gasDelta := gasUsed - target
+		delta := (parentBaseFee * gasDelta) / target / baseFeeMaxChangeDenominator
+		if delta == 0 {
+			delta = 1
+		}
+		return parentBaseFee + delta
+	}
+
+	gasDelta := target - gasUsed
+	delta := (parentBaseFee * gasDelta) / target / baseFeeMaxChangeDenominator
+	if delta >= parentBaseFee {
+		return 0
+	}
+	return parentBaseFee - delta
+}
+
+// includableAtBaseFee reports whether tx may be added to a block being
+// built against baseFee. BlockBuilder.BuildBlock should skip (not evict —
+// base fee can fall, so a tx priced out of this block may clear a later
+// one) any candidate this returns false for. Legacy transactions have no
+// cap to check against MaxFeePerGas, so they're always includable here;
+// the mempool's own PriorityThreshold/MinEffectiveGasPrice floors are what
+// gate them.
+func includableAtBaseFee(tx *mempool.Transaction, baseFee uint64) bool {
+	if !tx.IsDynamicFee() {
+		return true
+	}
+	return tx.MaxFeePerGas >= baseFee
+}
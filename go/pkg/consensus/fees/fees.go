@@ -0,0 +1,65 @@
+// Package fees implements the Critical Complex Equilibrium fee split:
+// every transaction fee is divided between the block's validator, a burn
+// address, and the network treasury in fixed proportions.
+package fees
+
+// Basis points (parts per 10,000) for the Critical Complex Equilibrium
+// split: 41.42% validator, 29.29% burn, 29.29% treasury.
+const (
+	ValidatorBps = 4142
+	BurnBps      = 2929
+	TreasuryBps  = 2929
+	totalBps     = 10000
+)
+
+// BurnAddress and TreasuryAddress are the fixed fee sinks for the Critical
+// Complex Equilibrium split: burn is the all-zero address, treasury is the
+// all-ones address. Defined here rather than in the consensus package that
+// credits them so that anything computing or previewing a split — the
+// consensus engine, the test-fees utilities, bindings.SimulateTransaction —
+// agrees on the same two addresses without importing consensus.
+var (
+	BurnAddress     [32]byte
+	TreasuryAddress [32]byte
+)
+
+func init() {
+	for i := range TreasuryAddress {
+		TreasuryAddress[i] = 0xFF
+	}
+}
+
+// SplitFee divides fee into validator, burn, and treasury cuts using
+// integer basis-point math, so the three always sum back to exactly fee —
+// unlike `uint64(float64(fee) * 0.4142)`, which loses wei to rounding on
+// every call and drifts the total supply out of equilibrium over many
+// blocks. Burn and treasury are computed by truncating division; whatever
+// wei that truncation leaves on the table is assigned to validator, so
+// the remainder never needs its own accounting and long-run distribution
+// still converges on-target since the rounding error is bounded by one
+// wei per transaction.
+func SplitFee(fee uint64) (validator, burn, treasury uint64) {
+	burn = fee * BurnBps / totalBps
+	treasury = fee * TreasuryBps / totalBps
+	validator = fee - burn - treasury
+	return validator, burn, treasury
+}
+
+// tipBps is ValidatorBps+TreasuryBps: the denominator SplitTip renormalizes
+// against, since under EIP-1559 the burn cut is already taken by the base
+// fee before a tip exists to split.
+const tipBps = ValidatorBps + TreasuryBps
+
+// SplitTip divides an EIP-1559 tip (the portion of a fee above the base
+// fee) between validator and treasury, preserving the same 41.42:29.29
+// ratio SplitFee uses between those two cuts — but renormalized over just
+// the two of them, since the tip has no burn component of its own: the
+// base-fee portion of an EIP-1559 transaction's fee is burned directly by
+// the caller (fee_distribution.go's distributeFee), not run through this
+// function. As with SplitFee, truncation's leftover wei goes to validator
+// so the two cuts always sum back to exactly tip.
+func SplitTip(tip uint64) (validator, treasury uint64) {
+	treasury = tip * TreasuryBps / tipBps
+	validator = tip - treasury
+	return validator, treasury
+}
@@ -0,0 +1,62 @@
+package fees
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSplitFeeSumsToFee(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 10000; i++ {
+		fee := r.Uint64() % 1_000_000_000_000
+		validator, burn, treasury := SplitFee(fee)
+
+		if sum := validator + burn + treasury; sum != fee {
+			t.Fatalf("fee %d: splits sum to %d (validator=%d burn=%d treasury=%d)", fee, sum, validator, burn, treasury)
+		}
+	}
+}
+
+func TestSplitFeeZero(t *testing.T) {
+	validator, burn, treasury := SplitFee(0)
+	if validator != 0 || burn != 0 || treasury != 0 {
+		t.Fatalf("SplitFee(0) = (%d, %d, %d), want all zero", validator, burn, treasury)
+	}
+}
+
+func TestSplitFeeApproximatesTargetRatios(t *testing.T) {
+	const fee = 1_000_000_000
+	validator, burn, treasury := SplitFee(fee)
+
+	wantValidator := fee * ValidatorBps / totalBps
+	if diff := int64(validator) - int64(wantValidator); diff < -1 || diff > 1 {
+		t.Fatalf("validator cut %d too far from target %d", validator, wantValidator)
+	}
+	if burn != fee*BurnBps/totalBps {
+		t.Fatalf("burn cut %d does not match basis-point computation", burn)
+	}
+	if treasury != fee*TreasuryBps/totalBps {
+		t.Fatalf("treasury cut %d does not match basis-point computation", treasury)
+	}
+}
+
+func TestSplitTipSumsToTip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 10000; i++ {
+		tip := r.Uint64() % 1_000_000_000_000
+		validator, treasury := SplitTip(tip)
+
+		if sum := validator + treasury; sum != tip {
+			t.Fatalf("tip %d: splits sum to %d (validator=%d treasury=%d)", tip, sum, validator, treasury)
+		}
+	}
+}
+
+func TestSplitTipZero(t *testing.T) {
+	validator, treasury := SplitTip(0)
+	if validator != 0 || treasury != 0 {
+		t.Fatalf("SplitTip(0) = (%d, %d), want all zero", validator, treasury)
+	}
+}
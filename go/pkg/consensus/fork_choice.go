@@ -2,184 +2,694 @@
 package consensus
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"sync"
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/bindings"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/receipts"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/store/blockstore"
 )
 
 // ChainTip represents a potential chain head
 type ChainTip struct {
 	Block       *Block
 	Height      uint64
-	TotalWeight uint64 // For PoA, weight = height (all blocks have same difficulty)
+	TotalWeight int64 // GHOST subtree weight: sum of latest-message attestations under this block, inclusive
 }
 
-// ForkChoice manages competing chains and selects the canonical chain
+// ForkChoice selects the canonical chain using a GHOST/LMD rule (as in
+// Ethereum's LMD-GHOST): every authority has at most one live attestation
+// (its Latest Message), and each block's weight is the count of
+// attestations cast for it or any of its descendants. The canonical tip is
+// found by descending from genesis, at each fork picking the child with
+// the greatest subtree weight (ties broken by lower block hash). This is
+// sturdier against equivocating authorities and short reorgs than picking
+// the longest chain, since flipping the tip requires moving attestation
+// weight, not just producing one more block.
 type ForkChoice struct {
-	// Current canonical chain
-	canonicalTip *ChainTip
+	genesisHash [32]byte
 
-	// Competing chain tips (block_hash -> ChainTip)
-	competingTips map[[32]byte]*ChainTip
-
-	// Block cache for chain traversal (block_hash -> Block)
+	// Full known block tree. blockCache must retain every block from any
+	// live (unpruned) tip back to finalizedHash — see pruneLocked.
 	blockCache map[[32]byte]*Block
+	children   map[[32]byte][][32]byte // parent hash -> child hashes
+
+	// GHOST subtree weights: weight[h] = number of latest-messages cast
+	// for h or a descendant of h. Monotonically non-increasing along any
+	// root-to-leaf path, which is what makes pruneLocked's safety check
+	// valid for an entire subtree at once.
+	weight map[[32]byte]int64
+
+	// latestVotes is the LMD half of LMD-GHOST: each authority contributes
+	// at most one attestation, and a new one from the same authority
+	// replaces (not adds to) their previous vote.
+	latestVotes map[[32]byte][32]byte // authority -> block hash
+
+	// totalAuthorities bounds how much additional weight any subtree could
+	// still accumulate; see pruneLocked. Updated via UpdateAuthorityCount
+	// as the PoA validator set changes.
+	totalAuthorities int
+
+	// canonicalHash/canonicalTip cache the result of the last descent from
+	// genesis, recomputed whenever a vote changes a subtree's weight.
+	canonicalHash [32]byte
+	canonicalTip  *ChainTip
+
+	// finalizedHash is the block pruneLocked treats as an irreversible
+	// checkpoint: blockCache always retains the path from finalizedHash to
+	// every live tip, and only subtrees branching off at or below it are
+	// pruning candidates. AddBlock rejects anything that doesn't descend
+	// from it (see descendsFromFinalizedLocked).
+	finalizedHash [32]byte
+
+	// finality configures the automatic finality rule applied after every
+	// vote (see checkAutoFinalityLocked). Set via SetFinalityConfig;
+	// Confirmations == 0 disables automatic finalization, leaving Finalize
+	// a manual/external call as before.
+	finality FinalityConfig
+
+	// blocks persists every block that becomes canonical (and removes one
+	// that stops being canonical after a reorg); nil until SetBlockStore is
+	// called, in which case fork choice behaves exactly as before.
+	blocks *blockstore.Store
+
+	// receipts persists the receipts (and combined log bloom) derived from
+	// each block that becomes canonical, alongside blocks; nil until
+	// SetReceiptsStore is called.
+	receipts *receipts.Store
 
 	log  *logger.Logger
 	lock sync.RWMutex
 }
 
-// NewForkChoice creates a new fork choice manager
-func NewForkChoice(genesisBlock *Block, log *logger.Logger) *ForkChoice {
+// FinalityConfig configures the simple finality rule checkAutoFinalityLocked
+// applies after every attestation: a block becomes finalized once it is
+// Confirmations blocks behind the canonical tip and its GHOST subtree
+// weight has reached a ThresholdNum/ThresholdDen supermajority of the PoA
+// set, mirroring "canonical for N confirmations by >=2/3 of validators".
+type FinalityConfig struct {
+	Confirmations              uint64
+	ThresholdNum, ThresholdDen int64
+}
+
+// DefaultFinalityConfig returns the standard 2/3-of-validators rule, waiting
+// 8 confirmations before a block is eligible so a handful of straggling
+// votes can't finalize a block the rest of the set hasn't seen yet.
+func DefaultFinalityConfig() FinalityConfig {
+	return FinalityConfig{Confirmations: 8, ThresholdNum: 2, ThresholdDen: 3}
+}
+
+// NewForkChoice creates a new fork choice manager rooted at genesisBlock.
+// authorityCount is the current PoA validator set size, used to bound how
+// much weight a competing subtree could still gain before it's safe to
+// prune (see UpdateAuthorityCount for validator set changes).
+func NewForkChoice(genesisBlock *Block, authorityCount int, log *logger.Logger) *ForkChoice {
 	fc := &ForkChoice{
-		canonicalTip: &ChainTip{
-			Block:       genesisBlock,
-			Height:      0,
-			TotalWeight: 0,
-		},
-		competingTips: make(map[[32]byte]*ChainTip),
-		blockCache:    make(map[[32]byte]*Block),
-		log:           log,
+		blockCache:       make(map[[32]byte]*Block),
+		children:         make(map[[32]byte][][32]byte),
+		weight:           make(map[[32]byte]int64),
+		latestVotes:      make(map[[32]byte][32]byte),
+		totalAuthorities: authorityCount,
+		finality:         DefaultFinalityConfig(),
+		log:              log,
 	}
 
-	// Cache genesis block
 	if genesisBlock != nil {
+		fc.genesisHash = genesisBlock.BlockHash
+		fc.finalizedHash = genesisBlock.BlockHash
 		fc.blockCache[genesisBlock.BlockHash] = genesisBlock
+		fc.weight[genesisBlock.BlockHash] = 0
+		fc.canonicalHash = genesisBlock.BlockHash
+		fc.canonicalTip = &ChainTip{Block: genesisBlock, Height: 0, TotalWeight: 0}
 	}
 
 	return fc
 }
 
-// AddBlock adds a block to fork choice consideration
-// Returns true if this block becomes the new canonical tip (triggering reorg)
+// UpdateAuthorityCount updates the PoA validator set size used to bound
+// prune safety (see pruneLocked). Call whenever the validator set changes
+// (a new validator registers, one is banned, etc.).
+func (fc *ForkChoice) UpdateAuthorityCount(n int) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	fc.totalAuthorities = n
+}
+
+// SetBlockStore wires a persistent blockstore.Store into fork choice: every
+// time the canonical tip changes, the newly-canonical blocks are written
+// and any blocks that fell off the canonical chain are deleted (see
+// recomputeCanonicalLocked). Passing nil disables persistence.
+func (fc *ForkChoice) SetBlockStore(blocks *blockstore.Store) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	fc.blocks = blocks
+}
+
+// SetReceiptsStore wires a persistent receipts.Store into fork choice:
+// every time the canonical tip changes, receipts (and the combined log
+// bloom) for the newly-canonical blocks are derived and written, and those
+// for blocks that fell off the canonical chain are deleted. Passing nil
+// disables receipt persistence.
+func (fc *ForkChoice) SetReceiptsStore(store *receipts.Store) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	fc.receipts = store
+}
+
+// SetFinalityConfig overrides the automatic finality rule (see
+// FinalityConfig). Passing the zero value disables automatic finalization;
+// Finalize remains callable directly either way.
+func (fc *ForkChoice) SetFinalityConfig(cfg FinalityConfig) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	fc.finality = cfg
+}
+
+// ErrConflictsWithFinalized is returned by AddBlock for a block whose chain
+// does not descend from the current finalized root — accepting it would
+// require reverting an irreversible checkpoint.
+var ErrConflictsWithFinalized = fmt.Errorf("fork choice: block conflicts with finalized checkpoint")
+
+// AddBlock adds a block to fork choice consideration. Producing a block is
+// itself treated as the producing validator's latest attestation for that
+// chain (see RecordAttestation), so adding a block can shift the canonical
+// tip on its own, without a separate vote. Returns true if the canonical
+// tip changed.
 func (fc *ForkChoice) AddBlock(block *Block) (bool, error) {
 	fc.lock.Lock()
 	defer fc.lock.Unlock()
 
-	// Check if we already have this block
 	if _, exists := fc.blockCache[block.BlockHash]; exists {
 		fc.log.WithField("block_hash", fmt.Sprintf("%x", block.BlockHash[:8])).Debug("Block already in fork choice")
 		return false, nil
 	}
 
-	// Validate block
 	if !block.IsValid() {
 		return false, fmt.Errorf("invalid block")
 	}
 
-	// Cache block
-	fc.blockCache[block.BlockHash] = block
+	if block.ParentHash != fc.genesisHash {
+		if _, exists := fc.blockCache[block.ParentHash]; !exists {
+			return false, fmt.Errorf("failed to find parent block: %x", block.ParentHash[:8])
+		}
+	}
 
-	// Verify parent exists
-	if _, err := fc.findChainTip(block.ParentHash); err != nil {
-		return false, fmt.Errorf("failed to find parent chain tip: %w", err)
+	if !fc.descendsFromFinalizedLocked(block.ParentHash) {
+		return false, ErrConflictsWithFinalized
 	}
 
-	newTip := &ChainTip{
-		Block:       block,
-		Height:      block.BlockNumber,
-		TotalWeight: block.BlockNumber, // For PoA, weight = height
+	fc.blockCache[block.BlockHash] = block
+	fc.children[block.ParentHash] = append(fc.children[block.ParentHash], block.BlockHash)
+	if _, exists := fc.weight[block.BlockHash]; !exists {
+		fc.weight[block.BlockHash] = 0
 	}
 
-	// Add to competing tips
-	fc.competingTips[block.BlockHash] = newTip
+	oldCanonical := fc.canonicalHash
 
-	// Check if this is a better chain than current canonical
-	shouldReorg := fc.shouldReorganize(newTip)
+	if err := fc.recordAttestationLocked(block.Validator, block.BlockHash); err != nil {
+		return false, err
+	}
 
-	if shouldReorg {
+	reorged := fc.canonicalHash != oldCanonical
+	if reorged {
 		fc.log.WithFields(logger.Fields{
-			"old_height": fc.canonicalTip.Height,
-			"new_height": newTip.Height,
-			"block_hash": fmt.Sprintf("%x", block.BlockHash[:8]),
+			"old_hash":         fmt.Sprintf("%x", oldCanonical[:8]),
+			"new_hash":         fmt.Sprintf("%x", fc.canonicalHash[:8]),
+			"new_height":       fc.canonicalTip.Height,
+			"canonical_weight": fc.canonicalTip.TotalWeight,
 		}).Info("Fork choice: selecting new canonical chain")
-
-		fc.canonicalTip = newTip
-
-		// Clean up old competing tips that are now clearly not canonical
-		fc.pruneCompetingTips()
 	} else {
 		fc.log.WithFields(logger.Fields{
-			"canonical_height": fc.canonicalTip.Height,
-			"block_height":     newTip.Height,
-			"block_hash":       fmt.Sprintf("%x", block.BlockHash[:8]),
+			"canonical_hash": fmt.Sprintf("%x", fc.canonicalHash[:8]),
+			"block_hash":     fmt.Sprintf("%x", block.BlockHash[:8]),
 		}).Debug("Fork choice: keeping current canonical chain")
 	}
 
-	return shouldReorg, nil
+	fc.pruneLocked()
+
+	return reorged, nil
 }
 
-// shouldReorganize determines if we should switch to a new chain
-// Fork choice rule: Longest valid chain (highest block number)
-func (fc *ForkChoice) shouldReorganize(newTip *ChainTip) bool {
-	// Rule 1: New chain must be longer (or equal but with better hash)
-	if newTip.Height > fc.canonicalTip.Height {
-		return true
+// ErrWitnessStateRootMismatch is returned by AddBlockWithWitness when
+// replaying a block's transactions against its witness bundle produces a
+// different root than the block claims, meaning either the block or the
+// witness is wrong.
+var ErrWitnessStateRootMismatch = fmt.Errorf("fork choice: witness does not support claimed state root")
+
+// AddBlockWithWitness is AddBlock's stateless-verification counterpart: a
+// light node that doesn't hold the full account database can pass the
+// witness bundle it received alongside block and have fork choice confirm
+// the block is internally consistent (its transactions, replayed against
+// the witness, actually produce its StateRoot) before accepting it, the
+// same way a full node trusts the result of applying the block to live
+// state. witness is optional — a nil witness falls back to AddBlock's
+// ordinary accept-on-validator-authority path.
+func (fc *ForkChoice) AddBlockWithWitness(block *Block, witness *bindings.Witness) (bool, error) {
+	if witness != nil {
+		txs := make([]bindings.Transaction, len(block.Transactions))
+		for i, tx := range block.Transactions {
+			txs[i] = bindings.Transaction{
+				From:     tx.From,
+				To:       tx.To,
+				Amount:   tx.Amount,
+				Nonce:    tx.Nonce,
+				GasLimit: tx.GasLimit,
+				GasPrice: tx.GasPrice,
+			}
+		}
+
+		stateRoot, err := bindings.VerifyBlockStateless(txs, witness)
+		if err != nil {
+			return false, fmt.Errorf("stateless verification failed: %w", err)
+		}
+		if stateRoot != block.StateRoot {
+			return false, ErrWitnessStateRootMismatch
+		}
 	}
 
-	// Rule 2: If same height, use block hash as tiebreaker (lower hash wins)
-	if newTip.Height == fc.canonicalTip.Height {
-		for i := 0; i < 32; i++ {
-			if newTip.Block.BlockHash[i] < fc.canonicalTip.Block.BlockHash[i] {
-				return true
-			} else if newTip.Block.BlockHash[i] > fc.canonicalTip.Block.BlockHash[i] {
-				return false
+	return fc.AddBlock(block)
+}
+
+// RecordAttestation registers authority's vote for blockHash as their
+// Latest Message (LMD), replacing any earlier vote. Replacing a vote
+// decrements the old vote's path weight before applying the new one, so
+// an authority can never contribute weight to two branches at once.
+func (fc *ForkChoice) RecordAttestation(authority [32]byte, blockHash [32]byte) error {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+
+	if err := fc.recordAttestationLocked(authority, blockHash); err != nil {
+		return err
+	}
+	fc.pruneLocked()
+	return nil
+}
+
+// recordAttestationLocked implements RecordAttestation; called with lock held.
+func (fc *ForkChoice) recordAttestationLocked(authority [32]byte, blockHash [32]byte) error {
+	if blockHash != fc.genesisHash {
+		if _, exists := fc.blockCache[blockHash]; !exists {
+			return fmt.Errorf("fork choice: cannot attest to unknown block %x", blockHash[:8])
+		}
+	}
+
+	if old, exists := fc.latestVotes[authority]; exists {
+		if old == blockHash {
+			return nil // already this authority's latest vote; nothing to move
+		}
+		fc.applyVoteDeltaLocked(old, -1)
+	}
+
+	fc.latestVotes[authority] = blockHash
+	fc.applyVoteDeltaLocked(blockHash, 1)
+
+	fc.recomputeCanonicalLocked()
+	fc.checkAutoFinalityLocked()
+	return nil
+}
+
+// descendsFromFinalizedLocked reports whether hash (a block already in
+// blockCache, or genesisHash) is finalizedHash itself or a descendant of
+// it. AddBlock uses this to reject chains that branched off at or before
+// the finalized checkpoint, per ErrConflictsWithFinalized.
+func (fc *ForkChoice) descendsFromFinalizedLocked(hash [32]byte) bool {
+	for {
+		if hash == fc.finalizedHash {
+			return true
+		}
+		if hash == fc.genesisHash {
+			return false
+		}
+		block, exists := fc.blockCache[hash]
+		if !exists {
+			return false // ancestor already pruned; can't have been finalized's descendant
+		}
+		hash = block.ParentHash
+	}
+}
+
+// applyVoteDeltaLocked adds delta to the weight of blockHash and every one
+// of its ancestors up to genesis, implementing the "subtree weight" in
+// GHOST: a vote for a block counts toward that block and everything
+// between it and the root.
+func (fc *ForkChoice) applyVoteDeltaLocked(blockHash [32]byte, delta int64) {
+	hash := blockHash
+	for {
+		fc.weight[hash] += delta
+		if hash == fc.genesisHash {
+			return
+		}
+		block, exists := fc.blockCache[hash]
+		if !exists {
+			return // ancestor already pruned; nothing further to update
+		}
+		hash = block.ParentHash
+	}
+}
+
+// recomputeCanonicalLocked descends from genesis, at each fork following
+// the child with the greatest subtree weight (ties broken by lower block
+// hash), and caches the result as canonicalHash/canonicalTip.
+func (fc *ForkChoice) recomputeCanonicalLocked() {
+	previousCanonical := fc.canonicalHash
+
+	hash := fc.genesisHash
+	for {
+		children := fc.children[hash]
+		if len(children) == 0 {
+			break
+		}
+
+		best := children[0]
+		for _, candidate := range children[1:] {
+			if fc.weight[candidate] > fc.weight[best] ||
+				(fc.weight[candidate] == fc.weight[best] && lessHash(candidate, best)) {
+				best = candidate
 			}
 		}
+		hash = best
 	}
 
-	return false
+	block := fc.blockCache[hash]
+	fc.canonicalHash = hash
+	fc.canonicalTip = &ChainTip{
+		Block:       block,
+		Height:      block.BlockNumber,
+		TotalWeight: fc.weight[hash],
+	}
+
+	if hash != previousCanonical {
+		fc.syncBlockStoreLocked(previousCanonical, hash)
+	}
+}
+
+// syncBlockStoreLocked brings fc.blocks (and fc.receipts, if wired) in line
+// with a canonical tip change from oldCanonical to newCanonical: every
+// block on the new canonical chain back to their common ancestor is
+// written, and every block on the old canonical chain back to that same
+// ancestor (now off the canonical chain) is deleted. No-op if neither store
+// is wired in.
+func (fc *ForkChoice) syncBlockStoreLocked(oldCanonical, newCanonical [32]byte) {
+	if fc.blocks == nil && fc.receipts == nil {
+		return
+	}
+
+	onOldChain := make(map[[32]byte]bool)
+	for hash := oldCanonical; ; {
+		onOldChain[hash] = true
+		if hash == fc.genesisHash {
+			break
+		}
+		block, exists := fc.blockCache[hash]
+		if !exists {
+			break
+		}
+		hash = block.ParentHash
+	}
+
+	var newChain [][32]byte
+	commonAncestor := newCanonical
+	for {
+		if onOldChain[commonAncestor] {
+			break
+		}
+		newChain = append(newChain, commonAncestor)
+		if commonAncestor == fc.genesisHash {
+			break
+		}
+		block, exists := fc.blockCache[commonAncestor]
+		if !exists {
+			break
+		}
+		commonAncestor = block.ParentHash
+	}
+
+	for hash := oldCanonical; hash != commonAncestor; {
+		block, exists := fc.blockCache[hash]
+		if !exists {
+			break
+		}
+		if fc.blocks != nil {
+			if err := fc.blocks.DeleteBlock(hash); err != nil {
+				fc.log.WithError(err).Warn("Failed to remove superseded block from blockstore")
+			}
+		}
+		if fc.receipts != nil {
+			if err := fc.receipts.DeleteBlockReceipts(hash); err != nil {
+				fc.log.WithError(err).Warn("Failed to remove superseded block's receipts")
+			}
+		}
+		hash = block.ParentHash
+	}
+
+	for i := len(newChain) - 1; i >= 0; i-- {
+		hash := newChain[i]
+		block := fc.blockCache[hash]
+		if fc.blocks != nil {
+			if err := fc.blocks.PutBlock(toStoreBlock(block), nil); err != nil {
+				fc.log.WithError(err).Warn("Failed to persist canonical block to blockstore")
+			}
+		}
+		if fc.receipts != nil {
+			if err := fc.receipts.PutBlockReceipts(block.BlockHash, block.BlockNumber, toReceipts(block)); err != nil {
+				fc.log.WithError(err).Warn("Failed to persist canonical block's receipts")
+			}
+		}
+	}
 }
 
-// findChainTip finds the chain tip for a given parent hash
-func (fc *ForkChoice) findChainTip(parentHash [32]byte) (*ChainTip, error) {
-	// Check if parent is in competing tips
-	if tip, exists := fc.competingTips[parentHash]; exists {
-		return tip, nil
+// toStoreBlock converts a consensus Block into the blockstore package's own
+// persisted shape, so blockstore need not depend on consensus internals.
+func toStoreBlock(block *Block) *blockstore.Block {
+	txs := make([]blockstore.TxRecord, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		txs[i] = blockstore.TxRecord{
+			Hash:    tx.Hash,
+			From:    tx.From,
+			To:      tx.To,
+			Amount:  tx.Amount,
+			Nonce:   tx.Nonce,
+			GasUsed: tx.GasLimit,
+			Fee:     tx.Fee,
+		}
 	}
 
-	// Check if parent is the canonical tip
-	if fc.canonicalTip.Block.BlockHash == parentHash {
-		return fc.canonicalTip, nil
+	return &blockstore.Block{
+		BlockHash:    block.BlockHash,
+		ParentHash:   block.ParentHash,
+		BlockNumber:  block.BlockNumber,
+		Validator:    block.Validator,
+		StateRoot:    block.StateRoot,
+		Timestamp:    block.Timestamp,
+		GasUsed:      block.GasUsed,
+		Transactions: txs,
 	}
+}
 
-	// Check if parent is in block cache
-	if block, exists := fc.blockCache[parentHash]; exists {
-		return &ChainTip{
-			Block:       block,
-			Height:      block.BlockNumber,
-			TotalWeight: block.BlockNumber,
-		}, nil
+// fromStoreBlock converts a persisted blockstore.Block back into a
+// consensus Block, the inverse of toStoreBlock. It's used only to
+// rehydrate ForkChoice from disk on restart (see Engine.rehydrateFromDisk),
+// so the loss of per-transaction fields blockstore doesn't retain (gas
+// price, signature, raw tx data) is fine: those were only needed to build
+// and execute the block once, not to replay it as an already-accepted
+// part of the canonical chain.
+func fromStoreBlock(stored *blockstore.Block) *Block {
+	txs := make([]mempool.Transaction, len(stored.Transactions))
+	for i, tx := range stored.Transactions {
+		txs[i] = mempool.Transaction{
+			Hash:     tx.Hash,
+			From:     tx.From,
+			To:       tx.To,
+			Amount:   tx.Amount,
+			Nonce:    tx.Nonce,
+			GasLimit: tx.GasUsed,
+			Fee:      tx.Fee,
+		}
 	}
 
-	return nil, fmt.Errorf("parent block not found: %x", parentHash[:8])
+	return &Block{
+		BlockHash:    stored.BlockHash,
+		ParentHash:   stored.ParentHash,
+		BlockNumber:  stored.BlockNumber,
+		Validator:    stored.Validator,
+		StateRoot:    stored.StateRoot,
+		Timestamp:    stored.Timestamp,
+		GasUsed:      stored.GasUsed,
+		Transactions: txs,
+	}
 }
 
-// pruneCompetingTips removes old tips that are clearly not canonical
-// Keeps tips within 10 blocks of canonical height
-func (fc *ForkChoice) pruneCompetingTips() {
-	const maxDepth = 10
+// transferLogTopic is topic[0] of the synthetic "Transfer" log toReceipts
+// emits for every transaction, the sha256 analogue of an EVM event
+// signature hash.
+var transferLogTopic = sha256.Sum256([]byte("Transfer(bytes32,bytes32,uint64)"))
+
+// toReceipts derives a receipts.Receipt per transaction in block, for
+// persisting alongside it once it becomes canonical (see
+// ForkChoice.SetReceiptsStore). There's no contract execution in this
+// chain yet (see pkg/execution.Simulator's doc comment), so every
+// transaction that made it into a block is treated as successful and its
+// only log is a synthetic transfer event — the same caveat
+// execution.Simulator.Simulate already makes about Logs.
+func toReceipts(block *Block) []receipts.Receipt {
+	out := make([]receipts.Receipt, len(block.Transactions))
+
+	var cumulativeGasUsed uint64
+	for i, tx := range block.Transactions {
+		cumulativeGasUsed += tx.GasLimit
+
+		out[i] = receipts.Receipt{
+			TxHash:            tx.Hash,
+			BlockHash:         block.BlockHash,
+			BlockNumber:       block.BlockNumber,
+			TxIndex:           i,
+			Status:            true,
+			GasUsed:           tx.GasLimit,
+			CumulativeGasUsed: cumulativeGasUsed,
+			Logs: []receipts.Log{{
+				Address: tx.To,
+				Topics:  [][32]byte{transferLogTopic, tx.From, tx.To},
+			}},
+		}
+	}
 
-	for hash, tip := range fc.competingTips {
-		if tip.Height+maxDepth < fc.canonicalTip.Height {
-			delete(fc.competingTips, hash)
-			fc.log.WithFields(logger.Fields{
-				"block_hash":  fmt.Sprintf("%x", hash[:8]),
-				"block_height": tip.Height,
-			}).Debug("Pruned old competing tip")
+	return out
+}
+
+// lessHash reports whether a sorts before b lexicographically, used as the
+// fork choice's tiebreaker between equally-weighted children.
+func lessHash(a, b [32]byte) bool {
+	for i := 0; i < 32; i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
 		}
 	}
+	return false
+}
+
+// Finalize marks blockHash, which must be an ancestor of (or equal to) the
+// current canonical tip, as an irreversible checkpoint: pruneLocked may
+// then reclaim any branch that forked off at or before it, and AddBlock
+// rejects any future block that doesn't descend from it (see
+// descendsFromFinalizedLocked). checkAutoFinalityLocked calls this
+// automatically once FinalityConfig's rule is satisfied; this method stays
+// exported for callers that want to finalize on their own criteria (e.g. a
+// future BFT engine's quorum certificates).
+func (fc *ForkChoice) Finalize(blockHash [32]byte) error {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
 
-	// Prune old blocks from cache (keep 100 blocks)
-	if len(fc.blockCache) > 100 {
-		// Simple pruning: remove blocks older than canonical - 50
-		for hash, block := range fc.blockCache {
-			if block.BlockNumber+50 < fc.canonicalTip.Height {
-				delete(fc.blockCache, hash)
+	hash := fc.canonicalHash
+	for {
+		if hash == blockHash {
+			fc.finalizeLocked(blockHash)
+			return nil
+		}
+		if hash == fc.genesisHash {
+			return fmt.Errorf("fork choice: %x is not an ancestor of the canonical tip", blockHash[:8])
+		}
+		hash = fc.blockCache[hash].ParentHash
+	}
+}
+
+// finalizeLocked implements Finalize's effect once blockHash has already
+// been confirmed as an ancestor of (or equal to) the canonical tip.
+func (fc *ForkChoice) finalizeLocked(blockHash [32]byte) {
+	fc.finalizedHash = blockHash
+	fc.pruneLocked()
+}
+
+// checkAutoFinalityLocked implements FinalityConfig's rule: once the block
+// Confirmations behind the canonical tip has accumulated a
+// ThresholdNum/ThresholdDen supermajority of totalAuthorities' vote weight,
+// it's finalized. Called after every vote, so finality advances as soon as
+// it's safe to rather than waiting for an external caller to notice.
+func (fc *ForkChoice) checkAutoFinalityLocked() {
+	if fc.finality.Confirmations == 0 || fc.totalAuthorities == 0 {
+		return
+	}
+	if fc.canonicalTip == nil || fc.canonicalTip.Height < fc.finality.Confirmations {
+		return
+	}
+
+	targetHeight := fc.canonicalTip.Height - fc.finality.Confirmations
+	if finalizedBlock, exists := fc.blockCache[fc.finalizedHash]; exists && finalizedBlock.BlockNumber >= targetHeight {
+		return // already finalized at or past the candidate depth
+	}
+
+	hash := fc.canonicalHash
+	for {
+		block, exists := fc.blockCache[hash]
+		if !exists {
+			return
+		}
+		if block.BlockNumber == targetHeight {
+			break
+		}
+		if hash == fc.genesisHash {
+			return
+		}
+		hash = block.ParentHash
+	}
+
+	if fc.weight[hash]*fc.finality.ThresholdDen >= int64(fc.totalAuthorities)*fc.finality.ThresholdNum {
+		fc.finalizeLocked(hash)
+	}
+}
+
+// pruneLocked reclaims any branch off the finalized-to-canonical mainline
+// whose subtree weight could never catch up to the canonical tip's, even
+// if every authority that hasn't yet cast a vote switched to it. This is a
+// conservative bound (it doesn't account for already-voted authorities
+// changing their mind), so it only ever prunes branches that are
+// decisively behind, never ones merely trailing the canonical tip.
+func (fc *ForkChoice) pruneLocked() {
+	mainline := make(map[[32]byte]bool)
+	for hash := fc.canonicalHash; ; {
+		mainline[hash] = true
+		if hash == fc.finalizedHash {
+			break
+		}
+		hash = fc.blockCache[hash].ParentHash
+	}
+
+	unvoted := int64(fc.totalAuthorities - len(fc.latestVotes))
+	if unvoted < 0 {
+		unvoted = 0
+	}
+	canonicalWeight := fc.weight[fc.canonicalHash]
+
+	var visit func(hash [32]byte)
+	visit = func(hash [32]byte) {
+		for _, child := range fc.children[hash] {
+			if mainline[child] {
+				visit(child)
+				continue
+			}
+			if fc.weight[child]+unvoted < canonicalWeight {
+				fc.deleteSubtreeLocked(child)
+			} else {
+				visit(child)
 			}
 		}
 	}
+	visit(fc.finalizedHash)
+}
+
+// deleteSubtreeLocked removes hash and everything beneath it from
+// blockCache/children/weight. Safe to call on a subtree whose vote(s), if
+// any, belong to authorities whose weight is already folded into the
+// parent's count (see applyVoteDeltaLocked), so no live vote is lost.
+func (fc *ForkChoice) deleteSubtreeLocked(hash [32]byte) {
+	for _, child := range fc.children[hash] {
+		fc.deleteSubtreeLocked(child)
+	}
+	delete(fc.children, hash)
+	delete(fc.blockCache, hash)
+	delete(fc.weight, hash)
 }
 
 // GetCanonicalTip returns the current canonical chain tip
@@ -189,6 +699,24 @@ func (fc *ForkChoice) GetCanonicalTip() *ChainTip {
 	return fc.canonicalTip
 }
 
+// GetFinalized returns the current finalized checkpoint, the irreversible
+// ancestor pruneLocked and AddBlock's descent check are anchored to. Nil
+// only if called before a genesis block has been set.
+func (fc *ForkChoice) GetFinalized() *ChainTip {
+	fc.lock.RLock()
+	defer fc.lock.RUnlock()
+
+	block, exists := fc.blockCache[fc.finalizedHash]
+	if !exists {
+		return nil
+	}
+	return &ChainTip{
+		Block:       block,
+		Height:      block.BlockNumber,
+		TotalWeight: fc.weight[fc.finalizedHash],
+	}
+}
+
 // GetCanonicalBlock returns the current canonical chain head block
 func (fc *ForkChoice) GetCanonicalBlock() *Block {
 	fc.lock.RLock()
@@ -241,10 +769,11 @@ func (fc *ForkChoice) GetStats() map[string]interface{} {
 	defer fc.lock.RUnlock()
 
 	return map[string]interface{}{
-		"canonical_height":  fc.canonicalTip.Height,
-		"canonical_hash":    fmt.Sprintf("%x", fc.canonicalTip.Block.BlockHash[:8]),
-		"competing_tips":    len(fc.competingTips),
-		"cached_blocks":     len(fc.blockCache),
-		"canonical_weight":  fc.canonicalTip.TotalWeight,
+		"canonical_height": fc.canonicalTip.Height,
+		"canonical_hash":   fmt.Sprintf("%x", fc.canonicalTip.Block.BlockHash[:8]),
+		"canonical_weight": fc.canonicalTip.TotalWeight,
+		"cached_blocks":    len(fc.blockCache),
+		"live_authorities": len(fc.latestVotes),
+		"finalized_hash":   fmt.Sprintf("%x", fc.finalizedHash[:8]),
 	}
 }
@@ -0,0 +1,168 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+func testBlock(hash, parent byte, number uint64) *Block {
+	var h, p [32]byte
+	h[0] = hash
+	p[0] = parent
+	return &Block{
+		BlockHash:    h,
+		ParentHash:   p,
+		BlockNumber:  number,
+		Transactions: []mempool.Transaction{},
+	}
+}
+
+// linkTestBlock inserts block directly into fc's block cache and child
+// index, bypassing AddBlock (and its IsValid() precondition — Block has no
+// concrete validity logic defined yet in this tree), so these tests can
+// exercise the GHOST weight/canonical-descent machinery in isolation.
+func linkTestBlock(fc *ForkChoice, block *Block) {
+	fc.blockCache[block.BlockHash] = block
+	fc.children[block.ParentHash] = append(fc.children[block.ParentHash], block.BlockHash)
+	if _, exists := fc.weight[block.BlockHash]; !exists {
+		fc.weight[block.BlockHash] = 0
+	}
+}
+
+func newTestForkChoice(authorityCount int) (*ForkChoice, *Block) {
+	genesis := testBlock(0x00, 0x00, 0)
+	fc := NewForkChoice(genesis, authorityCount, logger.NewLogger("error"))
+	return fc, genesis
+}
+
+func TestForkChoicePicksHeaviestSubtree(t *testing.T) {
+	fc, genesis := newTestForkChoice(3)
+
+	blockA := testBlock(0xAA, genesis.BlockHash[0], 1)
+	blockB := testBlock(0xBB, genesis.BlockHash[0], 1)
+	linkTestBlock(fc, blockA)
+	linkTestBlock(fc, blockB)
+
+	var v1, v2, v3 [32]byte
+	v1[0], v2[0], v3[0] = 1, 2, 3
+
+	if err := fc.RecordAttestation(v1, blockA.BlockHash); err != nil {
+		t.Fatalf("RecordAttestation failed: %v", err)
+	}
+	if err := fc.RecordAttestation(v2, blockA.BlockHash); err != nil {
+		t.Fatalf("RecordAttestation failed: %v", err)
+	}
+	if err := fc.RecordAttestation(v3, blockB.BlockHash); err != nil {
+		t.Fatalf("RecordAttestation failed: %v", err)
+	}
+
+	tip := fc.GetCanonicalTip()
+	if tip.Block.BlockHash != blockA.BlockHash {
+		t.Fatalf("canonical tip = %x, want blockA (2 votes vs 1)", tip.Block.BlockHash[:1])
+	}
+	if tip.TotalWeight != 2 {
+		t.Fatalf("canonical tip weight = %d, want 2", tip.TotalWeight)
+	}
+}
+
+func TestForkChoiceReassignsVoteOnUpdatedAttestation(t *testing.T) {
+	fc, genesis := newTestForkChoice(3)
+
+	blockA := testBlock(0xAA, genesis.BlockHash[0], 1)
+	blockB := testBlock(0xBB, genesis.BlockHash[0], 1)
+	linkTestBlock(fc, blockA)
+	linkTestBlock(fc, blockB)
+
+	var v1 [32]byte
+	v1[0] = 1
+
+	if err := fc.RecordAttestation(v1, blockA.BlockHash); err != nil {
+		t.Fatalf("RecordAttestation failed: %v", err)
+	}
+	if fc.GetCanonicalTip().Block.BlockHash != blockA.BlockHash {
+		t.Fatal("expected blockA to be canonical after the first vote")
+	}
+
+	// The same authority moving its vote to blockB should remove its weight
+	// from blockA and add it to blockB, not double-count.
+	if err := fc.RecordAttestation(v1, blockB.BlockHash); err != nil {
+		t.Fatalf("RecordAttestation failed: %v", err)
+	}
+	if fc.GetCanonicalTip().Block.BlockHash != blockB.BlockHash {
+		t.Fatal("expected blockB to become canonical once the authority moved its vote")
+	}
+	if w := fc.weight[blockA.BlockHash]; w != 0 {
+		t.Fatalf("blockA weight after vote moved away = %d, want 0", w)
+	}
+}
+
+func TestForkChoiceBreaksTiesByLowerHash(t *testing.T) {
+	fc, genesis := newTestForkChoice(3)
+
+	blockLow := testBlock(0x01, genesis.BlockHash[0], 1)
+	blockHigh := testBlock(0xFF, genesis.BlockHash[0], 1)
+	linkTestBlock(fc, blockLow)
+	linkTestBlock(fc, blockHigh)
+
+	// No votes at all: both subtrees have weight 0, so the tie is broken by
+	// lower hash.
+	fc.recomputeCanonicalLocked()
+
+	if fc.canonicalHash != blockLow.BlockHash {
+		t.Fatalf("canonical hash = %x, want the lower of the two tied hashes", fc.canonicalHash[:1])
+	}
+}
+
+func TestForkChoiceRecordAttestationRejectsUnknownBlock(t *testing.T) {
+	fc, _ := newTestForkChoice(3)
+
+	var v1, unknown [32]byte
+	v1[0] = 1
+	unknown[0] = 0xEE
+
+	if err := fc.RecordAttestation(v1, unknown); err == nil {
+		t.Fatal("expected attesting to an unknown block to fail")
+	}
+}
+
+func TestForkChoiceFinalizeRejectsNonAncestor(t *testing.T) {
+	fc, genesis := newTestForkChoice(3)
+
+	blockA := testBlock(0xAA, genesis.BlockHash[0], 1)
+	blockB := testBlock(0xBB, genesis.BlockHash[0], 1)
+	linkTestBlock(fc, blockA)
+	linkTestBlock(fc, blockB)
+
+	var v1 [32]byte
+	v1[0] = 1
+	if err := fc.RecordAttestation(v1, blockA.BlockHash); err != nil {
+		t.Fatalf("RecordAttestation failed: %v", err)
+	}
+
+	if err := fc.Finalize(blockB.BlockHash); err == nil {
+		t.Fatal("expected finalizing a block that is not an ancestor of the canonical tip to fail")
+	}
+}
+
+func TestForkChoiceFinalizeAcceptsAncestor(t *testing.T) {
+	fc, genesis := newTestForkChoice(3)
+
+	blockA := testBlock(0xAA, genesis.BlockHash[0], 1)
+	linkTestBlock(fc, blockA)
+
+	var v1 [32]byte
+	v1[0] = 1
+	if err := fc.RecordAttestation(v1, blockA.BlockHash); err != nil {
+		t.Fatalf("RecordAttestation failed: %v", err)
+	}
+
+	if err := fc.Finalize(blockA.BlockHash); err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+	finalized := fc.GetFinalized()
+	if finalized.Block.BlockHash != blockA.BlockHash {
+		t.Fatalf("GetFinalized = %x, want blockA", finalized.Block.BlockHash[:1])
+	}
+}
@@ -0,0 +1,52 @@
+package consensus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics for the EIP-1559 fee market: the rolling base fee
+// (set once per block by updateBaseFee) and the distribution of
+// per-transaction tips actually paid to validators (observed by
+// distributeFee). Package-level promauto registration follows the same
+// pattern as pkg/limiter's rate limiter metrics.
+var (
+	currentBaseFee = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "coinjecture_base_fee_wei",
+			Help: "Current EIP-1559 base fee per gas unit, in wei",
+		},
+	)
+
+	blockTips = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "coinjecture_block_tip_wei",
+			Help:    "Per-transaction effective tip paid to the block producer, in wei",
+			Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+		},
+	)
+)
+
+// MetricsSink receives per-block economic signals that updateBaseFee and
+// distributeFee compute — gas used, validator rewards, burned fees, and
+// treasury credits — so an external Prometheus exporter can track them
+// without this package importing a concrete metrics type. Set via
+// Engine.SetMetricsSink/BlockBuilder.SetMetricsSink; nil (the default) means
+// "don't report." Unlike currentBaseFee/blockTips above, these signals are
+// per-validator or need a live component (the exporter) wired in after
+// construction, so they go through an interface instead of package-level
+// promauto vars.
+type MetricsSink interface {
+	// ObserveBlockGasUsed records the gas used by a block just produced or
+	// accepted.
+	ObserveBlockGasUsed(gasUsed uint64)
+	// AddValidatorReward credits amount wei to validator (identified by a
+	// hex prefix of its public key) in the running reward total.
+	AddValidatorReward(validatorPubkeyHexPrefix string, amount uint64)
+	// AddFeesBurned adds amount wei to the running total sent to the burn
+	// address.
+	AddFeesBurned(amount uint64)
+	// AddTreasuryCredited adds amount wei to the running total credited to
+	// the treasury address.
+	AddTreasuryCredited(amount uint64)
+}
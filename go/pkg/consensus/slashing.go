@@ -2,13 +2,42 @@
 package consensus
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
 )
 
+// Prometheus metrics
+var (
+	slashSubmissionsRejected = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "coinjecture_slash_submissions_rejected_total",
+			Help: "Slash submissions rejected by the per-reporter rate limiter",
+		},
+		[]string{"reporter"},
+	)
+)
+
+// localReporter identifies Slash calls the node makes about its own
+// first-hand observations (a block it validated itself, a liveness window
+// it tracked), as opposed to evidence relayed by a peer or submitted over
+// the API. It still passes through the rate limiter like any other
+// reporter, just under its own bucket.
+const localReporter = "local"
+
 // SlashingOffense represents different types of slashable offenses
 type SlashingOffense uint8
 
@@ -28,50 +57,153 @@ const (
 
 // SlashingEvent records a slashable offense
 type SlashingEvent struct {
-	Validator   [32]byte        // Offending validator address
-	Offense     SlashingOffense // Type of offense
-	BlockNumber uint64          // Block height where offense occurred
-	Evidence    []byte          // Proof of offense (serialized block data, etc.)
-	Timestamp   int64           // When offense was detected
-	Severity    uint8           // Severity score (1=minor, 10=critical)
+	Validator     [32]byte        // Offending validator address
+	Offense       SlashingOffense // Type of offense
+	BlockNumber   uint64          // Block height where offense occurred
+	Evidence      []byte          // Proof of offense (serialized block data, etc.)
+	Timestamp     int64           // When offense was detected
+	Severity      uint8           // Severity score (1=minor, 10=critical)
+	SlashedAmount uint64          // Stake burned for this event, after slashing-period capping (0 if no StakeProvider is wired)
+}
+
+// StakeProvider is the economic backing Slash burns stake against. Wired
+// via SetStakeProvider; if never set, Slash falls back to its pre-existing
+// reputation/jail/ban-only bookkeeping with no stake burned.
+type StakeProvider interface {
+	// GetStake returns validator's current bonded stake.
+	GetStake(validator [32]byte) uint64
+	// BurnStake irrevocably destroys amount of validator's bonded stake.
+	BurnStake(validator [32]byte, amount uint64) error
+}
+
+// slashPeriod tracks the highest slash fraction already applied to a
+// validator within the current SlashingPeriod window, Cosmos-style: only
+// the incremental fraction above the period's running max is burned, so N
+// pieces of evidence for the same double-sign don't burn N times the
+// intended fraction.
+type slashPeriod struct {
+	periodStart uint64
+	maxFraction float64
 }
 
 // ValidatorStatus tracks validator reputation and slashing history
 type ValidatorStatus struct {
 	Address         [32]byte
 	IsActive        bool    // Whether validator is currently active
+	Banned          bool    // Permanently banned (TotalSeverity crossed BanThreshold); never unjailable
 	SlashCount      int     // Number of times slashed
 	TotalSeverity   int     // Cumulative severity score
 	LastSlashTime   int64   // Timestamp of last slash
-	MissedBlocks    int     // Consecutive missed blocks (for liveness tracking)
+	StartHeight     uint64  // Block height this validator was (re-)registered at; see livenessWindow grace period
 	ProducedBlocks  int     // Total blocks successfully produced
 	InvalidBlocks   int     // Total invalid blocks produced
 	ReputationScore float64 // 0.0 (banned) to 1.0 (perfect)
 }
 
+// livenessWindow is a Cosmos-style sliding bit-array over the last
+// LivenessWindow blocks: each block height writes a signed/missed bit at
+// height % LivenessWindow, and missedBlocksCounter is adjusted by the bit
+// flip so the current miss count is always O(1) to read, rather than a
+// naive consecutive-miss counter that a single signed block resets to zero.
+type livenessWindow struct {
+	bits                []bool
+	indexOffset         uint64 // height % len(bits) slot last written
+	missedBlocksCounter int
+}
+
+func newLivenessWindow(size int) *livenessWindow {
+	if size < 1 {
+		size = 1
+	}
+	return &livenessWindow{bits: make([]bool, size)}
+}
+
+// record sets height's slot to missed, adjusting missedBlocksCounter by the
+// bit flip (if any) relative to whatever outcome previously occupied that
+// slot from size blocks ago.
+func (w *livenessWindow) record(height uint64, missed bool) {
+	idx := height % uint64(len(w.bits))
+	if w.bits[idx] != missed {
+		if missed {
+			w.missedBlocksCounter++
+		} else {
+			w.missedBlocksCounter--
+		}
+		w.bits[idx] = missed
+	}
+	w.indexOffset = idx
+}
+
+// UnjailEvent records a validator's self-service recovery from jail via a
+// signed TxUnjail (see SlashingManager.Unjail). It isn't itself a slashable
+// offense, so it's tracked separately from SlashingEvent.
+type UnjailEvent struct {
+	Validator [32]byte
+	Timestamp int64
+}
+
 // SlashingConfig holds slashing parameters
 type SlashingConfig struct {
-	EnableSlashing       bool    // Master switch for slashing
-	BanThreshold         int     // Total severity score before permanent ban
-	JailThreshold        int     // Severity score for temporary jail
-	JailDuration         time.Duration // How long validators are jailed
-	LivenessWindow       int     // How many blocks to track for liveness
-	LivenessThreshold    int     // Max missed blocks before slashing
-	ReputationDecayRate  float64 // How fast reputation recovers (per block)
-	MinReputationToValidate float64 // Minimum reputation to remain active validator
+	EnableSlashing          bool          // Master switch for slashing
+	BanThreshold            int           // Total severity score before permanent ban
+	JailThreshold           int           // Severity score for temporary jail
+	JailDuration            time.Duration // How long validators are jailed
+	LivenessWindow          int           // How many blocks to track for liveness
+	MinSignedPerWindow      float64       // Min fraction of LivenessWindow that must be signed before slashing for liveness
+	ReputationDecayRate     float64       // How fast reputation recovers (per block)
+	MinReputationToValidate float64       // Minimum reputation to remain active validator
+
+	// MaxSlashSubmissionsPerMinute bounds how often a single reporter
+	// identity (peer ID, client IP, or localReporter) may trigger Slash,
+	// so a malicious peer can't spam fabricated evidence to force
+	// expensive verification. Zero disables rate limiting.
+	MaxSlashSubmissionsPerMinute float64
+
+	// MaxEventsInMemory caps how many SlashingEvents are kept in sm.events
+	// before the oldest are rotated out to AuditLogPath. Zero disables
+	// rotation (events grow unbounded in memory, the pre-existing behavior).
+	MaxEventsInMemory int
+
+	// AuditLogPath is the append-only JSON-lines file rotated events are
+	// written to. Ignored if MaxEventsInMemory is zero.
+	AuditLogPath string
+
+	// SlashFraction{DoubleSign,InvalidBlock,WrongTurn,Liveness} are the
+	// fraction of a validator's bonded stake (each in [0,1]) burned via
+	// StakeProvider.BurnStake for that offense. Ignored if no
+	// StakeProvider is wired (see SetStakeProvider).
+	SlashFractionDoubleSign   float64
+	SlashFractionInvalidBlock float64
+	SlashFractionWrongTurn    float64
+	SlashFractionLiveness     float64
+
+	// SlashingPeriod bounds, in blocks, the window within which a
+	// validator's total slashable fraction is capped at the single
+	// largest offense's fraction rather than the sum — following Cosmos's
+	// slashing-period de-duplication, so e.g. two independently-submitted
+	// pieces of evidence for the same equivocation don't each burn the
+	// full double-sign fraction.
+	SlashingPeriod uint64
 }
 
 // DefaultSlashingConfig returns sensible slashing defaults
 func DefaultSlashingConfig() SlashingConfig {
 	return SlashingConfig{
-		EnableSlashing:          true,
-		BanThreshold:            100,  // Permanent ban after 100 severity points
-		JailThreshold:           30,   // Temporary jail after 30 severity points
-		JailDuration:            1 * time.Hour,
-		LivenessWindow:          100,  // Track last 100 blocks
-		LivenessThreshold:       10,   // Max 10 consecutive missed blocks
-		ReputationDecayRate:     0.01, // Reputation recovers 1% per block
-		MinReputationToValidate: 0.6,  // Need 60% reputation to validate
+		EnableSlashing:               true,
+		BanThreshold:                 100, // Permanent ban after 100 severity points
+		JailThreshold:                30,  // Temporary jail after 30 severity points
+		JailDuration:                 1 * time.Hour,
+		LivenessWindow:               100,   // Track last 100 blocks
+		MinSignedPerWindow:           0.5,   // Must sign at least half of the window
+		ReputationDecayRate:          0.01,  // Reputation recovers 1% per block
+		MinReputationToValidate:      0.6,   // Need 60% reputation to validate
+		MaxSlashSubmissionsPerMinute: 10,    // 10 Slash calls/min per reporter identity
+		MaxEventsInMemory:            10000, // Rotate to disk past 10k in-memory events
+		SlashFractionDoubleSign:      0.05,  // Burn 5% of stake for equivocation
+		SlashFractionInvalidBlock:    0.02,  // Burn 2% of stake for an invalid block
+		SlashFractionWrongTurn:       0.01,  // Burn 1% of stake for a round-robin violation
+		SlashFractionLiveness:        0.001, // Burn 0.1% of stake for downtime
+		SlashingPeriod:               100,   // Cap total slashed fraction per 100-block window
 	}
 }
 
@@ -81,28 +213,145 @@ type SlashingManager struct {
 	log    *logger.Logger
 
 	// Validator tracking
-	validators map[[32]byte]*ValidatorStatus
+	validators  map[[32]byte]*ValidatorStatus
 	jailedUntil map[[32]byte]int64 // validator -> jail release timestamp
-	mu         sync.RWMutex
+	liveness    map[[32]byte]*livenessWindow
+	mu          sync.RWMutex
 
 	// Slashing events (for audit trail)
 	events     []*SlashingEvent
 	eventsLock sync.RWMutex
+
+	// Unjail events (for audit trail); shares eventsLock with events.
+	unjailEvents []*UnjailEvent
+
+	// Per-reporter-identity rate limiting on Slash submissions.
+	reportersMu sync.Mutex
+	reporters   map[string]*rate.Limiter
+
+	// Bounded on-disk audit log that sm.events rotates into past
+	// MaxEventsInMemory; see rotateEventsLocked.
+	auditMu   sync.Mutex
+	auditFile *os.File
+
+	// Economic backing for Slash; nil until SetStakeProvider is called, in
+	// which case Slash doesn't burn any stake.
+	stake StakeProvider
+
+	// Per-validator slashing-period tracking, guarded by mu (same lock
+	// Slash already holds while updating status).
+	slashPeriods map[[32]byte]*slashPeriod
 }
 
 // NewSlashingManager creates a new slashing manager
 func NewSlashingManager(config SlashingConfig, log *logger.Logger) *SlashingManager {
 	return &SlashingManager{
-		config:      config,
-		log:         log,
-		validators:  make(map[[32]byte]*ValidatorStatus),
-		jailedUntil: make(map[[32]byte]int64),
-		events:      make([]*SlashingEvent, 0),
+		config:       config,
+		log:          log,
+		validators:   make(map[[32]byte]*ValidatorStatus),
+		jailedUntil:  make(map[[32]byte]int64),
+		liveness:     make(map[[32]byte]*livenessWindow),
+		events:       make([]*SlashingEvent, 0),
+		reporters:    make(map[string]*rate.Limiter),
+		slashPeriods: make(map[[32]byte]*slashPeriod),
+	}
+}
+
+// SetStakeProvider wires sp as the stake backing for Slash's economic
+// penalties. Until called, Slash only updates reputation/jail/ban
+// bookkeeping and burns no stake.
+func (sm *SlashingManager) SetStakeProvider(sp StakeProvider) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.stake = sp
+}
+
+// Close releases resources held by sm, namely the audit log file opened by
+// rotateEventsLocked. Safe to call even if no rotation ever happened.
+func (sm *SlashingManager) Close() error {
+	sm.auditMu.Lock()
+	defer sm.auditMu.Unlock()
+	if sm.auditFile == nil {
+		return nil
+	}
+	err := sm.auditFile.Close()
+	sm.auditFile = nil
+	return err
+}
+
+// allowReporter checks reporter against its per-identity token bucket,
+// creating one on first use. A zero MaxSlashSubmissionsPerMinute disables
+// the check entirely (the pre-existing, unlimited behavior).
+func (sm *SlashingManager) allowReporter(reporter string) bool {
+	if sm.config.MaxSlashSubmissionsPerMinute <= 0 {
+		return true
+	}
+	if reporter == "" {
+		reporter = localReporter
+	}
+
+	sm.reportersMu.Lock()
+	limiter, exists := sm.reporters[reporter]
+	if !exists {
+		perSecond := sm.config.MaxSlashSubmissionsPerMinute / 60
+		limiter = rate.NewLimiter(rate.Limit(perSecond), int(sm.config.MaxSlashSubmissionsPerMinute))
+		sm.reporters[reporter] = limiter
+	}
+	sm.reportersMu.Unlock()
+
+	if !limiter.Allow() {
+		slashSubmissionsRejected.WithLabelValues(reporter).Inc()
+		return false
+	}
+	return true
+}
+
+// rotateEventsLocked appends the oldest events to AuditLogPath and trims
+// sm.events back down to MaxEventsInMemory, once it's grown past that
+// bound. Called with eventsLock held for writing. A zero MaxEventsInMemory
+// disables rotation (events accumulate in memory without bound).
+func (sm *SlashingManager) rotateEventsLocked() {
+	if sm.config.MaxEventsInMemory <= 0 || len(sm.events) <= sm.config.MaxEventsInMemory {
+		return
+	}
+
+	overflow := sm.events[:len(sm.events)-sm.config.MaxEventsInMemory]
+	sm.events = sm.events[len(sm.events)-sm.config.MaxEventsInMemory:]
+
+	if sm.config.AuditLogPath == "" {
+		return
+	}
+
+	sm.auditMu.Lock()
+	defer sm.auditMu.Unlock()
+
+	if sm.auditFile == nil {
+		f, err := os.OpenFile(sm.config.AuditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			sm.log.WithError(err).Warn("Failed to open slashing audit log, dropping rotated events")
+			return
+		}
+		sm.auditFile = f
+	}
+
+	for _, event := range overflow {
+		line, err := json.Marshal(event)
+		if err != nil {
+			sm.log.WithError(err).Warn("Failed to marshal slashing event for audit log")
+			continue
+		}
+		if _, err := sm.auditFile.Write(append(line, '\n')); err != nil {
+			sm.log.WithError(err).Warn("Failed to write slashing event to audit log")
+		}
 	}
 }
 
-// RegisterValidator registers a validator for tracking
-func (sm *SlashingManager) RegisterValidator(address [32]byte) {
+// RegisterValidator registers a validator for tracking at atHeight. The
+// validator gets a grace period of LivenessWindow blocks from atHeight
+// (see StartHeight) before its liveness window is evaluated, so a
+// newly-joined or newly-unjailed validator isn't slashed for blocks
+// produced before it existed.
+func (sm *SlashingManager) RegisterValidator(address [32]byte, atHeight uint64) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
@@ -113,18 +362,28 @@ func (sm *SlashingManager) RegisterValidator(address [32]byte) {
 	sm.validators[address] = &ValidatorStatus{
 		Address:         address,
 		IsActive:        true,
+		StartHeight:     atHeight,
 		ReputationScore: 1.0, // Start with perfect reputation
 	}
+	sm.liveness[address] = newLivenessWindow(sm.config.LivenessWindow)
 
 	sm.log.WithField("validator", fmt.Sprintf("%x", address[:8])).Info("Validator registered for slashing tracking")
 }
 
-// Slash records a slashing event for a validator
-func (sm *SlashingManager) Slash(validator [32]byte, offense SlashingOffense, blockNumber uint64, evidence []byte) error {
+// Slash records a slashing event for a validator. reporter identifies who's
+// triggering this call (a peer ID, a client IP, or localReporter for the
+// node's own first-hand observations) and is debited against that
+// identity's MaxSlashSubmissionsPerMinute budget, so a malicious peer can't
+// spam fabricated evidence to force repeated expensive verification.
+func (sm *SlashingManager) Slash(validator [32]byte, offense SlashingOffense, blockNumber uint64, evidence []byte, reporter string) error {
 	if !sm.config.EnableSlashing {
 		return nil // Slashing disabled
 	}
 
+	if !sm.allowReporter(reporter) {
+		return fmt.Errorf("slash submission rate limit exceeded for reporter %q", reporter)
+	}
+
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
@@ -142,19 +401,26 @@ func (sm *SlashingManager) Slash(validator [32]byte, offense SlashingOffense, bl
 	// Calculate severity based on offense type
 	severity := sm.getSeverity(offense)
 
+	// Burn stake, if a StakeProvider is wired, capped by the validator's
+	// slashing period so repeat evidence for the same infraction doesn't
+	// burn the fraction more than once.
+	burnedAmount := sm.burnStakeLocked(validator, offense, blockNumber)
+
 	// Create slashing event
 	event := &SlashingEvent{
-		Validator:   validator,
-		Offense:     offense,
-		BlockNumber: blockNumber,
-		Evidence:    evidence,
-		Timestamp:   time.Now().Unix(),
-		Severity:    severity,
+		Validator:     validator,
+		Offense:       offense,
+		BlockNumber:   blockNumber,
+		Evidence:      evidence,
+		Timestamp:     time.Now().Unix(),
+		Severity:      severity,
+		SlashedAmount: burnedAmount,
 	}
 
 	// Record event
 	sm.eventsLock.Lock()
 	sm.events = append(sm.events, event)
+	sm.rotateEventsLocked()
 	sm.eventsLock.Unlock()
 
 	// Update validator status
@@ -186,6 +452,7 @@ func (sm *SlashingManager) Slash(validator [32]byte, offense SlashingOffense, bl
 	// Check if validator should be banned
 	if status.TotalSeverity >= sm.config.BanThreshold {
 		status.IsActive = false
+		status.Banned = true
 		sm.log.WithFields(logger.Fields{
 			"validator": fmt.Sprintf("%x", validator[:8]),
 			"severity":  status.TotalSeverity,
@@ -231,6 +498,62 @@ func (sm *SlashingManager) getSeverity(offense SlashingOffense) uint8 {
 	}
 }
 
+// fractionForOffense returns the configured SlashFraction for offense.
+func (sm *SlashingManager) fractionForOffense(offense SlashingOffense) float64 {
+	switch offense {
+	case OffenseDoubleSign:
+		return sm.config.SlashFractionDoubleSign
+	case OffenseInvalidBlock:
+		return sm.config.SlashFractionInvalidBlock
+	case OffenseWrongTurn:
+		return sm.config.SlashFractionWrongTurn
+	case OffenseLiveness:
+		return sm.config.SlashFractionLiveness
+	default:
+		return 0
+	}
+}
+
+// burnStakeLocked burns validator's stake for offense at blockNumber and
+// returns the amount burned (0 if no StakeProvider is wired, the offense
+// carries no SlashFraction, or the fraction was already covered by an
+// earlier infraction in the same SlashingPeriod). Called with mu held.
+func (sm *SlashingManager) burnStakeLocked(validator [32]byte, offense SlashingOffense, blockNumber uint64) uint64 {
+	if sm.stake == nil {
+		return 0
+	}
+
+	fraction := sm.fractionForOffense(offense)
+	if fraction <= 0 {
+		return 0
+	}
+
+	period, exists := sm.slashPeriods[validator]
+	if !exists || blockNumber >= period.periodStart+sm.config.SlashingPeriod {
+		period = &slashPeriod{periodStart: blockNumber}
+		sm.slashPeriods[validator] = period
+	}
+
+	if fraction <= period.maxFraction {
+		return 0 // already slashed at this fraction or higher within the current period
+	}
+	incremental := fraction - period.maxFraction
+	period.maxFraction = fraction
+
+	stake := sm.stake.GetStake(validator)
+	amount := uint64(float64(stake) * incremental)
+	if amount == 0 {
+		return 0
+	}
+
+	if err := sm.stake.BurnStake(validator, amount); err != nil {
+		sm.log.WithError(err).WithField("validator", fmt.Sprintf("%x", validator[:8])).Warn("Failed to burn stake for slashing offense")
+		return 0
+	}
+
+	return amount
+}
+
 // IsValidatorActive checks if a validator can currently validate
 func (sm *SlashingManager) IsValidatorActive(validator [32]byte) bool {
 	sm.mu.RLock()
@@ -246,21 +569,85 @@ func (sm *SlashingManager) IsValidatorActive(validator [32]byte) bool {
 		return false
 	}
 
-	// Check if jailed
-	if jailUntil, jailed := sm.jailedUntil[validator]; jailed {
-		if time.Now().Unix() < jailUntil {
-			return false // Still jailed
-		}
-		// Jail expired, remove from jail
-		delete(sm.jailedUntil, validator)
-		sm.log.WithField("validator", fmt.Sprintf("%x", validator[:8])).Info("Validator released from jail")
+	// Check if jailed. Once JailDuration elapses the validator is eligible
+	// to unjail, but release isn't automatic: it stays jailed here until it
+	// signs and submits an explicit Unjail, so downtime recovery is
+	// accountable rather than silent.
+	if _, jailed := sm.jailedUntil[validator]; jailed {
+		return false
 	}
 
 	return true
 }
 
-// RecordBlockProduced records a successfully produced block (increases reputation)
-func (sm *SlashingManager) RecordBlockProduced(validator [32]byte) {
+// unjailDomain domain-separates the Unjail signing message so a validator's
+// signature can't be replayed as an approval for anything else.
+const unjailDomain = "coinjecture/unjail:"
+
+// unjailMessage is the canonical message a validator signs with their own
+// key (the validator address doubles as its Ed25519 public key) to prove
+// they're back online and request release from jail.
+func unjailMessage(validator [32]byte) []byte {
+	return []byte(unjailDomain + hex.EncodeToString(validator[:]))
+}
+
+// Unjail implements the Cosmos SDK TxUnjail model: a jailed validator signs
+// a request with its own key to prove it's back online, rather than being
+// silently released once JailDuration elapses (see IsValidatorActive). It
+// rejects the request before JailDuration has passed, refuses permanently
+// banned validators (TotalSeverity crossed BanThreshold), and records an
+// UnjailEvent to the audit trail on success. atHeight resets StartHeight so
+// the validator gets a fresh liveness grace period (see RecordMissedBlock)
+// rather than being immediately re-jailed by stale window data.
+func (sm *SlashingManager) Unjail(validator [32]byte, signature []byte, atHeight uint64) error {
+	if !ed25519.Verify(validator[:], unjailMessage(validator), signature) {
+		return fmt.Errorf("invalid unjail signature for validator %x", validator[:8])
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	status, exists := sm.validators[validator]
+	if !exists {
+		return fmt.Errorf("unknown validator %x", validator[:8])
+	}
+	if status.Banned {
+		return fmt.Errorf("validator %x is permanently banned and cannot be unjailed", validator[:8])
+	}
+
+	jailUntil, jailed := sm.jailedUntil[validator]
+	if !jailed {
+		return fmt.Errorf("validator %x is not jailed", validator[:8])
+	}
+	if now := time.Now().Unix(); now < jailUntil {
+		return fmt.Errorf("validator %x cannot unjail until %s", validator[:8], time.Unix(jailUntil, 0))
+	}
+
+	delete(sm.jailedUntil, validator)
+	status.StartHeight = atHeight
+	sm.liveness[validator] = newLivenessWindow(sm.config.LivenessWindow)
+
+	sm.eventsLock.Lock()
+	sm.unjailEvents = append(sm.unjailEvents, &UnjailEvent{Validator: validator, Timestamp: time.Now().Unix()})
+	sm.eventsLock.Unlock()
+
+	sm.log.WithField("validator", fmt.Sprintf("%x", validator[:8])).Info("Validator unjailed")
+	return nil
+}
+
+// GetUnjailEvents returns all unjail events (for audit)
+func (sm *SlashingManager) GetUnjailEvents() []*UnjailEvent {
+	sm.eventsLock.RLock()
+	defer sm.eventsLock.RUnlock()
+
+	events := make([]*UnjailEvent, len(sm.unjailEvents))
+	copy(events, sm.unjailEvents)
+	return events
+}
+
+// RecordBlockProduced records a successfully produced block at blockNumber
+// (increases reputation, marks the block's liveness window slot signed).
+func (sm *SlashingManager) RecordBlockProduced(validator [32]byte, blockNumber uint64) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
@@ -270,7 +657,7 @@ func (sm *SlashingManager) RecordBlockProduced(validator [32]byte) {
 	}
 
 	status.ProducedBlocks++
-	status.MissedBlocks = 0 // Reset missed blocks counter
+	sm.livenessWindowFor(validator).record(blockNumber, false)
 
 	// Slowly recover reputation with good behavior
 	if status.ReputationScore < 1.0 {
@@ -290,30 +677,50 @@ func (sm *SlashingManager) RecordBlockProduced(validator [32]byte) {
 	}
 }
 
-// RecordMissedBlock records a missed block (for liveness tracking)
+// livenessWindowFor returns validator's sliding liveness window, creating
+// one lazily for validators registered before this field existed. Callers
+// must hold sm.mu.
+func (sm *SlashingManager) livenessWindowFor(validator [32]byte) *livenessWindow {
+	w, exists := sm.liveness[validator]
+	if !exists {
+		w = newLivenessWindow(sm.config.LivenessWindow)
+		sm.liveness[validator] = w
+	}
+	return w
+}
+
+// RecordMissedBlock records a missed block at blockNumber in validator's
+// sliding liveness window (see livenessWindow) and slashes for
+// OffenseLiveness once the window's missed-block count exceeds
+// (1-MinSignedPerWindow)*LivenessWindow. Validators still inside their
+// post-registration/unjail grace period (blockNumber < StartHeight +
+// LivenessWindow) are never slashed for liveness, so the window has a
+// chance to fill with real data before it's judged.
 func (sm *SlashingManager) RecordMissedBlock(validator [32]byte, blockNumber uint64) error {
 	if !sm.config.EnableSlashing {
 		return nil
 	}
 
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
 
 	status, exists := sm.validators[validator]
 	if !exists {
+		sm.mu.Unlock()
 		return nil // Unknown validator, ignore
 	}
 
-	status.MissedBlocks++
+	window := sm.livenessWindowFor(validator)
+	window.record(blockNumber, true)
 
-	// Check if exceeded liveness threshold
-	if status.MissedBlocks >= sm.config.LivenessThreshold {
-		sm.mu.Unlock() // Unlock before calling Slash (which locks)
-		err := sm.Slash(validator, OffenseLiveness, blockNumber, nil)
-		sm.mu.Lock()
-		return err
-	}
+	inGracePeriod := blockNumber < status.StartHeight+uint64(sm.config.LivenessWindow)
+	missThreshold := (1 - sm.config.MinSignedPerWindow) * float64(sm.config.LivenessWindow)
+	shouldSlash := !inGracePeriod && float64(window.missedBlocksCounter) > missThreshold
 
+	sm.mu.Unlock()
+
+	if shouldSlash {
+		return sm.Slash(validator, OffenseLiveness, blockNumber, nil, localReporter)
+	}
 	return nil
 }
 
@@ -369,11 +776,184 @@ func (sm *SlashingManager) GetStats() map[string]interface{} {
 	sm.eventsLock.RUnlock()
 
 	return map[string]interface{}{
-		"total_validators": len(sm.validators),
-		"active_validators": activeCount,
-		"jailed_validators": jailedCount,
-		"banned_validators": bannedCount,
+		"total_validators":      len(sm.validators),
+		"active_validators":     activeCount,
+		"jailed_validators":     jailedCount,
+		"banned_validators":     bannedCount,
 		"total_slashing_events": eventCount,
-		"total_slashes": totalSlashes,
+		"total_slashes":         totalSlashes,
+	}
+}
+
+// ==================== GENESIS EXPORT/IMPORT ====================
+
+// GenesisState is the canonical export produced by ExportGenesis and
+// consumed by ImportGenesis: the full validator status map, jail schedule,
+// and slashing/unjail event log. Validators is sorted by address so two
+// nodes with the same logical state marshal to byte-identical JSON (Go's
+// encoding/json already sorts map keys, so JailedUntil needs no extra
+// sorting).
+type GenesisState struct {
+	Validators   []ValidatorStatus `json:"validators"`
+	JailedUntil  map[string]int64  `json:"jailed_until"` // keyed by hex validator address
+	Events       []*SlashingEvent  `json:"events"`
+	UnjailEvents []*UnjailEvent    `json:"unjail_events"`
+}
+
+// ExportGenesis serializes the full validator status map, jail schedule,
+// and event log to a canonical JSON blob, for chain-halt upgrades and
+// zero-height genesis exports (as Cosmos does for the slashing module).
+func (sm *SlashingManager) ExportGenesis() ([]byte, error) {
+	sm.mu.RLock()
+	validators := make([]ValidatorStatus, 0, len(sm.validators))
+	for _, status := range sm.validators {
+		validators = append(validators, *status)
+	}
+	sort.Slice(validators, func(i, j int) bool {
+		return bytes.Compare(validators[i].Address[:], validators[j].Address[:]) < 0
+	})
+
+	jailedUntil := make(map[string]int64, len(sm.jailedUntil))
+	for addr, until := range sm.jailedUntil {
+		jailedUntil[fmt.Sprintf("%x", addr)] = until
+	}
+	sm.mu.RUnlock()
+
+	sm.eventsLock.RLock()
+	events := make([]*SlashingEvent, len(sm.events))
+	copy(events, sm.events)
+	unjailEvents := make([]*UnjailEvent, len(sm.unjailEvents))
+	copy(unjailEvents, sm.unjailEvents)
+	sm.eventsLock.RUnlock()
+
+	data, err := json.Marshal(GenesisState{
+		Validators:   validators,
+		JailedUntil:  jailedUntil,
+		Events:       events,
+		UnjailEvents: unjailEvents,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis state: %w", err)
+	}
+	return data, nil
+}
+
+// ImportGenesis replaces this manager's entire state with data produced by
+// a prior ExportGenesis call, for chain-halt upgrades and zero-height
+// genesis imports. Liveness windows are reset fresh rather than imported,
+// since they're a rolling cache over recent blocks, not consensus state.
+func (sm *SlashingManager) ImportGenesis(data []byte) error {
+	var genesis GenesisState
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+	}
+
+	validators := make(map[[32]byte]*ValidatorStatus, len(genesis.Validators))
+	liveness := make(map[[32]byte]*livenessWindow, len(genesis.Validators))
+	for i := range genesis.Validators {
+		status := genesis.Validators[i]
+		validators[status.Address] = &status
+		liveness[status.Address] = newLivenessWindow(sm.config.LivenessWindow)
+	}
+
+	jailedUntil := make(map[[32]byte]int64, len(genesis.JailedUntil))
+	for addrHex, until := range genesis.JailedUntil {
+		var addr [32]byte
+		if _, err := fmt.Sscanf(addrHex, "%x", &addr); err != nil {
+			return fmt.Errorf("invalid jailed validator address %q: %w", addrHex, err)
+		}
+		jailedUntil[addr] = until
+	}
+
+	sm.mu.Lock()
+	sm.validators = validators
+	sm.jailedUntil = jailedUntil
+	sm.liveness = liveness
+	sm.mu.Unlock()
+
+	sm.eventsLock.Lock()
+	sm.events = genesis.Events
+	sm.unjailEvents = genesis.UnjailEvents
+	sm.eventsLock.Unlock()
+
+	sm.log.WithField("validator_count", len(validators)).Info("Slashing state imported from genesis")
+	return nil
+}
+
+// StateRoot returns a Merkle root over every validator's consensus-relevant
+// status fields, sorted by address so the result is deterministic
+// regardless of map iteration order. Consensus can include this in block
+// headers so a node whose slashing state has diverged (e.g. a byzantine
+// node that locally forgives itself) produces a different block hash
+// instead of silently disagreeing with honest peers.
+func (sm *SlashingManager) StateRoot() [32]byte {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	statuses := make([]*ValidatorStatus, 0, len(sm.validators))
+	for _, status := range sm.validators {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return bytes.Compare(statuses[i].Address[:], statuses[j].Address[:]) < 0
+	})
+
+	leaves := make([][32]byte, len(statuses))
+	for i, status := range statuses {
+		leaves[i] = hashValidatorStatus(status)
+	}
+
+	return merkleRoot(leaves)
+}
+
+// hashValidatorStatus hashes the fields of status that affect consensus
+// (address, active/banned, cumulative severity, start height), hand-packed
+// little-endian to match the rest of this repo's pre-codec hashing paths
+// (see computeTxHash in pkg/api).
+func hashValidatorStatus(status *ValidatorStatus) [32]byte {
+	buf := make([]byte, 0, 32+2+8+8)
+	buf = append(buf, status.Address[:]...)
+	buf = append(buf, boolToByte(status.IsActive), boolToByte(status.Banned))
+	buf = appendStateRootUint64(buf, uint64(status.TotalSeverity))
+	buf = appendStateRootUint64(buf, status.StartHeight)
+	return sha256.Sum256(buf)
+}
+
+func boolToByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func appendStateRootUint64(b []byte, v uint64) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], v)
+	return append(b, tmp[:]...)
+}
+
+// merkleRoot builds a simple binary Merkle tree over leaves: pairwise
+// sha256, duplicating the last leaf of a level when it's odd. Mirrors the
+// hash-pair-up pattern in pkg/codec/ssz's merkleize, but over an
+// arbitrary-length leaf list rather than a fixed SSZ container.
+func merkleRoot(leaves [][32]byte) [32]byte {
+	if len(leaves) == 0 {
+		return sha256.Sum256(nil)
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				pair := append(append([]byte{}, level[i][:]...), level[i+1][:]...)
+				next = append(next, sha256.Sum256(pair))
+			} else {
+				pair := append(append([]byte{}, level[i][:]...), level[i][:]...)
+				next = append(next, sha256.Sum256(pair))
+			}
+		}
+		level = next
 	}
+	return level[0]
 }
@@ -0,0 +1,126 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+func TestLivenessWindowRecordTracksMissedCount(t *testing.T) {
+	w := newLivenessWindow(4)
+
+	w.record(0, true)
+	w.record(1, true)
+	if w.missedBlocksCounter != 2 {
+		t.Fatalf("missedBlocksCounter = %d, want 2", w.missedBlocksCounter)
+	}
+
+	// Height 4 wraps around to slot 0, flipping it from missed back to
+	// signed.
+	w.record(4, false)
+	if w.missedBlocksCounter != 1 {
+		t.Fatalf("missedBlocksCounter after wraparound = %d, want 1", w.missedBlocksCounter)
+	}
+}
+
+func TestLivenessWindowRecordSameOutcomeIsNoop(t *testing.T) {
+	w := newLivenessWindow(4)
+	w.record(0, true)
+	w.record(4, true) // same slot, same outcome
+	if w.missedBlocksCounter != 1 {
+		t.Fatalf("missedBlocksCounter = %d, want 1 (repeated miss should not double-count)", w.missedBlocksCounter)
+	}
+}
+
+func newTestSlashingManager(livenessWindow int, minSigned float64) *SlashingManager {
+	cfg := DefaultSlashingConfig()
+	cfg.LivenessWindow = livenessWindow
+	cfg.MinSignedPerWindow = minSigned
+	return NewSlashingManager(cfg, logger.NewLogger("error"))
+}
+
+func TestRecordMissedBlockSlashesOnceThresholdExceeded(t *testing.T) {
+	sm := newTestSlashingManager(4, 0.5) // miss threshold: more than 2 misses out of 4
+	var validator [32]byte
+	validator[0] = 0x01
+	sm.RegisterValidator(validator, 0)
+
+	// Clear the grace period by advancing past StartHeight+LivenessWindow.
+	for h := uint64(0); h < 4; h++ {
+		sm.RecordBlockProduced(validator, h)
+	}
+
+	if err := sm.RecordMissedBlock(validator, 10); err != nil {
+		t.Fatalf("first miss should not slash yet: %v", err)
+	}
+	if err := sm.RecordMissedBlock(validator, 11); err != nil {
+		t.Fatalf("second miss should not slash yet: %v", err)
+	}
+	if err := sm.RecordMissedBlock(validator, 12); err != nil {
+		t.Fatalf("third miss should cross the threshold and slash, got error: %v", err)
+	}
+
+	events := sm.GetSlashingEvents()
+	if len(events) != 1 || events[0].Offense != OffenseLiveness {
+		t.Fatalf("expected exactly one OffenseLiveness slashing event, got %+v", events)
+	}
+}
+
+func TestRecordMissedBlockDuringGracePeriodDoesNotSlash(t *testing.T) {
+	sm := newTestSlashingManager(100, 0.5)
+	var validator [32]byte
+	validator[0] = 0x02
+	sm.RegisterValidator(validator, 0)
+
+	for h := uint64(0); h < 99; h++ {
+		if err := sm.RecordMissedBlock(validator, h); err != nil {
+			t.Fatalf("miss at height %d should stay inside the grace period, got: %v", h, err)
+		}
+	}
+
+	if got := sm.GetSlashingEvents(); len(got) != 0 {
+		t.Fatalf("expected no slashing events during the grace period, got %d", len(got))
+	}
+}
+
+func TestRecordMissedBlockUnknownValidatorIsIgnored(t *testing.T) {
+	sm := newTestSlashingManager(10, 0.5)
+	var validator [32]byte
+	validator[0] = 0x03
+
+	if err := sm.RecordMissedBlock(validator, 5); err != nil {
+		t.Fatalf("expected an unregistered validator to be silently ignored, got: %v", err)
+	}
+}
+
+func TestRecordBlockProducedRecoversReputationAndReactivates(t *testing.T) {
+	sm := newTestSlashingManager(10, 0.5)
+	sm.config.ReputationDecayRate = 0.3 // large enough to cross MinReputationToValidate in one call
+	var validator [32]byte
+	validator[0] = 0x04
+	sm.RegisterValidator(validator, 0)
+
+	// Two wrong-turn slashes drop reputation from 1.0 to 0.4, below the
+	// default 0.6 MinReputationToValidate, deactivating the validator.
+	if err := sm.Slash(validator, OffenseWrongTurn, 1, nil, localReporter); err != nil {
+		t.Fatalf("first slash failed: %v", err)
+	}
+	if err := sm.Slash(validator, OffenseWrongTurn, 2, nil, localReporter); err != nil {
+		t.Fatalf("second slash failed: %v", err)
+	}
+
+	deactivated := sm.GetValidatorStatus(validator)
+	if deactivated.IsActive {
+		t.Fatal("expected the validator to be deactivated once reputation fell below MinReputationToValidate")
+	}
+
+	sm.RecordBlockProduced(validator, 3)
+
+	updated := sm.GetValidatorStatus(validator)
+	if updated.ReputationScore <= deactivated.ReputationScore {
+		t.Fatalf("expected ReputationScore to increase, got %v (was %v)", updated.ReputationScore, deactivated.ReputationScore)
+	}
+	if !updated.IsActive {
+		t.Fatal("expected the validator to be reactivated once reputation crossed MinReputationToValidate")
+	}
+}
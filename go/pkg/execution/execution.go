@@ -0,0 +1,177 @@
+// Package execution provides a read-only transaction simulator used for
+// dry-run validation and gas estimation. It never writes to state: every
+// Simulate call reads current account balances and reports what applying
+// the transaction would do, without calling StateManager.ApplyTransaction.
+package execution
+
+import (
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+// MinGasLimit is the floor EstimateGas searches from — the cost of the
+// cheapest possible transaction (a bare transfer with no data).
+const MinGasLimit = 21000
+
+// gasPerDataByte is the simplified calldata cost model: every byte of
+// transaction data costs a flat amount of gas on top of MinGasLimit.
+// (Real calldata pricing distinguishes zero/non-zero bytes; there is no
+// opcode-level execution here to make that distinction meaningful yet.)
+const gasPerDataByte = 16
+
+// estimateSafetyMarginPct is added on top of the minimum simulated-successful
+// gas limit, mirroring geth's EstimateGas padding for state that may shift
+// between estimation and actual submission.
+const estimateSafetyMarginPct = 10
+
+// AccountDelta describes how Simulate would change one account's state.
+type AccountDelta struct {
+	BalanceBefore uint64
+	BalanceAfter  uint64
+	NonceBefore   uint64
+	NonceAfter    uint64
+}
+
+// Event is a simulated side effect worth surfacing to a caller, analogous to
+// an EVM log. This chain has no contract execution yet, so today only
+// transfer/escrow events are emitted.
+type Event struct {
+	Type string
+	Data map[string]interface{}
+}
+
+// ExecutionResult is the outcome of simulating a transaction against current
+// state. Err is set when the simulated execution would fail (insufficient
+// balance, out of gas, etc.) — that is not a Go error from Simulate itself,
+// which only errors on infrastructure failures like a state lookup error.
+type ExecutionResult struct {
+	UsedGas     uint64
+	RefundedGas uint64
+	Err         error
+	ReturnData  []byte
+	Logs        []Event
+	StateDiff   map[[32]byte]AccountDelta
+}
+
+// Simulator runs read-only simulations against a StateManager's current
+// account state.
+type Simulator struct {
+	state *state.StateManager
+}
+
+// NewSimulator creates a Simulator backed by sm.
+func NewSimulator(sm *state.StateManager) *Simulator {
+	return &Simulator{state: sm}
+}
+
+// Simulate evaluates tx against current account state without applying it.
+// The returned error is non-nil only when the simulation itself could not
+// be carried out (e.g. a state lookup failed); a transaction that would
+// fail on-chain simulates successfully with ExecutionResult.Err set.
+func (s *Simulator) Simulate(tx *mempool.Transaction) (*ExecutionResult, error) {
+	sender, err := s.state.GetAccount(tx.From)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sender account: %w", err)
+	}
+
+	usedGas := MinGasLimit + uint64(len(tx.Data))*gasPerDataByte
+
+	result := &ExecutionResult{
+		StateDiff: make(map[[32]byte]AccountDelta),
+	}
+
+	if usedGas > tx.GasLimit {
+		result.UsedGas = tx.GasLimit
+		result.Err = fmt.Errorf("out of gas: estimated cost %d exceeds gas limit %d", usedGas, tx.GasLimit)
+		return result, nil
+	}
+
+	feePerGas := tx.GasPrice
+	if tx.IsDynamicFee() {
+		feePerGas = tx.MaxFeePerGas
+	}
+	fee := tx.GasLimit * feePerGas
+	totalCost := tx.Amount + fee
+
+	if sender.Balance < totalCost {
+		result.UsedGas = tx.GasLimit
+		result.Err = fmt.Errorf("insufficient balance: have %d, need %d", sender.Balance, totalCost)
+		return result, nil
+	}
+
+	if tx.Nonce < sender.Nonce {
+		result.UsedGas = tx.GasLimit
+		result.Err = fmt.Errorf("nonce too old: tx=%d, account=%d", tx.Nonce, sender.Nonce)
+		return result, nil
+	}
+
+	recipient, err := s.state.GetAccount(tx.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recipient account: %w", err)
+	}
+
+	result.UsedGas = usedGas
+	result.RefundedGas = tx.GasLimit - usedGas
+	result.StateDiff[tx.From] = AccountDelta{
+		BalanceBefore: sender.Balance,
+		BalanceAfter:  sender.Balance - totalCost,
+		NonceBefore:   sender.Nonce,
+		NonceAfter:    sender.Nonce + 1,
+	}
+	result.StateDiff[tx.To] = AccountDelta{
+		BalanceBefore: recipient.Balance,
+		BalanceAfter:  recipient.Balance + tx.Amount,
+		NonceBefore:   recipient.Nonce,
+		NonceAfter:    recipient.Nonce,
+	}
+	result.Logs = append(result.Logs, Event{
+		Type: "transfer",
+		Data: map[string]interface{}{
+			"from":   tx.From,
+			"to":     tx.To,
+			"amount": tx.Amount,
+		},
+	})
+
+	return result, nil
+}
+
+// EstimateGas binary-searches [MinGasLimit, maxGasLimit] for the smallest
+// gas_limit at which tx simulates successfully, then pads the result by
+// estimateSafetyMarginPct, mirroring the classic geth EstimateGas approach.
+func EstimateGas(sim *Simulator, tx *mempool.Transaction, maxGasLimit uint64) (uint64, error) {
+	trial := *tx
+	trial.GasLimit = maxGasLimit
+
+	result, err := sim.Simulate(&trial)
+	if err != nil {
+		return 0, err
+	}
+	if result.Err != nil {
+		return 0, fmt.Errorf("transaction cannot succeed within max gas limit %d: %w", maxGasLimit, result.Err)
+	}
+
+	lo, hi := uint64(MinGasLimit), maxGasLimit
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		trial.GasLimit = mid
+
+		result, err := sim.Simulate(&trial)
+		if err != nil {
+			return 0, err
+		}
+		if result.Err == nil {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	estimate := hi + (hi*estimateSafetyMarginPct)/100
+	if estimate > maxGasLimit {
+		estimate = maxGasLimit
+	}
+	return estimate, nil
+}
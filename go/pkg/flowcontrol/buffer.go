@@ -0,0 +1,183 @@
+package flowcontrol
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	bufferValueGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "coinjecture_flowcontrol_buffer_value",
+			Help: "Current LES-style flow control buffer value (cost units) per peer",
+		},
+		[]string{"peer_id"},
+	)
+
+	bufferLimitGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "coinjecture_flowcontrol_buffer_limit",
+			Help: "Configured flow control buffer limit (cost units) per peer",
+		},
+		[]string{"peer_id"},
+	)
+)
+
+// ClientNode is a peer's LES-style flow control buffer: it starts with
+// BufferLimit cost-units of credit, which recharges continuously at
+// RechargeRate units/sec up to BufferLimit, mirroring go-ethereum LES's
+// les/flowcontrol.ClientNode.
+type ClientNode struct {
+	mu           sync.Mutex
+	peerID       string
+	value        float64
+	bufferLimit  float64
+	rechargeRate float64
+	lastUpdate   time.Time
+}
+
+func newClientNode(peerID string, bufferLimit, rechargeRate float64) *ClientNode {
+	n := &ClientNode{
+		peerID:       peerID,
+		value:        bufferLimit, // starts fully charged
+		bufferLimit:  bufferLimit,
+		rechargeRate: rechargeRate,
+		lastUpdate:   time.Now(),
+	}
+	bufferLimitGauge.WithLabelValues(peerID).Set(bufferLimit)
+	bufferValueGauge.WithLabelValues(peerID).Set(n.value)
+	return n
+}
+
+// recharge tops value up by elapsed*rechargeRate, capped at bufferLimit.
+// Caller must hold n.mu.
+func (n *ClientNode) recharge(now time.Time) {
+	elapsed := now.Sub(n.lastUpdate).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	n.value += elapsed * n.rechargeRate
+	if n.value > n.bufferLimit {
+		n.value = n.bufferLimit
+	}
+	n.lastUpdate = now
+}
+
+// admit recharges, then admits estimatedCost iff it fits in the current
+// buffer value, debiting it immediately (optimistic; finish reconciles
+// against the actual cost once known).
+func (n *ClientNode) admit(estimatedCost float64) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.recharge(time.Now())
+	if estimatedCost > n.value {
+		return false
+	}
+	n.value -= estimatedCost
+	bufferValueGauge.WithLabelValues(n.peerID).Set(n.value)
+	return true
+}
+
+// finish reconciles the debit made by admit: it refunds the difference
+// between what was estimated and what the request actually cost, which
+// itself debits the buffer further if the request ran over estimate.
+func (n *ClientNode) finish(estimatedCost, actualCost float64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.recharge(time.Now())
+	n.value += estimatedCost - actualCost
+	if n.value > n.bufferLimit {
+		n.value = n.bufferLimit
+	}
+	if n.value < 0 {
+		n.value = 0
+	}
+	bufferValueGauge.WithLabelValues(n.peerID).Set(n.value)
+}
+
+// Stats is a point-in-time snapshot of a peer's buffer state.
+type Stats struct {
+	PeerID       string  `json:"peer_id"`
+	Value        float64 `json:"value"`
+	BufferLimit  float64 `json:"buffer_limit"`
+	RechargeRate float64 `json:"recharge_rate"`
+}
+
+func (n *ClientNode) stats() Stats {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.recharge(time.Now())
+	return Stats{PeerID: n.peerID, Value: n.value, BufferLimit: n.bufferLimit, RechargeRate: n.rechargeRate}
+}
+
+// BufferManager tracks one ClientNode per peer, all sharing the same
+// BufferLimit/RechargeRate configuration.
+type BufferManager struct {
+	mu           sync.RWMutex
+	nodes        map[string]*ClientNode
+	bufferLimit  float64
+	rechargeRate float64
+}
+
+// NewBufferManager creates a manager that hands new peers bufferLimit
+// cost-units of starting credit, recharging at rechargeRate units/sec.
+func NewBufferManager(bufferLimit, rechargeRate float64) *BufferManager {
+	return &BufferManager{
+		nodes:        make(map[string]*ClientNode),
+		bufferLimit:  bufferLimit,
+		rechargeRate: rechargeRate,
+	}
+}
+
+func (m *BufferManager) nodeFor(peerID string) *ClientNode {
+	m.mu.RLock()
+	n, ok := m.nodes[peerID]
+	m.mu.RUnlock()
+	if ok {
+		return n
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n, ok := m.nodes[peerID]; ok {
+		return n
+	}
+	n = newClientNode(peerID, m.bufferLimit, m.rechargeRate)
+	m.nodes[peerID] = n
+	return n
+}
+
+// Admit reports whether peerID's buffer currently holds enough credit to
+// cover estimatedCost, debiting it optimistically if so. Call Finish once
+// the request completes to reconcile against its actual cost.
+func (m *BufferManager) Admit(peerID string, estimatedCost float64) (bool, error) {
+	n := m.nodeFor(peerID)
+	if !n.admit(estimatedCost) {
+		return false, fmt.Errorf("peer %s flow control buffer exhausted", peerID)
+	}
+	return true, nil
+}
+
+// Finish reconciles the debit Admit made for a request against its actual
+// measured cost, refunding (or further debiting) the difference.
+func (m *BufferManager) Finish(peerID string, estimatedCost, actualCost float64) {
+	m.nodeFor(peerID).finish(estimatedCost, actualCost)
+}
+
+// Peers returns a snapshot of every known peer's buffer state.
+func (m *BufferManager) Peers() []Stats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := make([]Stats, 0, len(m.nodes))
+	for _, n := range m.nodes {
+		stats = append(stats, n.stats())
+	}
+	return stats
+}
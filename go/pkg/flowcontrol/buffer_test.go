@@ -0,0 +1,88 @@
+package flowcontrol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBufferManagerAdmitWithinBudget(t *testing.T) {
+	m := NewBufferManager(100, 10)
+
+	allowed, err := m.Admit("peer-a", 50)
+	if err != nil {
+		t.Fatalf("Admit failed: %v", err)
+	}
+	if !allowed {
+		t.Fatal("expected a cost within the starting buffer to be admitted")
+	}
+}
+
+func TestBufferManagerAdmitRejectsOverBudget(t *testing.T) {
+	m := NewBufferManager(100, 10)
+
+	allowed, err := m.Admit("peer-a", 150)
+	if allowed || err == nil {
+		t.Fatal("expected a cost exceeding the buffer limit to be rejected with an error")
+	}
+}
+
+func TestBufferManagerFinishRefundsUnderEstimate(t *testing.T) {
+	m := NewBufferManager(100, 0)
+
+	if allowed, err := m.Admit("peer-a", 50); err != nil || !allowed {
+		t.Fatalf("Admit failed: allowed=%v err=%v", allowed, err)
+	}
+	m.Finish("peer-a", 50, 20)
+
+	stats := m.Peers()
+	if len(stats) != 1 {
+		t.Fatalf("expected one tracked peer, got %d", len(stats))
+	}
+	if stats[0].Value != 80 {
+		t.Fatalf("buffer value after refund = %v, want 80 (100 - 50 + (50 - 20))", stats[0].Value)
+	}
+}
+
+func TestBufferManagerFinishDebitsOverEstimate(t *testing.T) {
+	m := NewBufferManager(100, 0)
+
+	if allowed, err := m.Admit("peer-a", 50); err != nil || !allowed {
+		t.Fatalf("Admit failed: allowed=%v err=%v", allowed, err)
+	}
+	m.Finish("peer-a", 50, 80)
+
+	stats := m.Peers()
+	if stats[0].Value != 20 {
+		t.Fatalf("buffer value after over-estimate reconciliation = %v, want 20 (100 - 50 + (50 - 80))", stats[0].Value)
+	}
+}
+
+func TestBufferManagerRecharges(t *testing.T) {
+	n := newClientNode("peer-a", 100, 10)
+	n.value = 0
+	n.lastUpdate = time.Now().Add(-5 * time.Second)
+
+	if !n.admit(40) {
+		t.Fatal("expected the buffer to have recharged enough to admit a cost of 40 after 5s at 10 units/sec")
+	}
+}
+
+func TestBufferManagerPeersIsolatesPerPeer(t *testing.T) {
+	m := NewBufferManager(100, 0)
+
+	m.Admit("peer-a", 30)
+	m.Admit("peer-b", 60)
+
+	stats := m.Peers()
+	values := make(map[string]float64, len(stats))
+	for _, s := range stats {
+		values[s.PeerID] = s.Value
+	}
+
+	if values["peer-a"] != 70 {
+		t.Fatalf("peer-a buffer value = %v, want 70", values["peer-a"])
+	}
+	if values["peer-b"] != 40 {
+		t.Fatalf("peer-b buffer value = %v, want 40", values["peer-b"])
+	}
+}
@@ -0,0 +1,114 @@
+// Package flowcontrol implements LES-style (go-ethereum Light client
+// Subprotocol) cost-based admission: request costs aren't uniform (a small
+// transfer vs. a large block proposal verification), so instead of every
+// request costing "1", callers report how long each request kind actually
+// took and debit future requests of that kind by a rolling estimate of its
+// real cost rather than a flat count.
+package flowcontrol
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RequestKind identifies a class of request whose cost is tracked
+// independently, e.g. "/v1/submit_proof" or "/v1/transactions".
+type RequestKind string
+
+const (
+	// ewmaAlpha weights the most recent sample against the running average.
+	// Lower values smooth out noise; higher values track load shifts faster.
+	ewmaAlpha = 0.2
+
+	// costUnitMs is the duration, in milliseconds, that maps to one cost
+	// unit. Calibrated so a cheap request (a few ms of work) costs close to
+	// the rate limiter's pre-flowcontrol "one token per request" behavior,
+	// while an expensive one (e.g. a block proposal verification) naturally
+	// costs many units.
+	costUnitMs = 5.0
+
+	// defaultCost is the estimate returned for a kind that has never
+	// reported a sample.
+	defaultCost = 1.0
+)
+
+var requestCostMs = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "coinjecture_flowcontrol_request_cost_ms",
+		Help: "Rolling EWMA of measured request cost in milliseconds, by request kind",
+	},
+	[]string{"kind"},
+)
+
+// costTracker holds the rolling cost estimate for one RequestKind.
+type costTracker struct {
+	mu          sync.Mutex
+	ewmaMs      float64
+	sampleCount uint64
+}
+
+var (
+	trackersMu sync.RWMutex
+	trackers   = make(map[RequestKind]*costTracker)
+)
+
+// Report records a measured (duration, bytes) sample for kind, folding it
+// into that kind's rolling cost estimate. bytes is accepted so callers can
+// log/weight payload size, but it does not currently factor into the
+// estimate: duration already reflects the cost of processing whatever size
+// the request was.
+func Report(kind RequestKind, duration time.Duration, bytes int) {
+	t := trackerFor(kind)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ms := float64(duration) / float64(time.Millisecond)
+	if t.sampleCount == 0 {
+		t.ewmaMs = ms
+	} else {
+		t.ewmaMs = ewmaAlpha*ms + (1-ewmaAlpha)*t.ewmaMs
+	}
+	t.sampleCount++
+
+	requestCostMs.WithLabelValues(string(kind)).Set(t.ewmaMs)
+}
+
+// EstimatedCost returns the current rolling cost estimate for kind, in cost
+// units, or defaultCost if kind has never reported a sample.
+func EstimatedCost(kind RequestKind) float64 {
+	trackersMu.RLock()
+	t, ok := trackers[kind]
+	trackersMu.RUnlock()
+	if !ok {
+		return defaultCost
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sampleCount == 0 {
+		return defaultCost
+	}
+	return t.ewmaMs / costUnitMs
+}
+
+func trackerFor(kind RequestKind) *costTracker {
+	trackersMu.RLock()
+	t, ok := trackers[kind]
+	trackersMu.RUnlock()
+	if ok {
+		return t
+	}
+
+	trackersMu.Lock()
+	defer trackersMu.Unlock()
+	if t, ok := trackers[kind]; ok {
+		return t
+	}
+	t = &costTracker{}
+	trackers[kind] = t
+	return t
+}
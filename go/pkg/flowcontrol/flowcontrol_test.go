@@ -0,0 +1,35 @@
+package flowcontrol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimatedCostDefaultsForUnknownKind(t *testing.T) {
+	if got := EstimatedCost(RequestKind("never-reported")); got != defaultCost {
+		t.Fatalf("EstimatedCost for an unreported kind = %v, want defaultCost %v", got, defaultCost)
+	}
+}
+
+func TestReportUpdatesEstimatedCost(t *testing.T) {
+	kind := RequestKind("test-report-updates")
+
+	Report(kind, 10*time.Millisecond, 0)
+	want := 10.0 / costUnitMs
+	if got := EstimatedCost(kind); got != want {
+		t.Fatalf("EstimatedCost after a single sample = %v, want %v", got, want)
+	}
+}
+
+func TestReportConvergesTowardRepeatedSamples(t *testing.T) {
+	kind := RequestKind("test-report-converges")
+
+	for i := 0; i < 50; i++ {
+		Report(kind, 20*time.Millisecond, 0)
+	}
+
+	want := 20.0 / costUnitMs
+	if got := EstimatedCost(kind); got < want*0.99 || got > want*1.01 {
+		t.Fatalf("EstimatedCost after many identical samples = %v, want close to %v", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package ipfs
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+)
+
+func TestAttestPinSignsWithConfiguredKey(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	client := &IPFSClient{
+		config: config.IPFSConfig{
+			NodeSigningKeys: map[string]string{
+				"node-a": hex.EncodeToString(priv),
+			},
+		},
+	}
+
+	ts := time.Now()
+	att, err := client.attestPin("node-a", "cid123", 42, ts)
+	if err != nil {
+		t.Fatalf("attestPin failed: %v", err)
+	}
+	if att == nil {
+		t.Fatal("expected a non-nil attestation")
+	}
+
+	msg := AttestationMessage(att.CID, att.Size, att.Timestamp)
+	if !ed25519.Verify(pub, msg[:], att.Signature) {
+		t.Fatal("attestation signature does not verify against the node's public key")
+	}
+}
+
+func TestAttestPinNoConfiguredKey(t *testing.T) {
+	client := &IPFSClient{config: config.IPFSConfig{NodeSigningKeys: map[string]string{}}}
+
+	att, err := client.attestPin("node-b", "cid123", 42, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error for an unattested node, got %v", err)
+	}
+	if att != nil {
+		t.Fatal("expected a nil attestation when the node has no configured signing key")
+	}
+}
+
+func TestAttestPinInvalidKey(t *testing.T) {
+	client := &IPFSClient{
+		config: config.IPFSConfig{
+			NodeSigningKeys: map[string]string{"node-a": "not-hex"},
+		},
+	}
+
+	if _, err := client.attestPin("node-a", "cid123", 42, time.Now()); err == nil {
+		t.Fatal("expected an error for a malformed signing key")
+	}
+}
+
+func TestVerifyManifestAcceptsQuorum(t *testing.T) {
+	pubA, privA, _ := ed25519.GenerateKey(nil)
+	pubB, privB, _ := ed25519.GenerateKey(nil)
+
+	cid, size, ts := "cidXYZ", uint64(100), time.Now()
+	msg := AttestationMessage(cid, size, ts)
+
+	manifest := &PinManifest{
+		CID:    cid,
+		Size:   size,
+		Quorum: "2/3",
+		Attestations: []PinAttestation{
+			{NodeID: "node-a", CID: cid, Size: size, Timestamp: ts, Signature: ed25519.Sign(privA, msg[:])},
+			{NodeID: "node-b", CID: cid, Size: size, Timestamp: ts, Signature: ed25519.Sign(privB, msg[:])},
+		},
+	}
+
+	trustedKeys := map[string]ed25519.PublicKey{"node-a": pubA, "node-b": pubB}
+	if err := VerifyManifest(manifest, trustedKeys); err != nil {
+		t.Fatalf("VerifyManifest rejected a manifest meeting quorum: %v", err)
+	}
+}
+
+func TestVerifyManifestRejectsBelowQuorum(t *testing.T) {
+	pubA, privA, _ := ed25519.GenerateKey(nil)
+
+	cid, size, ts := "cidXYZ", uint64(100), time.Now()
+	msg := AttestationMessage(cid, size, ts)
+
+	manifest := &PinManifest{
+		CID:    cid,
+		Size:   size,
+		Quorum: "2/3",
+		Attestations: []PinAttestation{
+			{NodeID: "node-a", CID: cid, Size: size, Timestamp: ts, Signature: ed25519.Sign(privA, msg[:])},
+		},
+	}
+
+	trustedKeys := map[string]ed25519.PublicKey{"node-a": pubA}
+	if err := VerifyManifest(manifest, trustedKeys); err == nil {
+		t.Fatal("expected an error when fewer attestations than the quorum are valid")
+	}
+}
+
+func TestVerifyManifestRejectsDuplicateNode(t *testing.T) {
+	pubA, privA, _ := ed25519.GenerateKey(nil)
+
+	cid, size, ts := "cidXYZ", uint64(100), time.Now()
+	msg := AttestationMessage(cid, size, ts)
+	sig := ed25519.Sign(privA, msg[:])
+
+	manifest := &PinManifest{
+		CID:    cid,
+		Size:   size,
+		Quorum: "2/3",
+		Attestations: []PinAttestation{
+			{NodeID: "node-a", CID: cid, Size: size, Timestamp: ts, Signature: sig},
+			{NodeID: "node-a", CID: cid, Size: size, Timestamp: ts, Signature: sig},
+		},
+	}
+
+	trustedKeys := map[string]ed25519.PublicKey{"node-a": pubA}
+	if err := VerifyManifest(manifest, trustedKeys); err == nil {
+		t.Fatal("expected a repeated attestation from the same node not to count twice toward quorum")
+	}
+}
+
+func TestVerifyManifestRejectsWrongCIDOrSize(t *testing.T) {
+	pubA, privA, _ := ed25519.GenerateKey(nil)
+	pubB, privB, _ := ed25519.GenerateKey(nil)
+
+	cid, size, ts := "cidXYZ", uint64(100), time.Now()
+	msg := AttestationMessage(cid, size, ts)
+
+	manifest := &PinManifest{
+		CID:    cid,
+		Size:   size,
+		Quorum: "2/3",
+		Attestations: []PinAttestation{
+			{NodeID: "node-a", CID: cid, Size: size, Timestamp: ts, Signature: ed25519.Sign(privA, msg[:])},
+			// node-b attests a different CID; should not count toward this manifest's quorum.
+			{NodeID: "node-b", CID: "other-cid", Size: size, Timestamp: ts, Signature: ed25519.Sign(privB, msg[:])},
+		},
+	}
+
+	trustedKeys := map[string]ed25519.PublicKey{"node-a": pubA, "node-b": pubB}
+	if err := VerifyManifest(manifest, trustedKeys); err == nil {
+		t.Fatal("expected an error when only one attestation actually matches the manifest")
+	}
+}
@@ -2,10 +2,12 @@
 package ipfs
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,22 +29,24 @@ type IPFSClient struct {
 
 // PinResult represents the result of a pin operation
 type PinResult struct {
-	Node    string
-	Success bool
-	Error   error
-	Size    uint64
-	Hash    string
+	Node        string
+	Success     bool
+	Error       error
+	Size        uint64
+	Hash        string
+	Attestation *PinAttestation // set when Success and the node has a configured signing key
 }
 
 // PinManifest holds pinning metadata for audit
 type PinManifest struct {
-	CID          string    `json:"cid"`
-	Size         uint64    `json:"size"`
-	ContentHash  string    `json:"content_hash"`
-	PinnedNodes  []string  `json:"pinned_nodes"`
-	Quorum       string    `json:"quorum"`
-	Timestamp    time.Time `json:"timestamp"`
-	SignatureHex string    `json:"signature,omitempty"`
+	CID          string           `json:"cid"`
+	Size         uint64           `json:"size"`
+	ContentHash  string           `json:"content_hash"`
+	PinnedNodes  []string         `json:"pinned_nodes"`
+	Quorum       string           `json:"quorum"`
+	Timestamp    time.Time        `json:"timestamp"`
+	SignatureHex string           `json:"signature,omitempty"`
+	Attestations []PinAttestation `json:"attestations,omitempty"`
 }
 
 // NewIPFSClient creates a new IPFS client with quorum support
@@ -52,23 +56,9 @@ func NewIPFSClient(cfg config.IPFSConfig, log *logger.Logger) (*IPFSClient, erro
 	}
 
 	// Parse quorum (e.g., "2/3")
-	parts := strings.Split(cfg.PinQuorum, "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid quorum format: %s (expected format: N/M)", cfg.PinQuorum)
-	}
-
-	quorumNum, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return nil, fmt.Errorf("invalid quorum numerator: %s", parts[0])
-	}
-
-	quorumDen, err := strconv.Atoi(parts[1])
+	quorumNum, quorumDen, err := parseQuorum(cfg.PinQuorum)
 	if err != nil {
-		return nil, fmt.Errorf("invalid quorum denominator: %s", parts[1])
-	}
-
-	if quorumNum > quorumDen || quorumNum < 1 {
-		return nil, fmt.Errorf("invalid quorum: %d/%d", quorumNum, quorumDen)
+		return nil, err
 	}
 
 	// Create shell connections to all nodes
@@ -89,6 +79,31 @@ func NewIPFSClient(cfg config.IPFSConfig, log *logger.Logger) (*IPFSClient, erro
 	return client, nil
 }
 
+// parseQuorum parses a "N/M" quorum string (e.g. "2/3"), as used by both
+// IPFSConfig.PinQuorum and PinManifest.Quorum.
+func parseQuorum(s string) (num, den int, err error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid quorum format: %s (expected format: N/M)", s)
+	}
+
+	num, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid quorum numerator: %s", parts[0])
+	}
+
+	den, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid quorum denominator: %s", parts[1])
+	}
+
+	if num > den || num < 1 {
+		return 0, 0, fmt.Errorf("invalid quorum: %d/%d", num, den)
+	}
+
+	return num, den, nil
+}
+
 // PinWithQuorum pins content to quorum of IPFS nodes
 func (c *IPFSClient) PinWithQuorum(ctx context.Context, content io.Reader) (*PinManifest, error) {
 	// Read content into memory (needed for multiple uploads)
@@ -100,6 +115,10 @@ func (c *IPFSClient) PinWithQuorum(ctx context.Context, content io.Reader) (*Pin
 	// Compute content hash for integrity check
 	contentHash := sha256.Sum256(data)
 
+	// pinTime stamps both every node's attestation and the manifest itself,
+	// so an attestation's Timestamp matches the claim it's part of.
+	pinTime := time.Now()
+
 	// Pin to all nodes in parallel
 	results := make(chan PinResult, len(c.shells))
 	var wg sync.WaitGroup
@@ -143,11 +162,17 @@ func (c *IPFSClient) PinWithQuorum(ctx context.Context, content io.Reader) (*Pin
 				size = uint64(stat.CumulativeSize)
 			}
 
+			attestation, err := c.attestPin(nodeAddr, cid, size, pinTime)
+			if err != nil {
+				c.log.WithError(err).WithField("node", nodeAddr).Warn("Failed to sign pin attestation")
+			}
+
 			results <- PinResult{
-				Node:    nodeAddr,
-				Success: true,
-				Hash:    cid,
-				Size:    size,
+				Node:        nodeAddr,
+				Success:     true,
+				Hash:        cid,
+				Size:        size,
+				Attestation: attestation,
 			}
 
 		}(i, sh, c.config.Nodes[i])
@@ -177,6 +202,13 @@ func (c *IPFSClient) PinWithQuorum(ctx context.Context, content io.Reader) (*Pin
 		}
 	}
 
+	var attestations []PinAttestation
+	for _, pin := range successfulPins {
+		if pin.Attestation != nil {
+			attestations = append(attestations, *pin.Attestation)
+		}
+	}
+
 	// Check quorum
 	if len(successfulPins) < c.quorumNum {
 		return nil, fmt.Errorf("pin quorum not met: got %d/%d, required %d/%d",
@@ -195,12 +227,13 @@ func (c *IPFSClient) PinWithQuorum(ctx context.Context, content io.Reader) (*Pin
 	}
 
 	manifest := &PinManifest{
-		CID:         cid,
-		Size:        size,
-		ContentHash: fmt.Sprintf("%x", contentHash),
-		PinnedNodes: pinnedNodes,
-		Quorum:      c.config.PinQuorum,
-		Timestamp:   time.Now(),
+		CID:          cid,
+		Size:         size,
+		ContentHash:  fmt.Sprintf("%x", contentHash),
+		PinnedNodes:  pinnedNodes,
+		Quorum:       c.config.PinQuorum,
+		Timestamp:    pinTime,
+		Attestations: attestations,
 	}
 
 	return manifest, nil
@@ -255,8 +288,16 @@ func (c *IPFSClient) VerifyCID(ctx context.Context, cid string, expectedSize uin
 	return nil
 }
 
-// Get retrieves content by CID from any available node
-func (c *IPFSClient) Get(ctx context.Context, cid string) (io.ReadCloser, error) {
+// Get retrieves content by CID from any available node, falling back to
+// IPFSConfig.GatewayFallback's public HTTP gateways (see getFromGateways)
+// once every shell node has failed. If expectedHash is non-empty
+// (typically PinManifest.ContentHash), the retrieved bytes are SHA-256
+// verified against it before being returned — a node or gateway response
+// failing the check is treated the same as one that errored outright and
+// the next candidate is tried, so a compromised or malicious gateway can't
+// silently substitute different content for the requested CID. Passing ""
+// skips verification, matching VerifyCID's expectedHash convention.
+func (c *IPFSClient) Get(ctx context.Context, cid string, expectedHash string) (io.ReadCloser, error) {
 	// Try each node until one succeeds
 	for i, sh := range c.shells {
 		reader, err := sh.Cat(cid)
@@ -268,14 +309,164 @@ func (c *IPFSClient) Get(ctx context.Context, cid string) (io.ReadCloser, error)
 			continue
 		}
 
+		if expectedHash == "" {
+			c.log.WithField("node", c.config.Nodes[i]).
+				WithField("cid", cid).
+				Debug("Retrieved CID from node")
+			return reader, nil
+		}
+
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			c.log.WithError(err).
+				WithField("node", c.config.Nodes[i]).
+				WithField("cid", cid).
+				Debug("Failed to read CID body from node, trying next")
+			continue
+		}
+		if err := verifyContentHash(data, expectedHash); err != nil {
+			c.log.WithError(err).
+				WithField("node", c.config.Nodes[i]).
+				WithField("cid", cid).
+				Warn("Node returned content failing hash verification, trying next")
+			continue
+		}
+
 		c.log.WithField("node", c.config.Nodes[i]).
 			WithField("cid", cid).
 			Debug("Retrieved CID from node")
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	if len(c.config.GatewayFallback) == 0 {
+		return nil, fmt.Errorf("failed to retrieve CID from any node: %s", cid)
+	}
+
+	c.log.WithField("cid", cid).Warn("All IPFS shell nodes failed, falling back to public gateways")
+	return c.getFromGateways(ctx, cid, expectedHash)
+}
+
+// gatewayResult carries one gateway fetch's outcome back to the hedge loop
+// in getFromGateways.
+type gatewayResult struct {
+	index int
+	data  []byte
+	err   error
+}
+
+// getFromGateways retrieves cid from IPFSConfig.GatewayFallback's gateway
+// URL templates (each containing a "{cid}" placeholder) using a
+// hedged-request strategy: the first gateway is fired immediately, and if
+// it hasn't answered within HedgeDelay the next one is fired too (and so
+// on), racing whichever are in flight; the first successful, hash-verified
+// response wins and every other in-flight request is canceled. A
+// HedgeDelay of 0 disables the proactive hedge, falling back to trying
+// gateways strictly one at a time as each fails.
+func (c *IPFSClient) getFromGateways(ctx context.Context, cid string, expectedHash string) (io.ReadCloser, error) {
+	gateways := c.config.GatewayFallback
+	if len(gateways) == 0 {
+		return nil, fmt.Errorf("failed to retrieve CID from any node or gateway: %s", cid)
+	}
+
+	gwCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan gatewayResult, len(gateways))
+	fireGateway := func(i int) {
+		go func() {
+			data, err := c.fetchFromGateway(gwCtx, gateways[i], cid)
+			results <- gatewayResult{index: i, data: data, err: err}
+		}()
+	}
+
+	var hedgeTimer *time.Timer
+	var hedgeC <-chan time.Time
+	if c.config.HedgeDelay > 0 {
+		hedgeTimer = time.NewTimer(c.config.HedgeDelay)
+		defer hedgeTimer.Stop()
+		hedgeC = hedgeTimer.C
+	}
+
+	fireGateway(0)
+	fired, pending := 1, 1
+	var lastErr error
+
+	for pending > 0 {
+		select {
+		case res := <-results:
+			pending--
+
+			if res.err == nil && expectedHash != "" {
+				res.err = verifyContentHash(res.data, expectedHash)
+			}
+
+			if res.err != nil {
+				c.log.WithError(res.err).
+					WithField("gateway", gateways[res.index]).
+					WithField("cid", cid).
+					Debug("Gateway fetch failed, trying next")
+				lastErr = res.err
+				if fired < len(gateways) {
+					fireGateway(fired)
+					fired++
+					pending++
+				}
+				continue
+			}
 
-		return reader, nil
+			c.log.WithField("gateway", gateways[res.index]).
+				WithField("cid", cid).
+				Info("Retrieved CID from gateway fallback")
+			return io.NopCloser(bytes.NewReader(res.data)), nil
+
+		case <-hedgeC:
+			if fired < len(gateways) {
+				fireGateway(fired)
+				fired++
+				pending++
+			}
+			hedgeTimer.Reset(c.config.HedgeDelay)
+		}
 	}
 
-	return nil, fmt.Errorf("failed to retrieve CID from any node: %s", cid)
+	if lastErr != nil {
+		return nil, fmt.Errorf("failed to retrieve CID from any gateway: %w", lastErr)
+	}
+	return nil, fmt.Errorf("failed to retrieve CID from any node or gateway: %s", cid)
+}
+
+// fetchFromGateway issues one GET against template with its "{cid}"
+// placeholder substituted, returning the full response body.
+func (c *IPFSClient) fetchFromGateway(ctx context.Context, template string, cid string) ([]byte, error) {
+	url := strings.ReplaceAll(template, "{cid}", cid)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build gateway request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gateway returned status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// verifyContentHash reports an error unless sha256(data) matches
+// expectedHash, hex-encoded the same way PinManifest.ContentHash is.
+func verifyContentHash(data []byte, expectedHash string) error {
+	actual := sha256.Sum256(data)
+	if fmt.Sprintf("%x", actual) != expectedHash {
+		return fmt.Errorf("content hash mismatch: expected %s, got %x", expectedHash, actual)
+	}
+	return nil
 }
 
 // AuditCIDs checks a list of CIDs for integrity
@@ -0,0 +1,56 @@
+package limiter
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+)
+
+// virtualNodesPerPeer controls how many ring positions each real node
+// occupies. More positions smooth out the keyspace so adding or removing a
+// single peer only reshuffles a proportional slice of keys instead of
+// causing large, uneven jumps in ownership.
+const virtualNodesPerPeer = 64
+
+// hashRing assigns rate-limit keys to an owning node ID via consistent
+// hashing over the current peer set (see DistributedLimiter.rebuildRing).
+type hashRing struct {
+	positions []uint32
+	owners    map[uint32]string
+}
+
+// newHashRing builds a ring from the given node IDs. An empty nodeIDs slice
+// yields a ring whose Owner always returns "".
+func newHashRing(nodeIDs []string) *hashRing {
+	r := &hashRing{
+		owners: make(map[uint32]string, len(nodeIDs)*virtualNodesPerPeer),
+	}
+	for _, id := range nodeIDs {
+		for v := 0; v < virtualNodesPerPeer; v++ {
+			h := ringHash(fmt.Sprintf("%s#%d", id, v))
+			r.owners[h] = id
+			r.positions = append(r.positions, h)
+		}
+	}
+	sort.Slice(r.positions, func(i, j int) bool { return r.positions[i] < r.positions[j] })
+	return r
+}
+
+func ringHash(s string) uint32 {
+	return crc32.ChecksumIEEE([]byte(s))
+}
+
+// Owner returns the node ID responsible for key: the first ring position at
+// or after hash(key), wrapping around to the first position if key hashes
+// past the end of the ring.
+func (r *hashRing) Owner(key string) string {
+	if len(r.positions) == 0 {
+		return ""
+	}
+	h := ringHash(key)
+	i := sort.Search(len(r.positions), func(i int) bool { return r.positions[i] >= h })
+	if i == len(r.positions) {
+		i = 0
+	}
+	return r.owners[r.positions[i]]
+}
@@ -0,0 +1,45 @@
+package limiter
+
+import "testing"
+
+func TestHashRingEmptyHasNoOwner(t *testing.T) {
+	r := newHashRing(nil)
+	if owner := r.Owner("any-key"); owner != "" {
+		t.Fatalf("expected an empty ring to have no owner, got %q", owner)
+	}
+}
+
+func TestHashRingOwnershipIsStable(t *testing.T) {
+	r := newHashRing([]string{"node-a", "node-b", "node-c"})
+
+	first := r.Owner("some-key")
+	for i := 0; i < 10; i++ {
+		if got := r.Owner("some-key"); got != first {
+			t.Fatalf("Owner(%q) = %q on call %d, want stable %q", "some-key", got, i, first)
+		}
+	}
+}
+
+func TestHashRingSingleNodeOwnsEverything(t *testing.T) {
+	r := newHashRing([]string{"only-node"})
+
+	for _, key := range []string{"a", "b", "c", "peer:xyz"} {
+		if owner := r.Owner(key); owner != "only-node" {
+			t.Fatalf("Owner(%q) = %q, want only-node", key, owner)
+		}
+	}
+}
+
+func TestHashRingDistributesAcrossNodes(t *testing.T) {
+	r := newHashRing([]string{"node-a", "node-b", "node-c"})
+
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		key := "key-" + string(rune('a'+i%26)) + string(rune(i))
+		counts[r.Owner(key)]++
+	}
+
+	if len(counts) < 2 {
+		t.Fatalf("expected keys to spread across more than one node, got distribution %v", counts)
+	}
+}
@@ -0,0 +1,545 @@
+// Distributed rate limiting: partitions rate-limit keys across the P2P
+// cluster via consistent hashing, so N nodes enforce the configured limits
+// cluster-wide instead of each one enforcing them independently (which
+// otherwise yields an effective N x GlobalLimit).
+package limiter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/flowcontrol"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/p2p"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+const (
+	takeRPCMethod     = "ratelimit.take"
+	transferRPCMethod = "ratelimit.transfer"
+
+	// coalesceWindow bounds how long a Take waits for concurrent callers on
+	// the same key before the batch is forwarded to the owner.
+	coalesceWindow = 75 * time.Millisecond
+
+	rebalanceInterval = 10 * time.Second
+)
+
+var (
+	ownerForwardsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "coinjecture_rate_limit_owner_forwards_total",
+			Help: "Total Take RPCs forwarded to a key's owning peer",
+		},
+		[]string{"kind"},
+	)
+
+	ownerRepliesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "coinjecture_rate_limit_owner_replies_total",
+			Help: "Total replies received from owning peers for forwarded Take RPCs",
+		},
+		[]string{"kind"},
+	)
+
+	shadowBucketRejectTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "coinjecture_rate_limit_shadow_bucket_reject_total",
+			Help: "Total requests rejected from a non-owner's shadow bucket without a round-trip to the owner",
+		},
+		[]string{"kind"},
+	)
+)
+
+// takeRequest/takeResponse are the wire format for the takeRPCMethod RPC.
+type takeRequest struct {
+	Kind string `json:"kind"`
+	Key  string `json:"key"`
+	Hits int    `json:"hits"`
+}
+
+type takeResponse struct {
+	Allowed   bool      `json:"allowed"`
+	Remaining float64   `json:"remaining"`
+	ResetTime time.Time `json:"reset_time"`
+}
+
+// transferRequest carries one key's authoritative bucket state from its old
+// owner to its new owner after a rebalance.
+type transferRequest struct {
+	Kind       string    `json:"kind"`
+	Key        string    `json:"key"`
+	Tokens     float64   `json:"tokens"`
+	LastRefill time.Time `json:"last_refill"`
+}
+
+// pendingForward coalesces concurrent Take calls for the same key that
+// arrive within one coalesceWindow tick into a single RPC to the owner.
+type pendingForward struct {
+	hits   int
+	done   chan struct{}
+	result takeResponse
+	err    error
+}
+
+// shadowEntry is a non-owner's cached view of a key's last known state,
+// used to early-reject without a round-trip when clearly over limit.
+type shadowEntry struct {
+	remaining float64
+	resetTime time.Time
+}
+
+// DistributedLimiter is a cluster-aware Limiter: rate-limit keys (IP,
+// peer-ID, or a caller-supplied key) are partitioned across the P2P peer
+// set via consistent hashing. One peer owns the authoritative token bucket
+// for each key; every other peer forwards Take RPCs to the owner, coalesced
+// on a short tick, and keeps a local shadow bucket seeded from the owner's
+// last reply so it can early-reject without a round-trip when clearly over
+// limit.
+type DistributedLimiter struct {
+	cfg config.RateLimiterConfig
+	p2p *p2p.Manager
+	log *logger.Logger
+
+	// local handles everything that stays node-local: queue backpressure
+	// and the per-key token buckets this node owns.
+	local *RateLimiter
+
+	ringMu sync.RWMutex
+	ring   *hashRing
+
+	ownersMu sync.Mutex
+	owned    map[string]*rate.Limiter // key -> authoritative bucket, for keys this node owns
+
+	shadowMu sync.RWMutex
+	shadow   map[string]*shadowEntry
+
+	forwardMu  sync.Mutex
+	forwarding map[string]*pendingForward
+
+	stopChan chan struct{}
+}
+
+var _ Limiter = (*DistributedLimiter)(nil)
+
+// NewDistributedRateLimiter creates a cluster-aware rate limiter that
+// partitions keys across p2pMgr's peer set. It satisfies the same Limiter
+// interface as RateLimiter, so API handlers don't change.
+func NewDistributedRateLimiter(cfg config.RateLimiterConfig, p2pMgr *p2p.Manager, log *logger.Logger) *DistributedLimiter {
+	dl := &DistributedLimiter{
+		cfg:        cfg,
+		p2p:        p2pMgr,
+		log:        log,
+		local:      NewRateLimiter(cfg, log),
+		owned:      make(map[string]*rate.Limiter),
+		shadow:     make(map[string]*shadowEntry),
+		forwarding: make(map[string]*pendingForward),
+		stopChan:   make(chan struct{}),
+	}
+	dl.local.SetPeerScorer(p2pMgr)
+	dl.rebuildRing()
+
+	p2pMgr.RegisterHandler(takeRPCMethod, dl.handleTakeRPC)
+	p2pMgr.RegisterHandler(transferRPCMethod, dl.handleTransferRPC)
+
+	go dl.rebalanceLoop()
+
+	return dl
+}
+
+// Stop stops the rebalance loop and the embedded local limiter's cleanup.
+func (dl *DistributedLimiter) Stop() {
+	close(dl.stopChan)
+	dl.local.Stop()
+}
+
+// CheckIP checks if a request from ip costing cost units is allowed,
+// cluster-wide.
+func (dl *DistributedLimiter) CheckIP(ip string, cost float64) (bool, error) {
+	return dl.check("ip", ip, dl.cfg.IPLimit, cost)
+}
+
+// CheckPeerID checks if a request from peerID costing cost units is
+// allowed, cluster-wide. The cluster-wide token bucket is checked first;
+// the peer's LES-style flow control buffer, like CheckBackpressure's queue,
+// is node-local (this node's connection to peerID) rather than partitioned
+// across the cluster.
+func (dl *DistributedLimiter) CheckPeerID(peerID string, cost float64) (bool, error) {
+	allowed, err := dl.check("peer", peerID, dl.cfg.PeerIDLimit, cost)
+	if err != nil || !allowed {
+		return allowed, err
+	}
+	return dl.local.peerBuffers.Admit(peerID, cost)
+}
+
+// CheckRequest checks both IP and global limits for an HTTP request costing
+// cost units.
+func (dl *DistributedLimiter) CheckRequest(remoteAddr string, cost float64) (bool, error) {
+	if !dl.cfg.Enabled {
+		return true, nil
+	}
+
+	ip, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		ip = remoteAddr
+	}
+
+	return dl.CheckIP(ip, cost)
+}
+
+// FinishPeerRequest reconciles the flow control buffer debit CheckPeerID
+// made for peerID against the request's actual measured cost.
+func (dl *DistributedLimiter) FinishPeerRequest(peerID string, estimatedCost, actualCost float64) {
+	dl.local.peerBuffers.Finish(peerID, estimatedCost, actualCost)
+}
+
+// PeerBufferStats returns a snapshot of every known peer's flow control
+// buffer state.
+func (dl *DistributedLimiter) PeerBufferStats() []flowcontrol.Stats {
+	return dl.local.peerBuffers.Peers()
+}
+
+// CheckBackpressure reports node-local verification queue utilization; the
+// queue itself is not a cluster-wide resource, so this isn't distributed.
+func (dl *DistributedLimiter) CheckBackpressure() (bool, float64) {
+	return dl.local.CheckBackpressure()
+}
+
+// Stats returns current distributed rate limiter statistics.
+func (dl *DistributedLimiter) Stats() map[string]interface{} {
+	dl.ownersMu.Lock()
+	ownedCount := len(dl.owned)
+	dl.ownersMu.Unlock()
+
+	dl.shadowMu.RLock()
+	shadowCount := len(dl.shadow)
+	dl.shadowMu.RUnlock()
+
+	stats := dl.local.Stats()
+	stats["distributed"] = true
+	stats["self_id"] = dl.p2p.SelfID()
+	stats["owned_keys"] = ownedCount
+	stats["shadow_keys"] = shadowCount
+	return stats
+}
+
+// check runs the cluster-wide admission decision for a (kind, key) pair
+// costing cost units, first against the node-local backpressure queue and
+// the shared "global" key, then against the per-kind key itself.
+func (dl *DistributedLimiter) check(kind, key string, limit int, cost float64) (bool, error) {
+	if !dl.cfg.Enabled {
+		return true, nil
+	}
+
+	if _, util := dl.local.CheckBackpressure(); util >= 1.0 {
+		return false, fmt.Errorf("queue full: verification queue at capacity")
+	}
+
+	hits := costToHits(cost)
+
+	if allowed, err := dl.take("global", "", dl.cfg.GlobalLimit, hits); err != nil {
+		return false, err
+	} else if !allowed {
+		return false, fmt.Errorf("global rate limit exceeded")
+	}
+
+	allowed, err := dl.take(kind, key, limit, hits)
+	if err != nil {
+		return false, err
+	}
+	if !allowed {
+		return false, fmt.Errorf("%s rate limit exceeded", kind)
+	}
+	return true, nil
+}
+
+// take resolves the owner for (kind, key) and returns whether hits is
+// admitted, either by applying it locally (this node owns the key) or by
+// consulting the shadow bucket / forwarding to the owner.
+func (dl *DistributedLimiter) take(kind, rawKey string, limit, hits int) (bool, error) {
+	key := kind + ":" + rawKey
+	owner := dl.owner(key)
+
+	if owner == "" || owner == dl.p2p.SelfID() {
+		return dl.applyLocal(kind, key, limit, hits), nil
+	}
+
+	if reject, ok := dl.shadowReject(key); ok {
+		shadowBucketRejectTotal.WithLabelValues(kind).Inc()
+		return !reject, nil
+	}
+
+	resp, err := dl.forward(kind, key, owner, hits)
+	if err != nil {
+		// No peer transport / owner unreachable: fail open locally rather
+		// than block all admission on a single peer outage.
+		dl.log.WithError(err).WithField("key", key).Warn("Rate limit owner unreachable, falling back to local bucket")
+		return dl.applyLocal(kind, key, limit, hits), nil
+	}
+
+	dl.updateShadow(key, resp)
+	return resp.Allowed, nil
+}
+
+// applyLocal admits hits against this node's authoritative bucket for key,
+// creating one sized for limit/BurstMultiplier on first use.
+func (dl *DistributedLimiter) applyLocal(kind, key string, limit, hits int) bool {
+	dl.ownersMu.Lock()
+	l, exists := dl.owned[key]
+	if !exists {
+		l = rate.NewLimiter(rate.Limit(limit), int(float64(limit)*dl.cfg.BurstMultiplier))
+		dl.owned[key] = l
+	}
+	dl.ownersMu.Unlock()
+
+	return l.AllowN(time.Now(), hits)
+}
+
+// shadowReject reports whether key can be rejected purely from the cached
+// shadow bucket (no tokens left and the reset time hasn't passed yet), and
+// whether the shadow bucket had an opinion at all.
+func (dl *DistributedLimiter) shadowReject(key string) (reject bool, ok bool) {
+	dl.shadowMu.RLock()
+	defer dl.shadowMu.RUnlock()
+
+	entry, exists := dl.shadow[key]
+	if !exists {
+		return false, false
+	}
+	if entry.remaining > 0 || time.Now().After(entry.resetTime) {
+		return false, false
+	}
+	return true, true
+}
+
+func (dl *DistributedLimiter) updateShadow(key string, resp takeResponse) {
+	dl.shadowMu.Lock()
+	dl.shadow[key] = &shadowEntry{remaining: resp.Remaining, resetTime: resp.ResetTime}
+	dl.shadowMu.Unlock()
+}
+
+// forward coalesces hits for key into a single Take RPC per coalesceWindow
+// tick and blocks the caller until that tick's RPC completes.
+func (dl *DistributedLimiter) forward(kind, key, owner string, hits int) (takeResponse, error) {
+	dl.forwardMu.Lock()
+	pf, exists := dl.forwarding[key]
+	if !exists {
+		pf = &pendingForward{done: make(chan struct{})}
+		dl.forwarding[key] = pf
+		time.AfterFunc(coalesceWindow, func() { dl.flushForward(kind, key, owner) })
+	}
+	pf.hits += hits
+	dl.forwardMu.Unlock()
+
+	<-pf.done
+	return pf.result, pf.err
+}
+
+func (dl *DistributedLimiter) flushForward(kind, key, owner string) {
+	dl.forwardMu.Lock()
+	pf := dl.forwarding[key]
+	delete(dl.forwarding, key)
+	dl.forwardMu.Unlock()
+
+	if pf == nil {
+		return
+	}
+	defer close(pf.done)
+
+	ownerForwardsTotal.WithLabelValues(kind).Inc()
+
+	req := takeRequest{Kind: kind, Key: key, Hits: pf.hits}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		pf.err = fmt.Errorf("marshal take request: %w", err)
+		return
+	}
+
+	replyBytes, err := dl.p2p.SendRPC(owner, takeRPCMethod, payload)
+	if err != nil {
+		pf.err = err
+		return
+	}
+
+	var resp takeResponse
+	if err := json.Unmarshal(replyBytes, &resp); err != nil {
+		pf.err = fmt.Errorf("unmarshal take response: %w", err)
+		return
+	}
+	ownerRepliesTotal.WithLabelValues(kind).Inc()
+	pf.result = resp
+}
+
+// handleTakeRPC is the owner-side handler for takeRPCMethod: it applies the
+// forwarded hits to the local authoritative bucket and replies with the
+// resulting admission decision and remaining allowance.
+func (dl *DistributedLimiter) handleTakeRPC(fromPeerID string, payload []byte) ([]byte, error) {
+	var req takeRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, fmt.Errorf("unmarshal take request: %w", err)
+	}
+
+	limit := dl.limitFor(req.Kind)
+	allowed := dl.applyLocal(req.Kind, req.Key, limit, req.Hits)
+
+	dl.ownersMu.Lock()
+	l := dl.owned[req.Key]
+	dl.ownersMu.Unlock()
+
+	resp := takeResponse{Allowed: allowed}
+	if l != nil {
+		resp.Remaining = l.Tokens()
+		if resp.Remaining < 1 {
+			resp.ResetTime = time.Now().Add(time.Duration((1 - resp.Remaining) / float64(l.Limit()) * float64(time.Second)))
+		}
+	}
+
+	return json.Marshal(resp)
+}
+
+// handleTransferRPC is the new-owner-side handler for transferRPCMethod: it
+// installs bucket state handed off by a key's previous owner after a
+// rebalance, preserving in-flight token counts instead of resetting them.
+func (dl *DistributedLimiter) handleTransferRPC(fromPeerID string, payload []byte) ([]byte, error) {
+	var req transferRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, fmt.Errorf("unmarshal transfer request: %w", err)
+	}
+
+	limit := dl.limitFor(req.Kind)
+	burst := int(float64(limit) * dl.cfg.BurstMultiplier)
+	l := rate.NewLimiter(rate.Limit(limit), burst)
+	// rate.Limiter has no public setter for its token count, so approximate
+	// the transferred state by draining a fresh (full-burst) limiter down to
+	// the handed-off token count.
+	if deficit := burst - int(req.Tokens); deficit > 0 {
+		l.AllowN(req.LastRefill, deficit)
+	}
+
+	dl.ownersMu.Lock()
+	dl.owned[req.Key] = l
+	dl.ownersMu.Unlock()
+
+	return json.Marshal(struct{}{})
+}
+
+// limitFor returns the configured per-request limit for a key kind.
+func (dl *DistributedLimiter) limitFor(kind string) int {
+	switch kind {
+	case "ip":
+		return dl.cfg.IPLimit
+	case "peer":
+		return dl.cfg.PeerIDLimit
+	case "global":
+		return dl.cfg.GlobalLimit
+	default:
+		return dl.cfg.IPLimit
+	}
+}
+
+// owner returns the node ID currently assigned to key by the hash ring.
+func (dl *DistributedLimiter) owner(key string) string {
+	return dl.currentRing().Owner(key)
+}
+
+// currentRing returns the hash ring in effect at the time of the call.
+func (dl *DistributedLimiter) currentRing() *hashRing {
+	dl.ringMu.RLock()
+	defer dl.ringMu.RUnlock()
+	return dl.ring
+}
+
+// rebuildRing recomputes the hash ring from the current peer set.
+func (dl *DistributedLimiter) rebuildRing() *hashRing {
+	nodes := append(dl.p2p.Peers(), dl.p2p.SelfID())
+	ring := newHashRing(nodes)
+
+	dl.ringMu.Lock()
+	dl.ring = ring
+	dl.ringMu.Unlock()
+
+	return ring
+}
+
+// rebalanceLoop periodically rebuilds the ring and, when a key's owner
+// changes away from this node, hands its bucket state off to the new owner
+// in a single transfer RPC.
+func (dl *DistributedLimiter) rebalanceLoop() {
+	ticker := time.NewTicker(rebalanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			dl.rebalance()
+		case <-dl.stopChan:
+			return
+		}
+	}
+}
+
+// bucketHandoff is one key's bucket state due to transfer to a new owner.
+type bucketHandoff struct {
+	key      string
+	newOwner string
+	bucket   *rate.Limiter
+}
+
+func (dl *DistributedLimiter) rebalance() {
+	oldRing := dl.currentRing()
+	ring := dl.rebuildRing()
+	self := dl.p2p.SelfID()
+
+	dl.ownersMu.Lock()
+	var handoffs []bucketHandoff
+	for key, l := range dl.owned {
+		newOwner := ring.Owner(key)
+		if newOwner != "" && newOwner != self {
+			handoffs = append(handoffs, bucketHandoff{key: key, newOwner: newOwner, bucket: l})
+			delete(dl.owned, key)
+		}
+	}
+	dl.ownersMu.Unlock()
+
+	for _, h := range handoffs {
+		dl.transferTo(h.newOwner, h.key, h.bucket)
+	}
+
+	// Keys whose owner changed invalidate any shadow entry we were keeping
+	// for them; the next forward will re-seed it from the new owner.
+	dl.shadowMu.Lock()
+	for key := range dl.shadow {
+		if ring.Owner(key) != oldRing.Owner(key) {
+			delete(dl.shadow, key)
+		}
+	}
+	dl.shadowMu.Unlock()
+}
+
+func (dl *DistributedLimiter) transferTo(newOwner, key string, l *rate.Limiter) {
+	kind := key
+	if i := strings.IndexByte(key, ':'); i >= 0 {
+		kind = key[:i]
+	}
+
+	req := transferRequest{Kind: kind, Key: key, Tokens: l.Tokens(), LastRefill: time.Now()}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		dl.log.WithError(err).WithField("key", key).Warn("Failed to marshal rate limit bucket transfer")
+		return
+	}
+
+	if _, err := dl.p2p.SendRPC(newOwner, transferRPCMethod, payload); err != nil {
+		dl.log.WithError(err).WithFields(logger.Fields{
+			"key":       key,
+			"new_owner": newOwner,
+		}).Warn("Failed to transfer rate limit bucket to new owner")
+	}
+}
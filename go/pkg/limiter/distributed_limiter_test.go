@@ -0,0 +1,64 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+	"golang.org/x/time/rate"
+)
+
+func TestDistributedLimiterLimitFor(t *testing.T) {
+	dl := &DistributedLimiter{cfg: config.RateLimiterConfig{IPLimit: 1, PeerIDLimit: 2, GlobalLimit: 3}}
+
+	cases := map[string]int{
+		"ip":      1,
+		"peer":    2,
+		"global":  3,
+		"unknown": 1,
+	}
+	for kind, want := range cases {
+		if got := dl.limitFor(kind); got != want {
+			t.Fatalf("limitFor(%q) = %d, want %d", kind, got, want)
+		}
+	}
+}
+
+func TestDistributedLimiterApplyLocalCreatesAndReusesBucket(t *testing.T) {
+	dl := &DistributedLimiter{cfg: config.RateLimiterConfig{BurstMultiplier: 2.0}, owned: make(map[string]*rate.Limiter)}
+
+	if !dl.applyLocal("ip", "ip:1.2.3.4", 10, 1) {
+		t.Fatal("expected the first hit against a fresh bucket to be admitted")
+	}
+
+	dl.ownersMu.Lock()
+	_, exists := dl.owned["ip:1.2.3.4"]
+	dl.ownersMu.Unlock()
+	if !exists {
+		t.Fatal("expected applyLocal to create and retain an owned bucket for the key")
+	}
+}
+
+func TestDistributedLimiterShadowReject(t *testing.T) {
+	dl := &DistributedLimiter{shadow: make(map[string]*shadowEntry)}
+
+	if _, ok := dl.shadowReject("no-entry"); ok {
+		t.Fatal("expected no opinion for a key with no shadow entry")
+	}
+
+	dl.updateShadow("exhausted", takeResponse{Remaining: 0, ResetTime: time.Now().Add(time.Hour)})
+	reject, ok := dl.shadowReject("exhausted")
+	if !ok || !reject {
+		t.Fatal("expected a shadow entry with no remaining capacity and a future reset to be rejected")
+	}
+
+	dl.updateShadow("has-capacity", takeResponse{Remaining: 5, ResetTime: time.Now().Add(time.Hour)})
+	if reject, ok := dl.shadowReject("has-capacity"); ok && reject {
+		t.Fatal("expected a shadow entry with remaining capacity to not be rejected")
+	}
+
+	dl.updateShadow("reset-passed", takeResponse{Remaining: 0, ResetTime: time.Now().Add(-time.Second)})
+	if reject, ok := dl.shadowReject("reset-passed"); ok && reject {
+		t.Fatal("expected a shadow entry whose reset time has passed to not be rejected")
+	}
+}
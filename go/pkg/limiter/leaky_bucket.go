@@ -0,0 +1,135 @@
+package limiter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+	"golang.org/x/time/rate"
+)
+
+// bucket is the shared admission interface both traffic-shaping algorithms
+// implement, so getIPLimiter/getPeerLimiter/globalLimiter can hold either
+// one without the call sites caring which.
+type bucket interface {
+	// AllowN admits n hits, returning whether they were allowed, the
+	// capacity remaining afterward, and (when rejected) how long until
+	// enough capacity frees up to admit n again.
+	AllowN(now time.Time, n int) (allowed bool, remaining float64, resetAfter time.Duration)
+	// Idle reports whether the bucket is back at its resting state (full
+	// for token bucket, empty for leaky bucket) and can be evicted by
+	// cleanup.
+	Idle() bool
+}
+
+// leakyBucket implements the leaky-bucket algorithm: each admitted hit adds
+// to level, which continuously drains at leakRate. A request is admitted
+// iff level+n <= capacity, giving smoother, non-bursty traffic shaping than
+// the token bucket.
+type leakyBucket struct {
+	mu       sync.Mutex
+	level    float64
+	lastLeak time.Time
+	leakRate float64 // capacity units drained per second
+	capacity float64
+
+	// drainOverLimit makes a rejected request still fill the bucket to
+	// capacity (instead of leaving level unchanged), matching gubernator's
+	// semantics for callers that want the counter to keep advancing on
+	// rejection rather than letting retries land for free.
+	drainOverLimit bool
+}
+
+// newLeakyBucket creates an empty leaky bucket draining at leakRate units/
+// second with the given capacity.
+func newLeakyBucket(leakRate, capacity float64, drainOverLimit bool) *leakyBucket {
+	return &leakyBucket{
+		leakRate:       leakRate,
+		capacity:       capacity,
+		drainOverLimit: drainOverLimit,
+		lastLeak:       time.Now(),
+	}
+}
+
+// AllowN leaks elapsed*leakRate from level, then admits n iff level+n fits
+// within capacity.
+func (b *leakyBucket) AllowN(now time.Time, n int) (allowed bool, remaining float64, resetAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.leak(now)
+	hits := float64(n)
+
+	if b.level+hits <= b.capacity {
+		b.level += hits
+		return true, b.capacity - b.level, 0
+	}
+
+	overflow := b.level + hits - b.capacity
+	if b.leakRate > 0 {
+		resetAfter = time.Duration(overflow / b.leakRate * float64(time.Second))
+	}
+	if b.drainOverLimit {
+		b.level = b.capacity
+	}
+	return false, b.capacity - b.level, resetAfter
+}
+
+// leak drains the bucket for the time elapsed since the last leak.
+func (b *leakyBucket) leak(now time.Time) {
+	elapsed := now.Sub(b.lastLeak).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.level -= elapsed * b.leakRate
+	if b.level < 0 {
+		b.level = 0
+	}
+	b.lastLeak = now
+}
+
+// Idle reports whether the bucket has fully drained.
+func (b *leakyBucket) Idle() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.leak(time.Now())
+	return b.level <= 0
+}
+
+// tokenBucketLimiter adapts *rate.Limiter to the bucket interface so token
+// bucket and leaky bucket tiers can be stored and called interchangeably.
+type tokenBucketLimiter struct {
+	limiter *rate.Limiter
+}
+
+func newTokenBucketLimiter(limit int, burst int) *tokenBucketLimiter {
+	return &tokenBucketLimiter{limiter: rate.NewLimiter(rate.Limit(limit), burst)}
+}
+
+func (t *tokenBucketLimiter) AllowN(now time.Time, n int) (allowed bool, remaining float64, resetAfter time.Duration) {
+	allowed = t.limiter.AllowN(now, n)
+	remaining = t.limiter.TokensAt(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+	if !allowed {
+		if deficit := float64(n) - remaining; deficit > 0 && t.limiter.Limit() > 0 {
+			resetAfter = time.Duration(deficit / float64(t.limiter.Limit()) * float64(time.Second))
+		}
+	}
+	return allowed, remaining, resetAfter
+}
+
+func (t *tokenBucketLimiter) Idle() bool {
+	return t.limiter.Tokens() == float64(t.limiter.Burst())
+}
+
+// newBucket constructs the bucket implementation configured for a tier:
+// limit is requests/sec, burst is limit*burstMultiplier.
+func newBucket(algorithm config.Algorithm, limit int, burstMultiplier float64, drainOverLimit bool) bucket {
+	burst := int(float64(limit) * burstMultiplier)
+	if algorithm == config.LeakyBucket {
+		return newLeakyBucket(float64(limit), float64(burst), drainOverLimit)
+	}
+	return newTokenBucketLimiter(limit, burst)
+}
@@ -0,0 +1,113 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+)
+
+func TestLeakyBucketAdmitsWithinCapacity(t *testing.T) {
+	b := newLeakyBucket(10, 100, false)
+	now := time.Now()
+
+	allowed, remaining, resetAfter := b.AllowN(now, 50)
+	if !allowed {
+		t.Fatal("expected a hit within capacity to be admitted")
+	}
+	if remaining != 50 {
+		t.Fatalf("remaining = %v, want 50", remaining)
+	}
+	if resetAfter != 0 {
+		t.Fatalf("resetAfter = %v, want 0 on an admitted hit", resetAfter)
+	}
+}
+
+func TestLeakyBucketRejectsOverCapacity(t *testing.T) {
+	b := newLeakyBucket(10, 100, false)
+	now := time.Now()
+
+	if allowed, _, _ := b.AllowN(now, 100); !allowed {
+		t.Fatal("expected the first hit to fill the bucket exactly to capacity")
+	}
+
+	allowed, _, resetAfter := b.AllowN(now, 10)
+	if allowed {
+		t.Fatal("expected a hit exceeding capacity to be rejected")
+	}
+	if resetAfter <= 0 {
+		t.Fatalf("resetAfter = %v, want a positive wait", resetAfter)
+	}
+}
+
+func TestLeakyBucketDrainsOverTime(t *testing.T) {
+	b := newLeakyBucket(10, 100, false)
+	now := time.Now()
+
+	if allowed, _, _ := b.AllowN(now, 100); !allowed {
+		t.Fatal("expected the bucket to fill to capacity")
+	}
+
+	later := now.Add(5 * time.Second)
+	allowed, remaining, _ := b.AllowN(later, 40)
+	if !allowed {
+		t.Fatal("expected a hit to be admitted after the bucket leaked enough capacity")
+	}
+	if remaining != 10 {
+		t.Fatalf("remaining = %v, want 10 (100 - 50 leaked - 40 admitted)", remaining)
+	}
+}
+
+func TestLeakyBucketDrainOverLimit(t *testing.T) {
+	withDrain := newLeakyBucket(10, 100, true)
+	withoutDrain := newLeakyBucket(10, 100, false)
+	now := time.Now()
+
+	withDrain.AllowN(now, 150)
+	withoutDrain.AllowN(now, 150)
+
+	if withDrain.level != withDrain.capacity {
+		t.Fatalf("drainOverLimit bucket level = %v, want capacity %v", withDrain.level, withDrain.capacity)
+	}
+	if withoutDrain.level != 0 {
+		t.Fatalf("non-drainOverLimit bucket level = %v, want unchanged 0", withoutDrain.level)
+	}
+}
+
+func TestLeakyBucketIdle(t *testing.T) {
+	b := newLeakyBucket(10, 100, false)
+	if !b.Idle() {
+		t.Fatal("expected a freshly created bucket to be idle")
+	}
+
+	now := time.Now()
+	b.AllowN(now, 50)
+	if b.Idle() {
+		t.Fatal("expected a non-empty bucket to not be idle")
+	}
+}
+
+func TestTokenBucketLimiterIdle(t *testing.T) {
+	l := newTokenBucketLimiter(10, 20)
+	if !l.Idle() {
+		t.Fatal("expected a freshly created token bucket limiter to be idle (full)")
+	}
+
+	now := time.Now()
+	l.AllowN(now, 5)
+	if l.Idle() {
+		t.Fatal("expected a token bucket limiter with consumed tokens to not be idle")
+	}
+}
+
+func TestNewBucketSelectsAlgorithm(t *testing.T) {
+	lb := newBucket(config.LeakyBucket, 10, 2.0, false)
+	if _, ok := lb.(*leakyBucket); !ok {
+		t.Fatalf("expected newBucket(LeakyBucket, ...) to return a *leakyBucket, got %T", lb)
+	}
+
+	tb := newBucket(config.TokenBucket, 10, 2.0, false)
+	if _, ok := tb.(*tokenBucketLimiter); !ok {
+		t.Fatalf("expected newBucket for a non-leaky algorithm to return a *tokenBucketLimiter, got %T", tb)
+	}
+}
@@ -11,6 +11,7 @@ package limiter
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -18,9 +19,10 @@ import (
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/flowcontrol"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/p2p"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"golang.org/x/time/rate"
 )
 
 // Prometheus metrics
@@ -57,21 +59,67 @@ var (
 	)
 )
 
+// Limiter is the request-admission surface callers depend on. *RateLimiter
+// satisfies it directly (single node, local token buckets); *DistributedLimiter
+// satisfies it too (multi-node, cluster-wide buckets via peer coordination) so
+// the two are interchangeable from the caller's point of view.
+type Limiter interface {
+	// CheckIP/CheckPeerID/CheckRequest debit cost units (not a flat 1 per
+	// request) from the relevant buckets, which are now denominated in cost
+	// units/sec rather than requests/sec. See pkg/flowcontrol for how cost
+	// is estimated per request kind.
+	CheckIP(ip string, cost float64) (bool, error)
+	CheckPeerID(peerID string, cost float64) (bool, error)
+	CheckRequest(remoteAddr string, cost float64) (bool, error)
+	CheckBackpressure() (bool, float64)
+	Stats() map[string]interface{}
+
+	// FinishPeerRequest reconciles the LES-style flow control buffer debit
+	// CheckPeerID made for peerID against the request's actual measured
+	// cost, once known.
+	FinishPeerRequest(peerID string, estimatedCost, actualCost float64)
+	// PeerBufferStats returns a snapshot of every known peer's flow control
+	// buffer state, for the /flowcontrol/peers endpoint.
+	PeerBufferStats() []flowcontrol.Stats
+}
+
+// Decision is the outcome of an N-hit admission check: whether the hits
+// were allowed, the capacity left afterward, how long until enough
+// capacity frees up if they weren't, and which algorithm produced it.
+type Decision struct {
+	Allowed    bool
+	Remaining  float64
+	ResetAfter time.Duration
+	Algorithm  config.Algorithm
+}
+
 // RateLimiter provides multi-tier rate limiting
 type RateLimiter struct {
-	config      config.RateLimiterConfig
-	log         *logger.Logger
+	config config.RateLimiterConfig
+	log    *logger.Logger
 
 	// IP-based limiters
-	ipLimiters  map[string]*rate.Limiter
-	ipMutex     sync.RWMutex
+	ipLimiters map[string]bucket
+	ipMutex    sync.RWMutex
 
 	// Peer-ID-based limiters
-	peerLimiters map[string]*rate.Limiter
+	peerLimiters map[string]bucket
 	peerMutex    sync.RWMutex
 
 	// Global limiter
-	globalLimiter *rate.Limiter
+	globalLimiter bucket
+
+	// peerBuffers holds each peer's LES-style flow control credit, an
+	// admission dimension independent of the peerLimiters token/leaky
+	// buckets above: see pkg/flowcontrol.BufferManager.
+	peerBuffers *flowcontrol.BufferManager
+
+	// scorer optionally supplies each peer's reputation score (see
+	// p2p.Scorer), used by getPeerLimiter to scale a low-scoring peer's
+	// bucket down. Nil (the default, until SetPeerScorer is called) means
+	// every peer gets the full configured limit.
+	scorerMu sync.RWMutex
+	scorer   *p2p.Manager
 
 	// Backpressure: queue size tracking
 	currentQueueSize  int64 // atomic
@@ -86,14 +134,12 @@ type RateLimiter struct {
 // NewRateLimiter creates a new rate limiter
 func NewRateLimiter(cfg config.RateLimiterConfig, log *logger.Logger) *RateLimiter {
 	rl := &RateLimiter{
-		config:       cfg,
-		log:          log,
-		ipLimiters:   make(map[string]*rate.Limiter),
-		peerLimiters: make(map[string]*rate.Limiter),
-		globalLimiter: rate.NewLimiter(
-			rate.Limit(cfg.GlobalLimit),
-			int(float64(cfg.GlobalLimit)*cfg.BurstMultiplier),
-		),
+		config:           cfg,
+		log:              log,
+		ipLimiters:       make(map[string]bucket),
+		peerLimiters:     make(map[string]bucket),
+		globalLimiter:    newBucket(cfg.GlobalAlgorithm, cfg.GlobalLimit, cfg.BurstMultiplier, cfg.DrainOverLimit),
+		peerBuffers:      flowcontrol.NewBufferManager(cfg.BufferLimit, cfg.RechargeRate),
 		cleanupInterval:  5 * time.Minute,
 		stopChan:         make(chan struct{}),
 		currentQueueSize: 0,
@@ -106,8 +152,28 @@ func NewRateLimiter(cfg config.RateLimiterConfig, log *logger.Logger) *RateLimit
 	return rl
 }
 
-// CheckIP checks if request from IP is allowed
-func (rl *RateLimiter) CheckIP(ip string) (bool, error) {
+// SetPeerScorer wires mgr as the source of peer reputation scores (see
+// p2p.Scorer) for getPeerLimiter to scale bucket limits by. Until called,
+// every peer gets the full configured PeerIDLimit.
+func (rl *RateLimiter) SetPeerScorer(mgr *p2p.Manager) {
+	rl.scorerMu.Lock()
+	rl.scorer = mgr
+	rl.scorerMu.Unlock()
+}
+
+// costToHits rounds a (possibly fractional, flowcontrol-estimated) cost to
+// the integer hit count the underlying bucket implementations operate on,
+// always admitting at least 1 so a reported cost of 0 can't bypass limits.
+func costToHits(cost float64) int {
+	hits := int(math.Round(cost))
+	if hits < 1 {
+		hits = 1
+	}
+	return hits
+}
+
+// CheckIP checks if a request from ip costing cost units is allowed
+func (rl *RateLimiter) CheckIP(ip string, cost float64) (bool, error) {
 	if !rl.config.Enabled {
 		rateLimitAccepted.WithLabelValues("disabled").Inc()
 		return true, nil
@@ -122,8 +188,10 @@ func (rl *RateLimiter) CheckIP(ip string) (bool, error) {
 		return false, fmt.Errorf("queue full: verification queue at capacity")
 	}
 
+	hits := costToHits(cost)
+
 	// Check global limit (early reject)
-	if !rl.globalLimiter.Allow() {
+	if allowed, _, _ := rl.globalLimiter.AllowN(time.Now(), hits); !allowed {
 		rateLimitRejected.WithLabelValues("global").Inc()
 		rl.log.WithField("ip", ip).Warn("Global rate limit exceeded")
 		return false, fmt.Errorf("global rate limit exceeded")
@@ -132,7 +200,7 @@ func (rl *RateLimiter) CheckIP(ip string) (bool, error) {
 	// Get or create IP limiter
 	limiter := rl.getIPLimiter(ip)
 
-	if !limiter.Allow() {
+	if allowed, _, _ := limiter.AllowN(time.Now(), hits); !allowed {
 		rateLimitRejected.WithLabelValues("ip").Inc()
 		rl.log.WithField("ip", ip).Warn("IP rate limit exceeded")
 		return false, fmt.Errorf("IP rate limit exceeded")
@@ -142,8 +210,12 @@ func (rl *RateLimiter) CheckIP(ip string) (bool, error) {
 	return true, nil
 }
 
-// CheckPeerID checks if request from peer ID is allowed
-func (rl *RateLimiter) CheckPeerID(peerID string) (bool, error) {
+// CheckPeerID checks if a request from peer ID costing cost units is
+// allowed. Admission requires both the peer's token/leaky bucket (shared
+// with CheckIP's accounting style) and its LES-style flow control buffer to
+// have room; call FinishPeerRequest once the request's actual cost is known
+// to reconcile the buffer debit.
+func (rl *RateLimiter) CheckPeerID(peerID string, cost float64) (bool, error) {
 	if !rl.config.Enabled {
 		rateLimitAccepted.WithLabelValues("disabled").Inc()
 		return true, nil
@@ -158,8 +230,10 @@ func (rl *RateLimiter) CheckPeerID(peerID string) (bool, error) {
 		return false, fmt.Errorf("queue full: verification queue at capacity")
 	}
 
+	hits := costToHits(cost)
+
 	// Check global limit first
-	if !rl.globalLimiter.Allow() {
+	if allowed, _, _ := rl.globalLimiter.AllowN(time.Now(), hits); !allowed {
 		rateLimitRejected.WithLabelValues("global").Inc()
 		rl.log.WithField("peer_id", peerID).Warn("Global rate limit exceeded")
 		return false, fmt.Errorf("global rate limit exceeded")
@@ -168,18 +242,25 @@ func (rl *RateLimiter) CheckPeerID(peerID string) (bool, error) {
 	// Get or create peer limiter
 	limiter := rl.getPeerLimiter(peerID)
 
-	if !limiter.Allow() {
+	if allowed, _, _ := limiter.AllowN(time.Now(), hits); !allowed {
 		rateLimitRejected.WithLabelValues("peer").Inc()
 		rl.log.WithField("peer_id", peerID).Warn("Peer rate limit exceeded")
 		return false, fmt.Errorf("peer rate limit exceeded")
 	}
 
+	if allowed, err := rl.peerBuffers.Admit(peerID, cost); !allowed {
+		rateLimitRejected.WithLabelValues("peer_buffer").Inc()
+		rl.log.WithField("peer_id", peerID).Warn("Peer flow control buffer exhausted")
+		return false, err
+	}
+
 	rateLimitAccepted.WithLabelValues("peer").Inc()
 	return true, nil
 }
 
-// CheckRequest checks both IP and global limits for an HTTP request
-func (rl *RateLimiter) CheckRequest(remoteAddr string) (bool, error) {
+// CheckRequest checks both IP and global limits for an HTTP request costing
+// cost units
+func (rl *RateLimiter) CheckRequest(remoteAddr string, cost float64) (bool, error) {
 	if !rl.config.Enabled {
 		return true, nil
 	}
@@ -191,11 +272,60 @@ func (rl *RateLimiter) CheckRequest(remoteAddr string) (bool, error) {
 		ip = remoteAddr
 	}
 
-	return rl.CheckIP(ip)
+	return rl.CheckIP(ip, cost)
+}
+
+// FinishPeerRequest reconciles the flow control buffer debit CheckPeerID
+// made for peerID against the request's actual measured cost, refunding (or
+// further debiting) the difference.
+func (rl *RateLimiter) FinishPeerRequest(peerID string, estimatedCost, actualCost float64) {
+	rl.peerBuffers.Finish(peerID, estimatedCost, actualCost)
+}
+
+// PeerBufferStats returns a snapshot of every known peer's flow control
+// buffer state.
+func (rl *RateLimiter) PeerBufferStats() []flowcontrol.Stats {
+	return rl.peerBuffers.Peers()
+}
+
+// CheckIPN checks if hits requests from ip are allowed, returning a Decision
+// with the capacity remaining and (when rejected) how long until enough
+// capacity frees up, so callers can surface retry-after style feedback.
+func (rl *RateLimiter) CheckIPN(ip string, hits int) (Decision, error) {
+	if !rl.config.Enabled {
+		rateLimitAccepted.WithLabelValues("disabled").Inc()
+		return Decision{Allowed: true, Algorithm: rl.config.IPAlgorithm}, nil
+	}
+
+	currentQueue := atomic.LoadInt64(&rl.currentQueueSize)
+	if currentQueue >= rl.maxQueueSize {
+		atomic.AddUint64(&rl.queueFullRejected, 1)
+		rateLimitRejected.WithLabelValues("queue_full").Inc()
+		rl.log.WithField("ip", ip).WithField("queue_size", currentQueue).Warn("Queue full, rejecting request")
+		return Decision{Allowed: false, Algorithm: rl.config.IPAlgorithm}, fmt.Errorf("queue full: verification queue at capacity")
+	}
+
+	if allowed, remaining, resetAfter := rl.globalLimiter.AllowN(time.Now(), hits); !allowed {
+		rateLimitRejected.WithLabelValues("global").Inc()
+		rl.log.WithField("ip", ip).Warn("Global rate limit exceeded")
+		return Decision{Remaining: remaining, ResetAfter: resetAfter, Algorithm: rl.config.GlobalAlgorithm}, fmt.Errorf("global rate limit exceeded")
+	}
+
+	limiter := rl.getIPLimiter(ip)
+	allowed, remaining, resetAfter := limiter.AllowN(time.Now(), hits)
+	decision := Decision{Allowed: allowed, Remaining: remaining, ResetAfter: resetAfter, Algorithm: rl.config.IPAlgorithm}
+	if !allowed {
+		rateLimitRejected.WithLabelValues("ip").Inc()
+		rl.log.WithField("ip", ip).Warn("IP rate limit exceeded")
+		return decision, fmt.Errorf("IP rate limit exceeded")
+	}
+
+	rateLimitAccepted.WithLabelValues("ip").Inc()
+	return decision, nil
 }
 
-// getIPLimiter gets or creates limiter for IP
-func (rl *RateLimiter) getIPLimiter(ip string) *rate.Limiter {
+// getIPLimiter gets or creates the bucket for ip, per config.IPAlgorithm
+func (rl *RateLimiter) getIPLimiter(ip string) bucket {
 	rl.ipMutex.RLock()
 	limiter, exists := rl.ipLimiters[ip]
 	rl.ipMutex.RUnlock()
@@ -213,17 +343,14 @@ func (rl *RateLimiter) getIPLimiter(ip string) *rate.Limiter {
 		return limiter
 	}
 
-	limiter = rate.NewLimiter(
-		rate.Limit(rl.config.IPLimit),
-		int(float64(rl.config.IPLimit)*rl.config.BurstMultiplier),
-	)
+	limiter = newBucket(rl.config.IPAlgorithm, rl.config.IPLimit, rl.config.BurstMultiplier, rl.config.DrainOverLimit)
 	rl.ipLimiters[ip] = limiter
 
 	return limiter
 }
 
-// getPeerLimiter gets or creates limiter for peer ID
-func (rl *RateLimiter) getPeerLimiter(peerID string) *rate.Limiter {
+// getPeerLimiter gets or creates the bucket for peerID, per config.PeerIDAlgorithm
+func (rl *RateLimiter) getPeerLimiter(peerID string) bucket {
 	rl.peerMutex.RLock()
 	limiter, exists := rl.peerLimiters[peerID]
 	rl.peerMutex.RUnlock()
@@ -241,15 +368,30 @@ func (rl *RateLimiter) getPeerLimiter(peerID string) *rate.Limiter {
 		return limiter
 	}
 
-	limiter = rate.NewLimiter(
-		rate.Limit(rl.config.PeerIDLimit),
-		int(float64(rl.config.PeerIDLimit)*rl.config.BurstMultiplier),
-	)
+	limiter = newBucket(rl.config.PeerIDAlgorithm, rl.peerIDLimit(peerID), rl.config.BurstMultiplier, rl.config.DrainOverLimit)
 	rl.peerLimiters[peerID] = limiter
 
 	return limiter
 }
 
+// peerIDLimit returns peerID's configured limit scaled by its reputation
+// score (see p2p.Scorer): a peer at the minimum score (-100) gets 10% of
+// PeerIDLimit, climbing linearly to 100% at the maximum score (+100). Peers
+// with no recorded score default to a score of 0 (55% of the limit); if no
+// scorer is wired, every peer gets the full limit.
+func (rl *RateLimiter) peerIDLimit(peerID string) int {
+	rl.scorerMu.RLock()
+	scorer := rl.scorer
+	rl.scorerMu.RUnlock()
+
+	if scorer == nil {
+		return rl.config.PeerIDLimit
+	}
+
+	scale := math.Max(0.1, (scorer.Score(peerID)+100)/200)
+	return int(float64(rl.config.PeerIDLimit) * scale)
+}
+
 // cleanupStale removes inactive limiters periodically
 func (rl *RateLimiter) cleanupStale() {
 	ticker := time.NewTicker(rl.cleanupInterval)
@@ -269,8 +411,8 @@ func (rl *RateLimiter) cleanup() {
 	// Clean IP limiters
 	rl.ipMutex.Lock()
 	for ip, limiter := range rl.ipLimiters {
-		// Remove if limiter hasn't been used (has full tokens)
-		if limiter.Tokens() == float64(limiter.Burst()) {
+		// Remove if limiter hasn't been used (back at its resting state)
+		if limiter.Idle() {
 			delete(rl.ipLimiters, ip)
 		}
 	}
@@ -280,7 +422,7 @@ func (rl *RateLimiter) cleanup() {
 	// Clean peer limiters
 	rl.peerMutex.Lock()
 	for peerID, limiter := range rl.peerLimiters {
-		if limiter.Tokens() == float64(limiter.Burst()) {
+		if limiter.Idle() {
 			delete(rl.peerLimiters, peerID)
 		}
 	}
@@ -341,6 +483,11 @@ func (rl *RateLimiter) SetMaxQueueSize(maxSize int64) {
 	rl.maxQueueSize = maxSize
 }
 
+// GetMaxQueueSize returns the configured maximum queue size.
+func (rl *RateLimiter) GetMaxQueueSize() int64 {
+	return rl.maxQueueSize
+}
+
 // CheckBackpressure checks if queue is near capacity (for early warning)
 func (rl *RateLimiter) CheckBackpressure() (bool, float64) {
 	current := float64(atomic.LoadInt64(&rl.currentQueueSize))
@@ -0,0 +1,481 @@
+// Package admission implements EMA-driven backpressure and per-peer/
+// per-sender fair queuing in front of mempool.Mempool.AddTransaction, so a
+// pool under sustained overload degrades by admitting fewer, higher-fee
+// transactions in a fair order rather than by thrashing through repeated
+// evictLowestPriority calls. It backs config.FeaturesConfig.EnableAdmissionControl.
+package admission
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+// Admitter hands a transaction that has cleared the global rate limit and
+// its DRR turn to the next admission stage — ordinarily mp.AddTransaction,
+// but see Controller.SetAdmitter to route through mempool.Verifier.SubmitAsync
+// instead.
+type Admitter func(tx *mempool.Transaction) error
+
+// Config controls the three admission gates: a global token bucket, the
+// per-peer/per-sender deficit round robin fair queues, and the EMA-driven
+// dynamic priority floor.
+type Config struct {
+	// GlobalLimit and BurstMultiplier size the global token bucket, the same
+	// way config.RateLimiterConfig.GlobalLimit/BurstMultiplier size the
+	// request-level limiter in pkg/limiter.
+	GlobalLimit     int
+	BurstMultiplier float64
+
+	// Quantum is the deficit credited to a peer's (and, within it, a
+	// sender's) queue each time its turn comes up in the round robin; one
+	// request is dispatched per whole unit of accumulated deficit. The
+	// default of 1 dispatches exactly one request per queue per turn.
+	Quantum float64
+
+	// FullnessAlpha smooths the block-fullness EMA: ObserveBlockFullness
+	// folds in each new sample as alpha*sample + (1-alpha)*previous. Its
+	// reciprocal is roughly the number of trailing blocks (K) the EMA
+	// weighs most heavily.
+	FullnessAlpha float64
+
+	// HighWatermark/LowWatermark are EMA fullness levels (0-1) that raise
+	// and lower the dynamic priority threshold multiplier; see
+	// ObserveBlockFullness.
+	HighWatermark float64
+	LowWatermark  float64
+
+	// RaiseFactor/LowerFactor scale the multiplier on each observation that
+	// crosses HighWatermark/LowWatermark; RaiseFactor compounds on
+	// consecutive overloaded blocks, LowerFactor relaxes it back down to a
+	// floor of 1 (the mempool's own configured PriorityThreshold).
+	RaiseFactor float64
+	LowerFactor float64
+
+	// MinPriorityThreshold/MaxPriorityThreshold bound the effective
+	// threshold the controller will ever set, so a long overload streak
+	// can't ratchet it up without limit.
+	MinPriorityThreshold float64
+	MaxPriorityThreshold float64
+
+	// RaiseSeed is the threshold ObserveBlockFullness raises to on the first
+	// overloaded observation when the mempool's base PriorityThreshold is 0
+	// (its default), since multiplying zero by RaiseFactor would otherwise
+	// never move it.
+	RaiseSeed float64
+}
+
+// DefaultConfig returns a 10k/sec global bucket with 1.5x burst, a
+// one-request-per-turn DRR quantum, an EMA weighing roughly the last 10
+// blocks, and a threshold that doubles per overloaded block above 90%
+// fullness and halves per underloaded block below 50%, capped at 1000x the
+// mempool's base threshold.
+func DefaultConfig() Config {
+	return Config{
+		GlobalLimit:          10000,
+		BurstMultiplier:      1.5,
+		Quantum:              1,
+		FullnessAlpha:        0.2,
+		HighWatermark:        0.90,
+		LowWatermark:         0.50,
+		RaiseFactor:          2.0,
+		LowerFactor:          0.5,
+		MinPriorityThreshold: 0,
+		MaxPriorityThreshold: 1e12,
+		RaiseSeed:            1,
+	}
+}
+
+// MetricsSink receives admission control signals, so an external Prometheus
+// exporter can track them without this package depending on a concrete
+// metrics type — see Controller.SetMetricsSink, following the same
+// structural-interface convention as mempool.MetricsSink.
+type MetricsSink interface {
+	// SetPriorityThreshold reports the mempool's current effective
+	// PriorityThreshold after EMA-driven adjustment.
+	SetPriorityThreshold(threshold float64)
+	// SetGlobalQueueDepth reports how many admission requests are queued
+	// across every peer, waiting for their DRR turn.
+	SetGlobalQueueDepth(depth int)
+	// SetPeerQueueDepth reports how many admission requests peerID
+	// currently has queued.
+	SetPeerQueueDepth(peerID string, depth int)
+}
+
+// request is one transaction waiting to clear the fair queues, submitted on
+// behalf of peerID (empty for the local API, which has no P2P peer) and
+// sender tx.From.
+type request struct {
+	tx     *mempool.Transaction
+	result chan error
+}
+
+// senderQueue is one sender's FIFO of pending requests within a peer.
+type senderQueue struct {
+	pending []*request
+}
+
+// peerQueue holds one peer's pending requests, grouped by sender and
+// round-robined among them so a single sender relayed through this peer
+// can't starve its neighbors sharing that peer's turn.
+type peerQueue struct {
+	deficit     float64
+	senderOrder []string
+	senders     map[string]*senderQueue
+	nextSender  int
+	depth       int
+}
+
+// Controller gates admission to mp behind a global token bucket, then
+// dispatches queued requests in deficit-round-robin order across peers (and,
+// within each peer, across senders), and finally adjusts mp's
+// PriorityThreshold from an EMA of recent block fullness.
+type Controller struct {
+	mp     *mempool.Mempool
+	admit  Admitter
+	config Config
+	log    *logger.Logger
+	global *rate.Limiter
+
+	mu        sync.Mutex
+	peerOrder []string
+	peers     map[string]*peerQueue
+	nextPeer  int
+	depth     int
+
+	baseThreshold    float64
+	currentThreshold float64
+	fullnessEMA      float64
+	hasEMA           bool
+
+	metricsMu sync.RWMutex
+	metrics   MetricsSink
+
+	wake     chan struct{}
+	stopOnce sync.Once
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewController creates a Controller gating admission to mp. The default
+// Admitter calls mp.AddTransaction directly; use SetAdmitter to route
+// through a mempool.Verifier instead.
+func NewController(mp *mempool.Mempool, cfg Config, log *logger.Logger) *Controller {
+	burst := int(float64(cfg.GlobalLimit) * cfg.BurstMultiplier)
+
+	base := mp.PriorityThreshold()
+
+	c := &Controller{
+		mp:               mp,
+		admit:            defaultAdmitter(mp),
+		config:           cfg,
+		log:              log,
+		global:           rate.NewLimiter(rate.Limit(cfg.GlobalLimit), burst),
+		peers:            make(map[string]*peerQueue),
+		baseThreshold:    base,
+		currentThreshold: base,
+		wake:             make(chan struct{}, 1),
+		stop:             make(chan struct{}),
+	}
+
+	return c
+}
+
+// SetAdmitter overrides how a transaction that has cleared the fair queues
+// is handed off; nil restores the default of calling mp.AddTransaction
+// directly.
+func (c *Controller) SetAdmitter(admit Admitter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if admit == nil {
+		admit = defaultAdmitter(c.mp)
+	}
+	c.admit = admit
+}
+
+// defaultAdmitter adapts mp.AddTransaction's (evicted, err) return to the
+// Admitter shape, discarding the evicted hash since the default path has no
+// replacement-tracking caller to hand it to.
+func defaultAdmitter(mp *mempool.Mempool) Admitter {
+	return func(tx *mempool.Transaction) error {
+		_, err := mp.AddTransaction(tx)
+		return err
+	}
+}
+
+// SetMetricsSink wires sink to receive admission control signals going
+// forward. Passing nil (the default) disables reporting.
+func (c *Controller) SetMetricsSink(sink MetricsSink) {
+	c.metricsMu.Lock()
+	defer c.metricsMu.Unlock()
+	c.metrics = sink
+}
+
+// Start launches the DRR dispatcher goroutine.
+func (c *Controller) Start() {
+	c.wg.Add(1)
+	go c.dispatchLoop()
+}
+
+// Stop shuts down the dispatcher and resolves any still-queued request with
+// an error rather than leaving it hanging.
+func (c *Controller) Stop() {
+	c.stopOnce.Do(func() { close(c.stop) })
+	c.wg.Wait()
+}
+
+// Admit applies the global token bucket gate synchronously, then queues tx
+// for its DRR turn (fairly ordered against every other peer and sender
+// currently queued) and blocks until it has been admitted or rejected.
+// peerID identifies the P2P peer tx arrived from, or "" for the local API.
+func (c *Controller) Admit(peerID string, tx *mempool.Transaction) error {
+	if !c.global.Allow() {
+		return fmt.Errorf("admission: global rate limit exceeded")
+	}
+
+	req := &request{tx: tx, result: make(chan error, 1)}
+	senderKey := fmt.Sprintf("%x", tx.From)
+
+	c.mu.Lock()
+	c.enqueueLocked(peerID, senderKey, req)
+	c.mu.Unlock()
+
+	c.reportDepthLocked(peerID)
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+
+	return <-req.result
+}
+
+// enqueueLocked adds req to peerID's senderKey sub-queue, creating either as
+// needed. Callers must hold c.mu.
+func (c *Controller) enqueueLocked(peerID, senderKey string, req *request) {
+	pq, ok := c.peers[peerID]
+	if !ok {
+		pq = &peerQueue{senders: make(map[string]*senderQueue)}
+		c.peers[peerID] = pq
+		c.peerOrder = append(c.peerOrder, peerID)
+	}
+
+	sq, ok := pq.senders[senderKey]
+	if !ok {
+		sq = &senderQueue{}
+		pq.senders[senderKey] = sq
+		pq.senderOrder = append(pq.senderOrder, senderKey)
+	}
+
+	sq.pending = append(sq.pending, req)
+	pq.depth++
+	c.depth++
+}
+
+// dispatchLoop runs the two-level DRR scheduler: it round-robins peers,
+// crediting each a Quantum of deficit on its turn, and dispatches one
+// request from that peer (itself round-robined across the peer's senders)
+// once its deficit covers the cost of one request.
+func (c *Controller) dispatchLoop() {
+	defer c.wg.Done()
+
+	for {
+		req, peerID, ok := c.dispatchNext()
+		if !ok {
+			select {
+			case <-c.stop:
+				c.drain()
+				return
+			case <-c.wake:
+				continue
+			}
+		}
+
+		err := c.admit(req.tx)
+		req.result <- err
+		c.reportDepthLocked(peerID)
+
+		select {
+		case <-c.stop:
+			c.drain()
+			return
+		default:
+		}
+	}
+}
+
+// dispatchNext returns the next request due for dispatch under DRR, or
+// ok=false if every queue is currently empty.
+func (c *Controller) dispatchNext() (req *request, peerID string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for attempts := 0; len(c.peerOrder) > 0 && attempts < len(c.peerOrder); attempts++ {
+		idx := c.nextPeer % len(c.peerOrder)
+		peerID = c.peerOrder[idx]
+		pq := c.peers[peerID]
+		pq.deficit += c.config.Quantum
+
+		if pq.deficit < 1 {
+			c.nextPeer = idx + 1
+			continue
+		}
+
+		req = pq.dispatchOneLocked()
+		pq.deficit -= 1
+		c.depth--
+
+		if pq.depth == 0 {
+			delete(c.peers, peerID)
+			c.peerOrder = append(c.peerOrder[:idx], c.peerOrder[idx+1:]...)
+			// Don't advance nextPeer: the slot at idx now holds whatever
+			// used to follow it.
+		} else {
+			c.nextPeer = idx + 1
+		}
+
+		return req, peerID, true
+	}
+
+	return nil, "", false
+}
+
+// dispatchOneLocked pops the next request from pq's sender round robin.
+// Callers must hold Controller.mu.
+func (pq *peerQueue) dispatchOneLocked() *request {
+	idx := pq.nextSender % len(pq.senderOrder)
+	senderKey := pq.senderOrder[idx]
+	sq := pq.senders[senderKey]
+
+	req := sq.pending[0]
+	sq.pending = sq.pending[1:]
+	pq.depth--
+
+	if len(sq.pending) == 0 {
+		delete(pq.senders, senderKey)
+		pq.senderOrder = append(pq.senderOrder[:idx], pq.senderOrder[idx+1:]...)
+	} else {
+		pq.nextSender = idx + 1
+	}
+
+	return req
+}
+
+// drain resolves every still-queued request with an error; called once the
+// dispatcher is stopping.
+func (c *Controller) drain() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, pq := range c.peers {
+		for _, sq := range pq.senders {
+			for _, req := range sq.pending {
+				req.result <- fmt.Errorf("admission controller stopped")
+			}
+		}
+	}
+	c.peers = make(map[string]*peerQueue)
+	c.peerOrder = nil
+	c.depth = 0
+}
+
+// reportDepthLocked pushes the current global and per-peer queue depth to
+// the metrics sink, if one is wired in.
+func (c *Controller) reportDepthLocked(peerID string) {
+	c.metricsMu.RLock()
+	metrics := c.metrics
+	c.metricsMu.RUnlock()
+	if metrics == nil {
+		return
+	}
+
+	c.mu.Lock()
+	depth := c.depth
+	peerDepth := 0
+	if pq, ok := c.peers[peerID]; ok {
+		peerDepth = pq.depth
+	}
+	c.mu.Unlock()
+
+	metrics.SetGlobalQueueDepth(depth)
+	metrics.SetPeerQueueDepth(peerID, peerDepth)
+}
+
+// ObserveBlockFullness folds gasUsed/gasLimit into the block-fullness EMA
+// and adjusts the mempool's PriorityThreshold: once the EMA crosses
+// HighWatermark it compounds upward by RaiseFactor per observation (so a
+// sustained run of full blocks keeps raising the floor), and once it falls
+// below LowWatermark it relaxes back down by LowerFactor per observation,
+// floored at the mempool's original threshold. Callers — ordinarily a
+// consensus.Engine's new-block callback — should call this once per
+// produced or accepted block.
+func (c *Controller) ObserveBlockFullness(gasUsed, gasLimit uint64) {
+	if gasLimit == 0 {
+		return
+	}
+	fullness := float64(gasUsed) / float64(gasLimit)
+
+	c.mu.Lock()
+	if !c.hasEMA {
+		c.fullnessEMA = fullness
+		c.hasEMA = true
+	} else {
+		c.fullnessEMA = c.config.FullnessAlpha*fullness + (1-c.config.FullnessAlpha)*c.fullnessEMA
+	}
+
+	switch {
+	case c.fullnessEMA > c.config.HighWatermark:
+		if c.currentThreshold <= 0 {
+			c.currentThreshold = c.config.RaiseSeed
+		} else {
+			c.currentThreshold *= c.config.RaiseFactor
+		}
+	case c.fullnessEMA < c.config.LowWatermark:
+		c.currentThreshold *= c.config.LowerFactor
+		if c.currentThreshold < c.baseThreshold {
+			c.currentThreshold = c.baseThreshold
+		}
+	}
+
+	next := c.currentThreshold
+	if next < c.config.MinPriorityThreshold {
+		next = c.config.MinPriorityThreshold
+	}
+	if next > c.config.MaxPriorityThreshold {
+		next = c.config.MaxPriorityThreshold
+	}
+	c.currentThreshold = next
+	c.mu.Unlock()
+
+	c.mp.SetPriorityThreshold(next)
+
+	c.metricsMu.RLock()
+	metrics := c.metrics
+	c.metricsMu.RUnlock()
+	if metrics != nil {
+		metrics.SetPriorityThreshold(next)
+	}
+}
+
+// Threshold returns the PriorityThreshold the controller last set on the
+// mempool.
+func (c *Controller) Threshold() float64 {
+	return c.mp.PriorityThreshold()
+}
+
+// PeerQueueDepths returns a snapshot of every peer currently holding queued
+// admission requests, keyed by peer ID.
+func (c *Controller) PeerQueueDepths() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	depths := make(map[string]int, len(c.peers))
+	for peerID, pq := range c.peers {
+		depths[peerID] = pq.depth
+	}
+	return depths
+}
@@ -0,0 +1,118 @@
+package admission
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+func testTx(sender byte, nonce uint64) *mempool.Transaction {
+	from := [32]byte{sender}
+	return &mempool.Transaction{
+		Hash:      sha256.Sum256([]byte{sender, byte(nonce)}),
+		From:      from,
+		To:        [32]byte{9, 9, 9},
+		Amount:    1,
+		Nonce:     nonce,
+		GasLimit:  21000,
+		GasPrice:  100,
+		Timestamp: time.Now().Unix(),
+		TxType:    1,
+	}
+}
+
+func newTestController(t *testing.T) *Controller {
+	t.Helper()
+
+	cfg := mempool.DefaultConfig()
+	cfg.MaxSize = 1000
+	cfg.MaxSenderSharePercent = 100
+	mp := mempool.NewMempool(cfg, logger.NewLogger("error"))
+
+	acCfg := DefaultConfig()
+	acCfg.GlobalLimit = 1000
+	acCfg.BurstMultiplier = 10
+
+	c := NewController(mp, acCfg, logger.NewLogger("error"))
+	c.Start()
+	t.Cleanup(c.Stop)
+
+	return c
+}
+
+func TestControllerAdmitsValidTransaction(t *testing.T) {
+	c := newTestController(t)
+
+	if err := c.Admit("peer-a", testTx(1, 0)); err != nil {
+		t.Fatalf("expected clean admission, got: %v", err)
+	}
+}
+
+func TestControllerGlobalRateLimitRejects(t *testing.T) {
+	cfg := mempool.DefaultConfig()
+	mp := mempool.NewMempool(cfg, logger.NewLogger("error"))
+
+	acCfg := DefaultConfig()
+	acCfg.GlobalLimit = 1
+	acCfg.BurstMultiplier = 1
+
+	c := NewController(mp, acCfg, logger.NewLogger("error"))
+	c.Start()
+	t.Cleanup(c.Stop)
+
+	if err := c.Admit("peer-a", testTx(1, 0)); err != nil {
+		t.Fatalf("expected the first transaction to clear the global bucket, got: %v", err)
+	}
+	if err := c.Admit("peer-a", testTx(2, 0)); err == nil {
+		t.Fatal("expected the second transaction to be rejected by the exhausted global bucket")
+	}
+}
+
+func TestControllerFairQueuingAcrossPeers(t *testing.T) {
+	c := newTestController(t)
+
+	const perPeer = 5
+	results := make(chan error, 2*perPeer)
+	for i := 0; i < perPeer; i++ {
+		go func(i int) { results <- c.Admit("noisy-peer", testTx(byte(10+i), 0)) }(i)
+		go func(i int) { results <- c.Admit("quiet-peer", testTx(byte(100+i), 0)) }(i)
+	}
+
+	for i := 0; i < 2*perPeer; i++ {
+		select {
+		case err := <-results:
+			if err != nil {
+				t.Fatalf("expected clean admission, got: %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for admission result")
+		}
+	}
+}
+
+func TestControllerObserveBlockFullnessRaisesAndLowersThreshold(t *testing.T) {
+	c := newTestController(t)
+	base := c.Threshold()
+
+	for i := 0; i < 3; i++ {
+		c.ObserveBlockFullness(95, 100) // 95% full, above the 90% high watermark
+	}
+	raised := c.Threshold()
+	if raised <= base {
+		t.Fatalf("expected threshold to rise above base %v after sustained full blocks, got %v", base, raised)
+	}
+
+	for i := 0; i < 10; i++ {
+		c.ObserveBlockFullness(10, 100) // 10% full, below the 50% low watermark
+	}
+	lowered := c.Threshold()
+	if lowered >= raised {
+		t.Fatalf("expected threshold to fall back from %v after sustained empty blocks, got %v", raised, lowered)
+	}
+	if lowered < base {
+		t.Fatalf("expected threshold to settle back at base %v, not below it, got %v", base, lowered)
+	}
+}
@@ -0,0 +1,129 @@
+package mempool
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+)
+
+// mockGossipBus stands in for pkg/p2p's gossip protocol for these tests: a
+// transaction submitted to one node is relayed to every other node's
+// Verifier, the same way a real gossip fan-out would hand it to each peer's
+// admission pipeline. pkg/p2p has no mempool-gossip hook to exercise here
+// (it doesn't import pkg/mempool), so this bus only models the property
+// these tests care about — every node's Verifier+Mempool sees the same set
+// of competing transactions, in different arrival orders.
+type mockGossipBus struct {
+	nodes []*Verifier
+}
+
+// submit feeds tx to node's own Verifier, then relays it to every other
+// node on the bus, mirroring a local admission followed by gossip
+// propagation. It returns the SubmitAsync error from each node, indexed the
+// same as b.nodes.
+func (b *mockGossipBus) submit(t *testing.T, origin int, tx *Transaction) []error {
+	t.Helper()
+
+	errs := make([]error, len(b.nodes))
+
+	// Deliver to the originating node first, then fan out to the rest —
+	// order doesn't matter for convergence, but this mirrors "submit
+	// locally, then gossip" rather than "gossip to self".
+	order := make([]int, 0, len(b.nodes))
+	order = append(order, origin)
+	for i := range b.nodes {
+		if i != origin {
+			order = append(order, i)
+		}
+	}
+
+	for _, i := range order {
+		select {
+		case err := <-b.nodes[i].SubmitAsync(tx):
+			errs[i] = err
+		case <-time.After(time.Second):
+			t.Fatalf("node %d timed out verifying gossiped transaction", i)
+		}
+	}
+
+	return errs
+}
+
+// newMockGossipNetwork spins up n independent Mempool+Verifier pairs,
+// standing in for n in-process daemon instances sharing a mock P2P bus.
+func newMockGossipNetwork(t *testing.T, n int) (*mockGossipBus, []*Mempool) {
+	t.Helper()
+
+	bus := &mockGossipBus{}
+	mps := make([]*Mempool, n)
+	for i := 0; i < n; i++ {
+		v, mp := createTestVerifier(t)
+		bus.nodes = append(bus.nodes, v)
+		mps[i] = mp
+	}
+	return bus, mps
+}
+
+// TestAdversarialDoubleSpendConvergesAcrossNodes submits two transactions
+// spending the same (From, Nonce) to two different nodes concurrently, then
+// gossips each to the other. Despite the conflicting arrival order, both
+// nodes must converge on admitting only the higher-priced transaction —
+// never both, and never the underpriced one.
+func TestAdversarialDoubleSpendConvergesAcrossNodes(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	bus, mps := newMockGossipNetwork(t, 2)
+
+	txLow := createSignedTestTransaction(t, pub, priv, 0, 100)
+	txHigh := createSignedTestTransaction(t, pub, priv, 0, 1000) // well past the 10% price-bump floor
+
+	// Concurrent, conflicting submissions: node 0 sees the low-priced
+	// transaction first, node 1 sees the high-priced one first.
+	bus.submit(t, 0, txLow)
+	bus.submit(t, 1, txHigh)
+
+	for i, mp := range mps {
+		if mp.Size() != 1 {
+			t.Fatalf("node %d: expected exactly one admitted transaction for the shared (from, nonce) slot, got %d", i, mp.Size())
+		}
+		got, err := mp.GetTransaction(txHigh.Hash)
+		if err != nil {
+			t.Fatalf("node %d: expected the higher-priced transaction to win, got error: %v", i, err)
+		}
+		if got.GasPrice != txHigh.GasPrice {
+			t.Fatalf("node %d: expected winning gas price %d, got %d", i, txHigh.GasPrice, got.GasPrice)
+		}
+	}
+}
+
+// TestAdversarialSignatureMalleabilityRejected builds a transaction signed
+// over one Data payload, then swaps in a different Data payload while
+// keeping the stale signature — the classic malleability attempt. The
+// Verifier must reject it on every node rather than admit a transaction
+// whose effective payload was never actually signed.
+func TestAdversarialSignatureMalleabilityRejected(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	bus, mps := newMockGossipNetwork(t, 3)
+
+	tx := createSignedTestTransaction(t, pub, priv, 0, 100)
+	tx.Data = []byte("swapped payload the signature never covered")
+
+	errs := bus.submit(t, 0, tx)
+	for i, err := range errs {
+		if err == nil {
+			t.Fatalf("node %d: expected malleated transaction to be rejected", i)
+		}
+	}
+	for i, mp := range mps {
+		if mp.Size() != 0 {
+			t.Fatalf("node %d: malleated transaction should not have been admitted, mempool size %d", i, mp.Size())
+		}
+	}
+}
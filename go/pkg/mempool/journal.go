@@ -0,0 +1,281 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// JournalMetricsSink receives persistent journal size/replay signals, so an
+// external Prometheus exporter can track them without this package depending
+// on a concrete metrics type — see Mempool.SetJournalMetricsSink. Shaped the
+// same way as MetricsSink and VerifierMetricsSink: a handful of narrow
+// setters/observers rather than one broad interface.
+type JournalMetricsSink interface {
+	// SetJournalSize reports the current on-disk size of the journal file,
+	// in bytes.
+	SetJournalSize(bytes int)
+	// ObserveJournalReplay records how many journaled transactions were
+	// successfully re-admitted to the pool on Start versus rejected (e.g.
+	// because they'd since aged out or been superseded).
+	ObserveJournalReplay(accepted, rejected int)
+}
+
+// journalHeaderLen is the size of the length-prefix + CRC32 header written
+// ahead of every record's payload.
+const journalHeaderLen = 8
+
+// Journal append-writes every locally-accepted mempool transaction to a
+// file, so queued transactions survive a daemon restart — mirrors
+// go-ethereum's tx pool journal. Each record is a 4-byte little-endian
+// payload length, a 4-byte little-endian CRC32 of the payload, then the
+// payload itself, so a record half-written by a crash can be detected and
+// discarded during replay rather than corrupting the ones before it.
+type Journal struct {
+	path string
+	log  *logger.Logger
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewJournal opens (creating if necessary) the journal file at path.
+func NewJournal(path string, log *logger.Logger) (*Journal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mempool journal %q: %w", path, err)
+	}
+	return &Journal{path: path, log: log, file: f}, nil
+}
+
+// Insert appends tx to the journal as one length-prefixed, CRC32-checked
+// record.
+func (j *Journal) Insert(tx *Transaction) error {
+	payload := encodeJournalTx(tx)
+
+	var header [journalHeaderLen]byte
+	binary.LittleEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:8], crc32.ChecksumIEEE(payload))
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Write(header[:]); err != nil {
+		return fmt.Errorf("failed to write journal record header: %w", err)
+	}
+	if _, err := j.file.Write(payload); err != nil {
+		return fmt.Errorf("failed to write journal record payload: %w", err)
+	}
+	return nil
+}
+
+// Replay reads every intact record from the start of the journal, in append
+// order. It stops at the first record that's truncated or fails its CRC32
+// check — in practice the tail of a write interrupted by a crash — and
+// returns everything decoded before that point rather than erroring, since a
+// partial trailing write is expected, not exceptional. skippedBytes reports
+// how many trailing bytes were discarded this way, for logging.
+func (j *Journal) Replay() (txs []*Transaction, skippedBytes int, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, fmt.Errorf("failed to seek journal for replay: %w", err)
+	}
+	defer j.file.Seek(0, io.SeekEnd) // resume appending once replay is done
+
+	r := bufio.NewReader(j.file)
+	var header [journalHeaderLen]byte
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if !errors.Is(err, io.EOF) {
+				skippedBytes++
+			}
+			break
+		}
+
+		length := binary.LittleEndian.Uint32(header[0:4])
+		wantCRC := binary.LittleEndian.Uint32(header[4:8])
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			skippedBytes += journalHeaderLen + int(length)
+			break
+		}
+		if crc32.ChecksumIEEE(payload) != wantCRC {
+			skippedBytes += journalHeaderLen + int(length)
+			break
+		}
+
+		tx, err := decodeJournalTx(payload)
+		if err != nil {
+			skippedBytes += journalHeaderLen + int(length)
+			break
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, skippedBytes, nil
+}
+
+// Rotate atomically rewrites the journal to hold exactly live — typically
+// the pool's current contents — dropping anything previously journaled that
+// has since expired, been mined, or been replaced. The new file only
+// becomes visible via os.Rename once fully written, so a crash mid-rotation
+// leaves the previous journal intact.
+func (j *Journal) Rotate(live []*Transaction) error {
+	tmpPath := j.path + ".rotate"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create rotated journal: %w", err)
+	}
+
+	for _, tx := range live {
+		payload := encodeJournalTx(tx)
+		var header [journalHeaderLen]byte
+		binary.LittleEndian.PutUint32(header[0:4], uint32(len(payload)))
+		binary.LittleEndian.PutUint32(header[4:8], crc32.ChecksumIEEE(payload))
+
+		if _, err := tmp.Write(header[:]); err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to write rotated journal record: %w", err)
+		}
+		if _, err := tmp.Write(payload); err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to write rotated journal record: %w", err)
+		}
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to sync rotated journal: %w", err)
+	}
+	tmp.Close()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.file.Close(); err != nil {
+		return fmt.Errorf("failed to close old journal before rotation: %w", err)
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return fmt.Errorf("failed to install rotated journal: %w", err)
+	}
+
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to reopen journal after rotation: %w", err)
+	}
+	j.file = f
+	return nil
+}
+
+// Size returns the journal file's current on-disk size, in bytes.
+func (j *Journal) Size() (int64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	info, err := j.file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat journal: %w", err)
+	}
+	return info.Size(), nil
+}
+
+// Close closes the underlying journal file.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// journalTxFixedLen is the byte length of every fixed-width field
+// encodeJournalTx writes, ahead of tx.Data's own length prefix.
+const journalTxFixedLen = 32 + 32 + 32 + 8 + 8 + 8 + 8 + 8 + 8 + 64 + 8 + 8 + 1 + 8 + 4
+
+// encodeJournalTx packs the fields needed to reconstruct and re-admit tx
+// (everything AddTransaction doesn't recompute itself, such as AddedAt and
+// Priority) into a flat little-endian byte layout. Mirrors the hand-rolled
+// field packing verifier.go's dynamicFeeSigningMessage uses for the same
+// Transaction struct.
+func encodeJournalTx(tx *Transaction) []byte {
+	buf := make([]byte, 0, journalTxFixedLen+len(tx.Data))
+	buf = append(buf, tx.Hash[:]...)
+	buf = append(buf, tx.From[:]...)
+	buf = append(buf, tx.To[:]...)
+	buf = appendUint64LE(buf, tx.Amount)
+	buf = appendUint64LE(buf, tx.Nonce)
+	buf = appendUint64LE(buf, tx.GasLimit)
+	buf = appendUint64LE(buf, tx.GasPrice)
+	buf = appendUint64LE(buf, tx.MaxFeePerGas)
+	buf = appendUint64LE(buf, tx.MaxPriorityFeePerGas)
+	buf = append(buf, tx.Signature[:]...)
+	buf = appendUint64LE(buf, tx.ChainID)
+	buf = appendUint64LE(buf, uint64(tx.Timestamp))
+	buf = append(buf, tx.TxType)
+	buf = appendUint64LE(buf, tx.Fee)
+	buf = appendUint32LE(buf, uint32(len(tx.Data)))
+	buf = append(buf, tx.Data...)
+	return buf
+}
+
+// decodeJournalTx reverses encodeJournalTx.
+func decodeJournalTx(data []byte) (*Transaction, error) {
+	if len(data) < journalTxFixedLen {
+		return nil, fmt.Errorf("truncated journal record: need at least %d bytes, have %d", journalTxFixedLen, len(data))
+	}
+
+	tx := &Transaction{}
+	off := 0
+
+	copy(tx.Hash[:], data[off:off+32])
+	off += 32
+	copy(tx.From[:], data[off:off+32])
+	off += 32
+	copy(tx.To[:], data[off:off+32])
+	off += 32
+	tx.Amount = binary.LittleEndian.Uint64(data[off:])
+	off += 8
+	tx.Nonce = binary.LittleEndian.Uint64(data[off:])
+	off += 8
+	tx.GasLimit = binary.LittleEndian.Uint64(data[off:])
+	off += 8
+	tx.GasPrice = binary.LittleEndian.Uint64(data[off:])
+	off += 8
+	tx.MaxFeePerGas = binary.LittleEndian.Uint64(data[off:])
+	off += 8
+	tx.MaxPriorityFeePerGas = binary.LittleEndian.Uint64(data[off:])
+	off += 8
+	copy(tx.Signature[:], data[off:off+64])
+	off += 64
+	tx.ChainID = binary.LittleEndian.Uint64(data[off:])
+	off += 8
+	tx.Timestamp = int64(binary.LittleEndian.Uint64(data[off:]))
+	off += 8
+	tx.TxType = data[off]
+	off += 1
+	tx.Fee = binary.LittleEndian.Uint64(data[off:])
+	off += 8
+	dataLen := int(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+
+	if len(data)-off < dataLen {
+		return nil, fmt.Errorf("truncated journal record data: want %d bytes, have %d", dataLen, len(data)-off)
+	}
+	if dataLen > 0 {
+		tx.Data = make([]byte, dataLen)
+		copy(tx.Data, data[off:off+dataLen])
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,148 @@
+package mempool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+func newTestJournal(t *testing.T) (*Journal, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mempool.journal")
+	j, err := NewJournal(path, logger.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("Failed to open journal: %v", err)
+	}
+	return j, path
+}
+
+func TestJournalInsertAndReplay(t *testing.T) {
+	j, _ := newTestJournal(t)
+	defer j.Close()
+
+	tx1 := createTestTransaction(0, 100)
+	tx2 := createTestTransaction(1, 200)
+
+	if err := j.Insert(tx1); err != nil {
+		t.Fatalf("Failed to insert tx1: %v", err)
+	}
+	if err := j.Insert(tx2); err != nil {
+		t.Fatalf("Failed to insert tx2: %v", err)
+	}
+
+	txs, skipped, err := j.Replay()
+	if err != nil {
+		t.Fatalf("Failed to replay journal: %v", err)
+	}
+	if skipped != 0 {
+		t.Fatalf("Expected no skipped bytes, got %d", skipped)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("Expected 2 replayed transactions, got %d", len(txs))
+	}
+	if txs[0].Hash != tx1.Hash || txs[1].Hash != tx2.Hash {
+		t.Fatalf("Replayed transactions don't match what was inserted")
+	}
+	if txs[0].Nonce != tx1.Nonce || txs[0].GasPrice != tx1.GasPrice {
+		t.Fatalf("Replayed transaction fields don't match: got nonce=%d gasPrice=%d", txs[0].Nonce, txs[0].GasPrice)
+	}
+}
+
+func TestJournalReplaySkipsTruncatedTrailingRecord(t *testing.T) {
+	j, path := newTestJournal(t)
+
+	tx := createTestTransaction(0, 100)
+	if err := j.Insert(tx); err != nil {
+		t.Fatalf("Failed to insert tx: %v", err)
+	}
+	j.Close()
+
+	// Simulate a crash mid-write: append a few stray bytes that don't form
+	// a complete record.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("Failed to reopen journal for corruption: %v", err)
+	}
+	f.Write([]byte{1, 2, 3})
+	f.Close()
+
+	j2, err := NewJournal(path, logger.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("Failed to reopen journal: %v", err)
+	}
+	defer j2.Close()
+
+	txs, skipped, err := j2.Replay()
+	if err != nil {
+		t.Fatalf("Failed to replay journal: %v", err)
+	}
+	if len(txs) != 1 {
+		t.Fatalf("Expected 1 intact transaction, got %d", len(txs))
+	}
+	if skipped == 0 {
+		t.Fatal("Expected the trailing partial record to be reported as skipped bytes")
+	}
+}
+
+func TestJournalRotateDropsStaleEntries(t *testing.T) {
+	j, _ := newTestJournal(t)
+	defer j.Close()
+
+	stale := createTestTransaction(0, 100)
+	live := createTestTransaction(1, 200)
+
+	if err := j.Insert(stale); err != nil {
+		t.Fatalf("Failed to insert stale tx: %v", err)
+	}
+	if err := j.Insert(live); err != nil {
+		t.Fatalf("Failed to insert live tx: %v", err)
+	}
+
+	if err := j.Rotate([]*Transaction{live}); err != nil {
+		t.Fatalf("Failed to rotate journal: %v", err)
+	}
+
+	txs, _, err := j.Replay()
+	if err != nil {
+		t.Fatalf("Failed to replay rotated journal: %v", err)
+	}
+	if len(txs) != 1 || txs[0].Hash != live.Hash {
+		t.Fatalf("Expected only the live transaction to survive rotation, got %d txs", len(txs))
+	}
+}
+
+func TestMempoolJournalsAcrossRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mempool.journal")
+
+	cfg := DefaultConfig()
+	cfg.MaxSenderSharePercent = 100
+	cfg.JournalPath = path
+	log := logger.NewLogger("error")
+
+	m1 := NewMempool(cfg, log)
+	if err := m1.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start first mempool: %v", err)
+	}
+
+	tx := createTestTransaction(0, 100)
+	if _, err := m1.AddTransaction(tx); err != nil {
+		t.Fatalf("Failed to add transaction: %v", err)
+	}
+	m1.Stop()
+
+	m2 := NewMempool(cfg, log)
+	if err := m2.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start second mempool: %v", err)
+	}
+	defer m2.Stop()
+
+	if m2.Size() != 1 {
+		t.Fatalf("Expected the journaled transaction to survive restart, got size %d", m2.Size())
+	}
+	if _, err := m2.GetTransaction(tx.Hash); err != nil {
+		t.Fatalf("Expected journaled transaction to be present after restart: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ package mempool
 import (
 	"container/heap"
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -11,51 +12,236 @@ import (
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 )
 
+// ErrUnderpricedReplacement is wrapped into the error AddTransaction returns
+// when a same-(sender, nonce) replacement doesn't clear Config.PriceBumpPercent,
+// so callers (e.g. the API layer) can distinguish it from other rejection
+// reasons with errors.Is rather than string-matching the message.
+var ErrUnderpricedReplacement = errors.New("replacement transaction underpriced")
+
 // Transaction represents a pending transaction
 type Transaction struct {
-	Hash      [32]byte    // SHA-256 hash of transaction
-	From      [32]byte    // Sender address (Ed25519 public key)
-	To        [32]byte    // Recipient address
-	Amount    uint64      // Amount in wei
-	Nonce     uint64      // Nonce for replay protection
-	GasLimit  uint64      // Gas limit
-	GasPrice  uint64      // Gas price (wei per gas)
-	Signature [64]byte    // Ed25519 signature
-	Data      []byte      // Transaction data (problem submissions, etc.)
-	Timestamp int64       // Transaction timestamp
-	TxType    uint8       // 1=Transfer, 2=ProblemSubmission, 3=BountyPayment
-	Fee       uint64      // Calculated fee (gas_limit * gas_price)
-	AddedAt   time.Time   // When tx was added to mempool
-	Priority  float64     // Priority score for ordering
+	Hash                 [32]byte  // SHA-256 hash of transaction
+	From                 [32]byte  // Sender address (Ed25519 public key)
+	To                   [32]byte  // Recipient address
+	Amount               uint64    // Amount in wei
+	Nonce                uint64    // Nonce for replay protection
+	GasLimit             uint64    // Gas limit
+	GasPrice             uint64    // Gas price (wei per gas) — legacy, codec_version=1
+	MaxFeePerGas         uint64    // EIP-1559: absolute cap on wei per gas, codec_version=2
+	MaxPriorityFeePerGas uint64    // EIP-1559: tip offered to the block producer, codec_version=2
+	Signature            [64]byte  // Ed25519 signature
+	Data                 []byte    // Transaction data (problem submissions, etc.)
+	ChainID              uint64    // Replay protection: must match this node's configured chain id
+	Timestamp            int64     // Transaction timestamp
+	TxType               uint8     // 1=Transfer, 2=ProblemSubmission, 3=BountyPayment
+	Fee                  uint64    // Calculated fee (gas_limit * gas_price)
+	AddedAt              time.Time // When tx was added to mempool
+	Priority             float64   // Priority score for ordering
+}
+
+// IsDynamicFee reports whether the transaction carries EIP-1559 style fee caps
+// rather than a single legacy GasPrice.
+func (tx *Transaction) IsDynamicFee() bool {
+	return tx.MaxFeePerGas > 0 || tx.MaxPriorityFeePerGas > 0
+}
+
+// EffectiveTip computes the per-gas tip actually paid to the block producer
+// given the current rolling baseFee: min(MaxPriorityFeePerGas, MaxFeePerGas - baseFee).
+//
+// Legacy (non-dynamic-fee) transactions have no tip concept, so their
+// effective tip is simply their GasPrice.
+func (tx *Transaction) EffectiveTip(baseFee uint64) uint64 {
+	if !tx.IsDynamicFee() {
+		return tx.GasPrice
+	}
+
+	if tx.MaxFeePerGas <= baseFee {
+		return 0
+	}
+
+	headroom := tx.MaxFeePerGas - baseFee
+	if tx.MaxPriorityFeePerGas < headroom {
+		return tx.MaxPriorityFeePerGas
+	}
+	return headroom
 }
 
 // Config holds mempool configuration
 type Config struct {
-	MaxSize          int           // Maximum number of transactions
-	MaxTxAge         time.Duration // Maximum age before eviction (e.g., 1 hour)
-	CleanupInterval  time.Duration // How often to clean up expired txs
-	PriorityThreshold float64      // Minimum priority to accept
+	MaxSize           int           // Maximum number of transactions
+	MaxTxAge          time.Duration // Maximum age before eviction (e.g., 1 hour)
+	CleanupInterval   time.Duration // How often to clean up expired txs
+	PriorityThreshold float64       // Minimum priority to accept
+
+	// MaxSenderSharePercent bounds the fraction of MaxSize (0-100) that a
+	// single sender may occupy, so no one address can monopolize the pool.
+	// Once a sender is at its cap, a new transaction from it is only
+	// admitted by evicting that sender's own worst pending transaction.
+	MaxSenderSharePercent float64
+
+	// PriceBumpPercent is the minimum fractional gas price increase a
+	// replacement transaction must offer over the one it targets at the
+	// same (sender, nonce) — see ShouldReplace. 0.10 means a 10% bump.
+	PriceBumpPercent float64
+
+	// MaxPendingPerAccount bounds how many contiguous, chain-executable
+	// transactions a single sender may hold in the pending pool (see
+	// rebalanceSenderLocked). 0 means unlimited.
+	MaxPendingPerAccount int
+
+	// MaxQueuedPerAccount bounds how many nonce-gapped transactions a
+	// single sender may hold in the queued pool awaiting a gap fill. 0
+	// means unlimited.
+	MaxQueuedPerAccount int
+
+	// JournalPath, if set, makes the mempool append every locally-accepted
+	// transaction to this file and replay it back on Start, so a sender's
+	// queued transactions survive a daemon restart (mirrors go-ethereum's
+	// tx pool journal). Empty disables journaling.
+	JournalPath string
+
+	// JournalRotateInterval controls how often Start's background loop
+	// compacts the journal: it's rewritten to hold only transactions still
+	// live in the pool, dropping anything that's since expired, been
+	// mined, or been replaced. Has no effect if JournalPath is empty; 0
+	// disables periodic rotation (Journal.Rotate can still be called
+	// directly).
+	JournalRotateInterval time.Duration
+
+	// Locals lists sender addresses that get preferential treatment,
+	// mirroring the "local vs remote" distinction in go-ethereum's tx pool:
+	// their transactions bypass MinEffectiveGasPrice's floor, are never
+	// picked by size-based eviction (see evictLowestPriority), and survive
+	// journal replay on restart even if they'd no longer admit at face
+	// value. Nil means every sender is remote. See SetLocal/AddLocal.
+	Locals map[[32]byte]bool
 }
 
 // DefaultConfig returns sensible defaults
 func DefaultConfig() Config {
 	return Config{
-		MaxSize:          10000,                // 10k pending transactions
-		MaxTxAge:         1 * time.Hour,        // 1 hour max age
-		CleanupInterval:  5 * time.Minute,      // Cleanup every 5 minutes
-		PriorityThreshold: 0.0,                 // Accept all valid txs
+		MaxSize:               10000,           // 10k pending transactions
+		MaxTxAge:              1 * time.Hour,   // 1 hour max age
+		CleanupInterval:       5 * time.Minute, // Cleanup every 5 minutes
+		PriorityThreshold:     0.0,             // Accept all valid txs
+		MaxSenderSharePercent: 1.0,             // No sender may hold more than 1% of the pool
+		PriceBumpPercent:      0.10,            // Replacements must bid at least 10% higher
+		MaxPendingPerAccount:  16,              // Mirrors go-ethereum's default AccountSlots
+		MaxQueuedPerAccount:   64,              // Mirrors go-ethereum's default AccountQueue
+		JournalRotateInterval: 1 * time.Hour,
 	}
 }
 
+// MetricsSink receives mempool depth and fee signals as transactions are
+// admitted or removed, so an external Prometheus exporter can track them
+// without Mempool importing a concrete metrics type — see SetMetricsSink.
+// Defined here, next to the struct that uses it, rather than in pkg/metrics,
+// so that package can implement it instead of this one depending on it.
+type MetricsSink interface {
+	// SetMempoolSize reports the current number of pending transactions of
+	// the given tx type.
+	SetMempoolSize(txType uint8, count int)
+	// SetMempoolBytes reports the current estimated total size, in bytes,
+	// of all pending transactions.
+	SetMempoolBytes(bytes int)
+	// ObserveMempoolFee records the gas price of a transaction just
+	// admitted to the mempool.
+	ObserveMempoolFee(gasPrice uint64)
+	// ObserveMempoolReplacement records a same-(sender, nonce) replacement
+	// event, tagged with why it was accepted or rejected (e.g. "fee_bump",
+	// "underpriced").
+	ObserveMempoolReplacement(reason string)
+}
+
+// Scoring ranks mempool transactions for both admission and block-inclusion
+// order. Implementations are handed the sender's lowest currently-pending
+// nonce (its next executable, or "ready", nonce) so they can rank
+// nonce-gapped ("future") transactions below it without the pool needing a
+// full account-state view of its own.
+//
+// Any implementation must satisfy: for a fixed sender, a transaction whose
+// nonce equals readyNonce always outscores every other pending transaction
+// of that sender, regardless of fee.
+type Scoring interface {
+	Score(tx *Transaction, baseFee uint64, readyNonce uint64, hasReady bool) float64
+}
+
+// futureTxPenalty is subtracted once per nonce of gap between a transaction
+// and its sender's ready nonce. It's large enough that no realistic fee
+// difference lets a future transaction outrank a ready one, while still
+// ranking a 2-nonce gap below a 1-nonce gap.
+const futureTxPenalty = 1e18
+
+// NonceAndGasPrice is the default Scoring strategy, modeled on the
+// transaction pool ordering used by Parity/OpenEthereum: within a sender,
+// transactions rank strictly by nonce (the ready one first); across
+// senders, ready transactions rank by effective gas price.
+type NonceAndGasPrice struct{}
+
+// Score implements Scoring.
+func (NonceAndGasPrice) Score(tx *Transaction, baseFee uint64, readyNonce uint64, hasReady bool) float64 {
+	tip := float64(tx.EffectiveTip(baseFee))
+	if !hasReady || tx.Nonce == readyNonce {
+		return tip
+	}
+	gap := tx.Nonce - readyNonce
+	return tip - float64(gap)*futureTxPenalty
+}
+
+// NonceSource supplies a sender's current on-chain account nonce — the
+// first nonce not yet applied to a block — so the mempool can tell a
+// contiguous, chain-executable "pending" transaction from a nonce-gapped
+// "queued" one. See SetNonceSource.
+//
+// If unset, the mempool falls back to treating the lowest nonce it is
+// currently holding for a sender as that sender's floor, which reproduces
+// the pre-split behavior of always considering a sender's lowest pending
+// nonce "ready".
+type NonceSource interface {
+	AccountNonce(address [32]byte) uint64
+}
+
+// TxEventSink receives a notification for every transaction newly admitted
+// to the pool — not one replayed from the journal on restart — so an
+// external subscriber feed (e.g. pkg/api's WebSocket newPendingTransactions
+// topic) can react without this package depending on anything upstream. See
+// Mempool.SetTxEventSink.
+type TxEventSink interface {
+	OnTransactionAdded(tx *Transaction)
+}
+
+// ShouldReplace reports whether new may replace old as the pending
+// transaction for their shared (sender, nonce) slot. It requires new to bid
+// at least bumpPercent higher than old's gas price, so a sender can't churn
+// the pool with trivially-reordered same-nonce resubmissions.
+func ShouldReplace(old, new *Transaction, bumpPercent float64) bool {
+	threshold := float64(old.GasPrice) * (1 + bumpPercent)
+	return float64(new.GasPrice) >= threshold
+}
+
 // Mempool manages pending transactions with priority ordering
 type Mempool struct {
 	config Config
 	log    *logger.Logger
 
-	mu    sync.RWMutex
-	txs   map[[32]byte]*Transaction      // Hash → Transaction
-	queue priorityQueue                   // Priority queue for ordering
-	nonce map[[32]byte]uint64             // Address → highest nonce seen
+	mu          sync.RWMutex
+	txs         map[[32]byte]*Transaction            // Hash → Transaction, across both pools
+	queue       priorityQueue                        // Priority queue over the pending pool only
+	bySender    map[[32]byte]map[uint64]*Transaction // Sender → Nonce → Transaction, across both pools; source of truth for partitioning
+	pendingHash map[[32]byte]struct{}                // Hash set: which txs are currently in the pending (executable) pool
+	seenTxTypes map[uint8]struct{}                   // Every TxType ever admitted, so reportSize can zero out emptied types
+
+	baseFee              uint64 // Rolling EIP-1559 base fee, updated per block
+	minEffectiveGasPrice uint64 // Worst admitted tx's effective tip; 0 means the pool isn't full and has no floor
+
+	scoring     Scoring     // Never nil; defaults to NonceAndGasPrice
+	metrics     MetricsSink // nil unless SetMetricsSink is called
+	nonceSource NonceSource // nil unless SetNonceSource is called; see accountFloorLocked
+
+	journal        *Journal           // nil unless Config.JournalPath is set; created by Start
+	journalMetrics JournalMetricsSink // nil unless SetJournalMetricsSink is called
+
+	txEventSink TxEventSink // nil unless SetTxEventSink is called
 
 	stopChan chan struct{}
 }
@@ -63,74 +249,308 @@ type Mempool struct {
 // NewMempool creates a new mempool manager
 func NewMempool(cfg Config, log *logger.Logger) *Mempool {
 	m := &Mempool{
-		config:   cfg,
-		log:      log,
-		txs:      make(map[[32]byte]*Transaction),
-		queue:    make(priorityQueue, 0, cfg.MaxSize),
-		nonce:    make(map[[32]byte]uint64),
-		stopChan: make(chan struct{}),
+		config:      cfg,
+		log:         log,
+		txs:         make(map[[32]byte]*Transaction),
+		queue:       make(priorityQueue, 0, cfg.MaxSize),
+		bySender:    make(map[[32]byte]map[uint64]*Transaction),
+		pendingHash: make(map[[32]byte]struct{}),
+		seenTxTypes: make(map[uint8]struct{}),
+		scoring:     NonceAndGasPrice{},
+		stopChan:    make(chan struct{}),
 	}
 
 	heap.Init(&m.queue)
 	return m
 }
 
-// Start starts background cleanup goroutine
+// SetMetricsSink wires sink to receive mempool depth/fee updates going
+// forward. Passing nil (the default) disables reporting.
+func (m *Mempool) SetMetricsSink(sink MetricsSink) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = sink
+}
+
+// SetScoring swaps the ranking strategy used for admission and eviction
+// decisions going forward. Passing nil restores the default
+// NonceAndGasPrice strategy.
+func (m *Mempool) SetScoring(s Scoring) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s == nil {
+		s = NonceAndGasPrice{}
+	}
+	m.scoring = s
+}
+
+// SetNonceSource wires ns to answer each sender's current on-chain account
+// nonce going forward, replacing the lowest-known-pending-nonce fallback.
+// Passing nil restores that fallback.
+func (m *Mempool) SetNonceSource(ns NonceSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nonceSource = ns
+}
+
+// SetJournalMetricsSink wires sink to receive journal size/replay signals
+// going forward. Passing nil (the default) disables reporting. Has no
+// effect on a mempool with no JournalPath configured.
+func (m *Mempool) SetJournalMetricsSink(sink JournalMetricsSink) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.journalMetrics = sink
+}
+
+// SetTxEventSink wires sink to be notified of every transaction newly
+// admitted to the pool going forward. Passing nil (the default) disables
+// notification.
+func (m *Mempool) SetTxEventSink(sink TxEventSink) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.txEventSink = sink
+}
+
+// Start starts background cleanup goroutine. If Config.JournalPath is set, it
+// also opens (creating if necessary) the transaction journal, replays
+// whatever it holds back into the pool, and — if Config.JournalRotateInterval
+// is nonzero — starts a background loop that periodically compacts it.
 func (m *Mempool) Start(ctx context.Context) error {
 	m.log.WithField("max_size", m.config.MaxSize).Info("Starting mempool")
 
+	if m.config.JournalPath != "" {
+		j, err := NewJournal(m.config.JournalPath, m.log)
+		if err != nil {
+			return fmt.Errorf("failed to open mempool journal: %w", err)
+		}
+		m.mu.Lock()
+		m.journal = j
+		m.mu.Unlock()
+
+		if err := m.replayJournal(); err != nil {
+			return fmt.Errorf("failed to replay mempool journal: %w", err)
+		}
+
+		if m.config.JournalRotateInterval > 0 {
+			go m.journalRotateLoop()
+		}
+	}
+
 	go m.cleanupLoop()
 
 	return nil
 }
 
+// replayJournal reads every transaction the journal has on disk and
+// re-admits each through the normal AddTransaction path (minus re-journaling
+// it), logging and skipping any that no longer admit cleanly — e.g. one
+// that's since aged out, or that's been superseded by a later replacement
+// also found in the journal.
+func (m *Mempool) replayJournal() error {
+	txs, skippedBytes, err := m.journal.Replay()
+	if err != nil {
+		return err
+	}
+
+	accepted := 0
+	for _, tx := range txs {
+		if _, err := m.addTransaction(tx, false); err != nil {
+			m.log.WithFields(logger.Fields{
+				"hash":  fmt.Sprintf("%x", tx.Hash[:8]),
+				"error": err,
+			}).Debug("Journal replay rejected transaction")
+			continue
+		}
+		accepted++
+	}
+
+	m.log.WithFields(logger.Fields{
+		"replayed":      len(txs),
+		"accepted":      accepted,
+		"rejected":      len(txs) - accepted,
+		"skipped_bytes": skippedBytes,
+	}).Info("Replayed mempool journal")
+
+	if m.journalMetrics != nil {
+		m.journalMetrics.ObserveJournalReplay(accepted, len(txs)-accepted)
+		if size, err := m.journal.Size(); err == nil {
+			m.journalMetrics.SetJournalSize(int(size))
+		}
+	}
+
+	return nil
+}
+
+// journalRotateLoop periodically compacts the journal at
+// Config.JournalRotateInterval until Stop is called.
+func (m *Mempool) journalRotateLoop() {
+	ticker := time.NewTicker(m.config.JournalRotateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.rotateJournal()
+		case <-m.stopChan:
+			return
+		}
+	}
+}
+
+// rotateJournal snapshots the pool's current contents and hands them to
+// Journal.Rotate, so the on-disk journal never grows past what replaying it
+// would actually restore.
+func (m *Mempool) rotateJournal() {
+	m.mu.RLock()
+	live := make([]*Transaction, 0, len(m.txs))
+	for _, tx := range m.txs {
+		live = append(live, tx)
+	}
+	m.mu.RUnlock()
+
+	if err := m.journal.Rotate(live); err != nil {
+		m.log.WithField("error", err).Warn("Failed to rotate mempool journal")
+		return
+	}
+	if m.journalMetrics != nil {
+		if size, err := m.journal.Size(); err == nil {
+			m.journalMetrics.SetJournalSize(int(size))
+		}
+	}
+}
+
 // Stop stops the mempool
 func (m *Mempool) Stop() {
 	close(m.stopChan)
+	if m.journal != nil {
+		if err := m.journal.Close(); err != nil {
+			m.log.WithField("error", err).Warn("Failed to close mempool journal")
+		}
+	}
 	m.log.Info("Mempool stopped")
 }
 
-// AddTransaction adds a validated transaction to the mempool
+// AddTransaction adds a validated transaction to the mempool. If tx replaced
+// an existing same-(sender, nonce) transaction via RBF, the replaced
+// transaction's hash is returned so callers (e.g. gossip, submission
+// tracking) can stop waiting on it; otherwise the returned hash is nil.
 //
 // Returns error if:
-// - Transaction already exists
-// - Mempool is full (and tx priority is too low)
-// - Nonce is too old (replay protection)
-func (m *Mempool) AddTransaction(tx *Transaction) error {
+//   - Transaction already exists
+//   - Mempool is full and below its MinEffectiveGasPrice floor
+//   - Nonce is too old (replay protection)
+//   - The sender is at its per-sender cap and this tx doesn't beat its own worst
+//   - This replaces an existing (sender, nonce) slot without enough of a fee bump
+//   - The sender is at its MaxPendingPerAccount or MaxQueuedPerAccount cap,
+//     whichever pool this tx would land in
+func (m *Mempool) AddTransaction(tx *Transaction) (*[32]byte, error) {
+	return m.addTransaction(tx, true)
+}
+
+// addTransaction is AddTransaction's implementation, parameterized on
+// whether to append tx to the journal. journal is false only when
+// replayJournal is re-admitting a transaction the journal already holds, so
+// replay doesn't duplicate it right back into the file it just read it from.
+func (m *Mempool) addTransaction(tx *Transaction, journal bool) (*[32]byte, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// Check if already in mempool
 	if _, exists := m.txs[tx.Hash]; exists {
-		return fmt.Errorf("transaction already in mempool: %x", tx.Hash[:8])
+		return nil, fmt.Errorf("transaction already in mempool: %x", tx.Hash[:8])
 	}
 
-	// Check nonce ordering (must be >= current highest)
-	highestNonce, exists := m.nonce[tx.From]
-	if exists && tx.Nonce < highestNonce {
-		return fmt.Errorf("nonce too old: got %d, expected >= %d", tx.Nonce, highestNonce)
+	// Cheap reject: if the pool is full, don't even touch the heap or
+	// per-sender bookkeeping for a tx that can't possibly beat the worst
+	// admitted one. Local senders (see Config.Locals) are exempt: the floor
+	// exists to shed low-value remote spam once the pool is full, not to
+	// turn away the operator's own transactions.
+	if m.minEffectiveGasPrice > 0 && tx.EffectiveTip(m.baseFee) < m.minEffectiveGasPrice && !m.isLocalLocked(tx.From) {
+		return nil, fmt.Errorf("effective gas price %d below mempool floor %d", tx.EffectiveTip(m.baseFee), m.minEffectiveGasPrice)
 	}
 
-	// Calculate priority (higher fee = higher priority)
-	tx.Priority = calculatePriority(tx)
+	// Reject dynamic-fee txs that can't possibly cover the current base fee
+	if tx.IsDynamicFee() && tx.MaxFeePerGas < m.baseFee {
+		return nil, fmt.Errorf("max fee per gas %d below current base fee %d", tx.MaxFeePerGas, m.baseFee)
+	}
+
+	senderNonces := m.bySender[tx.From]
+	existing, isReplacement := senderNonces[tx.Nonce]
+	if !isReplacement {
+		if highest, hasSender := m.highestNonceLocked(tx.From); hasSender && tx.Nonce < highest {
+			return nil, fmt.Errorf("nonce too old: got %d, expected >= %d", tx.Nonce, highest)
+		}
+	} else if !ShouldReplace(existing, tx, m.config.PriceBumpPercent) {
+		if m.metrics != nil {
+			m.metrics.ObserveMempoolReplacement("underpriced")
+		}
+		return nil, fmt.Errorf("%w: gas price %d does not beat %d by %.0f%%", ErrUnderpricedReplacement, tx.GasPrice, existing.GasPrice, m.config.PriceBumpPercent*100)
+	}
+
+	// Calculate priority (higher effective tip = higher priority, subject
+	// to nonce-gap ranking — see Scoring). readyNonce is always the
+	// sender's floor now: rebalanceSenderLocked is what actually decides
+	// pending-vs-queued membership below, but the gap penalty this
+	// produces for a non-floor nonce is still what keeps a sender's
+	// higher nonces ranked under its lower ones once both land in pending.
+	floor := m.accountFloorLocked(tx.From)
+	tx.Priority = m.scoring.Score(tx, m.baseFee, floor, true)
 	tx.AddedAt = time.Now()
 
-	// Check priority threshold
-	if tx.Priority < m.config.PriorityThreshold {
-		return fmt.Errorf("priority too low: %.2f < %.2f", tx.Priority, m.config.PriorityThreshold)
+	// Check priority threshold against the raw effective tip rather than
+	// the nonce-gap-scored Priority: PriorityThreshold filters dust fees,
+	// it isn't meant to reject an otherwise-valid future transaction just
+	// because Scoring ranks it behind its sender's ready one.
+	if tip := float64(tx.EffectiveTip(m.baseFee)); tip < m.config.PriorityThreshold {
+		return nil, fmt.Errorf("priority too low: %.2f < %.2f", tip, m.config.PriorityThreshold)
+	}
+
+	var evicted *[32]byte
+	if isReplacement {
+		// Freeing the old slot up front keeps the MaxSize/cap checks below
+		// accurate: a same-(sender,nonce) replacement doesn't grow the pool.
+		evictedHash := existing.Hash
+		evicted = &evictedHash
+		m.deleteTx(existing)
+		if m.metrics != nil {
+			m.metrics.ObserveMempoolReplacement("fee_bump")
+		}
+	} else if len(senderNonces) >= m.senderCap() {
+		worst := m.senderWorst(tx.From)
+		if worst == nil || tx.Priority <= worst.Priority {
+			return nil, fmt.Errorf("sender %x at pool cap (%d txs) and new tx does not beat its worst pending tx", tx.From[:8], m.senderCap())
+		}
+		m.deleteTx(worst)
+	} else if m.wouldBePendingLocked(tx.From, tx.Nonce) {
+		if cap := m.config.MaxPendingPerAccount; cap > 0 {
+			if pendingCount, _ := m.senderPoolCountsLocked(tx.From); pendingCount >= cap {
+				return nil, fmt.Errorf("sender %x at pending pool cap (%d txs)", tx.From[:8], cap)
+			}
+		}
+	} else if cap := m.config.MaxQueuedPerAccount; cap > 0 {
+		if _, queuedCount := m.senderPoolCountsLocked(tx.From); queuedCount >= cap {
+			return nil, fmt.Errorf("sender %x at queued pool cap (%d txs)", tx.From[:8], cap)
+		}
 	}
 
 	// Evict lowest priority tx if mempool is full
 	if len(m.txs) >= m.config.MaxSize {
 		if err := m.evictLowestPriority(tx.Priority); err != nil {
-			return fmt.Errorf("mempool full and tx priority too low: %w", err)
+			return nil, fmt.Errorf("mempool full and tx priority too low: %w", err)
 		}
 	}
 
-	// Add to mempool
+	// Add to mempool. rebalanceSenderLocked places tx into the pending
+	// pool and its competitive heap if it's contiguous with the sender's
+	// floor, leaves it queued otherwise, and promotes any queued
+	// successors this fills a gap for.
 	m.txs[tx.Hash] = tx
-	heap.Push(&m.queue, tx)
-	m.nonce[tx.From] = tx.Nonce
+	if m.bySender[tx.From] == nil {
+		m.bySender[tx.From] = make(map[uint64]*Transaction)
+	}
+	m.bySender[tx.From][tx.Nonce] = tx
+	m.rebalanceSenderLocked(tx.From)
+	m.updateMinEffectiveGasPrice()
 
 	m.log.WithFields(logger.Fields{
 		"hash":     fmt.Sprintf("%x", tx.Hash[:8]),
@@ -142,7 +562,27 @@ func (m *Mempool) AddTransaction(tx *Transaction) error {
 		"size":     len(m.txs),
 	}).Debug("Transaction added to mempool")
 
-	return nil
+	if m.metrics != nil {
+		m.seenTxTypes[tx.TxType] = struct{}{}
+		m.reportSize()
+		m.metrics.ObserveMempoolFee(tx.GasPrice)
+	}
+
+	if journal && m.journal != nil {
+		if err := m.journal.Insert(tx); err != nil {
+			m.log.WithField("error", err).Warn("Failed to append transaction to mempool journal")
+		} else if m.journalMetrics != nil {
+			if size, err := m.journal.Size(); err == nil {
+				m.journalMetrics.SetJournalSize(int(size))
+			}
+		}
+	}
+
+	if journal && m.txEventSink != nil {
+		m.txEventSink.OnTransactionAdded(tx)
+	}
+
+	return evicted, nil
 }
 
 // GetTransaction retrieves a transaction by hash
@@ -163,31 +603,43 @@ func (m *Mempool) RemoveTransaction(hash [32]byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	_, exists := m.txs[hash]
+	tx, exists := m.txs[hash]
 	if !exists {
 		return fmt.Errorf("transaction not found: %x", hash[:8])
 	}
 
-	delete(m.txs, hash)
+	m.deleteTx(tx)
 	// Note: We don't remove from heap immediately (lazy deletion on Pop)
+	m.updateMinEffectiveGasPrice()
 
 	m.log.WithFields(logger.Fields{
 		"hash": fmt.Sprintf("%x", hash[:8]),
 		"size": len(m.txs),
 	}).Debug("Transaction removed from mempool")
 
+	if m.metrics != nil {
+		m.reportSize()
+	}
+
 	return nil
 }
 
-// GetTopTransactions returns the N highest-priority transactions
+// GetTopTransactions returns the N highest-priority transactions from the
+// pending pool.
 //
-// Used by block builders to select transactions for new blocks.
+// Used by block builders to select transactions for new blocks. Only
+// pending (contiguous, chain-executable) transactions are ever returned —
+// a queued, nonce-gapped transaction isn't safe to execute yet regardless
+// of how much it pays, so it never enters the heap this draws from (see
+// rebalanceSenderLocked). Because a sender's higher pending nonces still
+// score below its lowest one (see Scoring), this also pops a sender's
+// contiguous-nonce transactions out in strict nonce order.
 func (m *Mempool) GetTopTransactions(n int) []*Transaction {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	if n > len(m.txs) {
-		n = len(m.txs)
+	if n > len(m.pendingHash) {
+		n = len(m.pendingHash)
 	}
 
 	// Make a copy of queue to avoid modifying original
@@ -199,8 +651,9 @@ func (m *Mempool) GetTopTransactions(n int) []*Transaction {
 	for i := 0; i < n && len(queueCopy) > 0; i++ {
 		tx := heap.Pop(&queueCopy).(*Transaction)
 
-		// Skip if transaction was removed (lazy deletion)
-		if _, exists := m.txs[tx.Hash]; !exists {
+		// Skip if transaction was removed, or demoted back to queued
+		// (lazy deletion)
+		if _, isPending := m.pendingHash[tx.Hash]; !isPending {
 			i-- // Don't count this iteration
 			continue
 		}
@@ -211,6 +664,100 @@ func (m *Mempool) GetTopTransactions(n int) []*Transaction {
 	return result
 }
 
+// PendingSize returns the current number of transactions in the pending
+// (chain-executable) pool.
+func (m *Mempool) PendingSize() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.pendingHash)
+}
+
+// QueuedSize returns the current number of transactions in the queued
+// (nonce-gapped) pool.
+func (m *Mempool) QueuedSize() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.txs) - len(m.pendingHash)
+}
+
+// LocalPendingSize returns the current number of pending transactions sent
+// by a local account (see Config.Locals).
+func (m *Mempool) LocalPendingSize() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.countLocalLocked(true)
+}
+
+// LocalQueuedSize returns the current number of queued transactions sent by
+// a local account (see Config.Locals).
+func (m *Mempool) LocalQueuedSize() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.countLocalLocked(false)
+}
+
+// countLocalLocked counts transactions from local senders in the pending
+// pool (pending=true) or the queued pool (pending=false). Callers must hold
+// at least m.mu.RLock.
+func (m *Mempool) countLocalLocked(pending bool) int {
+	if m.config.Locals == nil {
+		return 0
+	}
+	count := 0
+	for sender, nonces := range m.bySender {
+		if !m.config.Locals[sender] {
+			continue
+		}
+		for _, tx := range nonces {
+			_, isPending := m.pendingHash[tx.Hash]
+			if isPending == pending {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Content returns a point-in-time copy of every pending and every queued
+// transaction, grouped by sender — mirrors go-ethereum's TxPool.Content,
+// letting a caller (e.g. the API layer) answer "what's executable right
+// now" versus "what's parked waiting for a gap fill" without reaching into
+// the mempool's internal indexes.
+func (m *Mempool) Content() (pending, queued map[[32]byte][]*Transaction) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pending = make(map[[32]byte][]*Transaction)
+	queued = make(map[[32]byte][]*Transaction)
+	for sender, nonces := range m.bySender {
+		for _, tx := range nonces {
+			if _, ok := m.pendingHash[tx.Hash]; ok {
+				pending[sender] = append(pending[sender], tx)
+			} else {
+				queued[sender] = append(queued[sender], tx)
+			}
+		}
+	}
+	return pending, queued
+}
+
+// Demote re-evaluates sender's pending/queued partition against the
+// current NonceSource floor: any transaction the chain has already applied
+// (nonce below the new floor) is dropped, and any queued transaction that
+// is now contiguous with the floor is promoted into pending. Call this
+// once per sender included in a just-applied block, since that sender's
+// NonceSource answer has just changed.
+func (m *Mempool) Demote(sender [32]byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rebalanceSenderLocked(sender)
+	m.updateMinEffectiveGasPrice()
+	if m.metrics != nil {
+		m.reportSize()
+	}
+}
+
 // Size returns the current number of transactions in mempool
 func (m *Mempool) Size() int {
 	m.mu.RLock()
@@ -218,6 +765,33 @@ func (m *Mempool) Size() int {
 	return len(m.txs)
 }
 
+// NextNonce returns the nonce one past sender's highest currently-pending
+// transaction in the mempool, or 0 if sender has no pending transactions.
+// This only reflects mempool state: a caller building on top of existing
+// chain history (e.g. the load test tool, or a block builder generating
+// follow-up transactions) still needs to take the greater of this value
+// and the sender's on-chain account nonce for a sender with no pending
+// transactions yet.
+func (m *Mempool) NextNonce(sender [32]byte) uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	highest, ok := m.highestNonceLocked(sender)
+	if !ok {
+		return 0
+	}
+	return highest + 1
+}
+
+// MinEffectiveGasPrice returns the effective tip floor incoming
+// transactions must clear while the pool is full, or 0 if the pool has
+// room and no floor is in effect.
+func (m *Mempool) MinEffectiveGasPrice() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.minEffectiveGasPrice
+}
+
 // Cleanup removes expired transactions
 func (m *Mempool) Cleanup() int {
 	m.mu.Lock()
@@ -226,23 +800,56 @@ func (m *Mempool) Cleanup() int {
 	now := time.Now()
 	removed := 0
 
-	for hash, tx := range m.txs {
+	for _, tx := range m.txs {
 		if now.Sub(tx.AddedAt) > m.config.MaxTxAge {
-			delete(m.txs, hash)
+			m.deleteTx(tx)
 			removed++
 		}
 	}
 
 	if removed > 0 {
+		m.updateMinEffectiveGasPrice()
+
 		m.log.WithFields(logger.Fields{
-			"removed": removed,
+			"removed":   removed,
 			"remaining": len(m.txs),
 		}).Info("Mempool cleanup completed")
+
+		if m.metrics != nil {
+			m.reportSize()
+		}
 	}
 
 	return removed
 }
 
+// reportSize recomputes the per-type pending count and total estimated byte
+// size and pushes both to m.metrics. Callers must hold m.mu. seenTxTypes
+// ensures a tx type that had pending transactions before but now has none is
+// reported as 0 rather than silently dropped from the gauge.
+func (m *Mempool) reportSize() {
+	counts := make(map[uint8]int, len(m.seenTxTypes))
+	totalBytes := 0
+
+	for _, tx := range m.txs {
+		counts[tx.TxType]++
+		totalBytes += txApproxSize(tx)
+	}
+
+	for txType := range m.seenTxTypes {
+		m.metrics.SetMempoolSize(txType, counts[txType])
+	}
+	m.metrics.SetMempoolBytes(totalBytes)
+}
+
+// txApproxSize estimates tx's on-wire size in bytes: the sum of its
+// fixed-width fields plus its variable-length Data payload. It's an estimate,
+// not an exact encoded size, which is all MempoolBytes needs.
+func txApproxSize(tx *Transaction) int {
+	const fixedFields = 32 + 32 + 32 + 8 + 8 + 8 + 8 + 8 + 8 + 64 + 8 + 8 + 1 + 8 // Hash+From+To+Amount+Nonce+GasLimit+GasPrice+MaxFeePerGas+MaxPriorityFeePerGas+Signature+ChainID+Timestamp+TxType+Fee
+	return fixedFields + len(tx.Data)
+}
+
 // cleanupLoop periodically removes expired transactions
 func (m *Mempool) cleanupLoop() {
 	ticker := time.NewTicker(m.config.CleanupInterval)
@@ -258,44 +865,300 @@ func (m *Mempool) cleanupLoop() {
 	}
 }
 
-// evictLowestPriority removes the lowest-priority transaction
+// evictLowestPriority removes the lowest-priority evictable transaction.
+// Callers must hold m.mu.
 func (m *Mempool) evictLowestPriority(newTxPriority float64) error {
-	if len(m.queue) == 0 {
-		return fmt.Errorf("cannot evict from empty mempool")
+	lowestTx := m.lowestPriorityRemoteLocked()
+	if lowestTx == nil {
+		return fmt.Errorf("no evictable transaction found")
 	}
 
-	// Peek at lowest priority tx (at end of heap)
-	lowestTx := m.queue[len(m.queue)-1]
-
 	if newTxPriority <= lowestTx.Priority {
 		return fmt.Errorf("new tx priority %.2f <= lowest priority %.2f", newTxPriority, lowestTx.Priority)
 	}
 
-	// Remove lowest priority tx
-	delete(m.txs, lowestTx.Hash)
+	m.deleteTx(lowestTx)
 
 	m.log.WithFields(logger.Fields{
-		"evicted_hash": fmt.Sprintf("%x", lowestTx.Hash[:8]),
+		"evicted_hash":     fmt.Sprintf("%x", lowestTx.Hash[:8]),
 		"evicted_priority": lowestTx.Priority,
-		"new_priority": newTxPriority,
+		"new_priority":     newTxPriority,
 	}).Debug("Evicted low-priority transaction")
 
 	return nil
 }
 
-// calculatePriority computes transaction priority score
-//
-// Priority = fee_per_gas * age_multiplier
-//
-// Higher fees and older transactions get higher priority.
-func calculatePriority(tx *Transaction) float64 {
-	// Base priority: fee per gas unit
-	feePerGas := float64(tx.Fee) / float64(tx.GasLimit)
+// lowestPriorityRemoteLocked scans the pending heap for the lowest-priority
+// transaction from a non-local sender (see Config.Locals), skipping any
+// stale entry left behind by deleteTx's lazy deletion (see its doc comment)
+// the same way GetTopTransactions does. A plain heap-tail peek isn't enough
+// here since it would have no way to skip over a local sender's entry.
+// Returns nil if every pending transaction is either stale or local, i.e.
+// there's nothing left to evict. Callers must hold m.mu.
+func (m *Mempool) lowestPriorityRemoteLocked() *Transaction {
+	var lowest *Transaction
+	for _, tx := range m.queue {
+		if _, isPending := m.pendingHash[tx.Hash]; !isPending {
+			continue
+		}
+		if m.isLocalLocked(tx.From) {
+			continue
+		}
+		if lowest == nil || tx.Priority < lowest.Priority {
+			lowest = tx
+		}
+	}
+	return lowest
+}
 
-	// Age multiplier (older transactions get slight priority boost)
-	ageBoost := 1.0 + (time.Since(tx.AddedAt).Seconds() / 3600.0) // +1.0 per hour
+// deleteTx removes tx from the hash and per-sender indexes, then
+// rebalances whatever's left of the sender's nonces between pending and
+// queued, since removing tx may have both broken a pending chain's
+// contiguity past it and (if tx itself was queued ahead of nothing) left
+// nothing to change. Callers must hold m.mu. The heap entry itself is left
+// in place; GetTopTransactions and evictLowestPriority both already treat
+// m.pendingHash membership as the source of truth and skip stale heap
+// entries lazily.
+func (m *Mempool) deleteTx(tx *Transaction) {
+	delete(m.txs, tx.Hash)
+	delete(m.pendingHash, tx.Hash)
+	if nonces, ok := m.bySender[tx.From]; ok {
+		delete(nonces, tx.Nonce)
+		if len(nonces) == 0 {
+			delete(m.bySender, tx.From)
+		}
+	}
+	m.rebalanceSenderLocked(tx.From)
+}
+
+// accountFloorLocked returns the first nonce eligible for sender's pending
+// pool: NonceSource's answer if one is wired, or otherwise the lowest
+// nonce the mempool is currently holding for sender (see NonceSource).
+// Callers must hold m.mu.
+func (m *Mempool) accountFloorLocked(sender [32]byte) uint64 {
+	if m.nonceSource != nil {
+		return m.nonceSource.AccountNonce(sender)
+	}
+	if lowest, ok := m.lowestNonceLocked(sender); ok {
+		return lowest
+	}
+	return 0
+}
 
-	return feePerGas * ageBoost
+// isLocalLocked reports whether sender is one of Config.Locals' addresses.
+// Callers must hold m.mu.
+func (m *Mempool) isLocalLocked(sender [32]byte) bool {
+	return m.config.Locals != nil && m.config.Locals[sender]
+}
+
+// SetLocal marks addr as a local account going forward: see Config.Locals
+// for what that changes. Call AddLocal instead when submitting a
+// transaction from a local account for the first time.
+func (m *Mempool) SetLocal(addr [32]byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.config.Locals == nil {
+		m.config.Locals = make(map[[32]byte]bool)
+	}
+	m.config.Locals[addr] = true
+}
+
+// AddLocal is AddTransaction for a transaction from one of the operator's
+// own accounts: it marks tx.From local (see SetLocal) before admitting tx,
+// so the bypasses Config.Locals grants apply to this submission too.
+func (m *Mempool) AddLocal(tx *Transaction) (*[32]byte, error) {
+	m.SetLocal(tx.From)
+	return m.addTransaction(tx, true)
+}
+
+// wouldBePendingLocked reports whether a transaction at (sender, nonce)
+// would land in the pending pool given sender's nonces as they stand right
+// now — i.e. whether nonce is reachable by walking sender's existing
+// contiguous run up from its floor. Callers must hold m.mu.
+func (m *Mempool) wouldBePendingLocked(sender [32]byte, nonce uint64) bool {
+	nonces := m.bySender[sender]
+	for n := m.accountFloorLocked(sender); ; n++ {
+		if n == nonce {
+			return true
+		}
+		if _, ok := nonces[n]; !ok {
+			return false
+		}
+	}
+}
+
+// senderPoolCountsLocked returns how many of sender's currently-held
+// transactions are pending versus queued. Callers must hold m.mu.
+func (m *Mempool) senderPoolCountsLocked(sender [32]byte) (pendingCount, queuedCount int) {
+	for _, tx := range m.bySender[sender] {
+		if _, ok := m.pendingHash[tx.Hash]; ok {
+			pendingCount++
+		} else {
+			queuedCount++
+		}
+	}
+	return pendingCount, queuedCount
+}
+
+// rebalanceSenderLocked recomputes sender's pending/queued partition from
+// scratch against the current account floor (see accountFloorLocked):
+// transactions already applied on-chain (nonce below the floor) are
+// dropped outright, the longest contiguous run starting at the floor (up
+// to MaxPendingPerAccount) is (re)admitted to the pending pool and its
+// competitive heap, and everything else is left — or moved back — to
+// queued. Called after every insertion or removal (via deleteTx), and
+// explicitly via Demote after a block applies, so pending/queued
+// membership never drifts from bySender's actual contents. Callers must
+// hold m.mu.
+func (m *Mempool) rebalanceSenderLocked(sender [32]byte) {
+	nonces := m.bySender[sender]
+	if len(nonces) == 0 {
+		return
+	}
+
+	floor := m.accountFloorLocked(sender)
+
+	for nonce, tx := range nonces {
+		if nonce < floor {
+			delete(nonces, nonce)
+			delete(m.txs, tx.Hash)
+			delete(m.pendingHash, tx.Hash)
+		}
+	}
+	if len(nonces) == 0 {
+		delete(m.bySender, sender)
+		return
+	}
+
+	maxPending := m.config.MaxPendingPerAccount
+	promoted := 0
+	for n := floor; ; n++ {
+		tx, ok := nonces[n]
+		if !ok || (maxPending > 0 && promoted >= maxPending) {
+			break
+		}
+		if _, already := m.pendingHash[tx.Hash]; !already {
+			m.pendingHash[tx.Hash] = struct{}{}
+			heap.Push(&m.queue, tx)
+		}
+		tx.Priority = m.scoring.Score(tx, m.baseFee, floor, true)
+		promoted++
+	}
+
+	for _, tx := range nonces {
+		if _, isPending := m.pendingHash[tx.Hash]; isPending {
+			continue
+		}
+		// Demoted (or never promoted): drop out of pending bookkeeping and
+		// refresh Priority so Content()/future promotion see an accurate
+		// gap score. The stale heap entry, if any, is skipped lazily.
+		delete(m.pendingHash, tx.Hash)
+		tx.Priority = m.scoring.Score(tx, m.baseFee, floor, true)
+	}
+}
+
+// senderWorst returns sender's lowest-priority pending transaction — the
+// one a same-sender cap check or replacement may evict to make room for a
+// higher-priority one from the same sender. Callers must hold m.mu.
+func (m *Mempool) senderWorst(sender [32]byte) *Transaction {
+	var worst *Transaction
+	for _, tx := range m.bySender[sender] {
+		if worst == nil || tx.Priority < worst.Priority {
+			worst = tx
+		}
+	}
+	return worst
+}
+
+// senderCap returns the maximum number of pending transactions a single
+// sender may occupy, derived from Config.MaxSenderSharePercent. Always at
+// least 1, so the cap can never lock a sender out entirely.
+func (m *Mempool) senderCap() int {
+	cap := int(float64(m.config.MaxSize) * m.config.MaxSenderSharePercent / 100.0)
+	if cap < 1 {
+		cap = 1
+	}
+	return cap
+}
+
+// lowestNonceLocked returns sender's lowest currently-pending nonce — its
+// next "ready" nonce. Callers must hold m.mu (read or write).
+func (m *Mempool) lowestNonceLocked(sender [32]byte) (uint64, bool) {
+	nonces, ok := m.bySender[sender]
+	if !ok || len(nonces) == 0 {
+		return 0, false
+	}
+	lowest, first := uint64(0), true
+	for n := range nonces {
+		if first || n < lowest {
+			lowest, first = n, false
+		}
+	}
+	return lowest, true
+}
+
+// highestNonceLocked returns sender's highest currently-pending nonce.
+// Callers must hold m.mu (read or write).
+func (m *Mempool) highestNonceLocked(sender [32]byte) (uint64, bool) {
+	nonces, ok := m.bySender[sender]
+	if !ok || len(nonces) == 0 {
+		return 0, false
+	}
+	highest, first := uint64(0), true
+	for n := range nonces {
+		if first || n > highest {
+			highest, first = n, false
+		}
+	}
+	return highest, true
+}
+
+// updateMinEffectiveGasPrice refreshes the cheap-reject floor from the
+// current lowest-priority pending transaction's effective tip, using the
+// same heap-tail lookup evictLowestPriority relies on. It's only meaningful
+// once the pool is at MaxSize; otherwise there's nothing to floor against.
+// Callers must hold m.mu.
+func (m *Mempool) updateMinEffectiveGasPrice() {
+	if len(m.queue) == 0 || len(m.txs) < m.config.MaxSize {
+		m.minEffectiveGasPrice = 0
+		return
+	}
+	lowest := m.queue[len(m.queue)-1]
+	m.minEffectiveGasPrice = lowest.EffectiveTip(m.baseFee)
+}
+
+// SetBaseFee updates the rolling base fee used for EIP-1559 priority
+// calculations and admission checks. Called by the consensus engine
+// after each block.
+func (m *Mempool) SetBaseFee(baseFee uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.baseFee = baseFee
+}
+
+// BaseFee returns the current rolling base fee.
+func (m *Mempool) BaseFee() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.baseFee
+}
+
+// SetPriorityThreshold updates the minimum effective tip AddTransaction
+// requires going forward. Called by pkg/mempool/admission to raise the
+// floor under sustained overload and lower it again once it passes, rather
+// than mutating Config directly.
+func (m *Mempool) SetPriorityThreshold(threshold float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config.PriorityThreshold = threshold
+}
+
+// PriorityThreshold returns the minimum effective tip AddTransaction
+// currently requires.
+func (m *Mempool) PriorityThreshold() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config.PriorityThreshold
 }
 
 // ==================== PRIORITY QUEUE IMPLEMENTATION ====================
@@ -3,6 +3,7 @@ package mempool
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"testing"
 	"time"
 
@@ -14,17 +15,22 @@ func createTestMempool() *Mempool {
 	cfg.MaxSize = 10 // Small size for testing
 	cfg.MaxTxAge = 1 * time.Second
 	cfg.CleanupInterval = 500 * time.Millisecond
+	cfg.MaxSenderSharePercent = 100 // Most tests don't exercise the per-sender cap; see TestMempoolSenderCap
 
 	log := logger.NewLogger("error")
 	return NewMempool(cfg, log)
 }
 
 func createTestTransaction(nonce uint64, gasPrice uint64) *Transaction {
-	hash := sha256.Sum256([]byte{byte(nonce), byte(gasPrice)})
+	return createTestTransactionFrom([32]byte{1, 2, 3}, nonce, gasPrice)
+}
+
+func createTestTransactionFrom(sender [32]byte, nonce uint64, gasPrice uint64) *Transaction {
+	hash := sha256.Sum256([]byte{sender[0], sender[1], sender[2], byte(nonce), byte(gasPrice)})
 
 	return &Transaction{
 		Hash:      hash,
-		From:      [32]byte{1, 2, 3},
+		From:      sender,
 		To:        [32]byte{4, 5, 6},
 		Amount:    1000000,
 		Nonce:     nonce,
@@ -44,7 +50,7 @@ func TestMempoolAddTransaction(t *testing.T) {
 
 	tx := createTestTransaction(0, 100)
 
-	err := m.AddTransaction(tx)
+	_, err := m.AddTransaction(tx)
 	if err != nil {
 		t.Fatalf("Failed to add transaction: %v", err)
 	}
@@ -60,13 +66,13 @@ func TestMempoolDuplicateTransaction(t *testing.T) {
 	tx := createTestTransaction(0, 100)
 
 	// Add first time
-	err := m.AddTransaction(tx)
+	_, err := m.AddTransaction(tx)
 	if err != nil {
 		t.Fatalf("Failed to add transaction: %v", err)
 	}
 
 	// Try to add again (should fail)
-	err = m.AddTransaction(tx)
+	_, err = m.AddTransaction(tx)
 	if err == nil {
 		t.Fatal("Expected error when adding duplicate transaction")
 	}
@@ -77,7 +83,7 @@ func TestMempoolNonceOrdering(t *testing.T) {
 
 	// Add transaction with nonce 5
 	tx1 := createTestTransaction(5, 100)
-	err := m.AddTransaction(tx1)
+	_, err := m.AddTransaction(tx1)
 	if err != nil {
 		t.Fatalf("Failed to add tx1: %v", err)
 	}
@@ -85,7 +91,7 @@ func TestMempoolNonceOrdering(t *testing.T) {
 	// Try to add transaction with nonce 3 (should fail - too old)
 	tx2 := createTestTransaction(3, 100)
 	tx2.Hash = sha256.Sum256([]byte{99}) // Different hash
-	err = m.AddTransaction(tx2)
+	_, err = m.AddTransaction(tx2)
 	if err == nil {
 		t.Fatal("Expected error when adding tx with old nonce")
 	}
@@ -93,7 +99,7 @@ func TestMempoolNonceOrdering(t *testing.T) {
 	// Add transaction with nonce 6 (should succeed)
 	tx3 := createTestTransaction(6, 100)
 	tx3.Hash = sha256.Sum256([]byte{98}) // Different hash
-	err = m.AddTransaction(tx3)
+	_, err = m.AddTransaction(tx3)
 	if err != nil {
 		t.Fatalf("Failed to add tx3: %v", err)
 	}
@@ -106,10 +112,11 @@ func TestMempoolNonceOrdering(t *testing.T) {
 func TestMempoolPriorityOrdering(t *testing.T) {
 	m := createTestMempool()
 
-	// Add transactions with different gas prices
-	tx1 := createTestTransaction(0, 100) // Low priority
-	tx2 := createTestTransaction(1, 500) // High priority
-	tx3 := createTestTransaction(2, 300) // Medium priority
+	// Add transactions from different senders with different gas prices;
+	// across senders, ranking is purely by effective gas price.
+	tx1 := createTestTransactionFrom([32]byte{1}, 0, 100) // Low priority
+	tx2 := createTestTransactionFrom([32]byte{2}, 0, 500) // High priority
+	tx3 := createTestTransactionFrom([32]byte{3}, 0, 300) // Medium priority
 
 	m.AddTransaction(tx1)
 	m.AddTransaction(tx2)
@@ -128,8 +135,69 @@ func TestMempoolPriorityOrdering(t *testing.T) {
 	}
 
 	// Highest priority should be tx2 (gas_price = 500)
-	if top[0].Nonce != 1 {
-		t.Fatalf("Expected highest priority tx to have nonce 1, got %d", top[0].Nonce)
+	if top[0].GasPrice != 500 {
+		t.Fatalf("Expected highest priority tx to have gas price 500, got %d", top[0].GasPrice)
+	}
+}
+
+func TestMempoolNonceGapRanksBelowReady(t *testing.T) {
+	m := createTestMempool()
+
+	// Same sender: nonce 0 is ready, nonce 1 is a gap-free follow-up, nonce
+	// 3 has a gap. Even with a much higher gas price, a nonce-gapped tx
+	// must rank below its sender's ready transaction — and, since it's
+	// gapped, it now lands in the queued pool rather than pending, so it's
+	// excluded from GetTopTransactions entirely until the gap at nonce 2
+	// is filled.
+	ready := createTestTransaction(0, 100)
+	next := createTestTransaction(1, 50)
+	future := createTestTransaction(3, 100000)
+
+	for _, tx := range []*Transaction{ready, next, future} {
+		if _, err := m.AddTransaction(tx); err != nil {
+			t.Fatalf("Failed to add tx nonce %d: %v", tx.Nonce, err)
+		}
+	}
+
+	top := m.GetTopTransactions(3)
+	if len(top) != 2 {
+		t.Fatalf("Expected 2 pending transactions, got %d", len(top))
+	}
+	if top[0].Nonce != 0 || top[1].Nonce != 1 {
+		t.Fatalf("Expected strict nonce order [0,1], got [%d,%d]", top[0].Nonce, top[1].Nonce)
+	}
+
+	if pending := m.PendingSize(); pending != 2 {
+		t.Fatalf("Expected 2 pending transactions, got %d", pending)
+	}
+	if queued := m.QueuedSize(); queued != 1 {
+		t.Fatalf("Expected 1 queued transaction, got %d", queued)
+	}
+}
+
+func TestMempoolPromotesNextReadyOnRemoval(t *testing.T) {
+	m := createTestMempool()
+
+	low := createTestTransaction(0, 100)
+	high := createTestTransaction(2, 100000) // gapped behind low
+
+	m.AddTransaction(low)
+	m.AddTransaction(high)
+
+	if high.Priority >= low.Priority {
+		t.Fatalf("Expected gapped tx to initially rank below the ready one")
+	}
+
+	if err := m.RemoveTransaction(low.Hash); err != nil {
+		t.Fatalf("Failed to remove low: %v", err)
+	}
+
+	// high is now the sender's lowest pending nonce, so it should be
+	// re-scored as ready rather than staying penalized for a gap that no
+	// longer exists.
+	top := m.GetTopTransactions(1)
+	if len(top) != 1 || top[0].Hash != high.Hash {
+		t.Fatal("Expected promoted tx to be the only, top-ranked transaction")
 	}
 }
 
@@ -137,11 +205,10 @@ func TestMempoolEviction(t *testing.T) {
 	m := createTestMempool()
 	// MaxSize = 10
 
-	// Fill mempool with low-priority transactions
+	// Fill mempool with low-priority transactions from distinct senders
 	for i := uint64(0); i < 10; i++ {
-		tx := createTestTransaction(i, 100) // Low gas price
-		tx.Hash = sha256.Sum256([]byte{byte(i)})
-		err := m.AddTransaction(tx)
+		tx := createTestTransactionFrom([32]byte{byte(i)}, 0, 100) // Low gas price
+		_, err := m.AddTransaction(tx)
 		if err != nil {
 			t.Fatalf("Failed to add transaction %d: %v", i, err)
 		}
@@ -152,9 +219,8 @@ func TestMempoolEviction(t *testing.T) {
 	}
 
 	// Add high-priority transaction (should evict lowest priority)
-	highPriorityTx := createTestTransaction(10, 1000) // High gas price
-	highPriorityTx.Hash = sha256.Sum256([]byte{99})
-	err := m.AddTransaction(highPriorityTx)
+	highPriorityTx := createTestTransactionFrom([32]byte{99}, 0, 1000) // High gas price
+	_, err := m.AddTransaction(highPriorityTx)
 	if err != nil {
 		t.Fatalf("Failed to add high-priority transaction: %v", err)
 	}
@@ -171,6 +237,168 @@ func TestMempoolEviction(t *testing.T) {
 	}
 }
 
+func TestMempoolLocalAccountsExemptFromEviction(t *testing.T) {
+	m := createTestMempool()
+	// MaxSize = 10
+
+	localSender := [32]byte{42}
+	localTx := createTestTransactionFrom(localSender, 0, 1) // Low gas price, would normally evict first
+	if _, err := m.AddLocal(localTx); err != nil {
+		t.Fatalf("Failed to add local transaction: %v", err)
+	}
+
+	for i := uint64(0); i < 9; i++ {
+		tx := createTestTransactionFrom([32]byte{byte(i + 1)}, 0, 100)
+		if _, err := m.AddTransaction(tx); err != nil {
+			t.Fatalf("Failed to add transaction %d: %v", i, err)
+		}
+	}
+
+	if m.Size() != 10 {
+		t.Fatalf("Expected size 10, got %d", m.Size())
+	}
+	if got := m.LocalPendingSize(); got != 1 {
+		t.Fatalf("Expected 1 local pending transaction, got %d", got)
+	}
+
+	// Pool is now full; the lowest-priority remote tx should be evicted
+	// instead of the (lower-priority, but local) localTx.
+	highPriorityTx := createTestTransactionFrom([32]byte{99}, 0, 1000)
+	if _, err := m.AddTransaction(highPriorityTx); err != nil {
+		t.Fatalf("Failed to add high-priority transaction: %v", err)
+	}
+
+	if _, err := m.GetTransaction(localTx.Hash); err != nil {
+		t.Fatal("Local transaction was evicted despite having the lowest priority")
+	}
+}
+
+func TestMempoolSenderCap(t *testing.T) {
+	m := createTestMempool()
+	m.config.MaxSenderSharePercent = 20 // 20% of MaxSize=10 -> cap of 2 per sender
+
+	sender := [32]byte{7}
+
+	tx1 := createTestTransactionFrom(sender, 0, 100)
+	tx2 := createTestTransactionFrom(sender, 1, 100)
+	if _, err := m.AddTransaction(tx1); err != nil {
+		t.Fatalf("Failed to add tx1: %v", err)
+	}
+	if _, err := m.AddTransaction(tx2); err != nil {
+		t.Fatalf("Failed to add tx2: %v", err)
+	}
+
+	// A third, nonce-gapped tx from the same sender is at the cap and
+	// scores below the sender's worst (tx2, which is ready at this point
+	// since tx1 hasn't been removed) — should be rejected.
+	tx3 := createTestTransactionFrom(sender, 5, 100)
+	if _, err := m.AddTransaction(tx3); err == nil {
+		t.Fatal("Expected sender cap to reject a third pending transaction")
+	}
+
+	if m.Size() != 2 {
+		t.Fatalf("Expected size 2, got %d", m.Size())
+	}
+}
+
+func TestMempoolReplaceByFee(t *testing.T) {
+	m := createTestMempool()
+
+	original := createTestTransaction(0, 100)
+	if _, err := m.AddTransaction(original); err != nil {
+		t.Fatalf("Failed to add original: %v", err)
+	}
+
+	// Same (sender, nonce) slot, same gas price: no bump at all
+	samePrice := createTestTransaction(0, 100)
+	samePrice.Hash = sha256.Sum256([]byte{41})
+	_, err := m.AddTransaction(samePrice)
+	if err == nil {
+		t.Fatal("Expected same-price replacement to be rejected")
+	}
+	if !errors.Is(err, ErrUnderpricedReplacement) {
+		t.Fatalf("Expected ErrUnderpricedReplacement, got: %v", err)
+	}
+
+	// Same (sender, nonce) slot, but too small a bump to replace
+	underbid := createTestTransaction(0, 105)
+	underbid.Hash = sha256.Sum256([]byte{42})
+	_, err = m.AddTransaction(underbid)
+	if err == nil {
+		t.Fatal("Expected underpriced replacement to be rejected")
+	}
+	if !errors.Is(err, ErrUnderpricedReplacement) {
+		t.Fatalf("Expected ErrUnderpricedReplacement, got: %v", err)
+	}
+
+	// A 10%+ bump replaces it
+	replacement := createTestTransaction(0, 111)
+	replacement.Hash = sha256.Sum256([]byte{43})
+	evicted, err := m.AddTransaction(replacement)
+	if err != nil {
+		t.Fatalf("Failed to add replacement: %v", err)
+	}
+	if evicted == nil || *evicted != original.Hash {
+		t.Fatalf("Expected replacement to report the original's hash as evicted, got %v", evicted)
+	}
+
+	if m.Size() != 1 {
+		t.Fatalf("Expected size 1 after replacement, got %d", m.Size())
+	}
+	if _, err := m.GetTransaction(original.Hash); err == nil {
+		t.Fatal("Expected original transaction to be replaced")
+	}
+}
+
+func TestMempoolAddTransactionReturnsNoEvictionForNewSlot(t *testing.T) {
+	m := createTestMempool()
+
+	tx := createTestTransaction(0, 100)
+	evicted, err := m.AddTransaction(tx)
+	if err != nil {
+		t.Fatalf("Failed to add transaction: %v", err)
+	}
+	if evicted != nil {
+		t.Fatalf("Expected no evicted hash for a fresh (sender, nonce) slot, got %v", *evicted)
+	}
+}
+
+func TestMempoolMinEffectiveGasPrice(t *testing.T) {
+	m := createTestMempool()
+
+	for i := uint64(0); i < 10; i++ {
+		tx := createTestTransactionFrom([32]byte{byte(i)}, 0, 100)
+		if _, err := m.AddTransaction(tx); err != nil {
+			t.Fatalf("Failed to add transaction %d: %v", i, err)
+		}
+	}
+
+	if floor := m.MinEffectiveGasPrice(); floor != 100 {
+		t.Fatalf("Expected floor of 100 once full, got %d", floor)
+	}
+
+	cheap := createTestTransactionFrom([32]byte{99}, 0, 50)
+	if _, err := m.AddTransaction(cheap); err == nil {
+		t.Fatal("Expected cheap transaction below the floor to be rejected")
+	}
+}
+
+func TestMempoolNextNonce(t *testing.T) {
+	m := createTestMempool()
+	sender := [32]byte{5}
+
+	if next := m.NextNonce(sender); next != 0 {
+		t.Fatalf("Expected 0 for a sender with no pending txs, got %d", next)
+	}
+
+	m.AddTransaction(createTestTransactionFrom(sender, 0, 100))
+	m.AddTransaction(createTestTransactionFrom(sender, 1, 100))
+
+	if next := m.NextNonce(sender); next != 2 {
+		t.Fatalf("Expected 2, got %d", next)
+	}
+}
+
 func TestMempoolRemoveTransaction(t *testing.T) {
 	m := createTestMempool()
 
@@ -204,11 +432,10 @@ func TestMempoolCleanup(t *testing.T) {
 	oldTx := createTestTransaction(0, 100)
 	oldTx.AddedAt = time.Now().Add(-2 * time.Second) // 2 seconds ago (older than MaxTxAge)
 	m.txs[oldTx.Hash] = oldTx
-	m.nonce[oldTx.From] = oldTx.Nonce
+	m.bySender[oldTx.From] = map[uint64]*Transaction{oldTx.Nonce: oldTx}
 
 	// Add recent transaction
-	recentTx := createTestTransaction(1, 100)
-	recentTx.Hash = sha256.Sum256([]byte{99})
+	recentTx := createTestTransactionFrom([32]byte{9}, 1, 100)
 	m.AddTransaction(recentTx)
 
 	if m.Size() != 2 {
@@ -250,7 +477,7 @@ func TestMempoolStartStop(t *testing.T) {
 
 	// Add a transaction
 	tx := createTestTransaction(0, 100)
-	err = m.AddTransaction(tx)
+	_, err = m.AddTransaction(tx)
 	if err != nil {
 		t.Fatalf("Failed to add transaction: %v", err)
 	}
@@ -261,3 +488,106 @@ func TestMempoolStartStop(t *testing.T) {
 	// Stop mempool
 	m.Stop()
 }
+
+func TestMempoolContentSplitsPendingAndQueued(t *testing.T) {
+	m := createTestMempool()
+
+	ready := createTestTransaction(0, 100)
+	gapped := createTestTransaction(2, 100)
+
+	if _, err := m.AddTransaction(ready); err != nil {
+		t.Fatalf("Failed to add ready tx: %v", err)
+	}
+	if _, err := m.AddTransaction(gapped); err != nil {
+		t.Fatalf("Failed to add gapped tx: %v", err)
+	}
+
+	pending, queued := m.Content()
+	if len(pending[ready.From]) != 1 || pending[ready.From][0].Hash != ready.Hash {
+		t.Fatalf("Expected ready tx in pending content, got %+v", pending)
+	}
+	if len(queued[gapped.From]) != 1 || queued[gapped.From][0].Hash != gapped.Hash {
+		t.Fatalf("Expected gapped tx in queued content, got %+v", queued)
+	}
+}
+
+// testNonceSource is a fixed-answer NonceSource stub for exercising
+// SetNonceSource/Demote without wiring up pkg/state.
+type testNonceSource map[[32]byte]uint64
+
+func (ns testNonceSource) AccountNonce(address [32]byte) uint64 { return ns[address] }
+
+func TestMempoolDemotePromotesOnNonceSourceAdvance(t *testing.T) {
+	m := createTestMempool()
+
+	sender := [32]byte{1, 2, 3}
+	gapped := createTestTransaction(1, 100)
+
+	ns := testNonceSource{sender: 0}
+	m.SetNonceSource(ns)
+
+	if _, err := m.AddTransaction(gapped); err != nil {
+		t.Fatalf("Failed to add tx: %v", err)
+	}
+	if m.PendingSize() != 0 || m.QueuedSize() != 1 {
+		t.Fatalf("Expected tx to be queued while below the account's nonce, got pending=%d queued=%d", m.PendingSize(), m.QueuedSize())
+	}
+
+	// Chain applies nonce 0; the account's floor advances to 1.
+	ns[sender] = 1
+	m.Demote(sender)
+
+	// Now contiguous with the new floor, so the tx should be pending.
+	if m.PendingSize() != 1 || m.QueuedSize() != 0 {
+		t.Fatalf("Expected tx to be promoted to pending, got pending=%d queued=%d", m.PendingSize(), m.QueuedSize())
+	}
+
+	// The chain now applies nonce 1 itself; the floor advances past it.
+	ns[sender] = 2
+	m.Demote(sender)
+
+	if m.PendingSize() != 0 || m.QueuedSize() != 0 {
+		t.Fatalf("Expected applied tx to be dropped, got pending=%d queued=%d", m.PendingSize(), m.QueuedSize())
+	}
+}
+
+func TestMempoolMaxPendingPerAccount(t *testing.T) {
+	m := createTestMempool()
+	m.config.MaxPendingPerAccount = 2
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if _, err := m.AddTransaction(createTestTransaction(nonce, 100)); err != nil {
+			t.Fatalf("Failed to add tx nonce %d: %v", nonce, err)
+		}
+	}
+
+	if _, err := m.AddTransaction(createTestTransaction(2, 100)); err == nil {
+		t.Fatal("Expected a third contiguous tx to be rejected at the pending pool cap")
+	}
+	if m.PendingSize() != 2 {
+		t.Fatalf("Expected pending pool to stay at its cap of 2, got %d", m.PendingSize())
+	}
+}
+
+func TestMempoolMaxQueuedPerAccount(t *testing.T) {
+	m := createTestMempool()
+	m.config.MaxQueuedPerAccount = 2
+
+	// Nonce 0 is ready and pending; nonces 2 and 4 are gapped and queued.
+	if _, err := m.AddTransaction(createTestTransaction(0, 100)); err != nil {
+		t.Fatalf("Failed to add ready tx: %v", err)
+	}
+	if _, err := m.AddTransaction(createTestTransaction(2, 100)); err != nil {
+		t.Fatalf("Failed to add first queued tx: %v", err)
+	}
+	if _, err := m.AddTransaction(createTestTransaction(4, 100)); err != nil {
+		t.Fatalf("Failed to add second queued tx: %v", err)
+	}
+
+	if _, err := m.AddTransaction(createTestTransaction(6, 100)); err == nil {
+		t.Fatal("Expected a third gapped tx to be rejected at the queued pool cap")
+	}
+	if m.QueuedSize() != 2 {
+		t.Fatalf("Expected queued pool to stay at its cap of 2, got %d", m.QueuedSize())
+	}
+}
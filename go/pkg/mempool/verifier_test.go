@@ -0,0 +1,129 @@
+package mempool
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// createSignedTestTransaction builds a legacy (non-dynamic-fee) transaction
+// signed by priv, so it passes Verifier.verifyOne via the SSZ signing hash.
+func createSignedTestTransaction(t *testing.T, pub ed25519.PublicKey, priv ed25519.PrivateKey, nonce uint64, gasPrice uint64) *Transaction {
+	t.Helper()
+
+	var from [32]byte
+	copy(from[:], pub)
+
+	tx := &Transaction{
+		Hash:      sha256.Sum256([]byte{from[0], byte(nonce), byte(gasPrice)}),
+		From:      from,
+		To:        [32]byte{4, 5, 6},
+		Amount:    1000000,
+		Nonce:     nonce,
+		GasLimit:  21000,
+		GasPrice:  gasPrice,
+		Data:      nil,
+		Timestamp: time.Now().Unix(),
+		TxType:    1, // Transfer
+		Fee:       21000 * gasPrice,
+	}
+
+	preimage, err := txSigningPreimage(tx)
+	if err != nil {
+		t.Fatalf("failed to build signing preimage: %v", err)
+	}
+	copy(tx.Signature[:], ed25519.Sign(priv, preimage))
+
+	return tx
+}
+
+func createTestVerifier(t *testing.T) (*Verifier, *Mempool) {
+	t.Helper()
+
+	mp := createTestMempool()
+	v := NewVerifier(mp, VerifierConfig{Workers: 2, BatchSize: 4, BatchWindow: 5 * time.Millisecond}, logger.NewLogger("error"))
+	v.Start()
+	t.Cleanup(v.Stop)
+
+	return v, mp
+}
+
+func TestVerifierSubmitAsyncAdmitsValidTransaction(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	v, mp := createTestVerifier(t)
+	tx := createSignedTestTransaction(t, pub, priv, 0, 100)
+
+	select {
+	case err := <-v.SubmitAsync(tx):
+		if err != nil {
+			t.Fatalf("expected transaction to verify and admit cleanly, got: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for verification result")
+	}
+
+	if mp.Size() != 1 {
+		t.Fatalf("expected mempool size 1, got %d", mp.Size())
+	}
+}
+
+func TestVerifierSubmitAsyncRejectsBadSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	v, mp := createTestVerifier(t)
+	tx := createSignedTestTransaction(t, pub, priv, 0, 100)
+	tx.Signature[0] ^= 0xFF // corrupt the signature
+
+	select {
+	case err := <-v.SubmitAsync(tx):
+		if err == nil {
+			t.Fatal("expected an error for a corrupted signature")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for verification result")
+	}
+
+	if mp.Size() != 0 {
+		t.Fatalf("expected mempool size 0 after rejection, got %d", mp.Size())
+	}
+}
+
+func TestVerifierBatchesConcurrentSubmissions(t *testing.T) {
+	v, mp := createTestVerifier(t)
+
+	const n = 6
+	results := make([]<-chan error, n)
+	for i := 0; i < n; i++ {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		tx := createSignedTestTransaction(t, pub, priv, 0, 100)
+		results[i] = v.SubmitAsync(tx)
+	}
+
+	for i, ch := range results {
+		select {
+		case err := <-ch:
+			if err != nil {
+				t.Fatalf("transaction %d: expected clean admission, got: %v", i, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("transaction %d: timed out waiting for verification result", i)
+		}
+	}
+
+	if mp.Size() != n {
+		t.Fatalf("expected mempool size %d, got %d", n, mp.Size())
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -16,13 +18,43 @@ type Exporter struct {
 	server *http.Server
 
 	// Metrics
-	BlocksSubmitted    *prometheus.CounterVec
-	VerificationTime   *prometheus.HistogramVec
-	PinQuorumSuccess   prometheus.Counter
-	PinQuorumFailures  prometheus.Counter
-	RateLimitExceeded  *prometheus.CounterVec
-	ParityMatches      prometheus.Counter
-	ParityDrifts       prometheus.Counter
+	BlocksSubmitted   *prometheus.CounterVec
+	VerificationTime  *prometheus.HistogramVec
+	PinQuorumSuccess  prometheus.Counter
+	PinQuorumFailures prometheus.Counter
+	RateLimitExceeded *prometheus.CounterVec
+	ParityMatches     prometheus.Counter
+	ParityDrifts      prometheus.Counter
+
+	// Economic signals, fed by mempool.MetricsSink and consensus.MetricsSink
+	// implementations below rather than read directly from those packages,
+	// so this package never needs to import them.
+	MempoolSize           *prometheus.GaugeVec
+	MempoolBytes          prometheus.Gauge
+	MempoolFeeHistogram   prometheus.Histogram
+	MempoolReplacedTotal  *prometheus.CounterVec
+	JournalSizeBytes      prometheus.Gauge
+	JournalReplayedTotal  *prometheus.CounterVec
+	BlockGasUsed          prometheus.Histogram
+	ValidatorRewardsTotal *prometheus.CounterVec
+	FeesBurnedTotal       prometheus.Counter
+	TreasuryCreditedTotal prometheus.Counter
+
+	// Fed by mempool.VerifierMetricsSink implementations below.
+	VerifyQueueDepth prometheus.Gauge
+	VerifyLatency    prometheus.Histogram
+	VerifyBatchSize  prometheus.Histogram
+
+	// Fed by admission.MetricsSink implementations below.
+	AdmissionPriorityThreshold prometheus.Gauge
+	AdmissionGlobalQueueDepth  prometheus.Gauge
+	AdmissionPeerQueueDepth    *prometheus.GaugeVec
+
+	// healthCheck and readyCheck back /healthz and /readyz; both are nil
+	// until a caller wires one in via SetHealthCheck/SetReadyCheck, since
+	// Exporter is constructed before the components it would check.
+	healthCheck func() error
+	readyCheck  func() (time.Duration, error)
 }
 
 // NewExporter creates a new Prometheus exporter
@@ -75,6 +107,111 @@ func NewExporter(port int) *Exporter {
 				Help: "Total legacy vs refactored hash mismatches",
 			},
 		),
+		MempoolSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "coinjecture_mempool_size",
+				Help: "Current number of pending transactions in the mempool, by tx type",
+			},
+			[]string{"tx_type"},
+		),
+		MempoolBytes: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "coinjecture_mempool_bytes",
+				Help: "Current estimated total size of pending mempool transactions, in bytes",
+			},
+		),
+		MempoolFeeHistogram: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "coinjecture_mempool_fee_histogram",
+				Help:    "Gas price of transactions admitted to the mempool, in wei per gas",
+				Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+			},
+		),
+		MempoolReplacedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "coinjecture_mempool_replaced_total",
+				Help: "Total same-(sender, nonce) replace-by-fee attempts, by outcome",
+			},
+			[]string{"reason"},
+		),
+		JournalSizeBytes: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "coinjecture_mempool_journal_bytes",
+				Help: "Current on-disk size of the mempool's persistent transaction journal, in bytes",
+			},
+		),
+		JournalReplayedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "coinjecture_mempool_journal_replayed_total",
+				Help: "Total journaled transactions seen on the last Start, by replay outcome",
+			},
+			[]string{"outcome"},
+		),
+		BlockGasUsed: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "coinjecture_block_gas_used",
+				Help:    "Gas used per produced or accepted block",
+				Buckets: prometheus.ExponentialBuckets(21000, 2, 12),
+			},
+		),
+		ValidatorRewardsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "coinjecture_validator_rewards_total",
+				Help: "Total fee wei credited to validators, by validator pubkey hex prefix",
+			},
+			[]string{"validator"},
+		),
+		FeesBurnedTotal: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "coinjecture_fees_burned_total",
+				Help: "Total fee wei sent to the burn address",
+			},
+		),
+		TreasuryCreditedTotal: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "coinjecture_treasury_credited_total",
+				Help: "Total fee wei credited to the treasury address",
+			},
+		),
+		VerifyQueueDepth: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "coinjecture_mempool_verify_queue_depth",
+				Help: "Current number of transactions queued for signature verification",
+			},
+		),
+		VerifyLatency: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "coinjecture_mempool_verify_latency_ms",
+				Help:    "Time from SubmitAsync to a verification result, in milliseconds",
+				Buckets: prometheus.ExponentialBuckets(0.01, 2, 14),
+			},
+		),
+		VerifyBatchSize: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "coinjecture_mempool_verify_batch_size",
+				Help:    "Number of transactions grouped into a single signature verification pass",
+				Buckets: prometheus.LinearBuckets(1, 4, 16),
+			},
+		),
+		AdmissionPriorityThreshold: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "coinjecture_admission_priority_threshold",
+				Help: "Current minimum effective tip the admission controller requires for entry to the mempool",
+			},
+		),
+		AdmissionGlobalQueueDepth: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "coinjecture_admission_global_queue_depth",
+				Help: "Current number of transactions queued for their DRR admission turn, across every peer",
+			},
+		),
+		AdmissionPeerQueueDepth: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "coinjecture_admission_peer_queue_depth",
+				Help: "Current number of transactions a peer has queued for their DRR admission turn",
+			},
+			[]string{"peer_id"},
+		),
 	}
 
 	// Register metrics
@@ -86,15 +223,173 @@ func NewExporter(port int) *Exporter {
 		e.RateLimitExceeded,
 		e.ParityMatches,
 		e.ParityDrifts,
+		e.MempoolSize,
+		e.MempoolBytes,
+		e.MempoolFeeHistogram,
+		e.MempoolReplacedTotal,
+		e.JournalSizeBytes,
+		e.JournalReplayedTotal,
+		e.BlockGasUsed,
+		e.ValidatorRewardsTotal,
+		e.FeesBurnedTotal,
+		e.TreasuryCreditedTotal,
+		e.VerifyQueueDepth,
+		e.VerifyLatency,
+		e.VerifyBatchSize,
+		e.AdmissionPriorityThreshold,
+		e.AdmissionGlobalQueueDepth,
+		e.AdmissionPeerQueueDepth,
 	)
 
 	return e
 }
 
+// ==================== ECONOMIC METRICS SINK ====================
+//
+// The methods below give Exporter the same shape as mempool.MetricsSink and
+// consensus.MetricsSink (see those packages) without this package importing
+// either — Go's structural interfaces let mempool.Mempool.SetMetricsSink and
+// consensus.Engine.SetMetricsSink accept an *Exporter directly.
+
+// SetMempoolSize implements mempool.MetricsSink.
+func (e *Exporter) SetMempoolSize(txType uint8, count int) {
+	e.MempoolSize.WithLabelValues(strconv.Itoa(int(txType))).Set(float64(count))
+}
+
+// SetMempoolBytes implements mempool.MetricsSink.
+func (e *Exporter) SetMempoolBytes(bytes int) {
+	e.MempoolBytes.Set(float64(bytes))
+}
+
+// ObserveMempoolFee implements mempool.MetricsSink.
+func (e *Exporter) ObserveMempoolFee(gasPrice uint64) {
+	e.MempoolFeeHistogram.Observe(float64(gasPrice))
+}
+
+// ObserveMempoolReplacement implements mempool.MetricsSink.
+func (e *Exporter) ObserveMempoolReplacement(reason string) {
+	e.MempoolReplacedTotal.WithLabelValues(reason).Inc()
+}
+
+// SetJournalSize implements mempool.JournalMetricsSink.
+func (e *Exporter) SetJournalSize(bytes int) {
+	e.JournalSizeBytes.Set(float64(bytes))
+}
+
+// ObserveJournalReplay implements mempool.JournalMetricsSink.
+func (e *Exporter) ObserveJournalReplay(accepted, rejected int) {
+	e.JournalReplayedTotal.WithLabelValues("accepted").Add(float64(accepted))
+	e.JournalReplayedTotal.WithLabelValues("rejected").Add(float64(rejected))
+}
+
+// ObserveBlockGasUsed implements consensus.MetricsSink.
+func (e *Exporter) ObserveBlockGasUsed(gasUsed uint64) {
+	e.BlockGasUsed.Observe(float64(gasUsed))
+}
+
+// AddValidatorReward implements consensus.MetricsSink.
+func (e *Exporter) AddValidatorReward(validatorPubkeyHexPrefix string, amount uint64) {
+	e.ValidatorRewardsTotal.WithLabelValues(validatorPubkeyHexPrefix).Add(float64(amount))
+}
+
+// AddFeesBurned implements consensus.MetricsSink.
+func (e *Exporter) AddFeesBurned(amount uint64) {
+	e.FeesBurnedTotal.Add(float64(amount))
+}
+
+// AddTreasuryCredited implements consensus.MetricsSink.
+func (e *Exporter) AddTreasuryCredited(amount uint64) {
+	e.TreasuryCreditedTotal.Add(float64(amount))
+}
+
+// SetVerifyQueueDepth implements mempool.VerifierMetricsSink.
+func (e *Exporter) SetVerifyQueueDepth(depth int) {
+	e.VerifyQueueDepth.Set(float64(depth))
+}
+
+// ObserveVerifyLatency implements mempool.VerifierMetricsSink.
+func (e *Exporter) ObserveVerifyLatency(d time.Duration) {
+	e.VerifyLatency.Observe(float64(d) / float64(time.Millisecond))
+}
+
+// ObserveVerifyBatchSize implements mempool.VerifierMetricsSink.
+func (e *Exporter) ObserveVerifyBatchSize(n int) {
+	e.VerifyBatchSize.Observe(float64(n))
+}
+
+// SetPriorityThreshold implements admission.MetricsSink.
+func (e *Exporter) SetPriorityThreshold(threshold float64) {
+	e.AdmissionPriorityThreshold.Set(threshold)
+}
+
+// SetGlobalQueueDepth implements admission.MetricsSink.
+func (e *Exporter) SetGlobalQueueDepth(depth int) {
+	e.AdmissionGlobalQueueDepth.Set(float64(depth))
+}
+
+// SetPeerQueueDepth implements admission.MetricsSink.
+func (e *Exporter) SetPeerQueueDepth(peerID string, depth int) {
+	e.AdmissionPeerQueueDepth.WithLabelValues(peerID).Set(float64(depth))
+}
+
+// ==================== HEALTH / READINESS ====================
+
+// SetHealthCheck wires the function /healthz calls to confirm state manager
+// connectivity (e.g. stateManager.Ping). Leaving it unset makes /healthz
+// always report healthy, since there would be nothing to check.
+func (e *Exporter) SetHealthCheck(fn func() error) {
+	e.healthCheck = fn
+}
+
+// SetReadyCheck wires the function /readyz calls to report how long ago the
+// chain last produced or accepted a block (e.g. Engine.LastBlockAge).
+// Leaving it unset makes /readyz report 503, since readiness is undefined
+// without a chain to measure against.
+func (e *Exporter) SetReadyCheck(fn func() (time.Duration, error)) {
+	e.readyCheck = fn
+}
+
+func (e *Exporter) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if e.healthCheck == nil {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"status":"ok"}`)
+		return
+	}
+
+	if err := e.healthCheck(); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, `{"status":"unhealthy","error":%q}`, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, `{"status":"ok"}`)
+}
+
+func (e *Exporter) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if e.readyCheck == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"status":"not_ready","error":"no readiness check configured"}`)
+		return
+	}
+
+	age, err := e.readyCheck()
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, `{"status":"not_ready","error":%q}`, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"status":"ready","last_block_age_seconds":%f}`, age.Seconds())
+}
+
 // Start starts the metrics HTTP server
 func (e *Exporter) Start() error {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", e.handleHealthz)
+	mux.HandleFunc("/readyz", e.handleReadyz)
 
 	e.server = &http.Server{
 		Addr:    fmt.Sprintf(":%d", e.port),
@@ -0,0 +1,505 @@
+// Equilibrium gossip: CID propagation over a lambda*sqrt(peers) random
+// fan-out, with a bounded seen-CID cache, per-peer outbound queues with
+// backpressure, and NACK-triggered quarantine. See doBroadcast, AnnounceCID,
+// RequestCID, and Subscribe.
+package p2p
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+const (
+	// gossipProtocol identifies the gossip RPC, both on the wire (once a
+	// real libp2p stream transport exists) and as the SendRPC method name
+	// today.
+	gossipProtocol = "/coinjecture/gossip/1.0.0"
+
+	// cidRequestProtocol is used by RequestCID to ask a specific peer
+	// whether it has a CID, outside the regular gossip tick.
+	cidRequestProtocol = gossipProtocol + "/request"
+
+	seenCacheCapacity = 100000
+	seenCacheTTL      = 15 * time.Minute
+
+	// maxOutboundQueue bounds how many pending CIDs a single peer's
+	// outbound queue can hold before the lowest-priority entries are
+	// dropped.
+	maxOutboundQueue = 1000
+
+	// gossipBatchSize bounds how many CIDs go out to one peer per tick.
+	gossipBatchSize = 64
+
+	// nackWindow/nackThreshold: more than nackThreshold NACKs from a peer
+	// within nackWindow triggers quarantine.
+	nackWindow    = 1 * time.Minute
+	nackThreshold = 5
+)
+
+// CIDEvent is delivered to Subscribe() channels when a CID is announced
+// locally or received from a peer.
+type CIDEvent struct {
+	CID        string
+	FromPeer   string // empty for locally originated announces
+	ReceivedAt time.Time
+}
+
+// backpressureChecker is the minimal surface Manager needs from the rate
+// limiter to scale gossip fan-out down and trim queues under load.
+// pkg/limiter can't be imported directly here since limiter imports p2p for
+// the distributed rate limiter, so this is wired up via
+// SetBackpressureChecker instead; limiter.Limiter already satisfies it.
+type backpressureChecker interface {
+	CheckBackpressure() (bool, float64)
+}
+
+// gossipItem is one pending CID in a peer's outbound queue.
+type gossipItem struct {
+	cid      string
+	priority int
+	addedAt  time.Time
+}
+
+// gossipBatch/gossipBatchReply are the wire format for gossipProtocol and
+// cidRequestProtocol.
+type gossipBatch struct {
+	CIDs []string `json:"cids"`
+}
+
+type gossipBatchReply struct {
+	Ack bool `json:"ack"`
+}
+
+// seenEntry is one seenCache record.
+type seenEntry struct {
+	cid       string
+	expiresAt time.Time
+}
+
+// seenCache is a bounded LRU with a TTL, used to dedupe CIDs that arrive (or
+// get announced) more than once so they're neither reprocessed nor
+// rebroadcast.
+type seenCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+func newSeenCache(capacity int, ttl time.Duration) *seenCache {
+	return &seenCache{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Seen reports whether cid was already marked seen (and not yet expired). If
+// not, it records cid as seen now and returns false.
+func (c *seenCache) Seen(cid string) bool {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[cid]; ok {
+		entry := el.Value.(*seenEntry)
+		if now.Before(entry.expiresAt) {
+			c.ll.MoveToFront(el)
+			return true
+		}
+		c.ll.Remove(el)
+		delete(c.items, cid)
+	}
+
+	c.evictExpired(now)
+	for c.ll.Len() >= c.capacity {
+		c.evictOldest()
+	}
+
+	el := c.ll.PushFront(&seenEntry{cid: cid, expiresAt: now.Add(c.ttl)})
+	c.items[cid] = el
+	return false
+}
+
+// Has reports whether cid is currently marked seen, without affecting its
+// recency or inserting it.
+func (c *seenCache) Has(cid string) bool {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[cid]
+	if !ok {
+		return false
+	}
+	return now.Before(el.Value.(*seenEntry).expiresAt)
+}
+
+func (c *seenCache) evictExpired(now time.Time) {
+	for {
+		back := c.ll.Back()
+		if back == nil {
+			return
+		}
+		entry := back.Value.(*seenEntry)
+		if now.Before(entry.expiresAt) {
+			return
+		}
+		c.ll.Remove(back)
+		delete(c.items, entry.cid)
+	}
+}
+
+func (c *seenCache) evictOldest() {
+	back := c.ll.Back()
+	if back == nil {
+		return
+	}
+	entry := back.Value.(*seenEntry)
+	c.ll.Remove(back)
+	delete(c.items, entry.cid)
+}
+
+// nackTracker counts NACKs per peer within a rolling window, so a peer that
+// NACKs (queue full) too often can be quarantined.
+type nackTracker struct {
+	mu   sync.Mutex
+	hits map[string][]time.Time
+}
+
+func newNackTracker() *nackTracker {
+	return &nackTracker{hits: make(map[string][]time.Time)}
+}
+
+// record adds a NACK for peerID and reports whether it has now exceeded
+// nackThreshold within nackWindow.
+func (t *nackTracker) record(peerID string) bool {
+	now := time.Now()
+	cutoff := now.Add(-nackWindow)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	kept := t.hits[peerID][:0]
+	for _, h := range t.hits[peerID] {
+		if h.After(cutoff) {
+			kept = append(kept, h)
+		}
+	}
+	kept = append(kept, now)
+	t.hits[peerID] = kept
+
+	return len(kept) > nackThreshold
+}
+
+// fanOut returns how many peers to gossip to this round: ceil(lambda *
+// sqrt(n)), shrunk linearly toward 1 as backpressure utilization climbs
+// from CheckBackpressure's 80% threshold to 100% (queue full).
+func (m *Manager) fanOut(n int) int {
+	if n == 0 {
+		return 0
+	}
+
+	base := math.Ceil(m.lambda * math.Sqrt(float64(n)))
+
+	near, util := m.checkBackpressure()
+	if !near {
+		return clampFanOut(int(base), n)
+	}
+
+	shrink := (util - 0.8) / 0.2
+	if shrink > 1 {
+		shrink = 1
+	}
+	if shrink < 0 {
+		shrink = 0
+	}
+	scaled := base - shrink*(base-1)
+	return clampFanOut(int(math.Round(scaled)), n)
+}
+
+func clampFanOut(k, n int) int {
+	if k < 1 {
+		k = 1
+	}
+	if k > n {
+		k = n
+	}
+	return k
+}
+
+func (m *Manager) checkBackpressure() (bool, float64) {
+	m.bpMu.RLock()
+	bp := m.bp
+	m.bpMu.RUnlock()
+	if bp == nil {
+		return false, 0
+	}
+	return bp.CheckBackpressure()
+}
+
+// selectFanOut returns k distinct, non-quarantined peer IDs (excluding
+// self) chosen uniformly at random.
+func (m *Manager) selectFanOut(k int) []string {
+	m.peersMu.RLock()
+	candidates := make([]string, 0, len(m.peers))
+	for id, peer := range m.peers {
+		if !peer.Quarantined && id != m.selfID {
+			candidates = append(candidates, id)
+		}
+	}
+	m.peersMu.RUnlock()
+
+	if k > len(candidates) {
+		k = len(candidates)
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	return candidates[:k]
+}
+
+// enqueue adds cid (at priority; higher propagates first) to peerID's
+// outbound queue, trimming to maxOutboundQueue if this pushes it over.
+func (m *Manager) enqueue(peerID, cid string, priority int) {
+	m.queueMu.Lock()
+	defer m.queueMu.Unlock()
+
+	q := append(m.queues[peerID], gossipItem{cid: cid, priority: priority, addedAt: time.Now()})
+	m.queues[peerID] = trimToCapacity(q, maxOutboundQueue)
+}
+
+// trimQueues drops entries from every peer's outbound queue down to
+// maxQueueSize, keeping the highest-priority ones. Called under sustained
+// backpressure so a slow peer's backlog doesn't grow unbounded.
+func (m *Manager) trimQueues(maxQueueSize int) {
+	m.queueMu.Lock()
+	defer m.queueMu.Unlock()
+	for peerID, q := range m.queues {
+		m.queues[peerID] = trimToCapacity(q, maxQueueSize)
+	}
+}
+
+// trimToCapacity keeps at most capacity entries, dropping the
+// lowest-priority ones first (ties broken by age, oldest dropped first).
+func trimToCapacity(q []gossipItem, capacity int) []gossipItem {
+	if len(q) <= capacity {
+		return q
+	}
+	sorted := make([]gossipItem, len(q))
+	copy(sorted, q)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].priority != sorted[j].priority {
+			return sorted[i].priority > sorted[j].priority
+		}
+		return sorted[i].addedAt.After(sorted[j].addedAt)
+	})
+	return sorted[:capacity]
+}
+
+// drain removes and returns up to n pending CIDs queued for peerID, highest
+// priority first.
+func (m *Manager) drain(peerID string, n int) []string {
+	m.queueMu.Lock()
+	defer m.queueMu.Unlock()
+
+	q := m.queues[peerID]
+	if len(q) == 0 {
+		return nil
+	}
+	sort.Slice(q, func(i, j int) bool { return q[i].priority > q[j].priority })
+	if n > len(q) {
+		n = len(q)
+	}
+
+	batch := make([]string, n)
+	for i, item := range q[:n] {
+		batch[i] = item.cid
+	}
+	m.queues[peerID] = q[n:]
+	return batch
+}
+
+// doBroadcast performs one equilibrium gossip round: it picks fanOut(n)
+// random peers and pushes each one's pending CID batch, shrinking fan-out
+// and trimming queues first if the verification queue is under backpressure.
+func (m *Manager) doBroadcast() {
+	m.peersMu.RLock()
+	n := len(m.peers)
+	m.peersMu.RUnlock()
+	if n == 0 {
+		return
+	}
+
+	if near, _ := m.checkBackpressure(); near {
+		m.trimQueues(maxOutboundQueue / 4)
+	}
+
+	peers := m.selectFanOut(m.fanOut(n))
+	for _, peerID := range peers {
+		batch := m.drain(peerID, gossipBatchSize)
+		if len(batch) == 0 {
+			continue
+		}
+		m.sendGossipBatch(peerID, batch)
+	}
+
+	m.log.WithFields(logger.Fields{
+		"fan_out":    len(peers),
+		"peer_count": n,
+	}).Debug("Equilibrium gossip broadcast")
+}
+
+func (m *Manager) sendGossipBatch(peerID string, cids []string) {
+	payload, err := json.Marshal(gossipBatch{CIDs: cids})
+	if err != nil {
+		m.log.WithError(err).Warn("Failed to marshal gossip batch")
+		return
+	}
+
+	replyBytes, err := m.SendRPC(peerID, gossipProtocol, payload)
+	if err != nil {
+		// No transport to this peer yet (see the libp2p TODOs in Start):
+		// treat as a dropped send rather than a NACK, there's nothing
+		// useful to quarantine the peer over beyond the reputation hit.
+		m.log.WithError(err).WithField("peer_id", peerID).Debug("Gossip send failed")
+		m.recordEvent(peerID, EventTimeout)
+		return
+	}
+
+	var reply gossipBatchReply
+	if err := json.Unmarshal(replyBytes, &reply); err != nil {
+		m.log.WithError(err).Warn("Failed to unmarshal gossip reply")
+		return
+	}
+	if reply.Ack {
+		return
+	}
+
+	if m.nacks.record(peerID) {
+		if err := m.QuarantinePeer(peerID, "gossip_overflow"); err != nil {
+			m.log.WithError(err).WithField("peer_id", peerID).Warn("Failed to quarantine peer after repeated gossip NACKs")
+		}
+	}
+}
+
+// handleGossipRPC is the receiving side of gossipProtocol: it NACKs whole
+// batches under backpressure, otherwise dedupes against the seen cache and
+// publishes each new CID to subscribers.
+func (m *Manager) handleGossipRPC(fromPeerID string, payload []byte) ([]byte, error) {
+	var batch gossipBatch
+	if err := json.Unmarshal(payload, &batch); err != nil {
+		return nil, fmt.Errorf("unmarshal gossip batch: %w", err)
+	}
+
+	if near, _ := m.checkBackpressure(); near {
+		return json.Marshal(gossipBatchReply{Ack: false})
+	}
+
+	for _, cid := range batch.CIDs {
+		if m.seen.Seen(cid) {
+			m.recordEvent(fromPeerID, EventDuplicateGossip)
+			continue
+		}
+		m.publish(CIDEvent{CID: cid, FromPeer: fromPeerID, ReceivedAt: time.Now()})
+	}
+
+	return json.Marshal(gossipBatchReply{Ack: true})
+}
+
+// handleCIDRequestRPC answers RequestCID: whether this node has seen cid.
+func (m *Manager) handleCIDRequestRPC(fromPeerID string, payload []byte) ([]byte, error) {
+	var req gossipBatch
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, fmt.Errorf("unmarshal CID request: %w", err)
+	}
+	if len(req.CIDs) == 0 {
+		return json.Marshal(gossipBatchReply{Ack: false})
+	}
+	return json.Marshal(gossipBatchReply{Ack: m.seen.Has(req.CIDs[0])})
+}
+
+// AnnounceCID marks cid as seen locally, queues it for gossip to every
+// known peer at priority (higher propagates first under backpressure), and
+// publishes it to subscribers. Re-announcing an already-seen CID is a no-op.
+func (m *Manager) AnnounceCID(cid string, priority int) error {
+	if m.seen.Seen(cid) {
+		return nil
+	}
+
+	m.peersMu.RLock()
+	peerIDs := make([]string, 0, len(m.peers))
+	for id, peer := range m.peers {
+		if !peer.Quarantined && id != m.selfID {
+			peerIDs = append(peerIDs, id)
+		}
+	}
+	m.peersMu.RUnlock()
+
+	for _, id := range peerIDs {
+		m.enqueue(id, cid, priority)
+	}
+
+	m.publish(CIDEvent{CID: cid, ReceivedAt: time.Now()})
+	return nil
+}
+
+// BroadcastCID announces cid to the network at normal priority.
+func (m *Manager) BroadcastCID(cid string) error {
+	return m.AnnounceCID(cid, 0)
+}
+
+// RequestCID asks peer directly whether it has cid, outside the regular
+// gossip tick, e.g. when the mempool/consensus layer is missing a CID
+// another peer already announced.
+func (m *Manager) RequestCID(peer, cid string) (bool, error) {
+	payload, err := json.Marshal(gossipBatch{CIDs: []string{cid}})
+	if err != nil {
+		return false, fmt.Errorf("marshal CID request: %w", err)
+	}
+
+	replyBytes, err := m.SendRPC(peer, cidRequestProtocol, payload)
+	if err != nil {
+		return false, err
+	}
+
+	var reply gossipBatchReply
+	if err := json.Unmarshal(replyBytes, &reply); err != nil {
+		return false, fmt.Errorf("unmarshal CID request reply: %w", err)
+	}
+	return reply.Ack, nil
+}
+
+// Subscribe returns a channel that receives every CID this node announces
+// or learns about from a peer. Callers (mempool, consensus) should drain it
+// promptly; the channel is buffered but not infinite, and a full channel
+// drops events rather than blocking gossip.
+func (m *Manager) Subscribe() <-chan CIDEvent {
+	ch := make(chan CIDEvent, 256)
+	m.subsMu.Lock()
+	m.subs = append(m.subs, ch)
+	m.subsMu.Unlock()
+	return ch
+}
+
+func (m *Manager) publish(event CIDEvent) {
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+	for _, ch := range m.subs {
+		select {
+		case ch <- event:
+		default:
+			m.log.WithField("cid", event.CID).Warn("Gossip subscriber channel full, dropping event")
+		}
+	}
+}
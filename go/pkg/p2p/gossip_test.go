@@ -0,0 +1,183 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	m, err := NewManager(config.P2PConfig{EquilibriumLambda: 0.7071, BroadcastInterval: 1000}, logger.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	return m
+}
+
+func TestSeenCacheFirstSeenReturnsFalse(t *testing.T) {
+	c := newSeenCache(10, time.Minute)
+	if c.Seen("cid-a") {
+		t.Fatal("expected the first Seen call for a CID to return false")
+	}
+	if !c.Seen("cid-a") {
+		t.Fatal("expected a repeated Seen call for the same CID to return true")
+	}
+}
+
+func TestSeenCacheExpires(t *testing.T) {
+	c := newSeenCache(10, time.Millisecond)
+	c.Seen("cid-a")
+	time.Sleep(5 * time.Millisecond)
+	if c.Seen("cid-a") {
+		t.Fatal("expected an expired entry to be treated as unseen")
+	}
+}
+
+func TestSeenCacheEvictsOldestOverCapacity(t *testing.T) {
+	c := newSeenCache(2, time.Minute)
+	c.Seen("cid-a")
+	c.Seen("cid-b")
+	c.Seen("cid-c")
+
+	if c.Has("cid-a") {
+		t.Fatal("expected the oldest entry to have been evicted once capacity was exceeded")
+	}
+	if !c.Has("cid-b") || !c.Has("cid-c") {
+		t.Fatal("expected the two most recent entries to still be present")
+	}
+}
+
+func TestNackTrackerThreshold(t *testing.T) {
+	tr := newNackTracker()
+
+	var exceeded bool
+	for i := 0; i <= nackThreshold; i++ {
+		exceeded = tr.record("peer-a")
+	}
+	if !exceeded {
+		t.Fatalf("expected recording more than %d NACKs to exceed the threshold", nackThreshold)
+	}
+}
+
+func TestNackTrackerWindowExpires(t *testing.T) {
+	tr := newNackTracker()
+	tr.hits["peer-a"] = []time.Time{time.Now().Add(-2 * nackWindow)}
+
+	if tr.record("peer-a") {
+		t.Fatal("expected a stale NACK outside the window to not count toward the threshold")
+	}
+}
+
+func TestClampFanOut(t *testing.T) {
+	if got := clampFanOut(0, 10); got != 1 {
+		t.Fatalf("clampFanOut(0, 10) = %d, want 1 (floor of 1)", got)
+	}
+	if got := clampFanOut(20, 10); got != 10 {
+		t.Fatalf("clampFanOut(20, 10) = %d, want 10 (capped at n)", got)
+	}
+	if got := clampFanOut(5, 10); got != 5 {
+		t.Fatalf("clampFanOut(5, 10) = %d, want 5 (unchanged)", got)
+	}
+}
+
+func TestTrimToCapacityKeepsHighestPriority(t *testing.T) {
+	q := []gossipItem{
+		{cid: "low", priority: 0, addedAt: time.Now()},
+		{cid: "high", priority: 10, addedAt: time.Now()},
+		{cid: "mid", priority: 5, addedAt: time.Now()},
+	}
+
+	trimmed := trimToCapacity(q, 2)
+	if len(trimmed) != 2 {
+		t.Fatalf("expected 2 entries after trimming to capacity 2, got %d", len(trimmed))
+	}
+	for _, item := range trimmed {
+		if item.cid == "low" {
+			t.Fatal("expected the lowest-priority entry to be dropped")
+		}
+	}
+}
+
+func TestTrimToCapacityUnderLimitIsNoop(t *testing.T) {
+	q := []gossipItem{{cid: "a", priority: 1, addedAt: time.Now()}}
+	if got := trimToCapacity(q, 5); len(got) != 1 {
+		t.Fatalf("expected trimToCapacity to be a no-op under capacity, got %d entries", len(got))
+	}
+}
+
+func TestManagerFanOutScalesWithPeers(t *testing.T) {
+	m := newTestManager(t)
+
+	if got := m.fanOut(0); got != 0 {
+		t.Fatalf("fanOut(0) = %d, want 0", got)
+	}
+	if got := m.fanOut(100); got < 1 || got > 100 {
+		t.Fatalf("fanOut(100) = %d, want a value in [1, 100]", got)
+	}
+}
+
+func TestManagerEnqueueAndDrain(t *testing.T) {
+	m := newTestManager(t)
+
+	m.enqueue("peer-a", "cid-1", 0)
+	m.enqueue("peer-a", "cid-2", 5)
+
+	batch := m.drain("peer-a", 10)
+	if len(batch) != 2 {
+		t.Fatalf("expected 2 drained CIDs, got %d", len(batch))
+	}
+	if batch[0] != "cid-2" {
+		t.Fatalf("expected the higher-priority CID first, got %q", batch[0])
+	}
+
+	if rest := m.drain("peer-a", 10); len(rest) != 0 {
+		t.Fatalf("expected the queue to be empty after draining everything, got %d", len(rest))
+	}
+}
+
+func TestManagerAnnounceCIDPublishesAndDedupes(t *testing.T) {
+	m := newTestManager(t)
+	sub := m.Subscribe()
+
+	if err := m.AnnounceCID("cid-1", 0); err != nil {
+		t.Fatalf("AnnounceCID failed: %v", err)
+	}
+
+	select {
+	case ev := <-sub:
+		if ev.CID != "cid-1" {
+			t.Fatalf("event CID = %q, want cid-1", ev.CID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a CIDEvent to be published after AnnounceCID")
+	}
+
+	// Re-announcing the same CID should be a no-op: no second event.
+	if err := m.AnnounceCID("cid-1", 0); err != nil {
+		t.Fatalf("AnnounceCID failed: %v", err)
+	}
+	select {
+	case ev := <-sub:
+		t.Fatalf("expected no event for a re-announced CID, got %+v", ev)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestManagerSelectFanOutExcludesSelfAndQuarantined(t *testing.T) {
+	m := newTestManager(t)
+	m.peers["peer-a"] = &Peer{ID: "peer-a"}
+	m.peers["peer-b"] = &Peer{ID: "peer-b", Quarantined: true}
+
+	selected := m.selectFanOut(5)
+	for _, id := range selected {
+		if id == "peer-b" {
+			t.Fatal("expected a quarantined peer to be excluded from fan-out selection")
+		}
+		if id == m.selfID {
+			t.Fatal("expected self to be excluded from fan-out selection")
+		}
+	}
+}
@@ -3,11 +3,16 @@ package p2p
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
 )
 
 // Manager handles P2P networking
@@ -15,17 +20,49 @@ type Manager struct {
 	config config.P2PConfig
 	log    *logger.Logger
 
+	// selfID identifies this node to the rest of the cluster, e.g. for
+	// services (like the distributed rate limiter) that need to know
+	// whether a consistent-hash ring assigns them a given key.
+	selfID string
+
 	// Equilibrium gossip
 	lambda            float64 // Equilibrium constant (√2/2 ≈ 0.7071)
 	broadcastInterval time.Duration
 
 	// Peer management
-	peers map[string]*Peer
+	peersMu sync.RWMutex
+	peers   map[string]*Peer
+
+	// RPC dispatch for services layered on top of P2P (e.g. distributed rate
+	// limiting). There is no real peer transport yet (see the TODOs in
+	// Start), so SendRPC can only route to locally registered handlers.
+	handlersMu sync.RWMutex
+	handlers   map[string]PeerRPCHandler
+
+	// Equilibrium gossip state: see gossip.go.
+	seen  *seenCache
+	nacks *nackTracker
+
+	bpMu sync.RWMutex
+	bp   backpressureChecker
+
+	queueMu sync.Mutex
+	queues  map[string][]gossipItem
+
+	subsMu sync.Mutex
+	subs   []chan CIDEvent
+
+	// scorer tracks peer reputation; see score.go.
+	scorer *Scorer
 
 	// Channels
 	stopChan chan struct{}
 }
 
+// PeerRPCHandler processes one incoming RPC addressed to a method a service
+// registered via RegisterHandler. fromPeerID identifies the caller.
+type PeerRPCHandler func(fromPeerID string, payload []byte) ([]byte, error)
+
 // Peer represents a network peer
 type Peer struct {
 	ID          string
@@ -37,14 +74,63 @@ type Peer struct {
 
 // NewManager creates a new P2P manager
 func NewManager(cfg config.P2PConfig, log *logger.Logger) (*Manager, error) {
-	return &Manager{
+	selfID, err := newSelfID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate node id: %w", err)
+	}
+
+	m := &Manager{
 		config:            cfg,
 		log:               log,
+		selfID:            selfID,
 		lambda:            cfg.EquilibriumLambda,
 		broadcastInterval: time.Duration(cfg.BroadcastInterval) * time.Millisecond,
 		peers:             make(map[string]*Peer),
+		handlers:          make(map[string]PeerRPCHandler),
+		seen:              newSeenCache(seenCacheCapacity, seenCacheTTL),
+		nacks:             newNackTracker(),
+		queues:            make(map[string][]gossipItem),
+		scorer:            NewScorer(cfg, log),
 		stopChan:          make(chan struct{}),
-	}, nil
+	}
+
+	m.RegisterHandler(gossipProtocol, m.handleGossipRPC)
+	m.RegisterHandler(cidRequestProtocol, m.handleCIDRequestRPC)
+
+	return m, nil
+}
+
+// SetBackpressureChecker wires bp as the signal doBroadcast uses to scale
+// gossip fan-out down and trim outbound queues under load. cmd/coinjectured
+// passes the rate limiter here once it's constructed, since RateLimiter
+// already satisfies this interface.
+func (m *Manager) SetBackpressureChecker(bp backpressureChecker) {
+	m.bpMu.Lock()
+	m.bp = bp
+	m.bpMu.Unlock()
+}
+
+// SetBanStore wires sm as the persistent backing store for this node's
+// peer banlist, so bans crossing BanThreshold survive process restarts.
+// cmd/coinjectured passes the state manager here once it's constructed.
+func (m *Manager) SetBanStore(sm *state.StateManager) {
+	m.scorer.SetBanStore(sm)
+}
+
+// newSelfID generates a random node identity. There's no persistent keypair
+// or address to derive one from yet, so this is just a fresh random ID per
+// process start.
+func newSelfID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// SelfID returns this node's P2P identity.
+func (m *Manager) SelfID() string {
+	return m.selfID
 }
 
 // Start starts the P2P network manager
@@ -57,7 +143,6 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	// TODO: Initialize libp2p host
 	// TODO: Connect to bootstrap peers
-	// TODO: Start equilibrium gossip loop
 
 	// Start background tasks
 	go m.equilibriumGossipLoop()
@@ -87,16 +172,6 @@ func (m *Manager) equilibriumGossipLoop() {
 	}
 }
 
-// doBroadcast performs a gossip broadcast round
-func (m *Manager) doBroadcast() {
-	// TODO: Implement equilibrium gossip
-	// 1. Select peers based on lambda (0.7071)
-	// 2. Apply backpressure if needed
-	// 3. Broadcast CIDs with fan-out control
-
-	m.log.Debug("Equilibrium gossip broadcast (stub)")
-}
-
 // peerMaintenanceLoop maintains peer connections
 func (m *Manager) peerMaintenanceLoop() {
 	ticker := time.NewTicker(30 * time.Second)
@@ -105,7 +180,7 @@ func (m *Manager) peerMaintenanceLoop() {
 	for {
 		select {
 		case <-ticker.C:
-			m.cleanupStaleP eers()
+			m.cleanupStalePeers()
 		case <-m.stopChan:
 			return
 		}
@@ -118,26 +193,39 @@ func (m *Manager) cleanupStalePeers() {
 	m.log.Debug("Peer cleanup (stub)")
 }
 
-// BroadcastCID broadcasts a CID to the network
-func (m *Manager) BroadcastCID(cid string) error {
-	// TODO: Implement gossip broadcast
-	m.log.WithField("cid", cid).Debug("Broadcasting CID (stub)")
-	return nil
-}
-
 // PeerCount returns the number of connected peers
 func (m *Manager) PeerCount() int {
+	m.peersMu.RLock()
+	defer m.peersMu.RUnlock()
 	return len(m.peers)
 }
 
+// Peers returns the IDs of currently known, non-quarantined peers. This is
+// the cluster membership view consistent-hashing services (like the
+// distributed rate limiter) build their ring over.
+func (m *Manager) Peers() []string {
+	m.peersMu.RLock()
+	defer m.peersMu.RUnlock()
+
+	ids := make([]string, 0, len(m.peers))
+	for id, peer := range m.peers {
+		if !peer.Quarantined {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // AddPeer adds a new peer
 func (m *Manager) AddPeer(id, address string) error {
+	m.peersMu.Lock()
 	m.peers[id] = &Peer{
 		ID:       id,
 		Address:  address,
-		Score:    100,
+		Score:    0, // neutral starting reputation; see Scorer
 		LastSeen: time.Now(),
 	}
+	m.peersMu.Unlock()
 
 	m.log.WithFields(logger.Fields{
 		"peer_id": id,
@@ -149,12 +237,17 @@ func (m *Manager) AddPeer(id, address string) error {
 
 // QuarantinePeer quarantines a misbehaving peer
 func (m *Manager) QuarantinePeer(id string, reason string) error {
+	m.peersMu.Lock()
 	peer, exists := m.peers[id]
+	if exists {
+		peer.Quarantined = true
+	}
+	m.peersMu.Unlock()
+
 	if !exists {
 		return fmt.Errorf("peer not found: %s", id)
 	}
 
-	peer.Quarantined = true
 	m.log.WithFields(logger.Fields{
 		"peer_id": id,
 		"reason":  reason,
@@ -162,3 +255,97 @@ func (m *Manager) QuarantinePeer(id string, reason string) error {
 
 	return nil
 }
+
+// UnquarantinePeer lifts a temporary quarantine, e.g. once a Scorer-driven
+// backoff period elapses. It's a no-op (not an error) if id is unknown,
+// since the peer may have been removed while quarantined.
+func (m *Manager) UnquarantinePeer(id string) {
+	m.peersMu.Lock()
+	peer, exists := m.peers[id]
+	if exists {
+		peer.Quarantined = false
+	}
+	m.peersMu.Unlock()
+
+	if exists {
+		m.log.WithField("peer_id", id).Info("Peer quarantine lifted")
+	}
+}
+
+// RecordPeerEvent applies event's configured weight to peerID's reputation
+// score (see score.go) and takes any resulting quarantine/ban action.
+// event should be one of the ScoreEvent constants; an unrecognized event is
+// a harmless no-op. Exposed as a plain string so callers outside pkg/p2p
+// (like the rate limiter) can report events without importing this package.
+func (m *Manager) RecordPeerEvent(peerID string, event string) {
+	m.recordEvent(peerID, ScoreEvent(event))
+}
+
+// recordEvent is RecordPeerEvent's typed counterpart for callers already in
+// pkg/p2p (e.g. gossip.go).
+func (m *Manager) recordEvent(peerID string, event ScoreEvent) {
+	score, quarantine, quarantineFor, ban := m.scorer.Record(peerID, event)
+
+	m.peersMu.Lock()
+	if peer, exists := m.peers[peerID]; exists {
+		peer.Score = int(math.Round(score))
+	}
+	m.peersMu.Unlock()
+
+	if ban {
+		if err := m.QuarantinePeer(peerID, "reputation score below ban threshold"); err != nil {
+			m.log.WithError(err).WithField("peer_id", peerID).Warn("Failed to quarantine banned peer")
+		}
+		return
+	}
+
+	if quarantine {
+		if err := m.QuarantinePeer(peerID, "reputation score below quarantine threshold"); err != nil {
+			m.log.WithError(err).WithField("peer_id", peerID).Warn("Failed to quarantine low-scoring peer")
+			return
+		}
+		time.AfterFunc(quarantineFor, func() { m.UnquarantinePeer(peerID) })
+	}
+}
+
+// Score returns peerID's current reputation score (see score.go).
+func (m *Manager) Score(peerID string) float64 {
+	return m.scorer.Score(peerID)
+}
+
+// PeerScores returns a reputation snapshot for every peer the scorer has
+// observed, for the /peers/score endpoint.
+func (m *Manager) PeerScores() []PeerScore {
+	return m.scorer.Snapshot()
+}
+
+// RegisterHandler installs the handler invoked for incoming RPCs addressed
+// to method. Services call this once at startup; see SendRPC.
+func (m *Manager) RegisterHandler(method string, handler PeerRPCHandler) {
+	m.handlersMu.Lock()
+	defer m.handlersMu.Unlock()
+	m.handlers[method] = handler
+}
+
+// SendRPC forwards payload to method on peerID and returns its reply.
+//
+// TODO: there is no real peer transport yet (see the libp2p TODOs in Start),
+// so this can only route to a locally registered handler when peerID is
+// this node's own selfID. That's enough to exercise caller logic end-to-end
+// in a single-node deployment; once real peer connections exist, this
+// should dial out and make an actual RPC instead of erroring for any other
+// peerID.
+func (m *Manager) SendRPC(peerID, method string, payload []byte) ([]byte, error) {
+	if peerID != m.selfID {
+		return nil, fmt.Errorf("no peer transport available: cannot reach peer %s", peerID)
+	}
+
+	m.handlersMu.RLock()
+	handler, ok := m.handlers[method]
+	m.handlersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no handler registered for method %q", method)
+	}
+
+	return handler(m.selfID, payload)
+}
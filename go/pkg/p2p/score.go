@@ -0,0 +1,241 @@
+// Peer reputation scoring: see Manager.RecordPeerEvent.
+package p2p
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ScoreEvent is a typed peer behavior observation that shifts a peer's
+// reputation score by a configurable weight (see config.PeerScoreWeights).
+type ScoreEvent string
+
+const (
+	EventValidBlock       ScoreEvent = "valid_block"
+	EventInvalidSignature ScoreEvent = "invalid_signature"
+	EventTimeout          ScoreEvent = "timeout"
+	EventDuplicateGossip  ScoreEvent = "duplicate_gossip"
+	EventRateLimitHit     ScoreEvent = "rate_limit_hit"
+)
+
+const (
+	minScore = -100.0
+	maxScore = 100.0
+
+	// quarantineBackoffBase is how long a peer is quarantined for its first
+	// scoring offense; the backoff doubles per consecutive re-offense.
+	quarantineBackoffBase = 1 * time.Minute
+)
+
+var peerScoreGauge = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "coinjecture_peer_score",
+		Help: "Current peer reputation score, in [-100, 100]",
+	},
+	[]string{"peer_id"},
+)
+
+// peerScoreState is one peer's reputation. Decay is applied lazily, at
+// whatever time it's next read or written, rather than on a ticker.
+type peerScoreState struct {
+	score      float64
+	lastUpdate time.Time
+
+	offenses         int // consecutive quarantines, for backoff doubling
+	quarantinedUntil time.Time
+	banned           bool
+}
+
+// PeerScore is a point-in-time reputation snapshot, for the /peers/score
+// endpoint.
+type PeerScore struct {
+	PeerID      string    `json:"peer_id"`
+	Score       float64   `json:"score"`
+	Quarantined bool      `json:"quarantined"`
+	Banned      bool      `json:"banned"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Scorer tracks peer reputation: typed behavior events shift a peer's score
+// by a configured weight, the score decays exponentially toward 0 with a
+// configurable half-life, and crossing QuarantineThreshold/BanThreshold
+// triggers quarantine (with doubling backoff per re-offense) or a
+// persistent ban.
+type Scorer struct {
+	mu   sync.Mutex
+	cfg  config.P2PConfig
+	log  *logger.Logger
+	bans *state.StateManager // persistent banlist; nil until SetBanStore
+
+	states map[string]*peerScoreState
+}
+
+// NewScorer creates a Scorer. The ban store can be wired in later via
+// SetBanStore; until then bans are tracked in memory only.
+func NewScorer(cfg config.P2PConfig, log *logger.Logger) *Scorer {
+	return &Scorer{
+		cfg:    cfg,
+		log:    log,
+		states: make(map[string]*peerScoreState),
+	}
+}
+
+// SetBanStore wires sm as the persistent backing store for bans crossing
+// BanThreshold, so they survive process restarts.
+func (s *Scorer) SetBanStore(sm *state.StateManager) {
+	s.mu.Lock()
+	s.bans = sm
+	s.mu.Unlock()
+}
+
+// decayedScore applies exponential decay for the time elapsed since st was
+// last touched, toward 0 with the configured half-life.
+func decayedScore(st *peerScoreState, halfLife time.Duration, now time.Time) float64 {
+	if st.score == 0 || halfLife <= 0 {
+		return st.score
+	}
+	elapsed := now.Sub(st.lastUpdate)
+	if elapsed <= 0 {
+		return st.score
+	}
+	return st.score * math.Exp(-elapsed.Seconds()/halfLife.Seconds())
+}
+
+func clampScore(score float64) float64 {
+	if score > maxScore {
+		return maxScore
+	}
+	if score < minScore {
+		return minScore
+	}
+	return score
+}
+
+func (s *Scorer) weightFor(event ScoreEvent) float64 {
+	switch event {
+	case EventValidBlock:
+		return s.cfg.ScoreWeights.ValidBlock
+	case EventInvalidSignature:
+		return s.cfg.ScoreWeights.InvalidSignature
+	case EventTimeout:
+		return s.cfg.ScoreWeights.Timeout
+	case EventDuplicateGossip:
+		return s.cfg.ScoreWeights.DuplicateGossip
+	case EventRateLimitHit:
+		return s.cfg.ScoreWeights.RateLimitHit
+	default:
+		return 0
+	}
+}
+
+// Record applies event's weight to peerID's score (after decaying it for
+// elapsed time) and reports whether the peer should now be quarantined or
+// banned. Quarantine backoff doubles per consecutive offense; a ban is
+// permanent until an operator clears the persistent banlist.
+func (s *Scorer) Record(peerID string, event ScoreEvent) (score float64, quarantine bool, quarantineFor time.Duration, ban bool) {
+	now := time.Now()
+
+	s.mu.Lock()
+	st, ok := s.states[peerID]
+	if !ok {
+		st = &peerScoreState{lastUpdate: now}
+		s.states[peerID] = st
+	}
+
+	st.score = clampScore(decayedScore(st, s.cfg.ScoreHalfLife, now) + s.weightFor(event))
+	st.lastUpdate = now
+	score = st.score
+
+	if s.cfg.PeerScoringEnabled {
+		switch {
+		case score <= float64(s.cfg.BanThreshold):
+			if !st.banned {
+				ban = true
+			}
+			st.banned = true
+		case score <= float64(s.cfg.QuarantineThreshold) && now.After(st.quarantinedUntil):
+			st.offenses++
+			quarantineFor = quarantineBackoffBase * time.Duration(int64(1)<<uint(st.offenses-1))
+			st.quarantinedUntil = now.Add(quarantineFor)
+			quarantine = true
+		}
+	}
+	bans := s.bans
+	s.mu.Unlock()
+
+	peerScoreGauge.WithLabelValues(peerID).Set(score)
+
+	if ban && bans != nil {
+		if err := bans.BanPeer(peerID, "reputation score fell to or below ban threshold"); err != nil {
+			s.log.WithError(err).WithField("peer_id", peerID).Error("Failed to persist peer ban")
+		}
+	}
+
+	return score, quarantine, quarantineFor, ban
+}
+
+// Score returns peerID's current (decayed) score without recording a new
+// event. Unknown peers default to 0.
+func (s *Scorer) Score(peerID string) float64 {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.states[peerID]
+	if !ok {
+		return 0
+	}
+	return decayedScore(st, s.cfg.ScoreHalfLife, now)
+}
+
+// IsBanned reports whether peerID is banned, checking both this process's
+// state and the persistent banlist (if wired via SetBanStore).
+func (s *Scorer) IsBanned(peerID string) bool {
+	s.mu.Lock()
+	st, ok := s.states[peerID]
+	bans := s.bans
+	s.mu.Unlock()
+
+	if ok && st.banned {
+		return true
+	}
+	if bans == nil {
+		return false
+	}
+
+	banned, err := bans.IsPeerBanned(peerID)
+	if err != nil {
+		s.log.WithError(err).WithField("peer_id", peerID).Warn("Failed to check persistent peer banlist")
+		return false
+	}
+	return banned
+}
+
+// Snapshot returns every tracked peer's current reputation, for the
+// /peers/score endpoint.
+func (s *Scorer) Snapshot() []PeerScore {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]PeerScore, 0, len(s.states))
+	for id, st := range s.states {
+		out = append(out, PeerScore{
+			PeerID:      id,
+			Score:       decayedScore(st, s.cfg.ScoreHalfLife, now),
+			Quarantined: now.Before(st.quarantinedUntil),
+			Banned:      st.banned,
+			UpdatedAt:   st.lastUpdate,
+		})
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+)
+
+func testScorerConfig() config.P2PConfig {
+	return config.P2PConfig{
+		PeerScoringEnabled:  true,
+		QuarantineThreshold: -20,
+		BanThreshold:        -80,
+		ScoreHalfLife:       time.Hour,
+		ScoreWeights: config.PeerScoreWeights{
+			ValidBlock:       5,
+			InvalidSignature: -50,
+			Timeout:          -2,
+			DuplicateGossip:  -1,
+			RateLimitHit:     -5,
+		},
+	}
+}
+
+func newTestScorer() *Scorer {
+	return NewScorer(testScorerConfig(), logger.NewLogger("error"))
+}
+
+func TestScorerRecordAppliesWeight(t *testing.T) {
+	s := newTestScorer()
+
+	score, quarantine, _, ban := s.Record("peer-a", EventValidBlock)
+	if score != 5 {
+		t.Fatalf("score = %v, want 5", score)
+	}
+	if quarantine || ban {
+		t.Fatal("expected a single valid-block event not to trigger quarantine or ban")
+	}
+}
+
+func TestScorerQuarantineOnThreshold(t *testing.T) {
+	s := newTestScorer()
+
+	var quarantine bool
+	for i := 0; i < 10 && !quarantine; i++ {
+		_, quarantine, _, _ = s.Record("peer-a", EventInvalidSignature)
+	}
+	if !quarantine {
+		t.Fatal("expected repeated invalid-signature events to eventually trigger quarantine")
+	}
+}
+
+func TestScorerBanOnThreshold(t *testing.T) {
+	s := newTestScorer()
+
+	var ban bool
+	for i := 0; i < 10 && !ban; i++ {
+		_, _, _, ban = s.Record("peer-a", EventInvalidSignature)
+	}
+	if !ban {
+		t.Fatal("expected enough invalid-signature events to eventually trigger a ban")
+	}
+	if !s.IsBanned("peer-a") {
+		t.Fatal("expected IsBanned to report true after a ban event")
+	}
+}
+
+func TestScorerQuarantineBackoffDoubles(t *testing.T) {
+	s := newTestScorer()
+
+	var firstQuarantineFor, secondQuarantineFor time.Duration
+	quarantines := 0
+	for i := 0; i < 20 && quarantines < 2; i++ {
+		_, q, quarantineFor, _ := s.Record("peer-a", EventTimeout)
+		if q {
+			quarantines++
+			if quarantines == 1 {
+				firstQuarantineFor = quarantineFor
+				// Force the quarantine window to have already elapsed so the
+				// next offense can re-trigger quarantine instead of being
+				// suppressed by "now.After(st.quarantinedUntil)".
+				s.mu.Lock()
+				s.states["peer-a"].quarantinedUntil = time.Now().Add(-time.Second)
+				s.mu.Unlock()
+			} else {
+				secondQuarantineFor = quarantineFor
+			}
+		}
+	}
+
+	if quarantines < 2 {
+		t.Fatalf("expected at least two quarantine events, got %d", quarantines)
+	}
+	if secondQuarantineFor <= firstQuarantineFor {
+		t.Fatalf("second quarantine backoff (%v) should exceed the first (%v)", secondQuarantineFor, firstQuarantineFor)
+	}
+}
+
+func TestScorerScoreDefaultsToZeroForUnknownPeer(t *testing.T) {
+	s := newTestScorer()
+	if got := s.Score("never-seen"); got != 0 {
+		t.Fatalf("Score for an unknown peer = %v, want 0", got)
+	}
+}
+
+func TestScorerScoreDecaysOverTime(t *testing.T) {
+	s := newTestScorer()
+	s.Record("peer-a", EventValidBlock)
+
+	s.mu.Lock()
+	s.states["peer-a"].lastUpdate = time.Now().Add(-s.cfg.ScoreHalfLife)
+	s.mu.Unlock()
+
+	decayed := s.Score("peer-a")
+	if decayed >= 5 || decayed <= 0 {
+		t.Fatalf("expected the score to have decayed to roughly half after one half-life, got %v", decayed)
+	}
+}
+
+func TestScorerSnapshotReflectsRecordedPeers(t *testing.T) {
+	s := newTestScorer()
+	s.Record("peer-a", EventValidBlock)
+	s.Record("peer-b", EventTimeout)
+
+	snapshot := s.Snapshot()
+	if len(snapshot) != 2 {
+		t.Fatalf("expected 2 peers in snapshot, got %d", len(snapshot))
+	}
+}
+
+func TestScorerDisabledNeverQuarantinesOrBans(t *testing.T) {
+	cfg := testScorerConfig()
+	cfg.PeerScoringEnabled = false
+	s := NewScorer(cfg, logger.NewLogger("error"))
+
+	for i := 0; i < 20; i++ {
+		_, quarantine, _, ban := s.Record("peer-a", EventInvalidSignature)
+		if quarantine || ban {
+			t.Fatal("expected a disabled scorer to never quarantine or ban regardless of score")
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package receipts
+
+import "crypto/sha256"
+
+// bloomBits is the bit width of a block's log bloom filter — 2048 bits (256
+// bytes), the same width geth uses for Block.Bloom, chosen because it keeps
+// false-positive rates low even for blocks with dozens of logs while still
+// being cheap to OR together and scan.
+const bloomBits = 2048
+
+// bloomHashes is how many bit positions each bloom input sets, geth's k=3.
+const bloomHashes = 3
+
+// Bloom is a block-level log bloom filter over every log's address and
+// topics, letting LogFilter skip decoding a block's receipts entirely when
+// none of its logs could possibly match the requested query.
+type Bloom [bloomBits / 8]byte
+
+// add sets bloomHashes bits derived from data's SHA-256 digest. Three
+// non-overlapping 16-bit windows of the digest give three bit positions
+// without needing three independent hash functions, mirroring
+// go-ethereum's bloom9.
+func (b *Bloom) add(data []byte) {
+	sum := sha256.Sum256(data)
+	for i := 0; i < bloomHashes; i++ {
+		bit := (uint16(sum[2*i])<<8 | uint16(sum[2*i+1])) % bloomBits
+		b[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// test reports whether every bit add(data) would set is already set — a
+// true result means "maybe present", a false result means "definitely
+// absent".
+func (b Bloom) test(data []byte) bool {
+	var probe Bloom
+	probe.add(data)
+	for i := range b {
+		if probe[i]&^b[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAddress reports whether a log from addr could be present in this
+// bloom.
+func (b Bloom) TestAddress(addr [32]byte) bool {
+	return b.test(addr[:])
+}
+
+// TestTopic reports whether a log carrying topic could be present in this
+// bloom.
+func (b Bloom) TestTopic(topic [32]byte) bool {
+	return b.test(topic[:])
+}
+
+// Merge ORs other into b, used to fold a transaction's logs (or a whole
+// block's receipts) into one running bloom.
+func (b *Bloom) Merge(other Bloom) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// NewBloom computes the bloom filter over a set of logs: every log's
+// address and topics.
+func NewBloom(logs []Log) Bloom {
+	var b Bloom
+	for _, l := range logs {
+		b.add(l.Address[:])
+		for _, topic := range l.Topics {
+			b.add(topic[:])
+		}
+	}
+	return b
+}
@@ -0,0 +1,51 @@
+package receipts
+
+import "testing"
+
+func addr(b byte) [32]byte {
+	var a [32]byte
+	a[0] = b
+	return a
+}
+
+func TestBloomAddressMatch(t *testing.T) {
+	present, absent := addr(1), addr(2)
+	bloom := NewBloom([]Log{{Address: present}})
+
+	if !bloom.TestAddress(present) {
+		t.Fatal("bloom should admit the address it was built from")
+	}
+	if bloom.TestAddress(absent) {
+		t.Fatal("bloom should not (in this small test) admit an address never added")
+	}
+}
+
+func TestBloomTopicMatch(t *testing.T) {
+	present, absent := addr(3), addr(4)
+	bloom := NewBloom([]Log{{Address: addr(0), Topics: [][32]byte{present}}})
+
+	if !bloom.TestTopic(present) {
+		t.Fatal("bloom should admit the topic it was built from")
+	}
+	if bloom.TestTopic(absent) {
+		t.Fatal("bloom should not (in this small test) admit a topic never added")
+	}
+}
+
+func TestBloomMerge(t *testing.T) {
+	a := NewBloom([]Log{{Address: addr(5)}})
+	b := NewBloom([]Log{{Address: addr(6)}})
+
+	a.Merge(b)
+
+	if !a.TestAddress(addr(5)) || !a.TestAddress(addr(6)) {
+		t.Fatal("merged bloom should admit addresses from both inputs")
+	}
+}
+
+func TestBloomEmptyMatchesNothing(t *testing.T) {
+	var bloom Bloom
+	if bloom.TestAddress(addr(7)) {
+		t.Fatal("zero-value bloom should not admit any address")
+	}
+}
@@ -0,0 +1,184 @@
+package receipts
+
+// FilterQuery describes a LogFilter range query: every log between
+// FromBlock and ToBlock (inclusive) that was emitted by one of Addresses
+// (if non-empty) and carries one of Topics (if non-empty) among its
+// topics. An empty Addresses or Topics list matches any address/topic,
+// mirroring eth_getLogs semantics.
+type FilterQuery struct {
+	FromBlock uint64
+	ToBlock   uint64
+	Addresses [][32]byte
+	Topics    [][32]byte
+}
+
+// LogEntry is one matched log, with enough surrounding context (which
+// transaction and block it came from, and its position within each) for a
+// caller to look up the rest of the receipt if needed.
+type LogEntry struct {
+	Log
+	BlockHash   [32]byte
+	BlockNumber uint64
+	TxHash      [32]byte
+	TxIndex     int
+	LogIndex    int
+}
+
+// FilterLogs walks [q.FromBlock, q.ToBlock], using each block's stored
+// bloom to cheaply skip blocks that couldn't possibly contain a matching
+// log, and only decoding receipts for the candidate blocks that remain —
+// the classic geth log-filter pattern (core/bloombits, filters.Filter).
+func (s *Store) FilterLogs(q FilterQuery) ([]LogEntry, error) {
+	if q.FromBlock > q.ToBlock {
+		return nil, nil
+	}
+
+	var matches []LogEntry
+
+	for number := q.FromBlock; ; number++ {
+		bloom, ok, err := s.GetBloom(number)
+		if err != nil {
+			return nil, err
+		}
+		if ok && bloomCouldMatch(bloom, q) {
+			receiptsInBlock, err := s.receiptsForNumberLocked(number)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, r := range receiptsInBlock {
+				for logIndex, l := range r.Logs {
+					if logMatches(l, q) {
+						matches = append(matches, LogEntry{
+							Log:         l,
+							BlockHash:   r.BlockHash,
+							BlockNumber: r.BlockNumber,
+							TxHash:      r.TxHash,
+							TxIndex:     r.TxIndex,
+							LogIndex:    logIndex,
+						})
+					}
+				}
+			}
+		}
+
+		if number == q.ToBlock {
+			break // checked after the body so ToBlock == MaxUint64 still runs once
+		}
+	}
+
+	return matches, nil
+}
+
+// receiptsForNumberLocked loads every receipt for the block at number,
+// taking its own read lock (FilterLogs can't hold the lock across this and
+// GetBloom without risking a deadlock against a concurrent writer).
+func (s *Store) receiptsForNumberLocked(number uint64) ([]Receipt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.Query(`SELECT tx_hash FROM tx_receipts WHERE block_number = ? ORDER BY tx_index ASC`, number)
+	if err != nil {
+		return nil, err
+	}
+	var txHashes []string
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		txHashes = append(txHashes, h)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]Receipt, 0, len(txHashes))
+	for _, h := range txHashes {
+		txHash, err := hexToHash(h)
+		if err != nil {
+			return nil, err
+		}
+		r, ok, err := s.getReceiptLocked(txHash)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, *r)
+		}
+	}
+
+	return out, nil
+}
+
+// bloomCouldMatch reports whether bloom admits the possibility of a log
+// satisfying q. An empty Addresses/Topics list is a wildcard.
+func bloomCouldMatch(bloom Bloom, q FilterQuery) bool {
+	if len(q.Addresses) > 0 {
+		found := false
+		for _, addr := range q.Addresses {
+			if bloom.TestAddress(addr) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(q.Topics) > 0 {
+		found := false
+		for _, topic := range q.Topics {
+			if bloom.TestTopic(topic) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// logMatches re-checks a decoded log against q exactly, since a bloom match
+// is only ever a "maybe" — false positives are expected and must be
+// filtered out here.
+func logMatches(l Log, q FilterQuery) bool {
+	if len(q.Addresses) > 0 {
+		matched := false
+		for _, addr := range q.Addresses {
+			if l.Address == addr {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(q.Topics) > 0 {
+		matched := false
+		for _, wantTopic := range q.Topics {
+			for _, topic := range l.Topics {
+				if topic == wantTopic {
+					matched = true
+					break
+				}
+			}
+			if matched {
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
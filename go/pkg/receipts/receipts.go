@@ -0,0 +1,291 @@
+// Package receipts persists per-transaction execution receipts and
+// per-block log blooms, and serves the LogFilter range query they exist to
+// support. It follows the same shape as pkg/store/blockstore (own SQLite
+// schema installed on a shared *sql.DB, JSON payload column for the parts
+// that don't need their own index) but is a separate store: blockstore
+// already carries a minimal pass/fail receipt for handleGetTransaction, and
+// widening that shape in place would mean every existing caller of
+// blockstore.Receipt has to learn about logs and bloom filters it doesn't
+// use.
+package receipts
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// Log is a single event emitted by a transaction: an address that produced
+// it, an ordered list of indexed topics (topic[0] is conventionally the
+// event signature), and opaque non-indexed data. This chain has no
+// contract execution yet (see pkg/execution.Event), so today only the
+// synthetic transfer log consensus.toReceipts derives from each
+// transaction's from/to is ever produced.
+type Log struct {
+	Address [32]byte
+	Topics  [][32]byte
+	Data    []byte
+}
+
+// Receipt is the full execution outcome of one transaction, persisted
+// alongside (but separately from) the minimal blockstore.Receipt.
+type Receipt struct {
+	TxHash            [32]byte
+	BlockHash         [32]byte
+	BlockNumber       uint64
+	TxIndex           int
+	Status            bool // true if the transaction succeeded
+	GasUsed           uint64
+	CumulativeGasUsed uint64 // sum of GasUsed for this and every earlier tx in the block
+	ContractAddress   *[32]byte // set only for a contract-creation transaction
+	Logs              []Log
+}
+
+// receiptPayload is the JSON-serialized form of the columns that don't need
+// their own index: logs (and, via ContractAddress, optional fields that
+// would otherwise need a nullable-column dance).
+type receiptPayload struct {
+	ContractAddress *[32]byte
+	Logs            []Log
+}
+
+// Store persists transaction receipts and per-block log blooms. It does
+// not own its *sql.DB — see blockstore.Store's doc comment, which this
+// mirrors.
+type Store struct {
+	db  *sql.DB
+	log *logger.Logger
+	mu  sync.RWMutex
+}
+
+const receiptsSchema = `
+CREATE TABLE IF NOT EXISTS tx_receipts (
+	tx_hash             TEXT    PRIMARY KEY,
+	block_hash          TEXT    NOT NULL,
+	block_number        INTEGER NOT NULL,
+	tx_index            INTEGER NOT NULL,
+	status              INTEGER NOT NULL,
+	gas_used            INTEGER NOT NULL,
+	cumulative_gas_used INTEGER NOT NULL,
+	payload             BLOB    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tx_receipts_block ON tx_receipts(block_hash);
+CREATE INDEX IF NOT EXISTS idx_tx_receipts_block_number ON tx_receipts(block_number);
+
+CREATE TABLE IF NOT EXISTS block_blooms (
+	block_number INTEGER PRIMARY KEY,
+	block_hash   TEXT    NOT NULL,
+	bloom        BLOB    NOT NULL
+);
+`
+
+// NewStore installs the receipts schema on db and returns a Store. db is
+// expected to already be open, and is shared with (not owned by) the
+// caller.
+func NewStore(db *sql.DB, log *logger.Logger) (*Store, error) {
+	if _, err := db.Exec(receiptsSchema); err != nil {
+		return nil, fmt.Errorf("failed to initialize receipts schema: %w", err)
+	}
+
+	return &Store{db: db, log: log}, nil
+}
+
+// PutBlockReceipts persists every receipt produced by a block along with
+// the block's combined log bloom, replacing whatever was previously stored
+// for that block hash. Called at finalization time, once a block joins the
+// canonical chain (see consensus.ForkChoice.SetReceiptsStore).
+func (s *Store) PutBlockReceipts(blockHash [32]byte, blockNumber uint64, receipts []Receipt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashHex := fmt.Sprintf("%x", blockHash)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin receipts transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var bloom Bloom
+	for _, r := range receipts {
+		payload, err := json.Marshal(receiptPayload{
+			ContractAddress: r.ContractAddress,
+			Logs:            r.Logs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode receipt payload: %w", err)
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO tx_receipts (tx_hash, block_hash, block_number, tx_index, status, gas_used, cumulative_gas_used, payload)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(tx_hash) DO UPDATE SET
+				block_hash = excluded.block_hash, block_number = excluded.block_number,
+				tx_index = excluded.tx_index, status = excluded.status,
+				gas_used = excluded.gas_used, cumulative_gas_used = excluded.cumulative_gas_used,
+				payload = excluded.payload
+		`, fmt.Sprintf("%x", r.TxHash), hashHex, blockNumber, r.TxIndex, r.Status, r.GasUsed, r.CumulativeGasUsed, payload); err != nil {
+			return fmt.Errorf("failed to store receipt %x: %w", r.TxHash[:8], err)
+		}
+
+		bloom.Merge(NewBloom(r.Logs))
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO block_blooms (block_number, block_hash, bloom)
+		VALUES (?, ?, ?)
+		ON CONFLICT(block_number) DO UPDATE SET block_hash = excluded.block_hash, bloom = excluded.bloom
+	`, blockNumber, hashHex, bloom[:]); err != nil {
+		return fmt.Errorf("failed to store block bloom: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// DeleteBlockReceipts removes a block's receipts and bloom, used when a
+// block is displaced from the canonical chain by a reorg (mirrors
+// blockstore.Store.DeleteBlock).
+func (s *Store) DeleteBlockReceipts(blockHash [32]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashHex := fmt.Sprintf("%x", blockHash)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin receipts transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM tx_receipts WHERE block_hash = ?`, hashHex); err != nil {
+		return fmt.Errorf("failed to remove receipts: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM block_blooms WHERE block_hash = ?`, hashHex); err != nil {
+		return fmt.Errorf("failed to remove block bloom: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetReceipt retrieves the full receipt for a confirmed transaction, if any.
+func (s *Store) GetReceipt(txHash [32]byte) (*Receipt, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.getReceiptLocked(txHash)
+}
+
+func (s *Store) getReceiptLocked(txHash [32]byte) (*Receipt, bool, error) {
+	var blockHashHex string
+	r := &Receipt{TxHash: txHash}
+	var payload []byte
+	err := s.db.QueryRow(`
+		SELECT block_hash, block_number, tx_index, status, gas_used, cumulative_gas_used, payload
+		FROM tx_receipts WHERE tx_hash = ?
+	`, fmt.Sprintf("%x", txHash)).Scan(&blockHashHex, &r.BlockNumber, &r.TxIndex, &r.Status, &r.GasUsed, &r.CumulativeGasUsed, &payload)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query receipt: %w", err)
+	}
+
+	blockHash, err := hexToHash(blockHashHex)
+	if err != nil {
+		return nil, false, err
+	}
+	r.BlockHash = blockHash
+
+	var p receiptPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, false, fmt.Errorf("failed to decode receipt payload: %w", err)
+	}
+	r.ContractAddress = p.ContractAddress
+	r.Logs = p.Logs
+
+	return r, true, nil
+}
+
+// GetReceiptsByBlock retrieves every receipt for a block, ordered by
+// transaction index.
+func (s *Store) GetReceiptsByBlock(blockHash [32]byte) ([]Receipt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.receiptsByBlockLocked(blockHash)
+}
+
+func (s *Store) receiptsByBlockLocked(blockHash [32]byte) ([]Receipt, error) {
+	rows, err := s.db.Query(`
+		SELECT tx_hash, block_number, tx_index, status, gas_used, cumulative_gas_used, payload
+		FROM tx_receipts WHERE block_hash = ?
+		ORDER BY tx_index ASC
+	`, fmt.Sprintf("%x", blockHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query receipts for block: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Receipt
+	for rows.Next() {
+		var txHashHex string
+		var payload []byte
+		r := Receipt{BlockHash: blockHash}
+		if err := rows.Scan(&txHashHex, &r.BlockNumber, &r.TxIndex, &r.Status, &r.GasUsed, &r.CumulativeGasUsed, &payload); err != nil {
+			return nil, fmt.Errorf("failed to scan receipt: %w", err)
+		}
+		txHash, err := hexToHash(txHashHex)
+		if err != nil {
+			return nil, err
+		}
+		r.TxHash = txHash
+
+		var p receiptPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("failed to decode receipt payload: %w", err)
+		}
+		r.ContractAddress = p.ContractAddress
+		r.Logs = p.Logs
+
+		out = append(out, r)
+	}
+
+	return out, rows.Err()
+}
+
+// GetBloom retrieves the combined log bloom for a block, if one has been
+// stored.
+func (s *Store) GetBloom(blockNumber uint64) (Bloom, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var bloom Bloom
+	var raw []byte
+	err := s.db.QueryRow(`SELECT bloom FROM block_blooms WHERE block_number = ?`, blockNumber).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return bloom, false, nil
+	}
+	if err != nil {
+		return bloom, false, fmt.Errorf("failed to query block bloom: %w", err)
+	}
+	if len(raw) != len(bloom) {
+		return bloom, false, fmt.Errorf("malformed stored bloom: %d bytes", len(raw))
+	}
+	copy(bloom[:], raw)
+
+	return bloom, true, nil
+}
+
+func hexToHash(s string) ([32]byte, error) {
+	var h [32]byte
+	decoded, err := hex.DecodeString(s)
+	if err != nil || len(decoded) != 32 {
+		return h, fmt.Errorf("malformed stored hash %q", s)
+	}
+	copy(h[:], decoded)
+	return h, nil
+}
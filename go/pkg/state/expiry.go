@@ -0,0 +1,188 @@
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// escrowExpiryIndexSchema indexes escrows by (state, expiry_block), the
+// pair ProcessExpiredEscrows filters on every sweep.
+const escrowExpiryIndexSchema = `
+CREATE INDEX IF NOT EXISTS idx_escrows_state_expiry ON escrows (state, expiry_block);
+`
+
+// expirySweepPollInterval is how often RunExpirySweeper checks whether the
+// chain has advanced far enough to run another sweep. It is independent of
+// tickBlocks: a short poll interval just means the sweeper notices a block
+// height change promptly, not that it sweeps every poll.
+const expirySweepPollInterval = 2 * time.Second
+
+// ExpiredEscrow is one escrow ProcessExpiredEscrows refunded, returned so
+// the caller can emit events (P2P gossip, metrics, audit log, etc).
+type ExpiredEscrow struct {
+	ID        [32]byte
+	Submitter [32]byte
+	Amount    uint64
+}
+
+// ProcessExpiredEscrows refunds every still-locked escrow whose expiry has
+// passed, patterned on Filecoin's market actor CronTick: in one SQL
+// transaction it finds every escrows row with state = EscrowLocked and
+// expiry_block <= currentBlock, credits the locked amount back to the
+// submitter's balance, and transitions the escrow to EscrowRefunded with
+// settled_block = currentBlock. This is what keeps the invariant "locked
+// escrow supply = sum of live locked amounts" holding after every block —
+// without it, expired bounties would stay locked until someone manually
+// refunded them.
+//
+// settlementTx is recorded on each refunded escrow the same way a
+// submitter-initiated RefundEscrow call would; RunExpirySweeper synthesizes
+// one per sweep since a timeout isn't triggered by any single transaction.
+func (sm *StateManager) ProcessExpiredEscrows(currentBlock uint64, settlementTx [32]byte) ([]ExpiredEscrow, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	tx, err := sm.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin expiry sweep: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT id, submitter, amount FROM escrows
+		WHERE state = ? AND expiry_block <= ?
+	`, EscrowLocked, currentBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired escrows: %w", err)
+	}
+
+	type candidate struct {
+		idHex, submitterHex string
+		amount              uint64
+	}
+	var candidates []candidate
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.idHex, &c.submitterHex, &c.amount); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan expired escrow: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("failed to iterate expired escrows: %w", err)
+	}
+	rows.Close()
+
+	settlementTxHex := fmt.Sprintf("%x", settlementTx)
+	now := time.Now().Unix()
+	expired := make([]ExpiredEscrow, 0, len(candidates))
+
+	for _, c := range candidates {
+		var id, submitter [32]byte
+		fmt.Sscanf(c.idHex, "%x", &id)
+		fmt.Sscanf(c.submitterHex, "%x", &submitter)
+
+		result, err := tx.Exec(`
+			UPDATE escrows
+			SET state = ?, settled_block = ?, settlement_tx = ?, updated_at = ?
+			WHERE id = ? AND state = ?
+		`, EscrowRefunded, currentBlock, settlementTxHex, now, c.idHex, EscrowLocked)
+		if err != nil {
+			return nil, fmt.Errorf("failed to refund escrow %x: %w", id[:8], err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			// Settled by something else between the SELECT and this
+			// UPDATE (e.g. a concurrent ReleaseEscrow/RefundEscrow);
+			// don't double-refund it.
+			continue
+		}
+
+		var priorBalance, nonce uint64
+		err = tx.QueryRow(`SELECT balance, nonce FROM accounts WHERE address = ?`, c.submitterHex).Scan(&priorBalance, &nonce)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to look up submitter %x: %w", submitter[:8], err)
+		}
+		newBalance := priorBalance + c.amount
+
+		if _, err := tx.Exec(`
+			INSERT INTO accounts (address, balance, nonce, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(address) DO UPDATE SET balance = excluded.balance, updated_at = excluded.updated_at
+		`, c.submitterHex, newBalance, nonce, now, now); err != nil {
+			return nil, fmt.Errorf("failed to refund submitter %x: %w", submitter[:8], err)
+		}
+		if err := recordAccountHistory(tx, submitter, currentBlock, newBalance, nonce); err != nil {
+			return nil, fmt.Errorf("failed to record account history for %x: %w", submitter[:8], err)
+		}
+
+		refundedBlock := currentBlock
+		if err := recordEscrowHistory(tx, id, currentBlock, EscrowRefunded, nil, &refundedBlock, &settlementTx); err != nil {
+			return nil, fmt.Errorf("failed to record escrow history for %x: %w", id[:8], err)
+		}
+
+		expired = append(expired, ExpiredEscrow{ID: id, Submitter: submitter, Amount: c.amount})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit expiry sweep: %w", err)
+	}
+
+	if len(expired) > 0 {
+		sm.log.WithFields(logger.Fields{
+			"count":         len(expired),
+			"current_block": currentBlock,
+		}).Info("Expired escrows auto-refunded")
+	}
+
+	return expired, nil
+}
+
+// RunExpirySweeper polls blockHeightFn every expirySweepPollInterval and
+// calls ProcessExpiredEscrows whenever the chain has advanced by at least
+// tickBlocks since the last sweep, until ctx is cancelled. Run it as its
+// own goroutine from node startup, alongside the other background loops
+// (e.g. Mempool.Start's cleanupLoop, Engine's blockProductionLoop).
+func (sm *StateManager) RunExpirySweeper(ctx context.Context, tickBlocks uint64, blockHeightFn func() uint64) {
+	ticker := time.NewTicker(expirySweepPollInterval)
+	defer ticker.Stop()
+
+	var lastSwept uint64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			height := blockHeightFn()
+			if height < lastSwept+tickBlocks {
+				continue
+			}
+			lastSwept = height
+
+			if _, err := sm.ProcessExpiredEscrows(height, expirySweepSettlementTx(height)); err != nil {
+				sm.log.WithError(err).Error("Failed to process expired escrows")
+			}
+		}
+	}
+}
+
+// expirySweepSettlementTx derives a deterministic settlement_tx for an
+// auto-refund sweep, since no user transaction triggers one.
+func expirySweepSettlementTx(blockHeight uint64) [32]byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], blockHeight)
+	return sha256.Sum256(append([]byte("expiry-sweep"), buf[:]...))
+}
@@ -0,0 +1,372 @@
+package state
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// accountHistorySchema and escrowHistorySchema back GetAccountAt/
+// GetEscrowAt/RewindTo/PruneHistoryBelow: a row per (key, block) records
+// that key's post-mutation state as of that block, so the node can answer
+// "balance of X at block N" and reorgs only have to touch the accounts
+// that actually changed instead of wiping the whole world (see
+// RewindTo). The primary key collapses several mutations of the same key
+// within one block into a single row — only the state at the end of a
+// block is ever queryable, matching GetAccountAt/GetEscrowAt's semantics.
+const accountHistorySchema = `
+CREATE TABLE IF NOT EXISTS account_history (
+	address TEXT    NOT NULL,
+	block   INTEGER NOT NULL,
+	balance INTEGER NOT NULL,
+	nonce   INTEGER NOT NULL,
+	PRIMARY KEY (address, block)
+);
+`
+
+const escrowHistorySchema = `
+CREATE TABLE IF NOT EXISTS escrow_history (
+	id            TEXT    NOT NULL,
+	block         INTEGER NOT NULL,
+	state         INTEGER NOT NULL,
+	recipient     TEXT,
+	settled_block INTEGER,
+	settlement_tx TEXT,
+	PRIMARY KEY (id, block)
+);
+`
+
+// recordAccountHistory stages address's post-mutation balance/nonce under
+// block, inside the same tx that wrote it to the accounts table. Callers
+// must hold sm.mu.
+func recordAccountHistory(tx *sql.Tx, address [32]byte, block uint64, balance, nonce uint64) error {
+	addressHex := fmt.Sprintf("%x", address)
+	_, err := tx.Exec(`
+		INSERT INTO account_history (address, block, balance, nonce)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(address, block) DO UPDATE SET balance = excluded.balance, nonce = excluded.nonce
+	`, addressHex, block, balance, nonce)
+	return err
+}
+
+// recordEscrowHistory stages id's post-mutation mutable fields (state,
+// recipient, settled_block, settlement_tx) under block, inside the same tx
+// that wrote them to the escrows table. The escrow's immutable fields
+// (submitter, amount, problem_hash, created_block, expiry_block) never
+// change after CreateEscrow, so they aren't duplicated into history — see
+// GetEscrowAt. Callers must hold sm.mu.
+func recordEscrowHistory(tx *sql.Tx, id [32]byte, block uint64, state uint8, recipient *[32]byte, settledBlock *uint64, settlementTx *[32]byte) error {
+	idHex := fmt.Sprintf("%x", id)
+
+	var recipientHex, settlementTxHex sql.NullString
+	if recipient != nil {
+		recipientHex = sql.NullString{String: fmt.Sprintf("%x", *recipient), Valid: true}
+	}
+	if settlementTx != nil {
+		settlementTxHex = sql.NullString{String: fmt.Sprintf("%x", *settlementTx), Valid: true}
+	}
+	var settledBlockVal sql.NullInt64
+	if settledBlock != nil {
+		settledBlockVal = sql.NullInt64{Int64: int64(*settledBlock), Valid: true}
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO escrow_history (id, block, state, recipient, settled_block, settlement_tx)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id, block) DO UPDATE SET
+			state = excluded.state,
+			recipient = excluded.recipient,
+			settled_block = excluded.settled_block,
+			settlement_tx = excluded.settlement_tx
+	`, idHex, block, state, recipientHex, settledBlockVal, settlementTxHex)
+	return err
+}
+
+// GetAccountAt returns address's balance and nonce as of block (its most
+// recent recorded state at or before that height), without needing to
+// replay or rewind anything. An address with no history at or before
+// block is reported as never having been touched yet, the same zero state
+// GetAccount returns for an address that doesn't exist.
+func (sm *StateManager) GetAccountAt(address [32]byte, block uint64) (*Account, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	addressHex := fmt.Sprintf("%x", address)
+
+	var balance, nonce uint64
+	err := sm.db.QueryRow(`
+		SELECT balance, nonce FROM account_history
+		WHERE address = ? AND block <= ?
+		ORDER BY block DESC LIMIT 1
+	`, addressHex, block).Scan(&balance, &nonce)
+
+	if err == sql.ErrNoRows {
+		return &Account{Address: address}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account history: %w", err)
+	}
+
+	return &Account{Address: address, Balance: balance, Nonce: nonce}, nil
+}
+
+// GetEscrowAt returns escrow id as it stood as of block: its immutable
+// fields (read from the live escrows row, since those never change after
+// CreateEscrow) combined with its most recent recorded mutable state
+// (state/recipient/settled_block/settlement_tx) at or before that height.
+// This assumes the escrow's live row still exists — if a RewindTo has
+// since deleted it (because it was created after the rewind target),
+// GetEscrowAt can no longer answer queries from before that deletion
+// either, since escrow_history alone doesn't carry the immutable fields
+// needed to reconstruct it.
+func (sm *StateManager) GetEscrowAt(id [32]byte, block uint64) (*Escrow, error) {
+	escrow, err := sm.GetEscrow(id)
+	if err != nil {
+		return nil, err
+	}
+	if escrow.CreatedBlock > block {
+		return nil, fmt.Errorf("escrow %x did not exist at block %d", id[:8], block)
+	}
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	idHex := fmt.Sprintf("%x", id)
+
+	var state uint8
+	var recipientHex, settlementTxHex sql.NullString
+	var settledBlock sql.NullInt64
+
+	err = sm.db.QueryRow(`
+		SELECT state, recipient, settled_block, settlement_tx FROM escrow_history
+		WHERE id = ? AND block <= ?
+		ORDER BY block DESC LIMIT 1
+	`, idHex, block).Scan(&state, &recipientHex, &settledBlock, &settlementTxHex)
+
+	if err == sql.ErrNoRows {
+		// No mutation recorded yet at or before block: still in its
+		// just-created state.
+		escrow.State = EscrowLocked
+		escrow.Recipient = nil
+		escrow.SettledBlock = nil
+		escrow.SettlementTx = nil
+		return escrow, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query escrow history: %w", err)
+	}
+
+	escrow.State = state
+	escrow.Recipient = nil
+	if recipientHex.Valid {
+		var recipient [32]byte
+		fmt.Sscanf(recipientHex.String, "%x", &recipient)
+		escrow.Recipient = &recipient
+	}
+	escrow.SettledBlock = nil
+	if settledBlock.Valid {
+		b := uint64(settledBlock.Int64)
+		escrow.SettledBlock = &b
+	}
+	escrow.SettlementTx = nil
+	if settlementTxHex.Valid {
+		var settlementTx [32]byte
+		fmt.Sscanf(settlementTxHex.String, "%x", &settlementTx)
+		escrow.SettlementTx = &settlementTx
+	}
+
+	return escrow, nil
+}
+
+// RewindTo restores accounts and escrows to their state as of block,
+// undoing every mutation recorded after it, then truncates history beyond
+// block. This is the non-destructive replacement for the old
+// ClearAccountState/ClearEscrowState + RestoreAccountSnapshot/
+// RestoreEscrowSnapshot reorg path: only keys that actually changed after
+// block are touched, everything else is left alone. Once it returns, the
+// caller (the block executor) replays the canonical chain forward from
+// block+1.
+func (sm *StateManager) RewindTo(block uint64) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	tx, err := sm.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin rewind: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := rewindAccounts(tx, block); err != nil {
+		return err
+	}
+	if err := rewindEscrows(tx, block); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rewind: %w", err)
+	}
+
+	sm.log.WithField("block", block).Info("State rewound via versioned history")
+	return nil
+}
+
+func rewindAccounts(tx *sql.Tx, block uint64) error {
+	rows, err := tx.Query(`SELECT DISTINCT address FROM account_history WHERE block > ?`, block)
+	if err != nil {
+		return fmt.Errorf("failed to find changed accounts: %w", err)
+	}
+	var addresses []string
+	for rows.Next() {
+		var addressHex string
+		if err := rows.Scan(&addressHex); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan changed account: %w", err)
+		}
+		addresses = append(addresses, addressHex)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("failed to iterate changed accounts: %w", err)
+	}
+	rows.Close()
+
+	now := time.Now().Unix()
+
+	for _, addressHex := range addresses {
+		var balance, nonce uint64
+		err := tx.QueryRow(`
+			SELECT balance, nonce FROM account_history
+			WHERE address = ? AND block <= ?
+			ORDER BY block DESC LIMIT 1
+		`, addressHex, block).Scan(&balance, &nonce)
+
+		if err == sql.ErrNoRows {
+			// Didn't exist yet as of block: it was created afterward.
+			if _, err := tx.Exec(`DELETE FROM accounts WHERE address = ?`, addressHex); err != nil {
+				return fmt.Errorf("failed to remove account created after rewind target: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up account history: %w", err)
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO accounts (address, balance, nonce, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(address) DO UPDATE SET balance = excluded.balance, nonce = excluded.nonce, updated_at = excluded.updated_at
+		`, addressHex, balance, nonce, now, now); err != nil {
+			return fmt.Errorf("failed to restore account: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM account_history WHERE block > ?`, block); err != nil {
+		return fmt.Errorf("failed to truncate account history: %w", err)
+	}
+
+	return nil
+}
+
+func rewindEscrows(tx *sql.Tx, block uint64) error {
+	rows, err := tx.Query(`SELECT DISTINCT id FROM escrow_history WHERE block > ?`, block)
+	if err != nil {
+		return fmt.Errorf("failed to find changed escrows: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var idHex string
+		if err := rows.Scan(&idHex); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan changed escrow: %w", err)
+		}
+		ids = append(ids, idHex)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("failed to iterate changed escrows: %w", err)
+	}
+	rows.Close()
+
+	now := time.Now().Unix()
+
+	for _, idHex := range ids {
+		var state uint8
+		var recipientHex, settlementTxHex sql.NullString
+		var settledBlock sql.NullInt64
+
+		err := tx.QueryRow(`
+			SELECT state, recipient, settled_block, settlement_tx FROM escrow_history
+			WHERE id = ? AND block <= ?
+			ORDER BY block DESC LIMIT 1
+		`, idHex, block).Scan(&state, &recipientHex, &settledBlock, &settlementTxHex)
+
+		if err == sql.ErrNoRows {
+			// Created after block: the escrow itself shouldn't exist yet.
+			if _, err := tx.Exec(`DELETE FROM escrows WHERE id = ?`, idHex); err != nil {
+				return fmt.Errorf("failed to remove escrow created after rewind target: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up escrow history: %w", err)
+		}
+
+		if _, err := tx.Exec(`
+			UPDATE escrows
+			SET state = ?, recipient = ?, settled_block = ?, settlement_tx = ?, updated_at = ?
+			WHERE id = ?
+		`, state, recipientHex, settledBlock, settlementTxHex, now, idHex); err != nil {
+			return fmt.Errorf("failed to restore escrow: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM escrow_history WHERE block > ?`, block); err != nil {
+		return fmt.Errorf("failed to truncate escrow history: %w", err)
+	}
+
+	return nil
+}
+
+// PruneHistoryBelow caps history growth by collapsing, per key, every
+// history row older than block down to just the one row needed to answer
+// GetAccountAt/GetEscrowAt/RewindTo queries at or after block — its most
+// recent row at or before block. Keys with no row at or before block (only
+// created after it) are left untouched, since there's nothing yet to
+// collapse.
+func (sm *StateManager) PruneHistoryBelow(block uint64) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	tx, err := sm.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin history prune: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		DELETE FROM account_history
+		WHERE block < (
+			SELECT MAX(h2.block) FROM account_history h2
+			WHERE h2.address = account_history.address AND h2.block <= ?
+		)
+	`, block); err != nil {
+		return fmt.Errorf("failed to prune account history: %w", err)
+	}
+
+	if _, err := tx.Exec(`
+		DELETE FROM escrow_history
+		WHERE block < (
+			SELECT MAX(h2.block) FROM escrow_history h2
+			WHERE h2.id = escrow_history.id AND h2.block <= ?
+		)
+	`, block); err != nil {
+		return fmt.Errorf("failed to prune escrow history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit history prune: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Signed IPFS pin manifest audit log: persists the quorum attestations
+// ipfs.PinWithQuorum produces (see pkg/ipfs/attestation.go) so a manifest
+// survives restarts and can be served back via GET /ipfs/manifest/{cid}.
+package state
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/ipfs"
+)
+
+const pinManifestSchema = `
+CREATE TABLE IF NOT EXISTS pin_manifests (
+	cid           TEXT    PRIMARY KEY,
+	size          INTEGER NOT NULL,
+	content_hash  TEXT    NOT NULL,
+	pinned_nodes  TEXT    NOT NULL,
+	quorum        TEXT    NOT NULL,
+	attestations  TEXT    NOT NULL,
+	signature_hex TEXT    NOT NULL DEFAULT '',
+	created_at    INTEGER NOT NULL
+)`
+
+// RecordPinManifest persists manifest, keyed by its CID. Re-recording the
+// same CID (e.g. a re-pin after node churn) overwrites the prior manifest.
+func (sm *StateManager) RecordPinManifest(manifest *ipfs.PinManifest) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	pinnedNodes, err := json.Marshal(manifest.PinnedNodes)
+	if err != nil {
+		return fmt.Errorf("failed to encode pinned nodes: %w", err)
+	}
+
+	attestations, err := json.Marshal(manifest.Attestations)
+	if err != nil {
+		return fmt.Errorf("failed to encode attestations: %w", err)
+	}
+
+	_, err = sm.db.Exec(`
+		INSERT INTO pin_manifests (cid, size, content_hash, pinned_nodes, quorum, attestations, signature_hex, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(cid) DO UPDATE SET
+			size = excluded.size,
+			content_hash = excluded.content_hash,
+			pinned_nodes = excluded.pinned_nodes,
+			quorum = excluded.quorum,
+			attestations = excluded.attestations,
+			signature_hex = excluded.signature_hex,
+			created_at = excluded.created_at
+	`, manifest.CID, manifest.Size, manifest.ContentHash, string(pinnedNodes), manifest.Quorum,
+		string(attestations), manifest.SignatureHex, manifest.Timestamp.Unix())
+
+	if err != nil {
+		return fmt.Errorf("failed to persist pin manifest: %w", err)
+	}
+
+	sm.log.WithFields(logger.Fields{
+		"cid":               manifest.CID,
+		"quorum":            manifest.Quorum,
+		"attestation_count": len(manifest.Attestations),
+	}).Info("Pin manifest recorded")
+
+	return nil
+}
+
+// GetPinManifest retrieves a persisted pin manifest by CID, for the
+// GET /ipfs/manifest/{cid} endpoint.
+func (sm *StateManager) GetPinManifest(cid string) (*ipfs.PinManifest, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var manifest ipfs.PinManifest
+	var pinnedNodes, attestations string
+	var createdAtUnix int64
+
+	err := sm.db.QueryRow(`
+		SELECT cid, size, content_hash, pinned_nodes, quorum, attestations, signature_hex, created_at
+		FROM pin_manifests
+		WHERE cid = ?
+	`, cid).Scan(
+		&manifest.CID,
+		&manifest.Size,
+		&manifest.ContentHash,
+		&pinnedNodes,
+		&manifest.Quorum,
+		&attestations,
+		&manifest.SignatureHex,
+		&createdAtUnix,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("pin manifest not found: %s", cid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pin manifest: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(pinnedNodes), &manifest.PinnedNodes); err != nil {
+		return nil, fmt.Errorf("failed to decode pinned nodes: %w", err)
+	}
+	if err := json.Unmarshal([]byte(attestations), &manifest.Attestations); err != nil {
+		return nil, fmt.Errorf("failed to decode attestations: %w", err)
+	}
+	manifest.Timestamp = time.Unix(createdAtUnix, 0)
+
+	return &manifest, nil
+}
@@ -0,0 +1,436 @@
+package state
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// journalEntry is one reversible change recorded against a BlockSession's
+// dirty account/escrow cache. RevertToSnapshot replays these in reverse to
+// undo a failed contract-like action without discarding the whole block.
+type journalEntry interface {
+	revert(s *BlockSession)
+}
+
+type balanceChange struct {
+	addr [32]byte
+	prev uint64
+}
+
+func (c *balanceChange) revert(s *BlockSession) {
+	s.accounts[c.addr].Balance = c.prev
+}
+
+type nonceChange struct {
+	addr [32]byte
+	prev uint64
+}
+
+func (c *nonceChange) revert(s *BlockSession) {
+	s.accounts[c.addr].Nonce = c.prev
+}
+
+type escrowStateChange struct {
+	id               [32]byte
+	prevState        uint8
+	prevRecipient    *[32]byte
+	prevSettledBlock *uint64
+	prevSettlementTx *[32]byte
+}
+
+func (c *escrowStateChange) revert(s *BlockSession) {
+	e := s.escrows[c.id]
+	e.State = c.prevState
+	e.Recipient = c.prevRecipient
+	e.SettledBlock = c.prevSettledBlock
+	e.SettlementTx = c.prevSettlementTx
+}
+
+type escrowCreate struct {
+	id [32]byte
+}
+
+func (c *escrowCreate) revert(s *BlockSession) {
+	delete(s.escrows, c.id)
+}
+
+// BlockSession is a block-scoped state transition, modeled on
+// go-ethereum's journal/stateObject pattern: every ApplyTx/CreateEscrow/
+// ReleaseEscrow/RefundEscrow mutates an in-memory dirty cache and appends a
+// journalEntry capable of undoing itself, so a failed contract-like action
+// can cheaply RevertToSnapshot instead of aborting the whole block. Nothing
+// touches SQLite until Commit, which flushes the cache in one sql.Tx and
+// then rolls it into the state trie (see StateManager.Commit) — replacing
+// the one-BEGIN/COMMIT-per-transfer overhead ApplyTransaction used to pay.
+//
+// Unlike StateManager's single sm.mu, a session only locks the specific
+// addresses/escrow IDs a call touches, so independent transfers within the
+// same block don't serialize behind each other.
+type BlockSession struct {
+	sm     *StateManager
+	height uint64
+
+	mu       sync.Mutex
+	accounts map[[32]byte]*Account
+	escrows  map[[32]byte]*Escrow
+	journal  []journalEntry
+	done     bool
+
+	keyLocksMu sync.Mutex
+	keyLocks   map[[32]byte]*sync.Mutex
+}
+
+// BeginBlock opens a new BlockSession for the given block height. Exactly
+// one of Commit or Discard must eventually be called on it.
+func (sm *StateManager) BeginBlock(height uint64) *BlockSession {
+	return &BlockSession{
+		sm:       sm,
+		height:   height,
+		accounts: make(map[[32]byte]*Account),
+		escrows:  make(map[[32]byte]*Escrow),
+		keyLocks: make(map[[32]byte]*sync.Mutex),
+	}
+}
+
+// lockKey locks the per-key mutex for an account address or escrow ID,
+// creating it on first use, and returns a function to unlock it.
+func (s *BlockSession) lockKey(key [32]byte) func() {
+	s.keyLocksMu.Lock()
+	l, ok := s.keyLocks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		s.keyLocks[key] = l
+	}
+	s.keyLocksMu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// loadAccount returns the session's cached copy of address, populating the
+// cache from the underlying StateManager on first access.
+func (s *BlockSession) loadAccount(address [32]byte) (*Account, error) {
+	s.mu.Lock()
+	if acct, ok := s.accounts[address]; ok {
+		s.mu.Unlock()
+		return acct, nil
+	}
+	s.mu.Unlock()
+
+	acct, err := s.sm.GetAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cached, ok := s.accounts[address]; ok {
+		return cached, nil
+	}
+	s.accounts[address] = acct
+	return acct, nil
+}
+
+// loadEscrow returns the session's cached copy of id, populating the cache
+// from the underlying StateManager on first access.
+func (s *BlockSession) loadEscrow(id [32]byte) (*Escrow, error) {
+	s.mu.Lock()
+	if escrow, ok := s.escrows[id]; ok {
+		s.mu.Unlock()
+		return escrow, nil
+	}
+	s.mu.Unlock()
+
+	escrow, err := s.sm.GetEscrow(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cached, ok := s.escrows[id]; ok {
+		return cached, nil
+	}
+	s.escrows[id] = escrow
+	return escrow, nil
+}
+
+// Snapshot returns a checkpoint identifier that can later be passed to
+// RevertToSnapshot to undo every change made since this call.
+func (s *BlockSession) Snapshot() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.journal)
+}
+
+// RevertToSnapshot undoes every journaled change made since the matching
+// Snapshot call, in reverse order.
+func (s *BlockSession) RevertToSnapshot(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.journal) - 1; i >= id; i-- {
+		s.journal[i].revert(s)
+	}
+	s.journal = s.journal[:id]
+}
+
+// ApplyTx deducts amount+fee from from and credits amount to to, the
+// session equivalent of StateManager.ApplyTransaction. Locking both
+// addresses (when they differ) makes concurrent ApplyTx calls that target
+// disjoint address pairs run without waiting on each other.
+func (s *BlockSession) ApplyTx(from, to [32]byte, amount, fee uint64) error {
+	unlockFrom := s.lockKey(from)
+	defer unlockFrom()
+	if to != from {
+		unlockTo := s.lockKey(to)
+		defer unlockTo()
+	}
+
+	sender, err := s.loadAccount(from)
+	if err != nil {
+		return fmt.Errorf("failed to load sender account: %w", err)
+	}
+
+	totalCost := amount + fee
+	if sender.Balance < totalCost {
+		return fmt.Errorf("insufficient balance: have %d, need %d", sender.Balance, totalCost)
+	}
+
+	recipient, err := s.loadAccount(to)
+	if err != nil {
+		return fmt.Errorf("failed to load recipient account: %w", err)
+	}
+
+	s.mu.Lock()
+	s.journal = append(s.journal,
+		&balanceChange{addr: from, prev: sender.Balance},
+		&nonceChange{addr: from, prev: sender.Nonce},
+	)
+	s.mu.Unlock()
+	sender.Balance -= totalCost
+	sender.Nonce++
+
+	s.mu.Lock()
+	s.journal = append(s.journal, &balanceChange{addr: to, prev: recipient.Balance})
+	s.mu.Unlock()
+	recipient.Balance += amount
+
+	return nil
+}
+
+// CreateEscrow stages a new escrow for this block. As with
+// StateManager.CreateEscrow, a duplicate ID is only caught once Commit
+// writes it to SQLite (the id column rejects the conflicting insert); this
+// only guards against creating the same ID twice within one session.
+func (s *BlockSession) CreateEscrow(escrow *Escrow) error {
+	unlock := s.lockKey(escrow.ID)
+	defer unlock()
+
+	s.mu.Lock()
+	if _, exists := s.escrows[escrow.ID]; exists {
+		s.mu.Unlock()
+		return fmt.Errorf("escrow already staged in this block: %x", escrow.ID[:8])
+	}
+	s.mu.Unlock()
+
+	now := time.Now()
+	created := &Escrow{
+		ID:           escrow.ID,
+		Submitter:    escrow.Submitter,
+		Amount:       escrow.Amount,
+		ProblemHash:  escrow.ProblemHash,
+		CreatedBlock: escrow.CreatedBlock,
+		ExpiryBlock:  escrow.ExpiryBlock,
+		State:        EscrowLocked,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	s.mu.Lock()
+	s.escrows[escrow.ID] = created
+	s.journal = append(s.journal, &escrowCreate{id: escrow.ID})
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ReleaseEscrow stages releasing escrow id to recipient, the session
+// equivalent of StateManager.ReleaseEscrow.
+func (s *BlockSession) ReleaseEscrow(id [32]byte, recipient [32]byte, settledBlock uint64, settlementTx [32]byte) error {
+	unlock := s.lockKey(id)
+	defer unlock()
+
+	escrow, err := s.loadEscrow(id)
+	if err != nil {
+		return fmt.Errorf("failed to load escrow: %w", err)
+	}
+	if escrow.State != EscrowLocked {
+		return fmt.Errorf("escrow not found or already settled: %x", id[:8])
+	}
+
+	s.mu.Lock()
+	s.journal = append(s.journal, &escrowStateChange{
+		id:               id,
+		prevState:        escrow.State,
+		prevRecipient:    escrow.Recipient,
+		prevSettledBlock: escrow.SettledBlock,
+		prevSettlementTx: escrow.SettlementTx,
+	})
+	s.mu.Unlock()
+
+	escrow.State = EscrowReleased
+	escrow.Recipient = &recipient
+	escrow.SettledBlock = &settledBlock
+	escrow.SettlementTx = &settlementTx
+
+	return nil
+}
+
+// RefundEscrow stages refunding escrow id to its submitter, the session
+// equivalent of StateManager.RefundEscrow.
+func (s *BlockSession) RefundEscrow(id [32]byte, settledBlock uint64, settlementTx [32]byte) error {
+	unlock := s.lockKey(id)
+	defer unlock()
+
+	escrow, err := s.loadEscrow(id)
+	if err != nil {
+		return fmt.Errorf("failed to load escrow: %w", err)
+	}
+	if escrow.State != EscrowLocked {
+		return fmt.Errorf("escrow not found or already settled: %x", id[:8])
+	}
+
+	s.mu.Lock()
+	s.journal = append(s.journal, &escrowStateChange{
+		id:               id,
+		prevState:        escrow.State,
+		prevRecipient:    escrow.Recipient,
+		prevSettledBlock: escrow.SettledBlock,
+		prevSettlementTx: escrow.SettlementTx,
+	})
+	s.mu.Unlock()
+
+	escrow.State = EscrowRefunded
+	escrow.SettledBlock = &settledBlock
+	escrow.SettlementTx = &settlementTx
+
+	return nil
+}
+
+// Discard abandons every staged change in the session without touching
+// SQLite. Call this instead of Commit when block execution fails outright.
+func (s *BlockSession) Discard() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+	s.journal = nil
+	s.accounts = nil
+	s.escrows = nil
+}
+
+// Commit flushes every account and escrow this session touched to SQLite
+// in a single sql.Tx, then hands off to StateManager.Commit to rebuild the
+// state trie and record this block's state root. It returns that root.
+func (s *BlockSession) Commit() ([32]byte, error) {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return trieHash{}, fmt.Errorf("block session already committed or discarded")
+	}
+	s.done = true
+
+	accounts := make([]*Account, 0, len(s.accounts))
+	for _, acct := range s.accounts {
+		accounts = append(accounts, acct)
+	}
+	escrows := make([]*Escrow, 0, len(s.escrows))
+	for _, escrow := range s.escrows {
+		escrows = append(escrows, escrow)
+	}
+	s.mu.Unlock()
+
+	sm := s.sm
+
+	err := func() error {
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
+
+		tx, err := sm.db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin block commit: %w", err)
+		}
+		defer tx.Rollback()
+
+		now := time.Now().Unix()
+
+		for _, acct := range accounts {
+			addressHex := fmt.Sprintf("%x", acct.Address)
+			if _, err := tx.Exec(`
+				INSERT INTO accounts (address, balance, nonce, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?)
+				ON CONFLICT(address) DO UPDATE SET balance = excluded.balance, nonce = excluded.nonce, updated_at = excluded.updated_at
+			`, addressHex, acct.Balance, acct.Nonce, now, now); err != nil {
+				return fmt.Errorf("failed to persist account %x: %w", acct.Address[:8], err)
+			}
+
+			if err := recordAccountHistory(tx, acct.Address, s.height, acct.Balance, acct.Nonce); err != nil {
+				return fmt.Errorf("failed to record account history for %x: %w", acct.Address[:8], err)
+			}
+		}
+
+		for _, escrow := range escrows {
+			idHex := fmt.Sprintf("%x", escrow.ID)
+			submitterHex := fmt.Sprintf("%x", escrow.Submitter)
+			problemHashHex := fmt.Sprintf("%x", escrow.ProblemHash)
+
+			var recipientHex, settlementTxHex sql.NullString
+			if escrow.Recipient != nil {
+				recipientHex = sql.NullString{String: fmt.Sprintf("%x", *escrow.Recipient), Valid: true}
+			}
+			if escrow.SettlementTx != nil {
+				settlementTxHex = sql.NullString{String: fmt.Sprintf("%x", *escrow.SettlementTx), Valid: true}
+			}
+			var settledBlock sql.NullInt64
+			if escrow.SettledBlock != nil {
+				settledBlock = sql.NullInt64{Int64: int64(*escrow.SettledBlock), Valid: true}
+			}
+
+			if _, err := tx.Exec(`
+				INSERT INTO escrows (id, submitter, amount, problem_hash, created_block, expiry_block,
+									 state, recipient, settled_block, settlement_tx, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT(id) DO UPDATE SET
+					state = excluded.state,
+					recipient = excluded.recipient,
+					settled_block = excluded.settled_block,
+					settlement_tx = excluded.settlement_tx,
+					updated_at = excluded.updated_at
+			`, idHex, submitterHex, escrow.Amount, problemHashHex, escrow.CreatedBlock, escrow.ExpiryBlock,
+				escrow.State, recipientHex, settledBlock, settlementTxHex, now, now); err != nil {
+				return fmt.Errorf("failed to persist escrow %x: %w", escrow.ID[:8], err)
+			}
+
+			if err := recordEscrowHistory(tx, escrow.ID, s.height, escrow.State, escrow.Recipient, escrow.SettledBlock, escrow.SettlementTx); err != nil {
+				return fmt.Errorf("failed to record escrow history for %x: %w", escrow.ID[:8], err)
+			}
+		}
+
+		return tx.Commit()
+	}()
+	if err != nil {
+		return trieHash{}, fmt.Errorf("failed to commit block session: %w", err)
+	}
+
+	sm.log.WithFields(logger.Fields{
+		"block_height": s.height,
+		"accounts":     len(accounts),
+		"escrows":      len(escrows),
+	}).Info("Block session committed")
+
+	return sm.Commit(s.height)
+}
@@ -0,0 +1,153 @@
+package state
+
+import "testing"
+
+func TestBlockSessionApplyTxAndCommit(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	from := [32]byte{1}
+	to := [32]byte{2}
+	if err := sm.CreateAccount(from, 1000); err != nil {
+		t.Fatalf("failed to create sender account: %v", err)
+	}
+	if err := sm.CreateAccount(to, 0); err != nil {
+		t.Fatalf("failed to create recipient account: %v", err)
+	}
+
+	session := sm.BeginBlock(1)
+	if err := session.ApplyTx(from, to, 100, 1); err != nil {
+		t.Fatalf("ApplyTx failed: %v", err)
+	}
+
+	if _, err := session.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	sender, err := sm.GetAccount(from)
+	if err != nil {
+		t.Fatalf("failed to fetch sender: %v", err)
+	}
+	if sender.Balance != 899 {
+		t.Fatalf("sender balance = %d, want 899", sender.Balance)
+	}
+	if sender.Nonce != 1 {
+		t.Fatalf("sender nonce = %d, want 1", sender.Nonce)
+	}
+
+	recipient, err := sm.GetAccount(to)
+	if err != nil {
+		t.Fatalf("failed to fetch recipient: %v", err)
+	}
+	if recipient.Balance != 100 {
+		t.Fatalf("recipient balance = %d, want 100", recipient.Balance)
+	}
+}
+
+func TestBlockSessionInsufficientBalanceLeavesStateUntouched(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	from := [32]byte{3}
+	to := [32]byte{4}
+	if err := sm.CreateAccount(from, 10); err != nil {
+		t.Fatalf("failed to create sender account: %v", err)
+	}
+	if err := sm.CreateAccount(to, 0); err != nil {
+		t.Fatalf("failed to create recipient account: %v", err)
+	}
+
+	session := sm.BeginBlock(1)
+	if err := session.ApplyTx(from, to, 100, 1); err == nil {
+		t.Fatal("expected ApplyTx to reject a transfer exceeding the sender's balance")
+	}
+	session.Discard()
+
+	sender, err := sm.GetAccount(from)
+	if err != nil {
+		t.Fatalf("failed to fetch sender: %v", err)
+	}
+	if sender.Balance != 10 {
+		t.Fatalf("sender balance = %d, want unchanged 10", sender.Balance)
+	}
+}
+
+func TestBlockSessionRevertToSnapshot(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	from := [32]byte{5}
+	to := [32]byte{6}
+	if err := sm.CreateAccount(from, 1000); err != nil {
+		t.Fatalf("failed to create sender account: %v", err)
+	}
+	if err := sm.CreateAccount(to, 0); err != nil {
+		t.Fatalf("failed to create recipient account: %v", err)
+	}
+
+	session := sm.BeginBlock(1)
+	snap := session.Snapshot()
+
+	if err := session.ApplyTx(from, to, 100, 1); err != nil {
+		t.Fatalf("ApplyTx failed: %v", err)
+	}
+	session.RevertToSnapshot(snap)
+
+	if _, err := session.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	sender, err := sm.GetAccount(from)
+	if err != nil {
+		t.Fatalf("failed to fetch sender: %v", err)
+	}
+	if sender.Balance != 1000 {
+		t.Fatalf("sender balance = %d, want unchanged 1000 after revert", sender.Balance)
+	}
+}
+
+func TestBlockSessionReleaseEscrow(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	escrow := &Escrow{
+		ID:           [32]byte{7},
+		Submitter:    [32]byte{8},
+		Amount:       500,
+		ProblemHash:  [32]byte{9},
+		CreatedBlock: 1,
+		ExpiryBlock:  100,
+	}
+	if err := sm.CreateEscrow(escrow); err != nil {
+		t.Fatalf("failed to create escrow: %v", err)
+	}
+
+	session := sm.BeginBlock(2)
+	recipient := [32]byte{10}
+	settlementTx := [32]byte{11}
+	if err := session.ReleaseEscrow(escrow.ID, recipient, 2, settlementTx); err != nil {
+		t.Fatalf("ReleaseEscrow failed: %v", err)
+	}
+	if _, err := session.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	got, err := sm.GetEscrow(escrow.ID)
+	if err != nil {
+		t.Fatalf("failed to fetch escrow: %v", err)
+	}
+	if got.State != EscrowReleased {
+		t.Fatalf("escrow state = %d, want EscrowReleased", got.State)
+	}
+	if got.Recipient == nil || *got.Recipient != recipient {
+		t.Fatalf("escrow recipient = %v, want %x", got.Recipient, recipient)
+	}
+}
+
+func TestBlockSessionCommitTwiceFails(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	session := sm.BeginBlock(1)
+	if _, err := session.Commit(); err != nil {
+		t.Fatalf("first Commit failed: %v", err)
+	}
+	if _, err := session.Commit(); err == nil {
+		t.Fatal("expected a second Commit on the same session to fail")
+	}
+}
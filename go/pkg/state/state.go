@@ -3,11 +3,14 @@ package state
 
 import (
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/receipts"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/store/blockstore"
 	_ "modernc.org/sqlite" // Pure-Go SQLite driver (no CGO required)
 )
 
@@ -22,18 +25,18 @@ type Account struct {
 
 // Escrow represents a bounty escrow
 type Escrow struct {
-	ID            [32]byte   // Deterministic escrow ID
-	Submitter     [32]byte   // Submitter address
-	Amount        uint64     // Locked amount (wei)
-	ProblemHash   [32]byte   // Problem hash
-	CreatedBlock  uint64     // Creation block
-	ExpiryBlock   uint64     // Expiry block
-	State         uint8      // 0=Locked, 1=Released, 2=Refunded
-	Recipient     *[32]byte  // Solver address (nil if unreleased)
-	SettledBlock  *uint64    // Settlement block (nil if unsettled)
-	SettlementTx  *[32]byte  // Settlement transaction hash
-	CreatedAt     time.Time  // Creation timestamp
-	UpdatedAt     time.Time  // Last update timestamp
+	ID           [32]byte  // Deterministic escrow ID
+	Submitter    [32]byte  // Submitter address
+	Amount       uint64    // Locked amount (wei)
+	ProblemHash  [32]byte  // Problem hash
+	CreatedBlock uint64    // Creation block
+	ExpiryBlock  uint64    // Expiry block
+	State        uint8     // 0=Locked, 1=Released, 2=Refunded
+	Recipient    *[32]byte // Solver address (nil if unreleased)
+	SettledBlock *uint64   // Settlement block (nil if unsettled)
+	SettlementTx *[32]byte // Settlement transaction hash
+	CreatedAt    time.Time // Creation timestamp
+	UpdatedAt    time.Time // Last update timestamp
 }
 
 // EscrowState constants
@@ -43,11 +46,54 @@ const (
 	EscrowRefunded uint8 = 2
 )
 
+// accountEscrowSchema creates the accounts and escrows tables every other
+// method in this file (GetAccount, CreateEscrow, GetAccountSnapshot, ...)
+// assumes already exist. It has to run before escrowExpiryIndexSchema,
+// which indexes the escrows table.
+const accountEscrowSchema = `
+CREATE TABLE IF NOT EXISTS accounts (
+	address    TEXT PRIMARY KEY,
+	balance    INTEGER NOT NULL,
+	nonce      INTEGER NOT NULL,
+	created_at INTEGER NOT NULL,
+	updated_at INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS escrows (
+	id             TEXT PRIMARY KEY,
+	submitter      TEXT NOT NULL,
+	amount         INTEGER NOT NULL,
+	problem_hash   TEXT NOT NULL,
+	created_block  INTEGER NOT NULL,
+	expiry_block   INTEGER NOT NULL,
+	state          INTEGER NOT NULL,
+	recipient      TEXT,
+	settled_block  INTEGER,
+	settlement_tx  TEXT,
+	created_at     INTEGER NOT NULL,
+	updated_at     INTEGER NOT NULL
+);
+`
+
+// decodeHex32 decodes a hex-encoded 32-byte column (address, escrow ID,
+// problem hash, ...) back into its fixed-size form. fmt.Sscanf("%x", ...)
+// can't scan into a *[32]byte — it only supports *[]byte — so it silently
+// fails and leaves the array zeroed instead of returning a usable error;
+// this decodes the bytes directly and copies them in.
+func decodeHex32(s string) [32]byte {
+	var out [32]byte
+	if b, err := hex.DecodeString(s); err == nil {
+		copy(out[:], b)
+	}
+	return out
+}
+
 // StateManager manages account and escrow state with SQL persistence
 type StateManager struct {
-	db  *sql.DB
-	log *logger.Logger
-	mu  sync.RWMutex
+	db       *sql.DB
+	blocks   *blockstore.Store
+	receipts *receipts.Store
+	log      *logger.Logger
+	mu       sync.RWMutex
 }
 
 // NewStateManager creates a new state manager
@@ -69,9 +115,68 @@ func NewStateManager(dbPath string, log *logger.Logger) (*StateManager, error) {
 		log.WithError(err).Warn("Failed to enable foreign keys (continuing without)")
 	}
 
+	if _, err := db.Exec(accountEscrowSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize account/escrow schema: %w", err)
+	}
+
+	if _, err := db.Exec(peerBanlistSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize peer banlist schema: %w", err)
+	}
+
+	if _, err := db.Exec(slashingEvidenceSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize slashing evidence schema: %w", err)
+	}
+
+	if _, err := db.Exec(depositSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize deposit schema: %w", err)
+	}
+
+	if _, err := db.Exec(stateTrieSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize state trie schema: %w", err)
+	}
+
+	if _, err := db.Exec(escrowExpiryIndexSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize escrow expiry index: %w", err)
+	}
+
+	if _, err := db.Exec(accountHistorySchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize account history schema: %w", err)
+	}
+
+	if _, err := db.Exec(escrowHistorySchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize escrow history schema: %w", err)
+	}
+
+	if _, err := db.Exec(pinManifestSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize pin manifest schema: %w", err)
+	}
+
+	blocks, err := blockstore.NewStore(db, log)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize blockstore: %w", err)
+	}
+
+	receiptsStore, err := receipts.NewStore(db, log)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize receipts store: %w", err)
+	}
+
 	sm := &StateManager{
-		db:  db,
-		log: log,
+		db:       db,
+		blocks:   blocks,
+		receipts: receiptsStore,
+		log:      log,
 	}
 
 	log.WithField("db_path", dbPath).Info("State manager initialized")
@@ -84,6 +189,12 @@ func (sm *StateManager) Close() error {
 	return sm.db.Close()
 }
 
+// Ping checks the underlying database connection is still alive, for
+// health checks (see api.handleHealth and the metrics exporter's /healthz).
+func (sm *StateManager) Ping() error {
+	return sm.db.Ping()
+}
+
 // ==================== ACCOUNT STATE ====================
 
 // GetAccount retrieves an account by address
@@ -101,7 +212,7 @@ func (sm *StateManager) GetAccount(address [32]byte) (*Account, error) {
 		FROM accounts
 		WHERE address = ?
 	`, addressHex).Scan(
-		new(string),       // address (discard, we already have it)
+		new(string), // address (discard, we already have it)
 		&account.Balance,
 		&account.Nonce,
 		&createdAtUnix,
@@ -204,7 +315,42 @@ func (sm *StateManager) UpdateAccount(address [32]byte, balance uint64, nonce ui
 	return nil
 }
 
-// ApplyTransaction applies a transaction to account state
+// CreditBalance adds amount to address's balance, creating the account
+// with that balance if it doesn't yet exist. Used for crediting fee sinks
+// (validator, burn, treasury — see consensus/fees.SplitFee) and other
+// additive-only balance changes where the caller doesn't already hold the
+// account's current balance the way ApplyTransaction's sender/recipient
+// do.
+func (sm *StateManager) CreditBalance(address [32]byte, amount uint64) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	addressHex := fmt.Sprintf("%x", address)
+	now := time.Now().Unix()
+
+	_, err := sm.db.Exec(`
+		INSERT INTO accounts (address, balance, nonce, created_at, updated_at)
+		VALUES (?, ?, 0, ?, ?)
+		ON CONFLICT(address) DO UPDATE SET balance = balance + excluded.balance, updated_at = excluded.updated_at
+	`, addressHex, amount, now, now)
+
+	if err != nil {
+		return fmt.Errorf("failed to credit balance: %w", err)
+	}
+
+	sm.log.WithFields(logger.Fields{
+		"address": fmt.Sprintf("%x", address[:8]),
+		"amount":  amount,
+	}).Debug("Balance credited")
+
+	return nil
+}
+
+// ApplyTransaction applies a single transaction to account state directly,
+// in its own sql.Tx. Block production goes through BlockSession instead
+// (see BeginBlock), which batches a whole block's transfers into one
+// sql.Tx and a single trie Commit; this stays around for one-off transfers
+// outside of block execution.
 //
 // This performs the actual state transition:
 // - Deduct amount + fee from sender
@@ -342,34 +488,6 @@ func (sm *StateManager) updateAccountTx(tx *sql.Tx, address [32]byte, balance, n
 
 // ==================== STATE ROLLBACK & REPLAY ====================
 
-// ClearAccountState clears all account state (for chain reorganization)
-func (sm *StateManager) ClearAccountState() error {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-
-	_, err := sm.db.Exec("DELETE FROM accounts")
-	if err != nil {
-		return fmt.Errorf("failed to clear accounts: %w", err)
-	}
-
-	sm.log.Warn("Account state cleared for chain reorganization")
-	return nil
-}
-
-// ClearEscrowState clears all escrow state (for chain reorganization)
-func (sm *StateManager) ClearEscrowState() error {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-
-	_, err := sm.db.Exec("DELETE FROM escrows")
-	if err != nil {
-		return fmt.Errorf("failed to clear escrows: %w", err)
-	}
-
-	sm.log.Warn("Escrow state cleared for chain reorganization")
-	return nil
-}
-
 // GetAccountSnapshot returns a snapshot of all accounts
 func (sm *StateManager) GetAccountSnapshot() (map[[32]byte]*Account, error) {
 	sm.mu.RLock()
@@ -395,13 +513,11 @@ func (sm *StateManager) GetAccountSnapshot() (map[[32]byte]*Account, error) {
 			return nil, fmt.Errorf("failed to scan account: %w", err)
 		}
 
-		var address [32]byte
-		fmt.Sscanf(addressHex, "%x", &address)
-		account.Address = address
+		account.Address = decodeHex32(addressHex)
 		account.CreatedAt = time.Unix(createdAtUnix, 0)
 		account.UpdatedAt = time.Unix(updatedAtUnix, 0)
 
-		snapshot[address] = &account
+		snapshot[account.Address] = &account
 	}
 
 	return snapshot, nil
@@ -434,6 +550,109 @@ func (sm *StateManager) RestoreAccountSnapshot(snapshot map[[32]byte]*Account) e
 	return nil
 }
 
+// GetEscrowSnapshot returns a snapshot of all escrows, the escrow half of
+// the state pair consensus.Engine caches per block (see GetAccountSnapshot).
+func (sm *StateManager) GetEscrowSnapshot() (map[[32]byte]*Escrow, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	snapshot := make(map[[32]byte]*Escrow)
+
+	rows, err := sm.db.Query(`
+		SELECT id, submitter, amount, problem_hash, created_block, expiry_block,
+			   state, recipient, settled_block, settlement_tx, created_at, updated_at
+		FROM escrows
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query escrows: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var idHex string
+		var submitterHex, problemHashHex, recipientHex, settlementTxHex sql.NullString
+		var settledBlock sql.NullInt64
+		var escrow Escrow
+		var createdAtUnix, updatedAtUnix int64
+
+		if err := rows.Scan(&idHex, &submitterHex, &escrow.Amount, &problemHashHex, &escrow.CreatedBlock,
+			&escrow.ExpiryBlock, &escrow.State, &recipientHex, &settledBlock, &settlementTxHex,
+			&createdAtUnix, &updatedAtUnix); err != nil {
+			return nil, fmt.Errorf("failed to scan escrow: %w", err)
+		}
+
+		escrow.ID = decodeHex32(idHex)
+
+		if submitterHex.Valid {
+			escrow.Submitter = decodeHex32(submitterHex.String)
+		}
+		if problemHashHex.Valid {
+			escrow.ProblemHash = decodeHex32(problemHashHex.String)
+		}
+		if recipientHex.Valid {
+			recipient := decodeHex32(recipientHex.String)
+			escrow.Recipient = &recipient
+		}
+		if settledBlock.Valid {
+			block := uint64(settledBlock.Int64)
+			escrow.SettledBlock = &block
+		}
+		if settlementTxHex.Valid {
+			settlementTx := decodeHex32(settlementTxHex.String)
+			escrow.SettlementTx = &settlementTx
+		}
+		escrow.CreatedAt = time.Unix(createdAtUnix, 0)
+		escrow.UpdatedAt = time.Unix(updatedAtUnix, 0)
+
+		snapshot[escrow.ID] = &escrow
+	}
+
+	return snapshot, rows.Err()
+}
+
+// RestoreEscrowSnapshot restores escrows from a snapshot taken by
+// GetEscrowSnapshot.
+func (sm *StateManager) RestoreEscrowSnapshot(snapshot map[[32]byte]*Escrow) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if _, err := sm.db.Exec("DELETE FROM escrows"); err != nil {
+		return fmt.Errorf("failed to clear escrows: %w", err)
+	}
+
+	for _, escrow := range snapshot {
+		idHex := fmt.Sprintf("%x", escrow.ID)
+		submitterHex := fmt.Sprintf("%x", escrow.Submitter)
+		problemHashHex := fmt.Sprintf("%x", escrow.ProblemHash)
+
+		var recipientHex, settlementTxHex sql.NullString
+		if escrow.Recipient != nil {
+			recipientHex = sql.NullString{String: fmt.Sprintf("%x", *escrow.Recipient), Valid: true}
+		}
+		if escrow.SettlementTx != nil {
+			settlementTxHex = sql.NullString{String: fmt.Sprintf("%x", *escrow.SettlementTx), Valid: true}
+		}
+		var settledBlock sql.NullInt64
+		if escrow.SettledBlock != nil {
+			settledBlock = sql.NullInt64{Int64: int64(*escrow.SettledBlock), Valid: true}
+		}
+
+		_, err := sm.db.Exec(`
+			INSERT INTO escrows (id, submitter, amount, problem_hash, created_block, expiry_block,
+								 state, recipient, settled_block, settlement_tx, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, idHex, submitterHex, escrow.Amount, problemHashHex, escrow.CreatedBlock, escrow.ExpiryBlock,
+			escrow.State, recipientHex, settledBlock, settlementTxHex, escrow.CreatedAt.Unix(), escrow.UpdatedAt.Unix())
+
+		if err != nil {
+			return fmt.Errorf("failed to restore escrow: %w", err)
+		}
+	}
+
+	sm.log.WithField("escrows_restored", len(snapshot)).Info("Escrow snapshot restored")
+	return nil
+}
+
 // ==================== ESCROW STATE ====================
 
 // GetEscrow retrieves an escrow by ID
@@ -480,22 +699,17 @@ func (sm *StateManager) GetEscrow(id [32]byte) (*Escrow, error) {
 
 	// Parse submitter
 	if submitterHex.Valid {
-		var submitter [32]byte
-		fmt.Sscanf(submitterHex.String, "%x", &submitter)
-		escrow.Submitter = submitter
+		escrow.Submitter = decodeHex32(submitterHex.String)
 	}
 
 	// Parse problem hash
 	if problemHashHex.Valid {
-		var problemHash [32]byte
-		fmt.Sscanf(problemHashHex.String, "%x", &problemHash)
-		escrow.ProblemHash = problemHash
+		escrow.ProblemHash = decodeHex32(problemHashHex.String)
 	}
 
 	// Parse optional recipient
 	if recipientHex.Valid {
-		var recipient [32]byte
-		fmt.Sscanf(recipientHex.String, "%x", &recipient)
+		recipient := decodeHex32(recipientHex.String)
 		escrow.Recipient = &recipient
 	}
 
@@ -507,8 +721,7 @@ func (sm *StateManager) GetEscrow(id [32]byte) (*Escrow, error) {
 
 	// Parse optional settlement tx
 	if settlementTxHex.Valid {
-		var settlementTx [32]byte
-		fmt.Sscanf(settlementTxHex.String, "%x", &settlementTx)
+		settlementTx := decodeHex32(settlementTxHex.String)
 		escrow.SettlementTx = &settlementTx
 	}
 
@@ -621,3 +834,201 @@ func (sm *StateManager) RefundEscrow(id [32]byte, settledBlock uint64, settlemen
 
 	return nil
 }
+
+// ==================== PEER BANLIST ====================
+
+const peerBanlistSchema = `
+CREATE TABLE IF NOT EXISTS peer_banlist (
+	peer_id   TEXT    PRIMARY KEY,
+	reason    TEXT    NOT NULL,
+	banned_at INTEGER NOT NULL
+)`
+
+// BanPeer persists peerID to the banlist, so a p2p.Scorer ban survives
+// process restarts. Re-banning an already-banned peer refreshes the reason
+// and timestamp.
+func (sm *StateManager) BanPeer(peerID string, reason string) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	_, err := sm.db.Exec(`
+		INSERT INTO peer_banlist (peer_id, reason, banned_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(peer_id) DO UPDATE SET reason = excluded.reason, banned_at = excluded.banned_at
+	`, peerID, reason, time.Now().Unix())
+
+	if err != nil {
+		return fmt.Errorf("failed to persist peer ban: %w", err)
+	}
+
+	sm.log.WithFields(logger.Fields{
+		"peer_id": peerID,
+		"reason":  reason,
+	}).Warn("Peer banned")
+
+	return nil
+}
+
+// IsPeerBanned reports whether peerID is on the persistent banlist.
+func (sm *StateManager) IsPeerBanned(peerID string) (bool, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var exists int
+	err := sm.db.QueryRow(`SELECT 1 FROM peer_banlist WHERE peer_id = ?`, peerID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to query peer banlist: %w", err)
+	}
+
+	return true, nil
+}
+
+// ==================== SLASHING EVIDENCE ====================
+
+const slashingEvidenceSchema = `
+CREATE TABLE IF NOT EXISTS slashing_evidence (
+	hash       TEXT    PRIMARY KEY,
+	offense    INTEGER NOT NULL,
+	height     INTEGER NOT NULL,
+	payload    BLOB    NOT NULL,
+	processed  INTEGER NOT NULL DEFAULT 0,
+	created_at INTEGER NOT NULL
+)`
+
+// PersistedEvidence is one row of the slashing_evidence table, handed back
+// to consensus.EvidencePool.LoadPersisted at startup.
+type PersistedEvidence struct {
+	Hash    [32]byte
+	Offense uint8
+	Height  uint64
+	Payload []byte
+}
+
+// PutEvidence persists an encoded evidence envelope (see
+// consensus.EvidencePool) under hash, so it survives a restart if the node
+// crashes before confirming it was handed to the slashing manager.
+// Re-submitting an already-stored hash is a no-op.
+func (sm *StateManager) PutEvidence(hash [32]byte, offense uint8, height uint64, payload []byte) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	_, err := sm.db.Exec(`
+		INSERT INTO slashing_evidence (hash, offense, height, payload, processed, created_at)
+		VALUES (?, ?, ?, ?, 0, ?)
+		ON CONFLICT(hash) DO NOTHING
+	`, fmt.Sprintf("%x", hash), offense, height, payload, time.Now().Unix())
+
+	if err != nil {
+		return fmt.Errorf("failed to persist slashing evidence: %w", err)
+	}
+
+	return nil
+}
+
+// MarkEvidenceProcessed flags hash as handed to SlashingManager.Slash, so
+// it isn't replayed on the next restart.
+func (sm *StateManager) MarkEvidenceProcessed(hash [32]byte) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	_, err := sm.db.Exec(`
+		UPDATE slashing_evidence SET processed = 1 WHERE hash = ?
+	`, fmt.Sprintf("%x", hash))
+
+	if err != nil {
+		return fmt.Errorf("failed to mark slashing evidence processed: %w", err)
+	}
+
+	return nil
+}
+
+// UnprocessedEvidence returns every piece of evidence that was persisted
+// but never confirmed processed, for consensus.EvidencePool to replay at
+// startup.
+func (sm *StateManager) UnprocessedEvidence() ([]PersistedEvidence, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	rows, err := sm.db.Query(`
+		SELECT hash, offense, height, payload FROM slashing_evidence WHERE processed = 0
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unprocessed slashing evidence: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []PersistedEvidence
+	for rows.Next() {
+		var hashHex string
+		var pe PersistedEvidence
+		if err := rows.Scan(&hashHex, &pe.Offense, &pe.Height, &pe.Payload); err != nil {
+			return nil, fmt.Errorf("failed to scan slashing evidence row: %w", err)
+		}
+
+		pe.Hash = decodeHex32(hashHex)
+
+		pending = append(pending, pe)
+	}
+
+	return pending, rows.Err()
+}
+
+// ==================== BLOCK STORAGE ====================
+
+// Blocks exposes the underlying blockstore.Store directly, for callers
+// (consensus.ForkChoice via SetBlockStore) that need to hold a reference
+// rather than go through StateManager for every call.
+func (sm *StateManager) Blocks() *blockstore.Store {
+	return sm.blocks
+}
+
+// Receipts exposes the underlying receipts.Store directly, for the same
+// reason Blocks does: consensus.ForkChoice holds this via
+// SetReceiptsStore, and the API layer queries it directly for receipt and
+// log-filter lookups.
+func (sm *StateManager) Receipts() *receipts.Store {
+	return sm.receipts
+}
+
+// PutBlock persists block and its receipts as the canonical block at its
+// height, via the embedded blockstore.Store. See consensus.ForkChoice,
+// which calls this when a block joins the canonical chain.
+func (sm *StateManager) PutBlock(block *blockstore.Block, receipts []blockstore.Receipt) error {
+	return sm.blocks.PutBlock(block, receipts)
+}
+
+// DeleteBlock removes a block from the blockstore. See consensus.ForkChoice,
+// which calls this when a block is displaced from the canonical chain by a
+// reorg.
+func (sm *StateManager) DeleteBlock(hash [32]byte) error {
+	return sm.blocks.DeleteBlock(hash)
+}
+
+// GetBlockByHash retrieves a canonical block and its receipts by hash.
+func (sm *StateManager) GetBlockByHash(hash [32]byte) (*blockstore.Block, []blockstore.Receipt, error) {
+	return sm.blocks.GetBlockByHash(hash)
+}
+
+// GetBlockByNumber retrieves a canonical block and its receipts by height.
+func (sm *StateManager) GetBlockByNumber(number uint64) (*blockstore.Block, []blockstore.Receipt, error) {
+	return sm.blocks.GetBlockByNumber(number)
+}
+
+// GetLatestBlock retrieves the highest canonical block stored, if any.
+func (sm *StateManager) GetLatestBlock() (*blockstore.Block, []blockstore.Receipt, error) {
+	return sm.blocks.GetLatestBlock()
+}
+
+// GetTransactionLocation returns the block hash and in-block index of a
+// confirmed transaction, for handleGetTransaction's blockstore fallback.
+func (sm *StateManager) GetTransactionLocation(txHash [32]byte) (blockHash [32]byte, index int, found bool, err error) {
+	return sm.blocks.GetTransactionLocation(txHash)
+}
+
+// GetReceipt retrieves the receipt for a confirmed transaction, if any.
+func (sm *StateManager) GetReceipt(txHash [32]byte) (*blockstore.Receipt, bool, error) {
+	return sm.blocks.GetReceipt(txHash)
+}
@@ -0,0 +1,172 @@
+package state
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+func newTestStateManager(t *testing.T) *StateManager {
+	t.Helper()
+
+	sm, err := NewStateManager(":memory:", logger.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("failed to create state manager: %v", err)
+	}
+	t.Cleanup(func() { sm.Close() })
+
+	return sm
+}
+
+func TestCommitIsDeterministicAndStable(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	addr := [32]byte{1, 2, 3}
+	if err := sm.CreateAccount(addr, 1000); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	root1, err := sm.Commit(1)
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	root2, err := sm.Commit(1)
+	if err != nil {
+		t.Fatalf("second Commit at the same height failed: %v", err)
+	}
+
+	if root1 != root2 {
+		t.Fatalf("expected committing the same state twice to produce the same root, got %x and %x", root1[:8], root2[:8])
+	}
+
+	got, err := sm.StateRoot(1)
+	if err != nil {
+		t.Fatalf("StateRoot failed: %v", err)
+	}
+	if got != root1 {
+		t.Fatalf("StateRoot returned %x, expected %x", got[:8], root1[:8])
+	}
+}
+
+func TestCommitRootChangesWithState(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	addr := [32]byte{4, 5, 6}
+	if err := sm.CreateAccount(addr, 1000); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	rootBefore, err := sm.Commit(1)
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	if err := sm.UpdateAccount(addr, 2000, 1); err != nil {
+		t.Fatalf("failed to update account: %v", err)
+	}
+
+	rootAfter, err := sm.Commit(2)
+	if err != nil {
+		t.Fatalf("second Commit failed: %v", err)
+	}
+
+	if rootBefore == rootAfter {
+		t.Fatal("expected the state root to change after updating an account's balance")
+	}
+}
+
+func TestStateRootUnknownHeight(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	if _, err := sm.StateRoot(99); err == nil {
+		t.Fatal("expected an error for a height that was never committed")
+	}
+}
+
+func TestProveAndVerifyAccountProof(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	addr := [32]byte{7, 8, 9}
+	if err := sm.CreateAccount(addr, 500); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	// A second account so the trie isn't a single bare leaf.
+	if err := sm.CreateAccount([32]byte{10, 11, 12}, 750); err != nil {
+		t.Fatalf("failed to create second account: %v", err)
+	}
+
+	root, err := sm.Commit(1)
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	acct, err := sm.GetAccount(addr)
+	if err != nil {
+		t.Fatalf("failed to fetch account: %v", err)
+	}
+
+	proof, err := sm.ProveAccount(addr, root)
+	if err != nil {
+		t.Fatalf("ProveAccount failed: %v", err)
+	}
+	if len(proof) == 0 {
+		t.Fatal("expected a non-empty proof")
+	}
+
+	if err := sm.VerifyAccountProof(root, addr, acct, proof); err != nil {
+		t.Fatalf("VerifyAccountProof rejected a valid proof: %v", err)
+	}
+
+	tampered := *acct
+	tampered.Balance++
+	if err := sm.VerifyAccountProof(root, addr, &tampered, proof); err == nil {
+		t.Fatal("expected VerifyAccountProof to reject a proof for the wrong account balance")
+	}
+}
+
+func TestProveAccountNotPresent(t *testing.T) {
+	sm := newTestStateManager(t)
+
+	if err := sm.CreateAccount([32]byte{1}, 100); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	root, err := sm.Commit(1)
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	if _, err := sm.ProveAccount([32]byte{0xff}, root); err == nil {
+		t.Fatal("expected an error proving an address absent from the trie")
+	}
+}
+
+func TestBuildTrieRejectsDuplicateKeys(t *testing.T) {
+	pairs := []trieLeafPair{
+		{Key: [32]byte{1}, Value: []byte("a")},
+		{Key: [32]byte{1}, Value: []byte("b")},
+	}
+
+	if _, _, err := buildTrie(pairs); err == nil {
+		t.Fatal("expected an error building a trie over duplicate keys")
+	}
+}
+
+func TestTrieNodeEncodeDecodeRoundTrip(t *testing.T) {
+	n := &trieNode{
+		Kind:  trieLeaf,
+		Path:  []byte{1, 2, 3, 4, 5},
+		Value: []byte("leaf value"),
+	}
+
+	decoded, err := decodeTrieNode(n.encode())
+	if err != nil {
+		t.Fatalf("decodeTrieNode failed: %v", err)
+	}
+
+	if decoded.Kind != n.Kind || !bytes.Equal(decoded.Path, n.Path) || !bytes.Equal(decoded.Value, n.Value) {
+		t.Fatalf("round-tripped node %+v does not match original %+v", decoded, n)
+	}
+}
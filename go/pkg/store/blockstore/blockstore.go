@@ -0,0 +1,443 @@
+// Package blockstore provides persistent storage for canonical blocks,
+// keyed by hash, number, and transaction hash, backed by the same SQLite
+// database used elsewhere in the repo for durable state (see
+// pkg/state.StateManager). It plays the role an embedded KV store
+// (Pebble/BadgerDB) would in a node with more storage engines available,
+// but reuses modernc.org/sqlite rather than adding a new dependency.
+package blockstore
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// TxRecord is the persisted shape of a transaction within a stored block.
+type TxRecord struct {
+	Hash    [32]byte
+	From    [32]byte
+	To      [32]byte
+	Amount  uint64
+	Nonce   uint64
+	GasUsed uint64
+	Fee     uint64
+}
+
+// Block is the persisted shape of a canonical block. It mirrors
+// consensus.Block's fields rather than importing that package, since
+// blockstore has no reason to depend on consensus internals.
+type Block struct {
+	BlockHash    [32]byte
+	ParentHash   [32]byte
+	BlockNumber  uint64
+	Validator    [32]byte
+	StateRoot    [32]byte
+	Timestamp    int64
+	GasUsed      uint64
+	Transactions []TxRecord
+}
+
+// Receipt is the outcome of executing one transaction within a block.
+type Receipt struct {
+	TxHash      [32]byte
+	BlockHash   [32]byte
+	BlockNumber uint64
+	Index       int
+	GasUsed     uint64
+	Success     bool
+}
+
+// blockPayload is the JSON-serialized form stored in the blocks table's
+// payload column; it carries everything not already broken out into its
+// own indexed column.
+type blockPayload struct {
+	ParentHash   [32]byte
+	Validator    [32]byte
+	StateRoot    [32]byte
+	Timestamp    int64
+	Transactions []TxRecord
+}
+
+// Store persists canonical blocks with schemas for block-by-hash,
+// hash-by-number, tx-by-hash, and receipts-by-block lookups. It does not
+// own its *sql.DB; callers (typically state.StateManager) are responsible
+// for opening and closing the underlying connection.
+type Store struct {
+	db  *sql.DB
+	log *logger.Logger
+	mu  sync.RWMutex
+}
+
+const blockstoreSchema = `
+CREATE TABLE IF NOT EXISTS blocks (
+	hash       TEXT    PRIMARY KEY,
+	number     INTEGER NOT NULL,
+	payload    BLOB    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_blocks_number ON blocks(number);
+
+CREATE TABLE IF NOT EXISTS block_by_number (
+	number INTEGER PRIMARY KEY,
+	hash   TEXT    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tx_index (
+	tx_hash    TEXT    PRIMARY KEY,
+	block_hash TEXT    NOT NULL,
+	tx_index   INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS receipts (
+	tx_hash      TEXT    PRIMARY KEY,
+	block_hash   TEXT    NOT NULL,
+	block_number INTEGER NOT NULL,
+	tx_index     INTEGER NOT NULL,
+	gas_used     INTEGER NOT NULL,
+	success      INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS chain_meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+`
+
+const latestHashKey = "latest_hash"
+
+// NewStore installs the blockstore schema on db and returns a Store. db is
+// expected to already be open (and is shared with, not owned by, the
+// caller — Store never closes it).
+func NewStore(db *sql.DB, log *logger.Logger) (*Store, error) {
+	if _, err := db.Exec(blockstoreSchema); err != nil {
+		return nil, fmt.Errorf("failed to initialize blockstore schema: %w", err)
+	}
+
+	return &Store{
+		db:  db,
+		log: log,
+	}, nil
+}
+
+// PutBlock persists block and its receipts as the canonical block at its
+// height, replacing whatever block previously occupied that height.
+func (s *Store) PutBlock(block *Block, receipts []Receipt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payload, err := json.Marshal(blockPayload{
+		ParentHash:   block.ParentHash,
+		Validator:    block.Validator,
+		StateRoot:    block.StateRoot,
+		Timestamp:    block.Timestamp,
+		Transactions: block.Transactions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode block payload: %w", err)
+	}
+
+	hashHex := fmt.Sprintf("%x", block.BlockHash)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin blockstore transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO blocks (hash, number, payload)
+		VALUES (?, ?, ?)
+		ON CONFLICT(hash) DO UPDATE SET number = excluded.number, payload = excluded.payload
+	`, hashHex, block.BlockNumber, payload); err != nil {
+		return fmt.Errorf("failed to store block: %w", err)
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO block_by_number (number, hash)
+		VALUES (?, ?)
+		ON CONFLICT(number) DO UPDATE SET hash = excluded.hash
+	`, block.BlockNumber, hashHex); err != nil {
+		return fmt.Errorf("failed to index block by number: %w", err)
+	}
+
+	for i, txn := range block.Transactions {
+		txHashHex := fmt.Sprintf("%x", txn.Hash)
+		if _, err := tx.Exec(`
+			INSERT INTO tx_index (tx_hash, block_hash, tx_index)
+			VALUES (?, ?, ?)
+			ON CONFLICT(tx_hash) DO UPDATE SET block_hash = excluded.block_hash, tx_index = excluded.tx_index
+		`, txHashHex, hashHex, i); err != nil {
+			return fmt.Errorf("failed to index transaction %x: %w", txn.Hash[:8], err)
+		}
+	}
+
+	for _, receipt := range receipts {
+		if _, err := tx.Exec(`
+			INSERT INTO receipts (tx_hash, block_hash, block_number, tx_index, gas_used, success)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(tx_hash) DO UPDATE SET
+				block_hash = excluded.block_hash, block_number = excluded.block_number,
+				tx_index = excluded.tx_index, gas_used = excluded.gas_used, success = excluded.success
+		`, fmt.Sprintf("%x", receipt.TxHash), hashHex, receipt.BlockNumber, receipt.Index, receipt.GasUsed, receipt.Success); err != nil {
+			return fmt.Errorf("failed to store receipt %x: %w", receipt.TxHash[:8], err)
+		}
+	}
+
+	var currentLatest uint64
+	row := tx.QueryRow(`SELECT value FROM chain_meta WHERE key = ?`, latestHashKey)
+	var currentLatestHash string
+	if err := row.Scan(&currentLatestHash); err == nil {
+		if err := tx.QueryRow(`SELECT number FROM blocks WHERE hash = ?`, currentLatestHash).Scan(&currentLatest); err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to read current latest block: %w", err)
+		}
+	} else if err != sql.ErrNoRows {
+		return fmt.Errorf("failed to read chain meta: %w", err)
+	}
+
+	if currentLatestHash == "" || block.BlockNumber >= currentLatest {
+		if _, err := tx.Exec(`
+			INSERT INTO chain_meta (key, value)
+			VALUES (?, ?)
+			ON CONFLICT(key) DO UPDATE SET value = excluded.value
+		`, latestHashKey, hashHex); err != nil {
+			return fmt.Errorf("failed to update latest block pointer: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteBlock removes a block (and its number/tx/receipt index entries)
+// from the store. Used when a block is superseded by a chain reorg and is
+// no longer canonical.
+func (s *Store) DeleteBlock(hash [32]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashHex := fmt.Sprintf("%x", hash)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin blockstore transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT tx_hash FROM tx_index WHERE block_hash = ?`, hashHex)
+	if err != nil {
+		return fmt.Errorf("failed to list transactions for block %x: %w", hash[:8], err)
+	}
+	var txHashes []string
+	for rows.Next() {
+		var txHash string
+		if err := rows.Scan(&txHash); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan tx hash: %w", err)
+		}
+		txHashes = append(txHashes, txHash)
+	}
+	rows.Close()
+
+	for _, txHash := range txHashes {
+		if _, err := tx.Exec(`DELETE FROM tx_index WHERE tx_hash = ?`, txHash); err != nil {
+			return fmt.Errorf("failed to remove tx index entry: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM receipts WHERE tx_hash = ?`, txHash); err != nil {
+			return fmt.Errorf("failed to remove receipt: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM block_by_number WHERE hash = ?`, hashHex); err != nil {
+		return fmt.Errorf("failed to remove block-by-number index: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM blocks WHERE hash = ?`, hashHex); err != nil {
+		return fmt.Errorf("failed to remove block: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetBlockByHash retrieves a block and its receipts by block hash.
+func (s *Store) GetBlockByHash(hash [32]byte) (*Block, []Receipt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var number uint64
+	var payload []byte
+	err := s.db.QueryRow(`SELECT number, payload FROM blocks WHERE hash = ?`, fmt.Sprintf("%x", hash)).Scan(&number, &payload)
+	if err == sql.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query block: %w", err)
+	}
+
+	block, err := decodeBlock(hash, number, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receipts, err := s.receiptsForBlockLocked(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return block, receipts, nil
+}
+
+// GetBlockByNumber retrieves a block and its receipts by block height.
+func (s *Store) GetBlockByNumber(number uint64) (*Block, []Receipt, error) {
+	s.mu.RLock()
+	var hashHex string
+	err := s.db.QueryRow(`SELECT hash FROM block_by_number WHERE number = ?`, number).Scan(&hashHex)
+	s.mu.RUnlock()
+	if err == sql.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query block by number: %w", err)
+	}
+
+	hash, err := hexToHash(hashHex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.GetBlockByHash(hash)
+}
+
+// GetLatestBlock retrieves the highest block number stored, if any.
+func (s *Store) GetLatestBlock() (*Block, []Receipt, error) {
+	s.mu.RLock()
+	var hashHex string
+	err := s.db.QueryRow(`SELECT value FROM chain_meta WHERE key = ?`, latestHashKey).Scan(&hashHex)
+	s.mu.RUnlock()
+	if err == sql.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query latest block pointer: %w", err)
+	}
+
+	hash, err := hexToHash(hashHex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.GetBlockByHash(hash)
+}
+
+// GetTransactionLocation returns the block hash and in-block index of a
+// confirmed transaction. found is false if the transaction has not been
+// indexed (e.g. it's only in the mempool, or unknown).
+func (s *Store) GetTransactionLocation(txHash [32]byte) (blockHash [32]byte, index int, found bool, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var hashHex string
+	err = s.db.QueryRow(`SELECT block_hash, tx_index FROM tx_index WHERE tx_hash = ?`, fmt.Sprintf("%x", txHash)).Scan(&hashHex, &index)
+	if err == sql.ErrNoRows {
+		return [32]byte{}, 0, false, nil
+	}
+	if err != nil {
+		return [32]byte{}, 0, false, fmt.Errorf("failed to query transaction index: %w", err)
+	}
+
+	blockHash, err = hexToHash(hashHex)
+	if err != nil {
+		return [32]byte{}, 0, false, err
+	}
+
+	return blockHash, index, true, nil
+}
+
+// GetReceipt retrieves the receipt for a confirmed transaction, if any.
+func (s *Store) GetReceipt(txHash [32]byte) (*Receipt, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	receipt, ok, err := s.receiptLocked(txHash)
+	return receipt, ok, err
+}
+
+func (s *Store) receiptLocked(txHash [32]byte) (*Receipt, bool, error) {
+	var blockHashHex string
+	receipt := &Receipt{TxHash: txHash}
+	err := s.db.QueryRow(`
+		SELECT block_hash, block_number, tx_index, gas_used, success
+		FROM receipts WHERE tx_hash = ?
+	`, fmt.Sprintf("%x", txHash)).Scan(&blockHashHex, &receipt.BlockNumber, &receipt.Index, &receipt.GasUsed, &receipt.Success)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query receipt: %w", err)
+	}
+
+	blockHash, err := hexToHash(blockHashHex)
+	if err != nil {
+		return nil, false, err
+	}
+	receipt.BlockHash = blockHash
+
+	return receipt, true, nil
+}
+
+func (s *Store) receiptsForBlockLocked(blockHash [32]byte) ([]Receipt, error) {
+	rows, err := s.db.Query(`
+		SELECT tx_hash, block_number, tx_index, gas_used, success
+		FROM receipts WHERE block_hash = ?
+		ORDER BY tx_index ASC
+	`, fmt.Sprintf("%x", blockHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query receipts for block: %w", err)
+	}
+	defer rows.Close()
+
+	var receipts []Receipt
+	for rows.Next() {
+		var txHashHex string
+		receipt := Receipt{BlockHash: blockHash}
+		if err := rows.Scan(&txHashHex, &receipt.BlockNumber, &receipt.Index, &receipt.GasUsed, &receipt.Success); err != nil {
+			return nil, fmt.Errorf("failed to scan receipt: %w", err)
+		}
+		txHash, err := hexToHash(txHashHex)
+		if err != nil {
+			return nil, err
+		}
+		receipt.TxHash = txHash
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
+
+func decodeBlock(hash [32]byte, number uint64, payload []byte) (*Block, error) {
+	var p blockPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode block payload: %w", err)
+	}
+
+	return &Block{
+		BlockHash:    hash,
+		ParentHash:   p.ParentHash,
+		BlockNumber:  number,
+		Validator:    p.Validator,
+		StateRoot:    p.StateRoot,
+		Timestamp:    p.Timestamp,
+		Transactions: p.Transactions,
+	}, nil
+}
+
+func hexToHash(s string) ([32]byte, error) {
+	var h [32]byte
+	decoded, err := hex.DecodeString(s)
+	if err != nil || len(decoded) != 32 {
+		return h, fmt.Errorf("malformed stored hash %q", s)
+	}
+	copy(h[:], decoded)
+	return h, nil
+}